@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"unicode"
+)
+
+// node is a parsed infix expression.
+type node interface {
+	eval(ctx context.Context, env *session) (int32, error)
+}
+
+type number int32
+
+type variable string
+
+type negate struct{ operand node }
+
+type binary struct {
+	op          rune
+	left, right node
+}
+
+func (n number) eval(context.Context, *session) (int32, error) {
+	return int32(n), nil
+}
+
+func (v variable) eval(_ context.Context, env *session) (int32, error) {
+	value, ok := env.lookup(string(v))
+	if !ok {
+		return 0, fmt.Errorf("unknown variable $%s", string(v))
+	}
+	return value, nil
+}
+
+func (n negate) eval(ctx context.Context, env *session) (int32, error) {
+	value, err := n.operand.eval(ctx, env)
+	if err != nil {
+		return 0, err
+	}
+	if value == math.MinInt32 {
+		return 0, errors.New("integer overflow")
+	}
+	return -value, nil
+}
+
+// eval evaluates both operands and sends the operation to the server, so
+// every step is computed, and recorded, by the real service.
+func (b binary) eval(ctx context.Context, env *session) (int32, error) {
+	left, err := b.left.eval(ctx, env)
+	if err != nil {
+		return 0, err
+	}
+	right, err := b.right.eval(ctx, env)
+	if err != nil {
+		return 0, err
+	}
+
+	switch b.op {
+	case '+':
+		return env.calc.Add(ctx, left, right)
+	case '-':
+		// The service has no subtraction; a - b is sent as a + (-b).
+		if right == math.MinInt32 {
+			return 0, errors.New("integer overflow")
+		}
+		return env.calc.Add(ctx, left, -right)
+	default:
+		return env.calc.Divide(ctx, left, right)
+	}
+}
+
+// token is a lexical element of an expression. Numbers are kept as text
+// until the parser knows whether a minus sign belongs to them.
+type token struct {
+	kind rune // 'n' number, '$' variable, or the operator/parenthesis itself
+	text string
+	pos  int
+}
+
+// tokenize splits an infix expression into tokens.
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && unicode.IsDigit(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: 'n', text: string(runes[start:i]), pos: start})
+		case r == '$':
+			start := i
+			i++
+			for i < len(runes) && isNameRune(runes[i]) {
+				i++
+			}
+			if i == start+1 {
+				return nil, fmt.Errorf("expected a variable name after $ at column %d", start+1)
+			}
+			tokens = append(tokens, token{kind: '$', text: string(runes[start+1 : i]), pos: start})
+		case r == '+' || r == '-' || r == '/' || r == '(' || r == ')':
+			tokens = append(tokens, token{kind: r, text: string(r), pos: i})
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected character %q at column %d", r, i+1)
+		}
+	}
+	return tokens, nil
+}
+
+func isNameRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// parser is a recursive-descent parser for:
+//
+//	expr    = term { ("+" | "-") term }
+//	term    = unary { "/" unary }
+//	unary   = "-" number | "-" unary | primary
+//	primary = number | "$" name | "(" expr ")"
+//
+// The operators are those the server supports. A minus sign directly in
+// front of a number is part of it, so -2147483648 can be written.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// parse parses a complete infix expression.
+func parse(input string) (node, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, errors.New("empty expression")
+	}
+
+	p := &parser{tokens: tokens}
+	n, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if tok, ok := p.peek(); ok {
+		return nil, fmt.Errorf("unexpected %q at column %d", tok.text, tok.pos+1)
+	}
+	return n, nil
+}
+
+func (p *parser) peek() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *parser) expr() (node, error) {
+	left, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, ok := p.peek()
+		if !ok || (tok.kind != '+' && tok.kind != '-') {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		left = binary{op: tok.kind, left: left, right: right}
+	}
+}
+
+func (p *parser) term() (node, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, ok := p.peek()
+		if !ok || tok.kind != '/' {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = binary{op: tok.kind, left: left, right: right}
+	}
+}
+
+func (p *parser) unary() (node, error) {
+	if tok, ok := p.peek(); ok && tok.kind == '-' {
+		p.pos++
+		if next, ok := p.peek(); ok && next.kind == 'n' {
+			p.pos++
+			return parseNumber("-"+next.text, tok.pos)
+		}
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return negate{operand: operand}, nil
+	}
+	return p.primary()
+}
+
+func (p *parser) primary() (node, error) {
+	tok, ok := p.peek()
+	if !ok {
+		return nil, errors.New("unexpected end of expression")
+	}
+	p.pos++
+
+	switch tok.kind {
+	case 'n':
+		return parseNumber(tok.text, tok.pos)
+	case '$':
+		return variable(tok.text), nil
+	case '(':
+		n, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if closing, ok := p.peek(); !ok || closing.kind != ')' {
+			return nil, fmt.Errorf("missing ) for ( at column %d", tok.pos+1)
+		}
+		p.pos++
+		return n, nil
+	default:
+		return nil, fmt.Errorf("unexpected %q at column %d", tok.text, tok.pos+1)
+	}
+}
+
+// parseNumber reads an integer literal, with its sign, that starts at
+// column pos+1.
+func parseNumber(text string, pos int) (node, error) {
+	n, err := strconv.ParseInt(text, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("number %s at column %d does not fit in 32 bits", text, pos+1)
+	}
+	return number(n), nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// show writes n in prefix notation, so the shape of a parsed tree can be
+// compared as text.
+func show(n node) string {
+	switch n := n.(type) {
+	case number:
+		return fmt.Sprint(int32(n))
+	case variable:
+		return "$" + string(n)
+	case negate:
+		return "(neg " + show(n.operand) + ")"
+	case binary:
+		return fmt.Sprintf("(%c %s %s)", n.op, show(n.left), show(n.right))
+	default:
+		return fmt.Sprintf("%T", n)
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"1", "1"},
+		{"  42 ", "42"},
+		{"1 + 2 - 3", "(- (+ 1 2) 3)"},
+		{"1 + 6 / 3", "(+ 1 (/ 6 3))"},
+		{"12 / 3 / 2", "(/ (/ 12 3) 2)"},
+		{"(1 + 6) / 7", "(/ (+ 1 6) 7)"},
+		{"-2147483648", "-2147483648"},
+		{"2147483647", "2147483647"},
+		{"1 - -2", "(- 1 -2)"},
+		{"-(1 + 2)", "(neg (+ 1 2))"},
+		{"--3", "(neg -3)"},
+		{"-$x / $last_1", "(/ (neg $x) $last_1)"},
+	}
+	for _, tt := range tests {
+		n, err := parse(tt.in)
+		if err != nil {
+			t.Errorf("parse(%q) error = %v", tt.in, err)
+			continue
+		}
+		if got := show(n); got != tt.want {
+			t.Errorf("parse(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", "empty expression"},
+		{"   ", "empty expression"},
+		{"2 * 3", `unexpected character '*' at column 3`},
+		{"1 +", "unexpected end of expression"},
+		{"(1 + 2", "missing ) for ( at column 1"},
+		{"1 2", `unexpected "2" at column 3`},
+		{"1 + )", `unexpected ")" at column 5`},
+		{"$", "expected a variable name after $ at column 1"},
+		{"2147483648", "number 2147483648 at column 1 does not fit in 32 bits"},
+		{"1 + -2147483649", "number -2147483649 at column 5 does not fit in 32 bits"},
+	}
+	for _, tt := range tests {
+		_, err := parse(tt.in)
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("parse(%q) error = %v, want %q", tt.in, err, tt.want)
+		}
+	}
+}
+
+func TestNegateOverflow(t *testing.T) {
+	n, err := parse("-(-2147483648)")
+	if err != nil {
+		t.Fatalf("parse error = %v", err)
+	}
+	if _, err := n.eval(context.Background(), nil); err == nil {
+		t.Errorf("eval(-(%d)) did not report an overflow", math.MinInt32)
+	}
+}
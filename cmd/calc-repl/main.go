@@ -0,0 +1,114 @@
+// Command calc-repl is an interactive calculator that evaluates infix
+// expressions on a running calculator service over gRPC.
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	pb "go-prisma-calculator/generated/proto"
+
+	"github.com/chzyer/readline"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// options are the command-line flags of the REPL.
+type options struct {
+	addr        string
+	timeout     time.Duration
+	useTLS      bool
+	caFile      string
+	serverName  string
+	skipVerify  bool
+	historyFile string
+}
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.addr, "addr", "localhost:50051", "gRPC address of the calculator service")
+	flag.DurationVar(&opts.timeout, "timeout", 5*time.Second, "deadline for each expression or command")
+	flag.BoolVar(&opts.useTLS, "tls", false, "connect with TLS")
+	flag.StringVar(&opts.caFile, "ca-file", "", "PEM file with the CA certificates to trust (implies -tls)")
+	flag.StringVar(&opts.serverName, "server-name", "", "override the server name checked against the certificate (implies -tls)")
+	flag.BoolVar(&opts.skipVerify, "insecure-skip-verify", false, "do not verify the server certificate (implies -tls)")
+	flag.StringVar(&opts.historyFile, "history-file", defaultHistoryFile(), "file that keeps the line-editing history")
+	flag.Parse()
+
+	if err := run(opts); err != nil {
+		fmt.Fprintln(os.Stderr, "calc-repl:", err)
+		os.Exit(1)
+	}
+}
+
+func run(opts options) error {
+	creds, err := transportCredentials(opts)
+	if err != nil {
+		return err
+	}
+
+	conn, err := grpc.NewClient(opts.addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	rl, err := readline.NewEx(&readline.Config{
+		Prompt:          "calc> ",
+		HistoryFile:     opts.historyFile,
+		InterruptPrompt: "^C",
+		EOFPrompt:       ":quit",
+		AutoComplete: readline.NewPrefixCompleter(
+			readline.PcItem(":history"),
+			readline.PcItem(":vars"),
+			readline.PcItem(":help"),
+			readline.PcItem(":quit"),
+		),
+	})
+	if err != nil {
+		return err
+	}
+	defer rl.Close()
+
+	return newSession(pb.NewCalculatorServiceClient(conn), opts.timeout, rl.Stdout()).run(rl)
+}
+
+// transportCredentials builds plaintext or TLS credentials from the flags.
+func transportCredentials(opts options) (credentials.TransportCredentials, error) {
+	if !opts.useTLS && opts.caFile == "" && opts.serverName == "" && !opts.skipVerify {
+		return insecure.NewCredentials(), nil
+	}
+
+	cfg := &tls.Config{
+		ServerName:         opts.serverName,
+		InsecureSkipVerify: opts.skipVerify,
+	}
+	if opts.caFile != "" {
+		pem, err := os.ReadFile(opts.caFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificates found in " + opts.caFile)
+		}
+		cfg.RootCAs = pool
+	}
+	return credentials.NewTLS(cfg), nil
+}
+
+// defaultHistoryFile keeps the line history in the user's home directory.
+func defaultHistoryFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".calc_repl_history")
+}
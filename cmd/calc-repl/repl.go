@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	pb "go-prisma-calculator/generated/proto"
+
+	"github.com/chzyer/readline"
+	"google.golang.org/grpc/status"
+)
+
+const helpText = `Enter an infix expression such as  (10 + $1) / 2  to evaluate it on the server.
+Each result is stored as $1, $2, ...; "name = expr" also stores it as $name.
+
+Commands:
+  :history [n]   show the last n calculations recorded by the server (default 10)
+  :vars          show the variables of this session
+  :help          show this help
+  :quit          leave the REPL (or press Ctrl-D)
+`
+
+// assignment matches "name = expr".
+var assignment = regexp.MustCompile(`^\s*([A-Za-z_][A-Za-z0-9_]*)\s*=(.*)$`)
+
+// calculator runs the individual operations of an expression.
+type calculator interface {
+	Add(ctx context.Context, a, b int32) (int32, error)
+	Divide(ctx context.Context, dividend, divisor int32) (int32, error)
+}
+
+// remoteCalculator runs operations on the server.
+type remoteCalculator struct {
+	client pb.CalculatorServiceClient
+}
+
+func (c remoteCalculator) Add(ctx context.Context, a, b int32) (int32, error) {
+	resp, err := c.client.Add(ctx, &pb.AddRequest{A: a, B: b})
+	if err != nil {
+		return 0, err
+	}
+	return resp.GetResult(), nil
+}
+
+func (c remoteCalculator) Divide(ctx context.Context, dividend, divisor int32) (int32, error) {
+	resp, err := c.client.Divide(ctx, &pb.DivideRequest{Dividend: dividend, Divisor: divisor})
+	if err != nil {
+		return 0, err
+	}
+	return resp.GetResult(), nil
+}
+
+// session is the state of one REPL run.
+type session struct {
+	client  pb.CalculatorServiceClient
+	calc    calculator
+	timeout time.Duration
+	out     io.Writer
+
+	results []int32
+	named   map[string]int32
+}
+
+func newSession(client pb.CalculatorServiceClient, timeout time.Duration, out io.Writer) *session {
+	return &session{
+		client:  client,
+		calc:    remoteCalculator{client: client},
+		timeout: timeout,
+		out:     out,
+		named:   make(map[string]int32),
+	}
+}
+
+// lookup resolves $N and $name variables.
+func (s *session) lookup(name string) (int32, bool) {
+	if n, err := strconv.Atoi(name); err == nil {
+		if n < 1 || n > len(s.results) {
+			return 0, false
+		}
+		return s.results[n-1], true
+	}
+	value, ok := s.named[name]
+	return value, ok
+}
+
+// run reads lines until EOF or :quit.
+func (s *session) run(rl *readline.Instance) error {
+	fmt.Fprintln(s.out, `Type an expression, or :help for help.`)
+	for {
+		line, err := rl.Readline()
+		if errors.Is(err, readline.ErrInterrupt) {
+			if line == "" {
+				return nil
+			}
+			continue
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		quit, err := s.handle(strings.TrimSpace(line))
+		if err != nil {
+			fmt.Fprintln(s.out, "error:", describe(err))
+		}
+		if quit {
+			return nil
+		}
+	}
+}
+
+// handle executes one line of input and reports whether the REPL should exit.
+func (s *session) handle(line string) (bool, error) {
+	if line == "" {
+		return false, nil
+	}
+
+	if strings.HasPrefix(line, ":") {
+		fields := strings.Fields(line)
+		switch fields[0] {
+		case ":quit", ":q", ":exit":
+			return true, nil
+		case ":help", ":h":
+			fmt.Fprint(s.out, helpText)
+			return false, nil
+		case ":vars":
+			s.printVars()
+			return false, nil
+		case ":history":
+			return false, s.history(fields[1:])
+		default:
+			return false, fmt.Errorf("unknown command %s, see :help", fields[0])
+		}
+	}
+
+	name, expression := "", line
+	if m := assignment.FindStringSubmatch(line); m != nil {
+		name, expression = m[1], m[2]
+	}
+
+	expr, err := parse(expression)
+	if err != nil {
+		return false, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	result, err := expr.eval(ctx, s)
+	if err != nil {
+		return false, err
+	}
+
+	s.results = append(s.results, result)
+	if name != "" {
+		s.named[name] = result
+		fmt.Fprintf(s.out, "$%d = $%s = %d\n", len(s.results), name, result)
+	} else {
+		fmt.Fprintf(s.out, "$%d = %d\n", len(s.results), result)
+	}
+	return false, nil
+}
+
+func (s *session) printVars() {
+	if len(s.results) == 0 {
+		fmt.Fprintln(s.out, "no variables yet")
+		return
+	}
+
+	w := tabwriter.NewWriter(s.out, 0, 0, 2, ' ', 0)
+	for i, value := range s.results {
+		fmt.Fprintf(w, "$%d\t%d\n", i+1, value)
+	}
+	names := make([]string, 0, len(s.named))
+	for name := range s.named {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "$%s\t%d\n", name, s.named[name])
+	}
+	w.Flush()
+}
+
+// history prints the most recent calculations stored by the server.
+func (s *session) history(args []string) error {
+	limit := 10
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n <= 0 {
+			return fmt.Errorf("invalid count %q", args[0])
+		}
+		limit = n
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	resp, err := s.client.ListCalculations(ctx, &pb.ListCalculationsRequest{Limit: int32(limit)})
+	if err != nil {
+		return err
+	}
+	if len(resp.GetCalculations()) == 0 {
+		fmt.Fprintln(s.out, "no calculations recorded yet")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(s.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "WHEN\tOPERATION\tA\tB\tRESULT")
+	for _, calc := range resp.GetCalculations() {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\n",
+			calc.GetCreatedAt().AsTime().Local().Format("2006-01-02 15:04:05"),
+			calc.GetOperation(), calc.GetA(), calc.GetB(), calc.GetResult())
+	}
+	return w.Flush()
+}
+
+// describe strips the gRPC status prefix from server errors.
+func describe(err error) string {
+	if st, ok := status.FromError(err); ok {
+		return st.Message()
+	}
+	return err.Error()
+}
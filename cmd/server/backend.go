@@ -0,0 +1,659 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	pb "go-prisma-calculator/generated/proto"
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/ports/in"
+	"go-prisma-calculator/internal/infrastructure/export"
+	"go-prisma-calculator/internal/infrastructure/providers"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// backend runs calculations and reads the history, either in-process
+// through the fx-built ports or against a remote server over gRPC.
+type backend interface {
+	in.CalculatorPort
+	in.HistoryPort
+	// ImportFile imports a CSV or NDJSON file.
+	ImportFile(ctx context.Context, format export.Format, r io.Reader, opts domain.ImportOptions) (*domain.ImportReport, error)
+	CreateTenant(ctx context.Context, tenant domain.Tenant) (*domain.Tenant, error)
+	ListTenants(ctx context.Context) ([]domain.Tenant, error)
+	UpdateTenant(ctx context.Context, tenant domain.Tenant) (*domain.Tenant, error)
+	DisableTenant(ctx context.Context, id string) (*domain.Tenant, error)
+	EnableTenant(ctx context.Context, id string) (*domain.Tenant, error)
+	Close() error
+}
+
+// openBackend returns the remote backend when --remote is set and an
+// in-process one otherwise. The in-process backend resolves the --tenant
+// itself and scopes the command's context to it; a remote server resolves
+// it from the tenant header.
+func (o *globalOptions) openBackend(cmd *cobra.Command) (backend, error) {
+	if o.remote != "" {
+		return dialRemote(o.remote, o.apiKey, o.tenant)
+	}
+
+	b, err := startLocal(cmd.Context(), o)
+	if err != nil {
+		return nil, err
+	}
+	tenant, err := b.tenants.ResolveTenant(domain.WithPrincipal(cmd.Context(), operator), o.tenant)
+	if err != nil {
+		b.Close()
+		return nil, err
+	}
+	cmd.SetContext(domain.WithTenant(cmd.Context(), *tenant))
+	return b, nil
+}
+
+// localBackend serves commands from an in-process fx application built from
+// providers.Core, so calculations are validated, cached and recorded exactly
+// as they are by the servers. Operators running it already have direct
+// database access, so the commands run as an admin principal.
+type localBackend struct {
+	in.CalculatorPort
+	in.HistoryPort
+	imports in.ImportPort
+	tenants in.TenantPort
+	app     *fx.App
+}
+
+func startLocal(ctx context.Context, opts *globalOptions) (*localBackend, error) {
+	cfg, err := opts.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	b := &localBackend{}
+	app := fx.New(
+		providers.Core,
+		fx.NopLogger,
+		fx.Replace(cfg, cliLogger()),
+		// Leave room for the database connection deadline; startup falls
+		// back to no-history mode once it passes.
+		fx.StartTimeout(cfg.DBConnectTimeout+5*time.Second),
+		fx.Populate(&b.CalculatorPort, &b.HistoryPort, &b.imports, &b.tenants),
+	)
+	if err := app.Start(ctx); err != nil {
+		return nil, err
+	}
+	b.app = app
+	return b, nil
+}
+
+// operator is the principal of in-process commands.
+var operator = domain.Principal{Name: "cli", Role: domain.RoleAdmin}
+
+// PurgeCalculation purges as the operator, who is allowed to.
+func (b *localBackend) PurgeCalculation(ctx context.Context, id string) error {
+	return b.HistoryPort.PurgeCalculation(domain.WithPrincipal(ctx, operator), id)
+}
+
+// VerifyChain verifies the audit chain as the operator, who is allowed to.
+func (b *localBackend) VerifyChain(ctx context.Context) (*domain.ChainVerification, error) {
+	return b.HistoryPort.VerifyChain(domain.WithPrincipal(ctx, operator))
+}
+
+// ImportFile imports as the operator, so principals recorded in the file are kept.
+func (b *localBackend) ImportFile(ctx context.Context, format export.Format, r io.Reader, opts domain.ImportOptions) (*domain.ImportReport, error) {
+	reader, err := export.NewReader(format, r)
+	if err != nil {
+		return nil, err
+	}
+	return b.imports.ImportCalculations(domain.WithPrincipal(ctx, operator), opts, reader.Read)
+}
+
+// CreateTenant creates a tenant as the operator.
+func (b *localBackend) CreateTenant(ctx context.Context, tenant domain.Tenant) (*domain.Tenant, error) {
+	return b.tenants.CreateTenant(domain.WithPrincipal(ctx, operator), tenant)
+}
+
+// ListTenants lists the tenants as the operator.
+func (b *localBackend) ListTenants(ctx context.Context) ([]domain.Tenant, error) {
+	return b.tenants.ListTenants(domain.WithPrincipal(ctx, operator))
+}
+
+// UpdateTenant updates a tenant as the operator.
+func (b *localBackend) UpdateTenant(ctx context.Context, tenant domain.Tenant) (*domain.Tenant, error) {
+	return b.tenants.UpdateTenant(domain.WithPrincipal(ctx, operator), tenant)
+}
+
+// DisableTenant disables a tenant as the operator.
+func (b *localBackend) DisableTenant(ctx context.Context, id string) (*domain.Tenant, error) {
+	return b.tenants.DisableTenant(domain.WithPrincipal(ctx, operator), id)
+}
+
+// EnableTenant enables a tenant as the operator.
+func (b *localBackend) EnableTenant(ctx context.Context, id string) (*domain.Tenant, error) {
+	return b.tenants.EnableTenant(domain.WithPrincipal(ctx, operator), id)
+}
+
+// Close stops the application, flushing any queued history writes.
+func (b *localBackend) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return b.app.Stop(ctx)
+}
+
+// remoteBackend serves commands from a running server over gRPC.
+type remoteBackend struct {
+	conn   *grpc.ClientConn
+	client pb.CalculatorServiceClient
+}
+
+// dialRemote connects to a server, sending the API key and the tenant with
+// every call, streaming ones included.
+func dialRemote(addr, apiKey, tenant string) (*remoteBackend, error) {
+	var headers []string
+	if apiKey != "" {
+		headers = append(headers, "x-api-key", apiKey)
+	}
+	if tenant != "" {
+		headers = append(headers, "x-tenant-id", tenant)
+	}
+
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if len(headers) > 0 {
+		opts = append(opts,
+			grpc.WithUnaryInterceptor(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+				return invoker(metadata.AppendToOutgoingContext(ctx, headers...), method, req, reply, cc, callOpts...)
+			}),
+			grpc.WithStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+				return streamer(metadata.AppendToOutgoingContext(ctx, headers...), desc, cc, method, callOpts...)
+			}),
+		)
+	}
+
+	conn, err := grpc.NewClient(addr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &remoteBackend{conn: conn, client: pb.NewCalculatorServiceClient(conn)}, nil
+}
+
+// Calculate asks the server to run an operation.
+func (r *remoteBackend) Calculate(ctx context.Context, operation string, operands ...int32) (*domain.Calculation, error) {
+	resp, err := r.client.Calculate(ctx, &pb.CalculateRequest{Operation: operation, Operands: operands})
+	if err != nil {
+		return nil, err
+	}
+	calc := &domain.Calculation{ID: resp.GetId(), Operation: operation, Result: int(resp.GetResult())}
+	if resp.GetCreatedAt() != nil {
+		calc.CreatedAt = resp.GetCreatedAt().AsTime()
+	}
+	if len(operands) > 0 {
+		calc.A = int(operands[0])
+	}
+	if len(operands) > 1 {
+		calc.B = int(operands[1])
+	}
+	return calc, nil
+}
+
+// ListOperations asks the server which operations the caller may run.
+func (r *remoteBackend) ListOperations(ctx context.Context) ([]domain.OperationSignature, error) {
+	resp, err := r.client.ListOperations(ctx, &pb.ListOperationsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	signatures := make([]domain.OperationSignature, 0, len(resp.GetOperations()))
+	for _, op := range resp.GetOperations() {
+		signatures = append(signatures, domain.OperationSignature{
+			Name:  op.GetName(),
+			Arity: int(op.GetArity()),
+			OperationDoc: domain.OperationDoc{
+				Description: op.GetDescription(),
+				Operands:    op.GetOperands(),
+				Commutative: op.GetCommutative(),
+			},
+		})
+	}
+	return signatures, nil
+}
+
+// Divide asks the server for a rounded division. The response only
+// carries the rounded quotient, so the calculation is read back from the
+// history to return it in full.
+func (r *remoteBackend) Divide(ctx context.Context, d domain.RoundedDivision) (*domain.Calculation, error) {
+	scale := int32(d.Scale)
+	resp, err := r.client.Divide(ctx, &pb.DivideRequest{
+		Dividend: d.Dividend,
+		Divisor:  d.Divisor,
+		Rounding: string(d.Rounding),
+		Scale:    &scale,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetCalculation(ctx, resp.GetId())
+}
+
+// Evaluate asks the server to evaluate a scientific function.
+func (r *remoteBackend) Evaluate(ctx context.Context, e domain.Evaluation) (*domain.Calculation, error) {
+	resp, err := r.client.Evaluate(ctx, &pb.EvaluateRequest{
+		Function:  e.Function,
+		Operands:  e.Operands,
+		Kind:      string(e.Kind),
+		Angle:     string(e.Options.Angle),
+		Precision: int32(e.Options.Precision),
+		Scale:     toProtoScale(e.Options.Scale),
+		Division:  string(e.Options.Division),
+		Rounding:  string(e.Options.Rounding),
+	})
+	if err != nil {
+		return nil, err
+	}
+	calc := &domain.Calculation{
+		ID:        resp.GetId(),
+		Operation: e.Function,
+		Kind:      domain.Kind(resp.GetKind()),
+		Operands:  resp.GetOperands(),
+		Value:     resp.GetValue(),
+		Details:   resp.GetDetails(),
+		Options: domain.EvaluationOptions{
+			Angle:     domain.AngleUnit(resp.GetAngle()),
+			Precision: int(resp.GetPrecision()),
+			Scale:     fromProtoScale(resp.Scale),
+			Division:  domain.DivisionMode(resp.GetDivision()),
+			Rounding:  domain.RoundingMode(resp.GetRounding()),
+		},
+	}
+	if resp.GetCreatedAt() != nil {
+		calc.CreatedAt = resp.GetCreatedAt().AsTime()
+	}
+	return calc, nil
+}
+
+// ListFunctions asks the server which functions the caller may evaluate.
+func (r *remoteBackend) ListFunctions(ctx context.Context) ([]domain.FunctionSignature, error) {
+	resp, err := r.client.ListFunctions(ctx, &pb.ListFunctionsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	signatures := make([]domain.FunctionSignature, 0, len(resp.GetFunctions()))
+	for _, f := range resp.GetFunctions() {
+		signatures = append(signatures, domain.FunctionSignature{
+			Kind:  domain.Kind(f.GetKind()),
+			Name:  f.GetName(),
+			Arity: int(f.GetArity()),
+			OperationDoc: domain.OperationDoc{
+				Description: f.GetDescription(),
+				Operands:    f.GetOperands(),
+				Commutative: f.GetCommutative(),
+			},
+			Options: f.GetOptions(),
+		})
+	}
+	return signatures, nil
+}
+
+// GetCalculation fetches a single calculation from the server's history.
+func (r *remoteBackend) GetCalculation(ctx context.Context, id string) (*domain.Calculation, error) {
+	resp, err := r.client.GetCalculation(ctx, &pb.GetCalculationRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	return fromProtoCalculation(resp), nil
+}
+
+// ListCalculations fetches a page of the server's history.
+func (r *remoteBackend) ListCalculations(ctx context.Context, filter domain.CalculationFilter) ([]domain.Calculation, error) {
+	resp, err := r.client.ListCalculations(ctx, &pb.ListCalculationsRequest{
+		Operation:      filter.Operation,
+		Limit:          int32(filter.Limit),
+		Offset:         int32(filter.Offset),
+		IncludeDeleted: filter.IncludeDeleted,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	calcs := make([]domain.Calculation, 0, len(resp.GetCalculations()))
+	for _, calc := range resp.GetCalculations() {
+		calcs = append(calcs, *fromProtoCalculation(calc))
+	}
+	return calcs, nil
+}
+
+// DeleteCalculation soft-deletes a calculation on the server.
+func (r *remoteBackend) DeleteCalculation(ctx context.Context, id string) error {
+	_, err := r.client.DeleteCalculation(ctx, &pb.DeleteCalculationRequest{Id: id})
+	return err
+}
+
+// RestoreCalculation restores a soft-deleted calculation on the server.
+func (r *remoteBackend) RestoreCalculation(ctx context.Context, id string) (*domain.Calculation, error) {
+	resp, err := r.client.RestoreCalculation(ctx, &pb.RestoreCalculationRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	return fromProtoCalculation(resp), nil
+}
+
+// PurgeCalculation permanently deletes a calculation on the server.
+func (r *remoteBackend) PurgeCalculation(ctx context.Context, id string) error {
+	_, err := r.client.PurgeCalculation(ctx, &pb.PurgeCalculationRequest{Id: id})
+	return err
+}
+
+// ExportCalculations streams the server's history as NDJSON and decodes it.
+func (r *remoteBackend) ExportCalculations(ctx context.Context, filter domain.CalculationFilter, fn func(domain.Calculation) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := r.client.ExportCalculations(ctx, &pb.ExportCalculationsRequest{
+		Operation:      filter.Operation,
+		Limit:          int32(filter.Limit),
+		Offset:         int32(filter.Offset),
+		IncludeDeleted: filter.IncludeDeleted,
+		Format:         string(export.FormatNDJSON),
+	})
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(&chunkReader{stream: stream})
+	for {
+		var record export.Record
+		if err := dec.Decode(&record); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := fn(record.Calculation()); err != nil {
+			return err
+		}
+	}
+}
+
+// importChunkSize is the amount of file data sent per import message.
+const importChunkSize = 64 << 10
+
+// ImportFile streams the file to the server in chunks.
+func (r *remoteBackend) ImportFile(ctx context.Context, format export.Format, file io.Reader, opts domain.ImportOptions) (*domain.ImportReport, error) {
+	stream, err := r.client.ImportCalculations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = stream.Send(&pb.ImportCalculationsRequest{Payload: &pb.ImportCalculationsRequest_Options{Options: &pb.ImportOptions{
+		Format:     string(format),
+		Recompute:  opts.Recompute,
+		OnMismatch: string(opts.OnMismatch),
+		DryRun:     opts.DryRun,
+	}}})
+	buf := make([]byte, importChunkSize)
+	for err == nil {
+		var n int
+		n, err = file.Read(buf)
+		if n > 0 {
+			if sendErr := stream.Send(&pb.ImportCalculationsRequest{Payload: &pb.ImportCalculationsRequest_Data{Data: buf[:n]}}); sendErr != nil {
+				err = sendErr
+			}
+		}
+	}
+	// io.EOF from Send means the server ended the call; CloseAndRecv reports why.
+	if err != io.EOF {
+		return nil, err
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.ImportReport{
+		Total:           int(resp.GetTotal()),
+		Accepted:        int(resp.GetAccepted()),
+		Rejected:        int(resp.GetRejected()),
+		Mismatched:      int(resp.GetMismatched()),
+		IssuesTruncated: resp.GetIssuesTruncated(),
+	}
+	for _, issue := range resp.GetIssues() {
+		report.Issues = append(report.Issues, domain.ImportIssue{
+			Line:   int(issue.GetLine()),
+			Kind:   domain.ImportIssueKind(issue.GetKind()),
+			Reason: issue.GetReason(),
+		})
+	}
+	return report, nil
+}
+
+// chunkReader reads the file carried by an export stream.
+type chunkReader struct {
+	stream grpc.ServerStreamingClient[pb.ExportChunk]
+	buf    []byte
+}
+
+func (c *chunkReader) Read(p []byte) (int, error) {
+	for len(c.buf) == 0 {
+		chunk, err := c.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		c.buf = chunk.GetData()
+	}
+	n := copy(p, c.buf)
+	c.buf = c.buf[n:]
+	return n, nil
+}
+
+// VerifyChain asks the server to verify the audit chain of the tenant.
+func (r *remoteBackend) VerifyChain(ctx context.Context) (*domain.ChainVerification, error) {
+	resp, err := r.client.VerifyChain(ctx, &pb.VerifyChainRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.ChainVerification{
+		TenantID:  resp.GetTenantId(),
+		Checked:   int(resp.GetChecked()),
+		Unchained: int(resp.GetUnchained()),
+		Truncated: resp.GetTruncated(),
+		Removed:   int(resp.GetRemoved()),
+		Head:      resp.GetHead(),
+	}
+	if b := resp.GetBroken(); b != nil {
+		report.Broken = &domain.ChainBreak{
+			ID:       b.GetId(),
+			Seq:      int(b.GetSeq()),
+			Reason:   domain.ChainBreakReason(b.GetReason()),
+			Expected: b.GetExpected(),
+			Actual:   b.GetActual(),
+		}
+	}
+	return report, nil
+}
+
+// GetStatistics fetches the statistics of the server's history.
+func (r *remoteBackend) GetStatistics(ctx context.Context, query domain.StatsQuery) (*domain.Statistics, error) {
+	req := &pb.GetStatisticsRequest{
+		Bucket:         string(query.Bucket),
+		Operation:      query.Operation,
+		TopPairs:       int32(query.TopPairs),
+		IncludeDeleted: query.IncludeDeleted,
+	}
+	if !query.From.IsZero() {
+		req.From = timestamppb.New(query.From)
+	}
+	if !query.To.IsZero() {
+		req.To = timestamppb.New(query.To)
+	}
+
+	resp, err := r.client.GetStatistics(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &domain.Statistics{
+		From:   resp.GetFrom().AsTime(),
+		To:     resp.GetTo().AsTime(),
+		Bucket: domain.StatsBucket(resp.GetBucket()),
+		Total:  int(resp.GetTotal()),
+	}
+	for _, op := range resp.GetOperations() {
+		stats.Operations = append(stats.Operations, domain.OperationStats{
+			Operation: op.GetOperation(),
+			Count:     int(op.GetCount()),
+			MinResult: int(op.GetMinResult()),
+			MaxResult: int(op.GetMaxResult()),
+			AvgResult: op.GetAvgResult(),
+		})
+	}
+	for _, b := range resp.GetHistogram() {
+		stats.Histogram = append(stats.Histogram, domain.HistogramBucket{Start: b.GetStart().AsTime(), Count: int(b.GetCount())})
+	}
+	for _, p := range resp.GetTopPairs() {
+		stats.TopPairs = append(stats.TopPairs, domain.OperandPairStats{
+			Operation: p.GetOperation(),
+			A:         int(p.GetA()),
+			B:         int(p.GetB()),
+			Count:     int(p.GetCount()),
+		})
+	}
+	for _, e := range resp.GetErrors() {
+		stats.Errors = append(stats.Errors, domain.ErrorStats{
+			Operation: e.GetOperation(),
+			Finished:  int(e.GetFinished()),
+			Failed:    int(e.GetFailed()),
+		})
+	}
+	return stats, nil
+}
+
+// CreateTenant creates a tenant on the server.
+func (r *remoteBackend) CreateTenant(ctx context.Context, tenant domain.Tenant) (*domain.Tenant, error) {
+	resp, err := r.client.CreateTenant(ctx, &pb.CreateTenantRequest{Tenant: toProtoTenant(tenant)})
+	if err != nil {
+		return nil, err
+	}
+	return fromProtoTenant(resp), nil
+}
+
+// ListTenants lists the server's tenants.
+func (r *remoteBackend) ListTenants(ctx context.Context) ([]domain.Tenant, error) {
+	resp, err := r.client.ListTenants(ctx, &pb.ListTenantsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	tenants := make([]domain.Tenant, 0, len(resp.GetTenants()))
+	for _, tenant := range resp.GetTenants() {
+		tenants = append(tenants, *fromProtoTenant(tenant))
+	}
+	return tenants, nil
+}
+
+// UpdateTenant updates a tenant on the server.
+func (r *remoteBackend) UpdateTenant(ctx context.Context, tenant domain.Tenant) (*domain.Tenant, error) {
+	resp, err := r.client.UpdateTenant(ctx, &pb.UpdateTenantRequest{Tenant: toProtoTenant(tenant)})
+	if err != nil {
+		return nil, err
+	}
+	return fromProtoTenant(resp), nil
+}
+
+// DisableTenant disables a tenant on the server.
+func (r *remoteBackend) DisableTenant(ctx context.Context, id string) (*domain.Tenant, error) {
+	resp, err := r.client.DisableTenant(ctx, &pb.DisableTenantRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	return fromProtoTenant(resp), nil
+}
+
+// EnableTenant enables a tenant on the server.
+func (r *remoteBackend) EnableTenant(ctx context.Context, id string) (*domain.Tenant, error) {
+	resp, err := r.client.EnableTenant(ctx, &pb.EnableTenantRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	return fromProtoTenant(resp), nil
+}
+
+// Close closes the gRPC connection.
+func (r *remoteBackend) Close() error {
+	return r.conn.Close()
+}
+
+func fromProtoCalculation(calc *pb.Calculation) *domain.Calculation {
+	resp := &domain.Calculation{
+		ID:        calc.GetId(),
+		Operation: calc.GetOperation(),
+		A:         int(calc.GetA()),
+		B:         int(calc.GetB()),
+		Result:    int(calc.GetResult()),
+		CreatedAt: calc.GetCreatedAt().AsTime(),
+		Hash:      calc.GetHash(),
+		PrevHash:  calc.GetPrevHash(),
+	}
+	if kind := domain.Kind(calc.GetKind()); kind != "" && kind != domain.KindInteger {
+		resp.Kind, resp.Operands, resp.Value, resp.Details = kind, calc.GetOperands(), calc.GetValue(), calc.GetDetails()
+		resp.Options = domain.EvaluationOptions{
+			Angle:     domain.AngleUnit(calc.GetAngle()),
+			Precision: int(calc.GetPrecision()),
+			Scale:     fromProtoScale(calc.Scale),
+			Division:  domain.DivisionMode(calc.GetDivision()),
+			Rounding:  domain.RoundingMode(calc.GetRounding()),
+		}
+	}
+	if calc.GetDeletedAt() != nil {
+		deletedAt := calc.GetDeletedAt().AsTime()
+		resp.DeletedAt = &deletedAt
+	}
+	return resp
+}
+
+// fromProtoScale and toProtoScale convert the optional scale option.
+func fromProtoScale(scale *int32) *int {
+	if scale == nil {
+		return nil
+	}
+	v := int(*scale)
+	return &v
+}
+
+func toProtoScale(scale *int) *int32 {
+	if scale == nil {
+		return nil
+	}
+	v := int32(*scale)
+	return &v
+}
+
+func toProtoTenant(tenant domain.Tenant) *pb.Tenant {
+	return &pb.Tenant{
+		Id:                tenant.ID,
+		Name:              tenant.Name,
+		AllowedOperations: tenant.AllowedOperations,
+		MaxOperand:        int32(tenant.MaxOperand),
+		MaxPendingJobs:    int32(tenant.MaxPendingJobs),
+	}
+}
+
+func fromProtoTenant(tenant *pb.Tenant) *domain.Tenant {
+	resp := &domain.Tenant{
+		ID:                tenant.GetId(),
+		Name:              tenant.GetName(),
+		AllowedOperations: tenant.GetAllowedOperations(),
+		MaxOperand:        int(tenant.GetMaxOperand()),
+		MaxPendingJobs:    int(tenant.GetMaxPendingJobs()),
+		CreatedAt:         tenant.GetCreatedAt().AsTime(),
+	}
+	if tenant.GetDisabledAt() != nil {
+		disabledAt := tenant.GetDisabledAt().AsTime()
+		resp.DisabledAt = &disabledAt
+	}
+	return resp
+}
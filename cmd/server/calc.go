@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	domain "go-prisma-calculator/internal/domain/models"
+
+	"github.com/spf13/cobra"
+)
+
+// newCalcCommand builds the "calc" subcommand, which runs a single
+// calculation of any registered operation, e.g. "calc add 1 2". With
+// --rounding or --scale, "calc divide" rounds the quotient instead of
+// truncating it.
+func newCalcCommand(opts *globalOptions) *cobra.Command {
+	var (
+		rounding string
+		scale    int
+	)
+	cmd := &cobra.Command{
+		Use:   "calc OPERATION OPERAND...",
+		Short: "Run a calculation in-process or on a remote server",
+		Long:  "Run a calculation in-process or on a remote server. The operations command lists the available operations.",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			operands := make([]int32, 0, len(args)-1)
+			for _, arg := range args[1:] {
+				operand, err := parseOperand(arg)
+				if err != nil {
+					return err
+				}
+				operands = append(operands, operand)
+			}
+
+			rounded := cmd.Flags().Changed("rounding") || cmd.Flags().Changed("scale")
+			if rounded && (args[0] != "divide" || len(operands) != 2) {
+				return fmt.Errorf("--rounding and --scale only apply to divide DIVIDEND DIVISOR")
+			}
+
+			b, err := opts.openBackend(cmd)
+			if err != nil {
+				return err
+			}
+			defer b.Close()
+
+			var calc *domain.Calculation
+			if rounded {
+				calc, err = b.Divide(cmd.Context(), domain.RoundedDivision{
+					Dividend: operands[0],
+					Divisor:  operands[1],
+					Rounding: domain.RoundingMode(rounding),
+					Scale:    scale,
+				})
+			} else {
+				calc, err = b.Calculate(cmd.Context(), args[0], operands...)
+			}
+			if err != nil {
+				return err
+			}
+			return newPrinter(cmd, opts.output).calculation(calc)
+		},
+	}
+	cmd.Flags().StringVar(&rounding, "rounding", "", "rounding of the divide quotient: truncate (default), floor, ceiling, half-up, half-even or half-away-from-zero")
+	cmd.Flags().IntVar(&scale, "scale", 0, "digits after the decimal point of the divide quotient")
+	return cmd
+}
+
+// newOperationsCommand builds the "operations" subcommand, which lists the
+// operations the tenant may run.
+func newOperationsCommand(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "operations",
+		Short: "List the operations calc can run",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := opts.openBackend(cmd)
+			if err != nil {
+				return err
+			}
+			defer b.Close()
+
+			signatures, err := b.ListOperations(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return newPrinter(cmd, opts.output).operations(signatures)
+		},
+	}
+}
+
+// newEvalCommand builds the "eval" subcommand, which evaluates a scientific
+// function, e.g. "eval sin 30 --angle degrees".
+func newEvalCommand(opts *globalOptions) *cobra.Command {
+	var (
+		kind      string
+		angle     string
+		precision int
+		scale     int
+		division  string
+		rounding  string
+	)
+	cmd := &cobra.Command{
+		Use:   "eval FUNCTION OPERAND...",
+		Short: "Evaluate a scientific function in-process or on a remote server",
+		Long:  "Evaluate a scientific function in-process or on a remote server. The functions command lists the available functions.",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := opts.openBackend(cmd)
+			if err != nil {
+				return err
+			}
+			defer b.Close()
+
+			options := domain.EvaluationOptions{
+				Angle:     domain.AngleUnit(angle),
+				Precision: precision,
+				Division:  domain.DivisionMode(division),
+				Rounding:  domain.RoundingMode(rounding),
+			}
+			if cmd.Flags().Changed("scale") {
+				options.Scale = &scale
+			}
+			calc, err := b.Evaluate(cmd.Context(), domain.Evaluation{
+				Function: args[0],
+				Kind:     domain.Kind(kind),
+				Operands: args[1:],
+				Options:  options,
+			})
+			if err != nil {
+				return err
+			}
+			return newPrinter(cmd, opts.output).calculation(calc)
+		},
+	}
+	cmd.Flags().StringVar(&kind, "kind", string(domain.KindFloat), "number kind: float, decimal, bigint, rational, complex or matrix")
+	cmd.Flags().StringVar(&angle, "angle", "", "angle unit of trigonometric functions: radians (default) or degrees")
+	cmd.Flags().IntVar(&precision, "precision", 0, "significant digits of decimal results (default 20)")
+	cmd.Flags().IntVar(&scale, "scale", domain.DefaultScale, "digits after the decimal point of the decimal rendering of rational results")
+	cmd.Flags().StringVar(&rounding, "rounding", "", "rounding of the decimal rendering of rational results (default half-away-from-zero)")
+	cmd.Flags().StringVar(&division, "division", "", "rounding of the divmod quotient: truncated (default), floored or euclidean")
+	return cmd
+}
+
+// newFunctionsCommand builds the "functions" subcommand, which lists the
+// scientific functions the tenant may evaluate.
+func newFunctionsCommand(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "functions",
+		Short: "List the functions eval can evaluate",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := opts.openBackend(cmd)
+			if err != nil {
+				return err
+			}
+			defer b.Close()
+
+			signatures, err := b.ListFunctions(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return newPrinter(cmd, opts.output).functions(signatures)
+		},
+	}
+}
+
+// parseOperand parses a 32-bit integer operand.
+func parseOperand(s string) (int32, error) {
+	n, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid operand %q: must be a 32-bit integer", s)
+	}
+	return int32(n), nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+
+	"go-prisma-calculator/internal/infrastructure/config"
+	"go-prisma-calculator/internal/infrastructure/providers"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+)
+
+// globalOptions holds the flags shared by every subcommand.
+type globalOptions struct {
+	// configFile is an env file to load instead of ./.env.
+	configFile string
+	// output is the output format: table, json or yaml.
+	output string
+	// remote is the gRPC address of a running server; empty runs in-process.
+	remote string
+	// apiKey authenticates commands sent to a remote server.
+	apiKey string
+	// tenant selects the tenant commands run in; empty uses the default tenant.
+	tenant string
+}
+
+// newRootCommand builds the command tree. Running the binary without a
+// subcommand starts the servers, as it always has.
+func newRootCommand() *cobra.Command {
+	opts := &globalOptions{}
+
+	serve := newServeCommand(opts)
+	root := &cobra.Command{
+		Use:           "server",
+		Short:         "Calculator service and operator tools",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		RunE:          serve.RunE,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return validateFormat(opts.output)
+		},
+	}
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&opts.configFile, "config", "", "env file to load instead of ./.env")
+	flags.StringVarP(&opts.output, "output", "o", formatTable, "output format: table, json or yaml")
+	flags.StringVar(&opts.remote, "remote", "", "gRPC address of a running server (default: run in-process)")
+	flags.StringVar(&opts.apiKey, "api-key", os.Getenv("CALCULATOR_API_KEY"), "API key for --remote (default $CALCULATOR_API_KEY)")
+	flags.StringVar(&opts.tenant, "tenant", os.Getenv("CALCULATOR_TENANT"), "tenant to run commands in (default $CALCULATOR_TENANT)")
+
+	root.AddCommand(
+		serve,
+		newMigrateCommand(opts),
+		newCalcCommand(opts),
+		newOperationsCommand(opts),
+		newEvalCommand(opts),
+		newFunctionsCommand(opts),
+		newHistoryCommand(opts),
+		newRetentionCommand(opts),
+		newTenantCommand(opts),
+	)
+	return root
+}
+
+// newServeCommand builds the "serve" subcommand, which starts the gRPC and REST servers.
+func newServeCommand(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "serve",
+		Short: "Start the gRPC and REST servers",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options := []fx.Option{
+				providers.Module,
+				// fx.Invoke tells fx to run a function. We use it to start our servers.
+				fx.Invoke(runServers),
+			}
+			if opts.configFile != "" {
+				cfg, err := opts.loadConfig()
+				if err != nil {
+					return err
+				}
+				options = append(options, fx.Replace(cfg))
+			}
+
+			// Create a new fx application and run it; fx manages the entire lifecycle.
+			fx.New(options...).Run()
+			return nil
+		},
+	}
+}
+
+// loadConfig loads the configuration selected by the --config flag.
+func (o *globalOptions) loadConfig() (*config.Config, error) {
+	if o.configFile != "" {
+		return config.LoadFile(o.configFile)
+	}
+	return config.NewConfig(), nil
+}
+
+// cliLogger returns the logger used by one-off commands. It writes warnings
+// and errors to stderr so it never mixes with the command output.
+func cliLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+}
@@ -0,0 +1,331 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/infrastructure/export"
+
+	"github.com/spf13/cobra"
+)
+
+// newHistoryCommand builds the "history" subcommand, which reads past calculations.
+func newHistoryCommand(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Browse and export the calculation history",
+	}
+	cmd.AddCommand(
+		newHistoryListCommand(opts),
+		newHistoryGetCommand(opts),
+		newHistoryExportCommand(opts),
+		newHistoryDeleteCommand(opts),
+		newHistoryRestoreCommand(opts),
+		newHistoryImportCommand(opts),
+		newHistoryStatsCommand(opts),
+		newHistoryVerifyCommand(opts),
+	)
+	return cmd
+}
+
+func newHistoryListCommand(opts *globalOptions) *cobra.Command {
+	var filter domain.CalculationFilter
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List past calculations, newest first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := opts.openBackend(cmd)
+			if err != nil {
+				return err
+			}
+			defer b.Close()
+
+			calcs, err := b.ListCalculations(cmd.Context(), filter)
+			if err != nil {
+				return err
+			}
+			return newPrinter(cmd, opts.output).calculations(calcs)
+		},
+	}
+
+	cmd.Flags().StringVar(&filter.Operation, "operation", "", "only list this operation, e.g. add")
+	cmd.Flags().IntVar(&filter.Limit, "limit", 20, "number of calculations to list")
+	cmd.Flags().IntVar(&filter.Offset, "offset", 0, "number of calculations to skip")
+	cmd.Flags().BoolVar(&filter.IncludeDeleted, "include-deleted", false, "also list soft-deleted calculations")
+	return cmd
+}
+
+func newHistoryGetCommand(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get ID",
+		Short: "Show a single past calculation",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := opts.openBackend(cmd)
+			if err != nil {
+				return err
+			}
+			defer b.Close()
+
+			calc, err := b.GetCalculation(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			return newPrinter(cmd, opts.output).calculation(calc)
+		},
+	}
+}
+
+func newHistoryExportCommand(opts *globalOptions) *cobra.Command {
+	var operation, file, format string
+	var includeDeleted bool
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the whole calculation history",
+		Long: `Exports the history, newest first. By default it is printed in the --output
+format; --format csv, ndjson or parquet streams a file instead.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var w export.Writer
+			if format != "" {
+				f, err := export.ParseFormat(format)
+				if err != nil {
+					return err
+				}
+				if f == export.FormatParquet && file == "" {
+					return errors.New("parquet exports need --file")
+				}
+				format = string(f)
+			}
+
+			b, err := opts.openBackend(cmd)
+			if err != nil {
+				return err
+			}
+			defer b.Close()
+
+			if file != "" {
+				f, err := os.Create(file)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				cmd.SetOut(f)
+			}
+			if format != "" {
+				w, _ = export.NewWriter(export.Format(format), cmd.OutOrStdout())
+			}
+
+			var all []domain.Calculation
+			count := 0
+			err = b.ExportCalculations(cmd.Context(), domain.CalculationFilter{
+				Operation:      operation,
+				IncludeDeleted: includeDeleted,
+			}, func(calc domain.Calculation) error {
+				count++
+				if w != nil {
+					return w.Write(calc)
+				}
+				all = append(all, calc)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			if w != nil {
+				err = w.Close()
+			} else {
+				err = newPrinter(cmd, opts.output).calculations(all)
+			}
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d calculations\n", count)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&operation, "operation", "", "only export this operation, e.g. add")
+	cmd.Flags().StringVarP(&file, "file", "f", "", "write to this file instead of stdout")
+	cmd.Flags().StringVar(&format, "format", "", "file format: csv, ndjson or parquet (default: --output)")
+	cmd.Flags().BoolVar(&includeDeleted, "include-deleted", false, "also export soft-deleted calculations")
+	return cmd
+}
+
+func newHistoryDeleteCommand(opts *globalOptions) *cobra.Command {
+	var purge bool
+
+	cmd := &cobra.Command{
+		Use:   "delete ID",
+		Short: "Soft-delete a calculation, or purge it permanently",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := opts.openBackend(cmd)
+			if err != nil {
+				return err
+			}
+			defer b.Close()
+
+			if purge {
+				err = b.PurgeCalculation(cmd.Context(), args[0])
+			} else {
+				err = b.DeleteCalculation(cmd.Context(), args[0])
+			}
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "Deleted calculation %s\n", args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&purge, "purge", false, "remove permanently instead of soft-deleting (admin only)")
+	return cmd
+}
+
+func newHistoryRestoreCommand(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore ID",
+		Short: "Restore a soft-deleted calculation",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := opts.openBackend(cmd)
+			if err != nil {
+				return err
+			}
+			defer b.Close()
+
+			calc, err := b.RestoreCalculation(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			return newPrinter(cmd, opts.output).calculation(calc)
+		},
+	}
+}
+
+func newHistoryImportCommand(opts *globalOptions) *cobra.Command {
+	var format, onMismatch string
+	var recompute, dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "import FILE",
+		Short: "Import past calculations from a CSV or NDJSON file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f := export.FormatFromFileName(args[0])
+			if format != "" {
+				var err error
+				if f, err = export.ParseFormat(format); err != nil {
+					return err
+				}
+			}
+
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			b, err := opts.openBackend(cmd)
+			if err != nil {
+				return err
+			}
+			defer b.Close()
+
+			report, err := b.ImportFile(cmd.Context(), f, file, domain.ImportOptions{
+				Recompute:  recompute,
+				OnMismatch: domain.MismatchPolicy(onMismatch),
+				DryRun:     dryRun,
+			})
+			if report != nil {
+				if printErr := newPrinter(cmd, opts.output).importReport(report); printErr != nil && err == nil {
+					err = printErr
+				}
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "", "csv or ndjson (default: from the file extension)")
+	cmd.Flags().BoolVar(&recompute, "recompute", false, "check every result with the calculator")
+	cmd.Flags().StringVar(&onMismatch, "on-mismatch", string(domain.MismatchReject), "what to do with mismatched rows: reject, correct or keep")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "validate the file without storing anything")
+	return cmd
+}
+
+func newHistoryStatsCommand(opts *globalOptions) *cobra.Command {
+	var query domain.StatsQuery
+	var since time.Duration
+	var bucket string
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Summarize the calculation history over a time range",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if since > 0 {
+				query.From = time.Now().Add(-since)
+			}
+			query.Bucket = domain.StatsBucket(bucket)
+
+			b, err := opts.openBackend(cmd)
+			if err != nil {
+				return err
+			}
+			defer b.Close()
+
+			stats, err := b.GetStatistics(cmd.Context(), query)
+			if err != nil {
+				return err
+			}
+			return newPrinter(cmd, opts.output).statistics(stats)
+		},
+	}
+
+	cmd.Flags().DurationVar(&since, "since", 7*24*time.Hour, "length of the range, ending now")
+	cmd.Flags().StringVar(&bucket, "bucket", "", "histogram bucket: hour or day (default: by range)")
+	cmd.Flags().StringVar(&query.Operation, "operation", "", "only summarize this operation, e.g. add")
+	cmd.Flags().IntVar(&query.TopPairs, "top", 10, "number of most frequent operand pairs")
+	cmd.Flags().BoolVar(&query.IncludeDeleted, "include-deleted", false, "also count soft-deleted calculations")
+	return cmd
+}
+
+func newHistoryVerifyCommand(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify",
+		Short: "Verify the audit chain of the tenant's history (admin only)",
+		Long: "Recomputes the hash of every calculation of the tenant, oldest first, and\n" +
+			"reports the first record that was altered or whose predecessor is missing.\n" +
+			"Exits with an error when the chain is broken.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := opts.openBackend(cmd)
+			if err != nil {
+				return err
+			}
+			defer b.Close()
+
+			report, err := b.VerifyChain(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if err := newPrinter(cmd, opts.output).chainVerification(report); err != nil {
+				return err
+			}
+			if !report.Valid() {
+				return fmt.Errorf("audit chain broken at calculation %s: %s", report.Broken.ID, report.Broken.Reason)
+			}
+			return nil
+		},
+	}
+}
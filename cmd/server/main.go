@@ -2,33 +2,35 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
+	"os"
 
 	// Import your providers and adapters
 	grpc_adapter "go-prisma-calculator/internal/infrastructure/adapter/grpc"
 	rest_adapter "go-prisma-calculator/internal/infrastructure/adapter/rest"
-	"go-prisma-calculator/internal/infrastructure/providers"
+	"go-prisma-calculator/internal/infrastructure/auth"
+	"go-prisma-calculator/internal/infrastructure/health"
+	"go-prisma-calculator/internal/infrastructure/signing"
+	"go-prisma-calculator/pkg/receipt"
 
 	// Import your generated protobuf package
 	pb "go-prisma-calculator/generated/proto"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/fx"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 func main() {
-	// Create a new fx application, providing all the dependency modules.
-	app := fx.New(
-		providers.Module,
-		// fx.Invoke tells fx to run a function. We use it to start our servers.
-		fx.Invoke(runServers),
-	)
-
-	// Run the application. fx will manage the entire lifecycle.
-	app.Run()
+	if err := newRootCommand().Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
 }
 
 // runServers is the function that depends on our adapters and logger to start the servers.
@@ -38,6 +40,9 @@ func runServers(
 	logger *slog.Logger,
 	grpcAdapter *grpc_adapter.Adapter,
 	restAdapter *rest_adapter.Adapter,
+	monitor *health.Monitor,
+	authenticator *auth.Authenticator,
+	signer *signing.Signer,
 ) {
 	// We use the fx Lifecycle to gracefully start and stop our servers.
 	lifecycle.Append(fx.Hook{
@@ -49,8 +54,18 @@ func runServers(
 					logger.Error("gRPC failed to listen", slog.String("error", err.Error()))
 					return
 				}
-				grpcServer := grpc.NewServer()
+				grpcServer := grpc.NewServer(
+					grpc.ChainUnaryInterceptor(
+						monitor.UnaryServerInterceptor(),
+						authenticator.UnaryServerInterceptor(),
+					),
+					grpc.ChainStreamInterceptor(
+						monitor.StreamServerInterceptor(),
+						authenticator.StreamServerInterceptor(),
+					),
+				)
 				pb.RegisterCalculatorServiceServer(grpcServer, grpcAdapter)
+				healthpb.RegisterHealthServer(grpcServer, monitor.GRPCHealthServer())
 				logger.Info("gRPC server listening on :50051")
 				if err := grpcServer.Serve(lis); err != nil {
 					logger.Error("gRPC server failed to serve", slog.String("error", err.Error()))
@@ -60,9 +75,50 @@ func runServers(
 			// Start Gin REST server in a separate goroutine
 			go func() {
 				router := gin.Default()
-				router.POST("/add", restAdapter.AddHandler)
-				router.POST("/divide", restAdapter.DivideHandler)
-				
+				router.Use(monitor.Middleware())
+				// API routes require credentials when AUTH_REQUIRED is set.
+				api := router.Group("/", authenticator.Middleware())
+				api.POST("/add", restAdapter.AddHandler)
+				api.POST("/divide", restAdapter.DivideHandler)
+				api.POST("/calculate", restAdapter.CalculateHandler)
+				api.GET("/operations", restAdapter.ListOperationsHandler)
+				api.POST("/functions/:name", restAdapter.EvaluateHandler)
+				api.GET("/functions", restAdapter.ListFunctionsHandler)
+
+				// Routes for asynchronous jobs
+				api.POST("/jobs", restAdapter.SubmitJobHandler)
+				api.GET("/jobs", restAdapter.ListJobsHandler)
+				api.GET("/jobs/:id", restAdapter.GetJobHandler)
+				api.POST("/jobs/:id/cancel", restAdapter.CancelJobHandler)
+
+				// Routes for the calculation history
+				api.GET("/calculations", restAdapter.ListCalculationsHandler)
+				api.GET("/calculations/export", restAdapter.ExportCalculationsHandler)
+				api.POST("/calculations/import", restAdapter.ImportCalculationsHandler)
+				api.GET("/calculations/:id", restAdapter.GetCalculationHandler)
+				api.DELETE("/calculations/:id", restAdapter.DeleteCalculationHandler)
+				api.POST("/calculations/:id/restore", restAdapter.RestoreCalculationHandler)
+				api.GET("/stats", restAdapter.GetStatisticsHandler)
+				api.GET("/audit/verify", restAdapter.VerifyChainHandler)
+
+				// Routes for administering tenants (admin only)
+				api.POST("/tenants", restAdapter.CreateTenantHandler)
+				api.GET("/tenants", restAdapter.ListTenantsHandler)
+				api.GET("/tenants/:id", restAdapter.GetTenantHandler)
+				api.PUT("/tenants/:id", restAdapter.UpdateTenantHandler)
+				api.POST("/tenants/:id/disable", restAdapter.DisableTenantHandler)
+				api.POST("/tenants/:id/enable", restAdapter.EnableTenantHandler)
+
+				// Liveness/readiness probes
+				router.GET("/healthz", monitor.LiveHandler)
+				router.GET("/readyz", monitor.ReadyHandler)
+
+				// Public keys that verify calculation receipts
+				router.GET(receipt.WellKnownPath, signer.KeysHandler)
+
+				// Prometheus metrics
+				router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
 				// Routes for Swagger/OpenAPI documentation
 				router.StaticFile("/swagger.json", "./docs/calculator.swagger.json")
 				router.GET("/swagger", func(c *gin.Context) {
@@ -114,4 +170,4 @@ const swaggerHTML = `
     </script>
 </body>
 </html>
-`
\ No newline at end of file
+`
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+
+	"go-prisma-calculator/internal/infrastructure/migrations"
+
+	"github.com/spf13/cobra"
+)
+
+// migrationRecord is the printable form of a migration's status.
+type migrationRecord struct {
+	Version   int64  `json:"version" yaml:"version"`
+	Name      string `json:"name" yaml:"name"`
+	Status    string `json:"status" yaml:"status"`
+	AppliedAt string `json:"appliedAt,omitempty" yaml:"appliedAt,omitempty"`
+}
+
+// newMigrateCommand builds the "migrate" subcommand, which manages the database schema.
+func newMigrateCommand(opts *globalOptions) *cobra.Command {
+	var steps int
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Manage the database schema",
+		Long: `Applies the versioned SQL migrations embedded in this binary. Concurrent runs
+are serialized with a Postgres advisory lock, so every replica may run
+"migrate up" on start.`,
+	}
+	cmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "print the SQL that would run without executing it")
+
+	// withMigrator connects to the configured database and runs fn.
+	withMigrator := func(cmd *cobra.Command, fn func(ctx context.Context, m *migrations.Migrator) error) error {
+		cfg, err := opts.loadConfig()
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		migrator, err := migrations.Connect(ctx, cfg.DatabaseURL, cliLogger())
+		if err != nil {
+			return err
+		}
+		defer migrator.Close(context.WithoutCancel(ctx))
+		migrator.DryRun = dryRun
+		migrator.Out = cmd.OutOrStdout()
+
+		return fn(ctx, migrator)
+	}
+
+	up := &cobra.Command{
+		Use:   "up",
+		Short: "Apply pending migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withMigrator(cmd, func(ctx context.Context, m *migrations.Migrator) error {
+				return m.Up(ctx, steps)
+			})
+		},
+	}
+	up.Flags().IntVar(&steps, "steps", 0, "number of migrations to apply (default all)")
+
+	down := &cobra.Command{
+		Use:   "down",
+		Short: "Revert applied migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withMigrator(cmd, func(ctx context.Context, m *migrations.Migrator) error {
+				return m.Down(ctx, steps)
+			})
+		},
+	}
+	down.Flags().IntVar(&steps, "steps", 1, "number of migrations to revert")
+
+	status := &cobra.Command{
+		Use:   "status",
+		Short: "Show which migrations have been applied",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withMigrator(cmd, func(ctx context.Context, m *migrations.Migrator) error {
+				statuses, err := m.Status(ctx)
+				if err != nil {
+					return err
+				}
+
+				records := make([]migrationRecord, 0, len(statuses))
+				for _, s := range statuses {
+					record := migrationRecord{Version: s.Version, Name: s.Name, Status: "pending"}
+					if s.Applied {
+						record.Status = "applied"
+						record.AppliedAt = s.AppliedAt.Format("2006-01-02 15:04:05 MST")
+					}
+					records = append(records, record)
+				}
+				return newPrinter(cmd, opts.output).migrations(records)
+			})
+		},
+	}
+
+	cmd.AddCommand(up, down, status)
+	return cmd
+}
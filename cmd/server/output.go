@@ -0,0 +1,455 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	domain "go-prisma-calculator/internal/domain/models"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats accepted by the --output flag.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+)
+
+// validateFormat rejects unknown --output values before a command does any work.
+func validateFormat(format string) error {
+	switch format {
+	case formatTable, formatJSON, formatYAML:
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q: want table, json or yaml", format)
+	}
+}
+
+// calculationRecord is the printable form of a calculation.
+type calculationRecord struct {
+	ID        string `json:"id,omitempty" yaml:"id,omitempty"`
+	Operation string `json:"operation" yaml:"operation"`
+	A         int    `json:"a" yaml:"a"`
+	B         int    `json:"b" yaml:"b"`
+	Result    int    `json:"result" yaml:"result"`
+	// Kind, Operands, Value, Details and Options are set for calculations
+	// that are not integer ones.
+	Kind      domain.Kind               `json:"kind,omitempty" yaml:"kind,omitempty"`
+	Operands  []string                  `json:"operands,omitempty" yaml:"operands,omitempty"`
+	Value     string                    `json:"value,omitempty" yaml:"value,omitempty"`
+	Details   map[string]string         `json:"details,omitempty" yaml:"details,omitempty"`
+	Options   *domain.EvaluationOptions `json:"options,omitempty" yaml:"options,omitempty"`
+	CreatedAt *time.Time                `json:"createdAt,omitempty" yaml:"createdAt,omitempty"`
+	DeletedAt *time.Time                `json:"deletedAt,omitempty" yaml:"deletedAt,omitempty"`
+}
+
+func toCalculationRecord(calc *domain.Calculation) calculationRecord {
+	record := calculationRecord{
+		ID:        calc.ID,
+		Operation: calc.Operation,
+		A:         calc.A,
+		B:         calc.B,
+		Result:    calc.Result,
+	}
+	if !calc.IsInteger() {
+		record.Kind, record.Operands, record.Value, record.Options = calc.Kind, calc.Operands, calc.Value, &calc.Options
+		record.Details = calc.Details
+	}
+	if !calc.CreatedAt.IsZero() {
+		createdAt := calc.CreatedAt
+		record.CreatedAt = &createdAt
+	}
+	record.DeletedAt = calc.DeletedAt
+	return record
+}
+
+// printer writes command results in the selected output format.
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func newPrinter(cmd *cobra.Command, format string) *printer {
+	return &printer{w: cmd.OutOrStdout(), format: format}
+}
+
+// calculation prints a single calculation.
+func (p *printer) calculation(calc *domain.Calculation) error {
+	record := toCalculationRecord(calc)
+	return p.print(record, calculationHeader, func(w io.Writer) {
+		writeCalculationRow(w, record)
+	})
+}
+
+// calculations prints a list of calculations.
+func (p *printer) calculations(calcs []domain.Calculation) error {
+	records := make([]calculationRecord, 0, len(calcs))
+	for i := range calcs {
+		records = append(records, toCalculationRecord(&calcs[i]))
+	}
+	return p.print(records, calculationHeader, func(w io.Writer) {
+		for _, record := range records {
+			writeCalculationRow(w, record)
+		}
+	})
+}
+
+// migrations prints the status of every migration.
+func (p *printer) migrations(records []migrationRecord) error {
+	return p.print(records, "VERSION\tNAME\tSTATUS\tAPPLIED AT", func(w io.Writer) {
+		for _, r := range records {
+			fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", r.Version, r.Name, r.Status, r.AppliedAt)
+		}
+	})
+}
+
+// importReportRecord is the printable form of an import report.
+type importReportRecord struct {
+	Total           int                 `json:"total" yaml:"total"`
+	Accepted        int                 `json:"accepted" yaml:"accepted"`
+	Rejected        int                 `json:"rejected" yaml:"rejected"`
+	Mismatched      int                 `json:"mismatched" yaml:"mismatched"`
+	Issues          []importIssueRecord `json:"issues" yaml:"issues"`
+	IssuesTruncated bool                `json:"issuesTruncated,omitempty" yaml:"issuesTruncated,omitempty"`
+}
+
+type importIssueRecord struct {
+	Line   int    `json:"line" yaml:"line"`
+	Kind   string `json:"kind" yaml:"kind"`
+	Reason string `json:"reason" yaml:"reason"`
+}
+
+// importReport prints the totals of an import followed by its issues.
+func (p *printer) importReport(report *domain.ImportReport) error {
+	record := importReportRecord{
+		Total:           report.Total,
+		Accepted:        report.Accepted,
+		Rejected:        report.Rejected,
+		Mismatched:      report.Mismatched,
+		Issues:          make([]importIssueRecord, 0, len(report.Issues)),
+		IssuesTruncated: report.IssuesTruncated,
+	}
+	for _, issue := range report.Issues {
+		record.Issues = append(record.Issues, importIssueRecord{Line: issue.Line, Kind: string(issue.Kind), Reason: issue.Reason})
+	}
+
+	return p.print(record, "TOTAL\tACCEPTED\tREJECTED\tMISMATCHED", func(w io.Writer) {
+		fmt.Fprintf(w, "%d\t%d\t%d\t%d\n", record.Total, record.Accepted, record.Rejected, record.Mismatched)
+		if len(record.Issues) == 0 {
+			return
+		}
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "LINE\tKIND\tREASON")
+		for _, issue := range record.Issues {
+			fmt.Fprintf(w, "%d\t%s\t%s\n", issue.Line, issue.Kind, issue.Reason)
+		}
+		if record.IssuesTruncated {
+			fmt.Fprintln(w, "...\t\tmore issues not shown")
+		}
+	})
+}
+
+// statisticsRecord is the printable form of the history statistics.
+type statisticsRecord struct {
+	From       time.Time               `json:"from" yaml:"from"`
+	To         time.Time               `json:"to" yaml:"to"`
+	Bucket     string                  `json:"bucket" yaml:"bucket"`
+	Total      int                     `json:"total" yaml:"total"`
+	Operations []operationStatsRecord  `json:"operations" yaml:"operations"`
+	Histogram  []histogramBucketRecord `json:"histogram" yaml:"histogram"`
+	TopPairs   []operandPairRecord     `json:"topPairs" yaml:"topPairs"`
+	Errors     []errorStatsRecord      `json:"errors" yaml:"errors"`
+}
+
+type operationStatsRecord struct {
+	Operation string  `json:"operation" yaml:"operation"`
+	Count     int     `json:"count" yaml:"count"`
+	MinResult int     `json:"minResult" yaml:"minResult"`
+	MaxResult int     `json:"maxResult" yaml:"maxResult"`
+	AvgResult float64 `json:"avgResult" yaml:"avgResult"`
+}
+
+type histogramBucketRecord struct {
+	Start time.Time `json:"start" yaml:"start"`
+	Count int       `json:"count" yaml:"count"`
+}
+
+type operandPairRecord struct {
+	Operation string `json:"operation" yaml:"operation"`
+	A         int    `json:"a" yaml:"a"`
+	B         int    `json:"b" yaml:"b"`
+	Count     int    `json:"count" yaml:"count"`
+}
+
+type errorStatsRecord struct {
+	Operation string  `json:"operation" yaml:"operation"`
+	Finished  int     `json:"finished" yaml:"finished"`
+	Failed    int     `json:"failed" yaml:"failed"`
+	Rate      float64 `json:"rate" yaml:"rate"`
+}
+
+// statistics prints the per-operation summary followed by the histogram,
+// the most frequent operand pairs and the job error rates.
+func (p *printer) statistics(stats *domain.Statistics) error {
+	record := statisticsRecord{
+		From:       stats.From,
+		To:         stats.To,
+		Bucket:     string(stats.Bucket),
+		Total:      stats.Total,
+		Operations: make([]operationStatsRecord, 0, len(stats.Operations)),
+		Histogram:  make([]histogramBucketRecord, 0, len(stats.Histogram)),
+		TopPairs:   make([]operandPairRecord, 0, len(stats.TopPairs)),
+		Errors:     make([]errorStatsRecord, 0, len(stats.Errors)),
+	}
+	for _, op := range stats.Operations {
+		record.Operations = append(record.Operations, operationStatsRecord(op))
+	}
+	for _, b := range stats.Histogram {
+		record.Histogram = append(record.Histogram, histogramBucketRecord(b))
+	}
+	for _, pair := range stats.TopPairs {
+		record.TopPairs = append(record.TopPairs, operandPairRecord(pair))
+	}
+	for _, e := range stats.Errors {
+		record.Errors = append(record.Errors, errorStatsRecord{Operation: e.Operation, Finished: e.Finished, Failed: e.Failed, Rate: e.Rate()})
+	}
+
+	return p.print(record, "OPERATION\tCOUNT\tMIN\tMAX\tAVG", func(w io.Writer) {
+		for _, op := range record.Operations {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%.2f\n", op.Operation, op.Count, op.MinResult, op.MaxResult, op.AvgResult)
+		}
+		fmt.Fprintf(w, "total\t%d\t\t\t\n", record.Total)
+
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "%s\tCOUNT\n", strings.ToUpper(record.Bucket))
+		for _, b := range record.Histogram {
+			fmt.Fprintf(w, "%s\t%d\n", b.Start.Format(time.RFC3339), b.Count)
+		}
+
+		if len(record.TopPairs) > 0 {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, "OPERATION\tA\tB\tCOUNT")
+			for _, pair := range record.TopPairs {
+				fmt.Fprintf(w, "%s\t%d\t%d\t%d\n", pair.Operation, pair.A, pair.B, pair.Count)
+			}
+		}
+
+		if len(record.Errors) > 0 {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, "JOB OPERATION\tFINISHED\tFAILED\tRATE")
+			for _, e := range record.Errors {
+				fmt.Fprintf(w, "%s\t%d\t%d\t%.1f%%\n", e.Operation, e.Finished, e.Failed, e.Rate*100)
+			}
+		}
+	})
+}
+
+// chainVerificationRecord is the printable form of an audit chain verification.
+type chainVerificationRecord struct {
+	TenantID  string            `json:"tenantId" yaml:"tenantId"`
+	Valid     bool              `json:"valid" yaml:"valid"`
+	Checked   int               `json:"checked" yaml:"checked"`
+	Unchained int               `json:"unchained" yaml:"unchained"`
+	Truncated bool              `json:"truncated" yaml:"truncated"`
+	Removed   int               `json:"removed" yaml:"removed"`
+	Head      string            `json:"head" yaml:"head"`
+	Broken    *chainBreakRecord `json:"broken,omitempty" yaml:"broken,omitempty"`
+}
+
+type chainBreakRecord struct {
+	ID       string `json:"id" yaml:"id"`
+	Seq      int    `json:"seq" yaml:"seq"`
+	Reason   string `json:"reason" yaml:"reason"`
+	Expected string `json:"expected" yaml:"expected"`
+	Actual   string `json:"actual" yaml:"actual"`
+}
+
+// chainVerification prints the outcome of an audit chain verification and
+// the first broken link, if any.
+func (p *printer) chainVerification(report *domain.ChainVerification) error {
+	record := chainVerificationRecord{
+		TenantID:  report.TenantID,
+		Valid:     report.Valid(),
+		Checked:   report.Checked,
+		Unchained: report.Unchained,
+		Truncated: report.Truncated,
+		Removed:   report.Removed,
+		Head:      report.Head,
+	}
+	if b := report.Broken; b != nil {
+		record.Broken = &chainBreakRecord{ID: b.ID, Seq: b.Seq, Reason: string(b.Reason), Expected: b.Expected, Actual: b.Actual}
+	}
+
+	return p.print(record, "TENANT\tVALID\tCHECKED\tUNCHAINED\tTRUNCATED\tREMOVED\tHEAD", func(w io.Writer) {
+		fmt.Fprintf(w, "%s\t%t\t%d\t%d\t%t\t%d\t%s\n", record.TenantID, record.Valid, record.Checked, record.Unchained, record.Truncated, record.Removed, record.Head)
+		if b := record.Broken; b != nil {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, "BROKEN AT\tSEQ\tREASON\tEXPECTED\tACTUAL")
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", b.ID, b.Seq, b.Reason, b.Expected, b.Actual)
+		}
+	})
+}
+
+// tenantRecord is the printable form of a tenant.
+type tenantRecord struct {
+	ID                string     `json:"id" yaml:"id"`
+	Name              string     `json:"name" yaml:"name"`
+	AllowedOperations []string   `json:"allowedOperations" yaml:"allowedOperations"`
+	MaxOperand        int        `json:"maxOperand" yaml:"maxOperand"`
+	MaxPendingJobs    int        `json:"maxPendingJobs" yaml:"maxPendingJobs"`
+	CreatedAt         time.Time  `json:"createdAt" yaml:"createdAt"`
+	DisabledAt        *time.Time `json:"disabledAt,omitempty" yaml:"disabledAt,omitempty"`
+}
+
+// tenants prints tenants with their configuration.
+func (p *printer) tenants(tenants []domain.Tenant) error {
+	records := make([]tenantRecord, 0, len(tenants))
+	for _, t := range tenants {
+		records = append(records, tenantRecord{
+			ID:                t.ID,
+			Name:              t.Name,
+			AllowedOperations: t.AllowedOperations,
+			MaxOperand:        t.MaxOperand,
+			MaxPendingJobs:    t.MaxPendingJobs,
+			CreatedAt:         t.CreatedAt,
+			DisabledAt:        t.DisabledAt,
+		})
+	}
+
+	return p.print(records, "ID\tNAME\tOPERATIONS\tMAX OPERAND\tMAX PENDING JOBS\tDISABLED AT", func(w io.Writer) {
+		for _, r := range records {
+			operations := strings.Join(r.AllowedOperations, ",")
+			if operations == "" {
+				operations = "all"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", r.ID, r.Name, operations, limit(r.MaxOperand), limit(r.MaxPendingJobs), formatTime(r.DisabledAt))
+		}
+	})
+}
+
+// operationRecord is the printable form of an operation signature.
+type operationRecord struct {
+	Name        string   `json:"name" yaml:"name"`
+	Arity       int      `json:"arity" yaml:"arity"`
+	Operands    []string `json:"operands" yaml:"operands"`
+	Commutative bool     `json:"commutative" yaml:"commutative"`
+	Description string   `json:"description" yaml:"description"`
+}
+
+// operations prints operation signatures.
+func (p *printer) operations(signatures []domain.OperationSignature) error {
+	records := make([]operationRecord, 0, len(signatures))
+	for _, sig := range signatures {
+		records = append(records, operationRecord{
+			Name:        sig.Name,
+			Arity:       sig.Arity,
+			Operands:    sig.Operands,
+			Commutative: sig.Commutative,
+			Description: sig.Description,
+		})
+	}
+
+	return p.print(records, "NAME\tOPERANDS\tCOMMUTATIVE\tDESCRIPTION", func(w io.Writer) {
+		for _, r := range records {
+			fmt.Fprintf(w, "%s\t%s\t%t\t%s\n", r.Name, strings.Join(r.Operands, ","), r.Commutative, r.Description)
+		}
+	})
+}
+
+// functionRecord is the printable form of a function signature.
+type functionRecord struct {
+	Kind        domain.Kind `json:"kind" yaml:"kind"`
+	Name        string      `json:"name" yaml:"name"`
+	Arity       int         `json:"arity" yaml:"arity"`
+	Operands    []string    `json:"operands" yaml:"operands"`
+	Options     []string    `json:"options" yaml:"options"`
+	Description string      `json:"description" yaml:"description"`
+}
+
+// functions prints function signatures.
+func (p *printer) functions(signatures []domain.FunctionSignature) error {
+	records := make([]functionRecord, 0, len(signatures))
+	for _, sig := range signatures {
+		records = append(records, functionRecord{
+			Kind:        sig.Kind,
+			Name:        sig.Name,
+			Arity:       sig.Arity,
+			Operands:    sig.Operands,
+			Options:     sig.Options,
+			Description: sig.Description,
+		})
+	}
+
+	return p.print(records, "KIND\tNAME\tOPERANDS\tOPTIONS\tDESCRIPTION", func(w io.Writer) {
+		for _, r := range records {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.Kind, r.Name, strings.Join(r.Operands, ","), strings.Join(r.Options, ","), r.Description)
+		}
+	})
+}
+
+// limit renders a limit where 0 means unlimited.
+func limit(v int) string {
+	if v == 0 {
+		return "-"
+	}
+	return strconv.Itoa(v)
+}
+
+// print encodes v as JSON or YAML, or renders a table from header and rows.
+func (p *printer) print(v any, header string, rows func(w io.Writer)) error {
+	switch p.format {
+	case formatJSON:
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case formatYAML:
+		enc := yaml.NewEncoder(p.w)
+		enc.SetIndent(2)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		return enc.Close()
+	default:
+		tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, header)
+		rows(tw)
+		return tw.Flush()
+	}
+}
+
+const calculationHeader = "ID\tOPERATION\tA\tB\tRESULT\tCREATED AT\tDELETED AT"
+
+func writeCalculationRow(w io.Writer, r calculationRecord) {
+	id := r.ID
+	if id == "" {
+		id = "-"
+	}
+	if r.Kind != "" {
+		// Functions have any number of textual operands; they share the A
+		// column and B is left empty. Details follow the value.
+		value := r.Value
+		for _, name := range slices.Sorted(maps.Keys(r.Details)) {
+			value += " " + name + "=" + r.Details[name]
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t-\t%s\t%s\t%s\n", id, r.Operation, strings.Join(r.Operands, ","), value, formatTime(r.CreatedAt), formatTime(r.DeletedAt))
+		return
+	}
+	fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%s\t%s\n", id, r.Operation, r.A, r.B, r.Result, formatTime(r.CreatedAt), formatTime(r.DeletedAt))
+}
+
+// formatTime renders an optional timestamp for tables.
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"go-prisma-calculator/internal/infrastructure/providers"
+	"go-prisma-calculator/internal/infrastructure/retention"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+)
+
+// retentionRecord is the printable outcome of one retention rule.
+type retentionRecord struct {
+	Rule         string `json:"rule" yaml:"rule"`
+	Action       string `json:"action" yaml:"action"`
+	Calculations int    `json:"calculations" yaml:"calculations"`
+}
+
+// newRetentionCommand builds the "retention" subcommand, which applies the
+// configured retention policy on demand.
+func newRetentionCommand(opts *globalOptions) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "retention",
+		Short: "Expire old calculations",
+	}
+
+	run := &cobra.Command{
+		Use:   "run",
+		Short: "Apply the retention policy once",
+		Long: `Applies the RETENTION_* policy from the configuration once, deleting or
+archiving expired calculations exactly as the scheduled enforcer would.
+RETENTION_ENABLED does not need to be set.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.remote != "" {
+				return errors.New("retention runs against the database directly and does not support --remote")
+			}
+
+			cfg, err := opts.loadConfig()
+			if err != nil {
+				return err
+			}
+			if dryRun {
+				cfg.RetentionDryRun = true
+			}
+
+			var enforcer *retention.Enforcer
+			app := fx.New(
+				providers.Core,
+				fx.NopLogger,
+				fx.Replace(cfg, cliLogger()),
+				fx.StartTimeout(cfg.DBConnectTimeout+5*time.Second),
+				fx.Populate(&enforcer),
+			)
+			if err := app.Err(); err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			if err := app.Start(ctx); err != nil {
+				return err
+			}
+			defer func() {
+				stopCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+				defer cancel()
+				app.Stop(stopCtx)
+			}()
+
+			if !enforcer.Policy().Enabled() {
+				return errors.New("no retention policy is configured")
+			}
+
+			report, err := enforcer.RunOnce(ctx)
+			if err != nil {
+				return err
+			}
+			return newPrinter(cmd, opts.output).retention(toRetentionRecords(report, cfg.RetentionMode))
+		},
+	}
+	run.Flags().BoolVar(&dryRun, "dry-run", false, "only report what would be expired")
+
+	cmd.AddCommand(run)
+	return cmd
+}
+
+// toRetentionRecords lists the rules of a report in a stable order.
+func toRetentionRecords(report retention.Report, mode string) []retentionRecord {
+	action := "deleted"
+	switch {
+	case report.DryRun:
+		action = "would expire"
+	case retention.Mode(mode) == retention.ModeArchive:
+		action = "archived"
+	}
+
+	var records []retentionRecord
+	for _, rule := range []string{retention.RuleOperationMaxAge, retention.RuleMaxAge, retention.RuleMaxRows} {
+		records = append(records, retentionRecord{Rule: rule, Action: action, Calculations: report.Expired[rule]})
+	}
+	return records
+}
+
+// retention prints the outcome of a retention run.
+func (p *printer) retention(records []retentionRecord) error {
+	return p.print(records, "RULE\tACTION\tCALCULATIONS", func(w io.Writer) {
+		for _, r := range records {
+			fmt.Fprintf(w, "%s\t%s\t%d\n", r.Rule, r.Action, r.Calculations)
+		}
+	})
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+
+	domain "go-prisma-calculator/internal/domain/models"
+
+	"github.com/spf13/cobra"
+)
+
+// newTenantCommand builds the "tenant" subcommand, which administers tenants.
+// Remote servers only accept it with an admin API key.
+func newTenantCommand(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tenant",
+		Short: "Create, configure and disable tenants",
+	}
+	cmd.AddCommand(
+		newTenantCreateCommand(opts),
+		newTenantListCommand(opts),
+		newTenantUpdateCommand(opts),
+		newTenantStateCommand(opts, "disable", "Reject further requests to a tenant", backend.DisableTenant),
+		newTenantStateCommand(opts, "enable", "Accept requests to a disabled tenant again", backend.EnableTenant),
+	)
+	return cmd
+}
+
+func newTenantCreateCommand(opts *globalOptions) *cobra.Command {
+	var tenant domain.Tenant
+
+	cmd := &cobra.Command{
+		Use:   "create ID",
+		Short: "Create a tenant",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := opts.openBackend(cmd)
+			if err != nil {
+				return err
+			}
+			defer b.Close()
+
+			tenant.ID = args[0]
+			if tenant.Name == "" {
+				tenant.Name = tenant.ID
+			}
+			created, err := b.CreateTenant(cmd.Context(), tenant)
+			if err != nil {
+				return err
+			}
+			return newPrinter(cmd, opts.output).tenants([]domain.Tenant{*created})
+		},
+	}
+
+	addTenantFlags(cmd, &tenant)
+	return cmd
+}
+
+func newTenantListCommand(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List every tenant",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := opts.openBackend(cmd)
+			if err != nil {
+				return err
+			}
+			defer b.Close()
+
+			tenants, err := b.ListTenants(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return newPrinter(cmd, opts.output).tenants(tenants)
+		},
+	}
+}
+
+func newTenantUpdateCommand(opts *globalOptions) *cobra.Command {
+	var tenant domain.Tenant
+
+	cmd := &cobra.Command{
+		Use:   "update ID",
+		Short: "Replace the name, allowed operations and limits of a tenant",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := opts.openBackend(cmd)
+			if err != nil {
+				return err
+			}
+			defer b.Close()
+
+			tenant.ID = args[0]
+			if tenant.Name == "" {
+				tenant.Name = tenant.ID
+			}
+			updated, err := b.UpdateTenant(cmd.Context(), tenant)
+			if err != nil {
+				return err
+			}
+			return newPrinter(cmd, opts.output).tenants([]domain.Tenant{*updated})
+		},
+	}
+
+	addTenantFlags(cmd, &tenant)
+	return cmd
+}
+
+// newTenantStateCommand builds "disable" or "enable", which differ only in
+// the backend method they call.
+func newTenantStateCommand(opts *globalOptions, use, short string, apply func(backend, context.Context, string) (*domain.Tenant, error)) *cobra.Command {
+	return &cobra.Command{
+		Use:   use + " ID",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := opts.openBackend(cmd)
+			if err != nil {
+				return err
+			}
+			defer b.Close()
+
+			tenant, err := apply(b, cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			return newPrinter(cmd, opts.output).tenants([]domain.Tenant{*tenant})
+		},
+	}
+}
+
+func addTenantFlags(cmd *cobra.Command, tenant *domain.Tenant) {
+	cmd.Flags().StringVar(&tenant.Name, "name", "", "display name (default: the ID)")
+	cmd.Flags().StringSliceVar(&tenant.AllowedOperations, "allowed-operations", nil, "operations the tenant may run, e.g. add,divide (default: all)")
+	cmd.Flags().IntVar(&tenant.MaxOperand, "max-operand", 0, "largest absolute operand value; 0 is unlimited")
+	cmd.Flags().IntVar(&tenant.MaxPendingJobs, "max-pending-jobs", 0, "most unfinished jobs at a time; 0 is unlimited")
+}
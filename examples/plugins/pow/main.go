@@ -0,0 +1,49 @@
+//go:build wasip1
+
+// Command pow is an example calculator plugin that raises an integer to a
+// non-negative power. It implements the ABI documented in
+// internal/infrastructure/plugins. Build it into the plugin directory with:
+//
+//	GOOS=wasip1 GOARCH=wasm go build -buildmode=c-shared -o plugins/pow.wasm ./examples/plugins/pow
+package main
+
+import "unsafe"
+
+// metadata is returned by calc_metadata; it must stay reachable.
+var metadata = []byte(`{"name":"pow","arity":2,"description":"Raises base to a non-negative exponent; wraps around on overflow.","operands":["base","exponent"],"commutative":false}`)
+
+// Status codes of calc_evaluate.
+const (
+	statusOK       = 0
+	statusRejected = 1
+)
+
+//go:wasmexport calc_abi_version
+func abiVersion() int32 { return 1 }
+
+//go:wasmexport calc_metadata
+func calcMetadata() int64 {
+	ptr := uintptr(unsafe.Pointer(unsafe.SliceData(metadata)))
+	return int64(ptr)<<32 | int64(len(metadata))
+}
+
+//go:wasmexport calc_evaluate
+func calcEvaluate(base, exponent int32) int64 {
+	if exponent < 0 {
+		return result(statusRejected, 0)
+	}
+	power := int32(1)
+	for ; exponent > 0; exponent >>= 1 {
+		if exponent&1 == 1 {
+			power *= base
+		}
+		base *= base
+	}
+	return result(statusOK, power)
+}
+
+func result(status uint32, value int32) int64 {
+	return int64(uint64(status)<<32 | uint64(uint32(value)))
+}
+
+func main() {}
@@ -4,14 +4,14 @@
 // 	protoc        v6.32.0
 // source: calculator.proto
 
-// The package name should match the Go package for consistency.
-
 package proto
 
 import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -24,6 +24,7 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// --- Messages ---
 // AddRequest defines the structure for an addition RPC call.
 type AddRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -77,11 +78,21 @@ func (x *AddRequest) GetB() int32 {
 	return 0
 }
 
-// DivideRequest defines the structure for a division RPC call.
+// DivideRequest defines the structure for a division RPC call. Without a
+// rounding mode or scale the quotient is truncated to an integer; with one
+// of them the quotient is rounded to scale digits after the decimal point
+// and returned in the value of the response.
 type DivideRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Dividend      int32                  `protobuf:"varint,1,opt,name=dividend,proto3" json:"dividend,omitempty"`
-	Divisor       int32                  `protobuf:"varint,2,opt,name=divisor,proto3" json:"divisor,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Dividend int32                  `protobuf:"varint,1,opt,name=dividend,proto3" json:"dividend,omitempty"`
+	Divisor  int32                  `protobuf:"varint,2,opt,name=divisor,proto3" json:"divisor,omitempty"`
+	// rounding is "truncate" (the default), "floor", "ceiling", "half-up",
+	// "half-even" or "half-away-from-zero".
+	Rounding string `protobuf:"bytes,3,opt,name=rounding,proto3" json:"rounding,omitempty"`
+	// scale is the number of digits after the decimal point, from 0 to 100.
+	// With a rounding mode or scale, the division is recorded as a "divide"
+	// calculation of kind "rational", with the rounding mode and scale.
+	Scale         *int32 `protobuf:"varint,4,opt,name=scale,proto3,oneof" json:"scale,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -130,28 +141,44 @@ func (x *DivideRequest) GetDivisor() int32 {
 	return 0
 }
 
-// CalculationResponse is the generic response for all calculation RPCs.
-type CalculationResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Result        int32                  `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+func (x *DivideRequest) GetRounding() string {
+	if x != nil {
+		return x.Rounding
+	}
+	return ""
+}
+
+func (x *DivideRequest) GetScale() int32 {
+	if x != nil && x.Scale != nil {
+		return *x.Scale
+	}
+	return 0
+}
+
+// CalculateRequest runs any registered operation; see ListOperations.
+type CalculateRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Operation string                 `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	// operands must match the arity of the operation.
+	Operands      []int32 `protobuf:"varint,2,rep,packed,name=operands,proto3" json:"operands,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CalculationResponse) Reset() {
-	*x = CalculationResponse{}
+func (x *CalculateRequest) Reset() {
+	*x = CalculateRequest{}
 	mi := &file_calculator_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CalculationResponse) String() string {
+func (x *CalculateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CalculationResponse) ProtoMessage() {}
+func (*CalculateRequest) ProtoMessage() {}
 
-func (x *CalculationResponse) ProtoReflect() protoreflect.Message {
+func (x *CalculateRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_calculator_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -163,71 +190,3916 @@ func (x *CalculationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CalculationResponse.ProtoReflect.Descriptor instead.
-func (*CalculationResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CalculateRequest.ProtoReflect.Descriptor instead.
+func (*CalculateRequest) Descriptor() ([]byte, []int) {
 	return file_calculator_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *CalculationResponse) GetResult() int32 {
+func (x *CalculateRequest) GetOperation() string {
 	if x != nil {
-		return x.Result
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *CalculateRequest) GetOperands() []int32 {
+	if x != nil {
+		return x.Operands
+	}
+	return nil
+}
+
+// ListOperationsRequest lists the operations the caller may run.
+type ListOperationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOperationsRequest) Reset() {
+	*x = ListOperationsRequest{}
+	mi := &file_calculator_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOperationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOperationsRequest) ProtoMessage() {}
+
+func (x *ListOperationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOperationsRequest.ProtoReflect.Descriptor instead.
+func (*ListOperationsRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{3}
+}
+
+// ListOperationsResponse describes the available operations.
+type ListOperationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operations    []*OperationSignature  `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOperationsResponse) Reset() {
+	*x = ListOperationsResponse{}
+	mi := &file_calculator_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOperationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOperationsResponse) ProtoMessage() {}
+
+func (x *ListOperationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOperationsResponse.ProtoReflect.Descriptor instead.
+func (*ListOperationsResponse) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListOperationsResponse) GetOperations() []*OperationSignature {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+// OperationSignature describes a registered operation.
+type OperationSignature struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Arity       int32                  `protobuf:"varint,2,opt,name=arity,proto3" json:"arity,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// operands names each operand in order, e.g. "dividend" and "divisor".
+	Operands      []string `protobuf:"bytes,4,rep,name=operands,proto3" json:"operands,omitempty"`
+	Commutative   bool     `protobuf:"varint,5,opt,name=commutative,proto3" json:"commutative,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperationSignature) Reset() {
+	*x = OperationSignature{}
+	mi := &file_calculator_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationSignature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationSignature) ProtoMessage() {}
+
+func (x *OperationSignature) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationSignature.ProtoReflect.Descriptor instead.
+func (*OperationSignature) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *OperationSignature) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OperationSignature) GetArity() int32 {
+	if x != nil {
+		return x.Arity
 	}
 	return 0
 }
 
-var File_calculator_proto protoreflect.FileDescriptor
+func (x *OperationSignature) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
 
-const file_calculator_proto_rawDesc = "" +
-	"\n" +
-	"\x10calculator.proto\x12\x05proto\x1a\x1cgoogle/api/annotations.proto\"(\n" +
-	"\n" +
-	"AddRequest\x12\f\n" +
-	"\x01a\x18\x01 \x01(\x05R\x01a\x12\f\n" +
-	"\x01b\x18\x02 \x01(\x05R\x01b\"E\n" +
-	"\rDivideRequest\x12\x1a\n" +
-	"\bdividend\x18\x01 \x01(\x05R\bdividend\x12\x18\n" +
-	"\adivisor\x18\x02 \x01(\x05R\adivisor\"-\n" +
-	"\x13CalculationResponse\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\x05R\x06result2\xb0\x01\n" +
-	"\x11CalculatorService\x12H\n" +
-	"\x03Add\x12\x11.proto.AddRequest\x1a\x1a.proto.CalculationResponse\"\x12\x82\xd3\xe4\x93\x02\f:\x01*\"\a/v1/add\x12Q\n" +
-	"\x06Divide\x12\x14.proto.DivideRequest\x1a\x1a.proto.CalculationResponse\"\x15\x82\xd3\xe4\x93\x02\x0f:\x01*\"\n" +
-	"/v1/divideB&Z$go-prisma-calculator/generated/protob\x06proto3"
+func (x *OperationSignature) GetOperands() []string {
+	if x != nil {
+		return x.Operands
+	}
+	return nil
+}
 
-var (
-	file_calculator_proto_rawDescOnce sync.Once
-	file_calculator_proto_rawDescData []byte
-)
+func (x *OperationSignature) GetCommutative() bool {
+	if x != nil {
+		return x.Commutative
+	}
+	return false
+}
 
-func file_calculator_proto_rawDescGZIP() []byte {
-	file_calculator_proto_rawDescOnce.Do(func() {
-		file_calculator_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_calculator_proto_rawDesc), len(file_calculator_proto_rawDesc)))
-	})
-	return file_calculator_proto_rawDescData
+// EvaluateRequest evaluates a scientific function; see ListFunctions.
+type EvaluateRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Function string                 `protobuf:"bytes,1,opt,name=function,proto3" json:"function,omitempty"`
+	// operands are numbers in text form, e.g. "0.5" or "-1e3"; bigint
+	// operands are integers of any size, e.g. "12345678901234567890" or
+	// "0xffffffffffffffff"; rational operands are integers, fractions or
+	// decimals, e.g. "-7/2" or "0.125"; complex operands are written as
+	// "1+2i", or sent as complex_operands instead; matrix operands are
+	// JSON arrays of rows, e.g. "[[1,2],[3,4]]", or sent as matrix_operands
+	// instead.
+	Operands []string `protobuf:"bytes,2,rep,name=operands,proto3" json:"operands,omitempty"`
+	// kind is "float" (the default), "decimal", "bigint", "rational",
+	// "complex" or "matrix".
+	Kind string `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	// angle is "radians" (the default) or "degrees" for trigonometric
+	// functions and their inverses.
+	Angle string `protobuf:"bytes,4,opt,name=angle,proto3" json:"angle,omitempty"`
+	// precision is the number of significant digits of decimal results,
+	// from 1 to 100; 0 selects the default of 20.
+	Precision int32 `protobuf:"varint,5,opt,name=precision,proto3" json:"precision,omitempty"`
+	// scale is the number of digits after the decimal point of the decimal
+	// rendering of a rational result, from 0 to 100; unset selects 20.
+	Scale *int32 `protobuf:"varint,6,opt,name=scale,proto3,oneof" json:"scale,omitempty"`
+	// division is "truncated" (the default), "floored" or "euclidean" and
+	// selects how divmod rounds its quotient.
+	Division string `protobuf:"bytes,7,opt,name=division,proto3" json:"division,omitempty"`
+	// rounding selects how the decimal rendering of a rational result is
+	// rounded to its scale; see DivideRequest. It defaults to
+	// "half-away-from-zero".
+	Rounding string `protobuf:"bytes,8,opt,name=rounding,proto3" json:"rounding,omitempty"`
+	// complex_operands are complex operands as real/imag pairs. They replace
+	// operands, and kind defaults to "complex" with them.
+	ComplexOperands []*Complex `protobuf:"bytes,9,rep,name=complex_operands,json=complexOperands,proto3" json:"complex_operands,omitempty"`
+	// matrix_operands are matrix operands. They replace operands, and kind
+	// defaults to "matrix" with them.
+	MatrixOperands []*Matrix `protobuf:"bytes,10,rep,name=matrix_operands,json=matrixOperands,proto3" json:"matrix_operands,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-var file_calculator_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
-var file_calculator_proto_goTypes = []any{
-	(*AddRequest)(nil),          // 0: proto.AddRequest
-	(*DivideRequest)(nil),       // 1: proto.DivideRequest
-	(*CalculationResponse)(nil), // 2: proto.CalculationResponse
+func (x *EvaluateRequest) Reset() {
+	*x = EvaluateRequest{}
+	mi := &file_calculator_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
-var file_calculator_proto_depIdxs = []int32{
-	0, // 0: proto.CalculatorService.Add:input_type -> proto.AddRequest
-	1, // 1: proto.CalculatorService.Divide:input_type -> proto.DivideRequest
-	2, // 2: proto.CalculatorService.Add:output_type -> proto.CalculationResponse
-	2, // 3: proto.CalculatorService.Divide:output_type -> proto.CalculationResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+
+func (x *EvaluateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func init() { file_calculator_proto_init() }
-func file_calculator_proto_init() {
-	if File_calculator_proto != nil {
-		return
+func (*EvaluateRequest) ProtoMessage() {}
+
+func (x *EvaluateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateRequest.ProtoReflect.Descriptor instead.
+func (*EvaluateRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *EvaluateRequest) GetFunction() string {
+	if x != nil {
+		return x.Function
+	}
+	return ""
+}
+
+func (x *EvaluateRequest) GetOperands() []string {
+	if x != nil {
+		return x.Operands
+	}
+	return nil
+}
+
+func (x *EvaluateRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *EvaluateRequest) GetAngle() string {
+	if x != nil {
+		return x.Angle
+	}
+	return ""
+}
+
+func (x *EvaluateRequest) GetPrecision() int32 {
+	if x != nil {
+		return x.Precision
+	}
+	return 0
+}
+
+func (x *EvaluateRequest) GetScale() int32 {
+	if x != nil && x.Scale != nil {
+		return *x.Scale
+	}
+	return 0
+}
+
+func (x *EvaluateRequest) GetDivision() string {
+	if x != nil {
+		return x.Division
+	}
+	return ""
+}
+
+func (x *EvaluateRequest) GetRounding() string {
+	if x != nil {
+		return x.Rounding
+	}
+	return ""
+}
+
+func (x *EvaluateRequest) GetComplexOperands() []*Complex {
+	if x != nil {
+		return x.ComplexOperands
+	}
+	return nil
+}
+
+func (x *EvaluateRequest) GetMatrixOperands() []*Matrix {
+	if x != nil {
+		return x.MatrixOperands
+	}
+	return nil
+}
+
+// Complex is a complex number.
+type Complex struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Real          float64                `protobuf:"fixed64,1,opt,name=real,proto3" json:"real,omitempty"`
+	Imag          float64                `protobuf:"fixed64,2,opt,name=imag,proto3" json:"imag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Complex) Reset() {
+	*x = Complex{}
+	mi := &file_calculator_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Complex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Complex) ProtoMessage() {}
+
+func (x *Complex) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Complex.ProtoReflect.Descriptor instead.
+func (*Complex) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Complex) GetReal() float64 {
+	if x != nil {
+		return x.Real
+	}
+	return 0
+}
+
+func (x *Complex) GetImag() float64 {
+	if x != nil {
+		return x.Imag
+	}
+	return 0
+}
+
+// Matrix is a matrix of doubles. Vectors are matrices of one row or one
+// column, and scalar results such as determinants are 1×1 matrices.
+type Matrix struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rows          []*MatrixRow           `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Matrix) Reset() {
+	*x = Matrix{}
+	mi := &file_calculator_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Matrix) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Matrix) ProtoMessage() {}
+
+func (x *Matrix) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Matrix.ProtoReflect.Descriptor instead.
+func (*Matrix) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Matrix) GetRows() []*MatrixRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+// MatrixRow is a row of a Matrix; every row has the same length.
+type MatrixRow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []float64              `protobuf:"fixed64,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatrixRow) Reset() {
+	*x = MatrixRow{}
+	mi := &file_calculator_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatrixRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatrixRow) ProtoMessage() {}
+
+func (x *MatrixRow) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatrixRow.ProtoReflect.Descriptor instead.
+func (*MatrixRow) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MatrixRow) GetValues() []float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// EvaluationResponse is the result of a scientific function.
+type EvaluationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Value string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// id and created_at identify the recorded calculation. id is empty, and
+	// there is no receipt, when it was not recorded in degraded mode.
+	Id        string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Receipt   *Receipt               `protobuf:"bytes,4,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	Kind      string                 `protobuf:"bytes,5,opt,name=kind,proto3" json:"kind,omitempty"`
+	// operands are the operands in canonical form.
+	Operands []string `protobuf:"bytes,6,rep,name=operands,proto3" json:"operands,omitempty"`
+	// angle, precision, scale and division are the options that affected
+	// the value.
+	Angle     string `protobuf:"bytes,7,opt,name=angle,proto3" json:"angle,omitempty"`
+	Precision int32  `protobuf:"varint,8,opt,name=precision,proto3" json:"precision,omitempty"`
+	// details are further results, e.g. "decimal", the decimal rendering of
+	// a rational value, or "remainder", the remainder of divmod.
+	Details  map[string]string `protobuf:"bytes,9,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Scale    *int32            `protobuf:"varint,10,opt,name=scale,proto3,oneof" json:"scale,omitempty"`
+	Division string            `protobuf:"bytes,11,opt,name=division,proto3" json:"division,omitempty"`
+	Rounding string            `protobuf:"bytes,12,opt,name=rounding,proto3" json:"rounding,omitempty"`
+	// complex_value and complex_operands repeat value and operands as
+	// real/imag pairs for kind "complex".
+	ComplexValue    *Complex   `protobuf:"bytes,13,opt,name=complex_value,json=complexValue,proto3" json:"complex_value,omitempty"`
+	ComplexOperands []*Complex `protobuf:"bytes,14,rep,name=complex_operands,json=complexOperands,proto3" json:"complex_operands,omitempty"`
+	// matrix_value and matrix_operands repeat value and operands as
+	// matrices for kind "matrix".
+	MatrixValue    *Matrix   `protobuf:"bytes,15,opt,name=matrix_value,json=matrixValue,proto3" json:"matrix_value,omitempty"`
+	MatrixOperands []*Matrix `protobuf:"bytes,16,rep,name=matrix_operands,json=matrixOperands,proto3" json:"matrix_operands,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *EvaluationResponse) Reset() {
+	*x = EvaluationResponse{}
+	mi := &file_calculator_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluationResponse) ProtoMessage() {}
+
+func (x *EvaluationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluationResponse.ProtoReflect.Descriptor instead.
+func (*EvaluationResponse) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *EvaluationResponse) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *EvaluationResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EvaluationResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *EvaluationResponse) GetReceipt() *Receipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+func (x *EvaluationResponse) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *EvaluationResponse) GetOperands() []string {
+	if x != nil {
+		return x.Operands
+	}
+	return nil
+}
+
+func (x *EvaluationResponse) GetAngle() string {
+	if x != nil {
+		return x.Angle
+	}
+	return ""
+}
+
+func (x *EvaluationResponse) GetPrecision() int32 {
+	if x != nil {
+		return x.Precision
+	}
+	return 0
+}
+
+func (x *EvaluationResponse) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+func (x *EvaluationResponse) GetScale() int32 {
+	if x != nil && x.Scale != nil {
+		return *x.Scale
+	}
+	return 0
+}
+
+func (x *EvaluationResponse) GetDivision() string {
+	if x != nil {
+		return x.Division
+	}
+	return ""
+}
+
+func (x *EvaluationResponse) GetRounding() string {
+	if x != nil {
+		return x.Rounding
+	}
+	return ""
+}
+
+func (x *EvaluationResponse) GetComplexValue() *Complex {
+	if x != nil {
+		return x.ComplexValue
+	}
+	return nil
+}
+
+func (x *EvaluationResponse) GetComplexOperands() []*Complex {
+	if x != nil {
+		return x.ComplexOperands
+	}
+	return nil
+}
+
+func (x *EvaluationResponse) GetMatrixValue() *Matrix {
+	if x != nil {
+		return x.MatrixValue
+	}
+	return nil
+}
+
+func (x *EvaluationResponse) GetMatrixOperands() []*Matrix {
+	if x != nil {
+		return x.MatrixOperands
+	}
+	return nil
+}
+
+// ListFunctionsRequest lists the functions the caller may evaluate.
+type ListFunctionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFunctionsRequest) Reset() {
+	*x = ListFunctionsRequest{}
+	mi := &file_calculator_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFunctionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFunctionsRequest) ProtoMessage() {}
+
+func (x *ListFunctionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFunctionsRequest.ProtoReflect.Descriptor instead.
+func (*ListFunctionsRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{11}
+}
+
+// ListFunctionsResponse describes the available functions of every kind.
+type ListFunctionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Functions     []*FunctionSignature   `protobuf:"bytes,1,rep,name=functions,proto3" json:"functions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFunctionsResponse) Reset() {
+	*x = ListFunctionsResponse{}
+	mi := &file_calculator_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFunctionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFunctionsResponse) ProtoMessage() {}
+
+func (x *ListFunctionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFunctionsResponse.ProtoReflect.Descriptor instead.
+func (*ListFunctionsResponse) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListFunctionsResponse) GetFunctions() []*FunctionSignature {
+	if x != nil {
+		return x.Functions
+	}
+	return nil
+}
+
+// FunctionSignature describes a scientific function of a kind of number.
+type FunctionSignature struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Kind        string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Arity       int32                  `protobuf:"varint,3,opt,name=arity,proto3" json:"arity,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Operands    []string               `protobuf:"bytes,5,rep,name=operands,proto3" json:"operands,omitempty"`
+	Commutative bool                   `protobuf:"varint,6,opt,name=commutative,proto3" json:"commutative,omitempty"`
+	// options lists the options the function uses: "angle", "precision",
+	// "scale", "rounding" or "division".
+	Options       []string `protobuf:"bytes,7,rep,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FunctionSignature) Reset() {
+	*x = FunctionSignature{}
+	mi := &file_calculator_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FunctionSignature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FunctionSignature) ProtoMessage() {}
+
+func (x *FunctionSignature) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FunctionSignature.ProtoReflect.Descriptor instead.
+func (*FunctionSignature) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *FunctionSignature) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *FunctionSignature) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FunctionSignature) GetArity() int32 {
+	if x != nil {
+		return x.Arity
+	}
+	return 0
+}
+
+func (x *FunctionSignature) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *FunctionSignature) GetOperands() []string {
+	if x != nil {
+		return x.Operands
+	}
+	return nil
+}
+
+func (x *FunctionSignature) GetCommutative() bool {
+	if x != nil {
+		return x.Commutative
+	}
+	return false
+}
+
+func (x *FunctionSignature) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// CalculationResponse is the generic response for all calculation RPCs.
+type CalculationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// result is always set, except for a Divide request with a rounding mode
+	// or scale whose rounded quotient is not an integer of 32 bits.
+	Result *int32 `protobuf:"varint,1,opt,name=result,proto3,oneof" json:"result,omitempty"`
+	// id and created_at identify the recorded calculation. id is empty when
+	// the result was not recorded, e.g. a cache hit with CACHE_RECORD_HITS
+	// off or a calculation in degraded mode, and such results carry no
+	// receipt.
+	Id        string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// receipt is set when the server signs its results.
+	Receipt *Receipt `protobuf:"bytes,4,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	// value is the rounded quotient of a Divide request with a rounding mode
+	// or scale, e.g. "3.50"; result then holds it when the scale is 0 and it
+	// fits into 32 bits.
+	Value         string `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalculationResponse) Reset() {
+	*x = CalculationResponse{}
+	mi := &file_calculator_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalculationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalculationResponse) ProtoMessage() {}
+
+func (x *CalculationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalculationResponse.ProtoReflect.Descriptor instead.
+func (*CalculationResponse) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CalculationResponse) GetResult() int32 {
+	if x != nil && x.Result != nil {
+		return *x.Result
+	}
+	return 0
+}
+
+func (x *CalculationResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CalculationResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *CalculationResponse) GetReceipt() *Receipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+func (x *CalculationResponse) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// Receipt is a detached signature over the canonical operation, operands,
+// result, ID and creation time of a calculation. It is verified with the
+// public key named by key_id, published at /.well-known/calculator-keys.
+type Receipt struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	KeyId string                 `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// algorithm is always "Ed25519".
+	Algorithm     string `protobuf:"bytes,2,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Signature     []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Receipt) Reset() {
+	*x = Receipt{}
+	mi := &file_calculator_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Receipt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Receipt) ProtoMessage() {}
+
+func (x *Receipt) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Receipt.ProtoReflect.Descriptor instead.
+func (*Receipt) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Receipt) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *Receipt) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *Receipt) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+// SubmitJobRequest queues an operation for asynchronous execution.
+type SubmitJobRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// operation is the name of the calculation to run, e.g. "add" or "divide".
+	Operation     string `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	A             int32  `protobuf:"varint,2,opt,name=a,proto3" json:"a,omitempty"`
+	B             int32  `protobuf:"varint,3,opt,name=b,proto3" json:"b,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitJobRequest) Reset() {
+	*x = SubmitJobRequest{}
+	mi := &file_calculator_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitJobRequest) ProtoMessage() {}
+
+func (x *SubmitJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitJobRequest.ProtoReflect.Descriptor instead.
+func (*SubmitJobRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SubmitJobRequest) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetA() int32 {
+	if x != nil {
+		return x.A
+	}
+	return 0
+}
+
+func (x *SubmitJobRequest) GetB() int32 {
+	if x != nil {
+		return x.B
+	}
+	return 0
+}
+
+// GetJobRequest identifies a single job.
+type GetJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobRequest) Reset() {
+	*x = GetJobRequest{}
+	mi := &file_calculator_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobRequest) ProtoMessage() {}
+
+func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
+func (*GetJobRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// CancelJobRequest identifies the job to cancel.
+type CancelJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelJobRequest) Reset() {
+	*x = CancelJobRequest{}
+	mi := &file_calculator_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelJobRequest) ProtoMessage() {}
+
+func (x *CancelJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelJobRequest.ProtoReflect.Descriptor instead.
+func (*CancelJobRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CancelJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// ListJobsRequest filters and pages through submitted jobs.
+type ListJobsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// status limits the listing to one of: pending, running, succeeded, failed, cancelled.
+	Status        string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Limit         int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsRequest) Reset() {
+	*x = ListJobsRequest{}
+	mi := &file_calculator_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsRequest) ProtoMessage() {}
+
+func (x *ListJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListJobsRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListJobsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListJobsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListJobsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// ListJobsResponse contains one page of jobs, newest first.
+type ListJobsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jobs          []*Job                 `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsResponse) Reset() {
+	*x = ListJobsResponse{}
+	mi := &file_calculator_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsResponse) ProtoMessage() {}
+
+func (x *ListJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListJobsResponse) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListJobsResponse) GetJobs() []*Job {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+// Job describes an asynchronous calculation and, once finished, its outcome.
+type Job struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Operation  string                 `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
+	A          int32                  `protobuf:"varint,3,opt,name=a,proto3" json:"a,omitempty"`
+	B          int32                  `protobuf:"varint,4,opt,name=b,proto3" json:"b,omitempty"`
+	Status     string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Result     *int32                 `protobuf:"varint,6,opt,name=result,proto3,oneof" json:"result,omitempty"`
+	Error      string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	StartedAt  *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	// calculation_id is the calculation recorded by a succeeded job.
+	CalculationId string `protobuf:"bytes,11,opt,name=calculation_id,json=calculationId,proto3" json:"calculation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Job) Reset() {
+	*x = Job{}
+	mi := &file_calculator_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Job) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Job) ProtoMessage() {}
+
+func (x *Job) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Job.ProtoReflect.Descriptor instead.
+func (*Job) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Job) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Job) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *Job) GetA() int32 {
+	if x != nil {
+		return x.A
+	}
+	return 0
+}
+
+func (x *Job) GetB() int32 {
+	if x != nil {
+		return x.B
+	}
+	return 0
+}
+
+func (x *Job) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Job) GetResult() int32 {
+	if x != nil && x.Result != nil {
+		return *x.Result
+	}
+	return 0
+}
+
+func (x *Job) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Job) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Job) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *Job) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+func (x *Job) GetCalculationId() string {
+	if x != nil {
+		return x.CalculationId
+	}
+	return ""
+}
+
+// GetCalculationRequest identifies a single past calculation.
+type GetCalculationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCalculationRequest) Reset() {
+	*x = GetCalculationRequest{}
+	mi := &file_calculator_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCalculationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCalculationRequest) ProtoMessage() {}
+
+func (x *GetCalculationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCalculationRequest.ProtoReflect.Descriptor instead.
+func (*GetCalculationRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetCalculationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// ListCalculationsRequest filters and pages through the calculation history.
+type ListCalculationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// operation limits the listing to one operation, e.g. "add".
+	Operation string `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	Limit     int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset    int32  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// include_deleted also lists soft-deleted calculations.
+	IncludeDeleted bool `protobuf:"varint,4,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListCalculationsRequest) Reset() {
+	*x = ListCalculationsRequest{}
+	mi := &file_calculator_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCalculationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCalculationsRequest) ProtoMessage() {}
+
+func (x *ListCalculationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCalculationsRequest.ProtoReflect.Descriptor instead.
+func (*ListCalculationsRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListCalculationsRequest) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *ListCalculationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListCalculationsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListCalculationsRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+// ListCalculationsResponse contains one page of calculations, newest first.
+type ListCalculationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Calculations  []*Calculation         `protobuf:"bytes,1,rep,name=calculations,proto3" json:"calculations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCalculationsResponse) Reset() {
+	*x = ListCalculationsResponse{}
+	mi := &file_calculator_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCalculationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCalculationsResponse) ProtoMessage() {}
+
+func (x *ListCalculationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCalculationsResponse.ProtoReflect.Descriptor instead.
+func (*ListCalculationsResponse) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListCalculationsResponse) GetCalculations() []*Calculation {
+	if x != nil {
+		return x.Calculations
+	}
+	return nil
+}
+
+// Calculation is a calculation recorded in the history.
+type Calculation struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Operation string                 `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
+	A         int32                  `protobuf:"varint,3,opt,name=a,proto3" json:"a,omitempty"`
+	B         int32                  `protobuf:"varint,4,opt,name=b,proto3" json:"b,omitempty"`
+	Result    int32                  `protobuf:"varint,5,opt,name=result,proto3" json:"result,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// deleted_at is set when the calculation has been soft-deleted.
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// hash links the calculation into its tenant's audit chain; prev_hash is
+	// the hash of the calculation saved before it.
+	Hash     string `protobuf:"bytes,8,opt,name=hash,proto3" json:"hash,omitempty"`
+	PrevHash string `protobuf:"bytes,9,opt,name=prev_hash,json=prevHash,proto3" json:"prev_hash,omitempty"`
+	// kind is "integer" for calculations with a, b and result; other kinds
+	// ("float", "decimal", "bigint") record their operands, value and options
+	// as text, so big integers keep every digit.
+	Kind      string            `protobuf:"bytes,10,opt,name=kind,proto3" json:"kind,omitempty"`
+	Operands  []string          `protobuf:"bytes,11,rep,name=operands,proto3" json:"operands,omitempty"`
+	Value     string            `protobuf:"bytes,12,opt,name=value,proto3" json:"value,omitempty"`
+	Angle     string            `protobuf:"bytes,13,opt,name=angle,proto3" json:"angle,omitempty"`
+	Precision int32             `protobuf:"varint,14,opt,name=precision,proto3" json:"precision,omitempty"`
+	Details   map[string]string `protobuf:"bytes,15,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Scale     *int32            `protobuf:"varint,16,opt,name=scale,proto3,oneof" json:"scale,omitempty"`
+	Division  string            `protobuf:"bytes,17,opt,name=division,proto3" json:"division,omitempty"`
+	Rounding  string            `protobuf:"bytes,18,opt,name=rounding,proto3" json:"rounding,omitempty"`
+	// complex_value and complex_operands repeat value and operands as
+	// real/imag pairs for kind "complex".
+	ComplexValue    *Complex   `protobuf:"bytes,19,opt,name=complex_value,json=complexValue,proto3" json:"complex_value,omitempty"`
+	ComplexOperands []*Complex `protobuf:"bytes,20,rep,name=complex_operands,json=complexOperands,proto3" json:"complex_operands,omitempty"`
+	// matrix_value and matrix_operands repeat value and operands as
+	// matrices for kind "matrix".
+	MatrixValue    *Matrix   `protobuf:"bytes,21,opt,name=matrix_value,json=matrixValue,proto3" json:"matrix_value,omitempty"`
+	MatrixOperands []*Matrix `protobuf:"bytes,22,rep,name=matrix_operands,json=matrixOperands,proto3" json:"matrix_operands,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Calculation) Reset() {
+	*x = Calculation{}
+	mi := &file_calculator_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Calculation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Calculation) ProtoMessage() {}
+
+func (x *Calculation) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Calculation.ProtoReflect.Descriptor instead.
+func (*Calculation) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Calculation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Calculation) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *Calculation) GetA() int32 {
+	if x != nil {
+		return x.A
+	}
+	return 0
+}
+
+func (x *Calculation) GetB() int32 {
+	if x != nil {
+		return x.B
+	}
+	return 0
+}
+
+func (x *Calculation) GetResult() int32 {
+	if x != nil {
+		return x.Result
+	}
+	return 0
+}
+
+func (x *Calculation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Calculation) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Calculation) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *Calculation) GetPrevHash() string {
+	if x != nil {
+		return x.PrevHash
+	}
+	return ""
+}
+
+func (x *Calculation) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Calculation) GetOperands() []string {
+	if x != nil {
+		return x.Operands
+	}
+	return nil
+}
+
+func (x *Calculation) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Calculation) GetAngle() string {
+	if x != nil {
+		return x.Angle
+	}
+	return ""
+}
+
+func (x *Calculation) GetPrecision() int32 {
+	if x != nil {
+		return x.Precision
+	}
+	return 0
+}
+
+func (x *Calculation) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+func (x *Calculation) GetScale() int32 {
+	if x != nil && x.Scale != nil {
+		return *x.Scale
+	}
+	return 0
+}
+
+func (x *Calculation) GetDivision() string {
+	if x != nil {
+		return x.Division
+	}
+	return ""
+}
+
+func (x *Calculation) GetRounding() string {
+	if x != nil {
+		return x.Rounding
+	}
+	return ""
+}
+
+func (x *Calculation) GetComplexValue() *Complex {
+	if x != nil {
+		return x.ComplexValue
+	}
+	return nil
+}
+
+func (x *Calculation) GetComplexOperands() []*Complex {
+	if x != nil {
+		return x.ComplexOperands
+	}
+	return nil
+}
+
+func (x *Calculation) GetMatrixValue() *Matrix {
+	if x != nil {
+		return x.MatrixValue
+	}
+	return nil
+}
+
+func (x *Calculation) GetMatrixOperands() []*Matrix {
+	if x != nil {
+		return x.MatrixOperands
+	}
+	return nil
+}
+
+// DeleteCalculationRequest identifies the calculation to soft-delete.
+type DeleteCalculationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCalculationRequest) Reset() {
+	*x = DeleteCalculationRequest{}
+	mi := &file_calculator_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCalculationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCalculationRequest) ProtoMessage() {}
+
+func (x *DeleteCalculationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCalculationRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCalculationRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DeleteCalculationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// RestoreCalculationRequest identifies the soft-deleted calculation to restore.
+type RestoreCalculationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreCalculationRequest) Reset() {
+	*x = RestoreCalculationRequest{}
+	mi := &file_calculator_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreCalculationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreCalculationRequest) ProtoMessage() {}
+
+func (x *RestoreCalculationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreCalculationRequest.ProtoReflect.Descriptor instead.
+func (*RestoreCalculationRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RestoreCalculationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// PurgeCalculationRequest identifies the calculation to remove permanently.
+type PurgeCalculationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeCalculationRequest) Reset() {
+	*x = PurgeCalculationRequest{}
+	mi := &file_calculator_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeCalculationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeCalculationRequest) ProtoMessage() {}
+
+func (x *PurgeCalculationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeCalculationRequest.ProtoReflect.Descriptor instead.
+func (*PurgeCalculationRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *PurgeCalculationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// ExportCalculationsRequest selects the calculations to export and the file format.
+type ExportCalculationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// operation limits the export to one operation, e.g. "add".
+	Operation string `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	// limit caps the number of exported calculations; 0 exports all of them.
+	Limit  int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// include_deleted also exports soft-deleted calculations.
+	IncludeDeleted bool `protobuf:"varint,4,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	// format is "csv", "ndjson" or "parquet"; it defaults to "csv".
+	Format        string `protobuf:"bytes,5,opt,name=format,proto3" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportCalculationsRequest) Reset() {
+	*x = ExportCalculationsRequest{}
+	mi := &file_calculator_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportCalculationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportCalculationsRequest) ProtoMessage() {}
+
+func (x *ExportCalculationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportCalculationsRequest.ProtoReflect.Descriptor instead.
+func (*ExportCalculationsRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ExportCalculationsRequest) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *ExportCalculationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ExportCalculationsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ExportCalculationsRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+func (x *ExportCalculationsRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+// ExportChunk is the next piece of the exported file.
+type ExportChunk struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// content_type is the media type of the file; it is only set on the first chunk.
+	ContentType   string `protobuf:"bytes,1,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Data          []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportChunk) Reset() {
+	*x = ExportChunk{}
+	mi := &file_calculator_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportChunk) ProtoMessage() {}
+
+func (x *ExportChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportChunk.ProtoReflect.Descriptor instead.
+func (*ExportChunk) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ExportChunk) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ExportChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// ImportCalculationsRequest carries the import options in the first message
+// and the content of a CSV or NDJSON file in the following ones.
+type ImportCalculationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ImportCalculationsRequest_Options
+	//	*ImportCalculationsRequest_Data
+	Payload       isImportCalculationsRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportCalculationsRequest) Reset() {
+	*x = ImportCalculationsRequest{}
+	mi := &file_calculator_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportCalculationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportCalculationsRequest) ProtoMessage() {}
+
+func (x *ImportCalculationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportCalculationsRequest.ProtoReflect.Descriptor instead.
+func (*ImportCalculationsRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ImportCalculationsRequest) GetPayload() isImportCalculationsRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ImportCalculationsRequest) GetOptions() *ImportOptions {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportCalculationsRequest_Options); ok {
+			return x.Options
+		}
+	}
+	return nil
+}
+
+func (x *ImportCalculationsRequest) GetData() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportCalculationsRequest_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+type isImportCalculationsRequest_Payload interface {
+	isImportCalculationsRequest_Payload()
+}
+
+type ImportCalculationsRequest_Options struct {
+	Options *ImportOptions `protobuf:"bytes,1,opt,name=options,proto3,oneof"`
+}
+
+type ImportCalculationsRequest_Data struct {
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3,oneof"`
+}
+
+func (*ImportCalculationsRequest_Options) isImportCalculationsRequest_Payload() {}
+
+func (*ImportCalculationsRequest_Data) isImportCalculationsRequest_Payload() {}
+
+// ImportOptions controls how an import file is read and checked.
+type ImportOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// format is "csv" or "ndjson"; it defaults to "csv".
+	Format string `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	// recompute checks every result with the calculator.
+	Recompute bool `protobuf:"varint,2,opt,name=recompute,proto3" json:"recompute,omitempty"`
+	// on_mismatch is "reject" (default), "correct" or "keep" and applies to
+	// rows whose recomputed result differs.
+	OnMismatch string `protobuf:"bytes,3,opt,name=on_mismatch,json=onMismatch,proto3" json:"on_mismatch,omitempty"`
+	// dry_run validates the file without storing anything.
+	DryRun        bool `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportOptions) Reset() {
+	*x = ImportOptions{}
+	mi := &file_calculator_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportOptions) ProtoMessage() {}
+
+func (x *ImportOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportOptions.ProtoReflect.Descriptor instead.
+func (*ImportOptions) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ImportOptions) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ImportOptions) GetRecompute() bool {
+	if x != nil {
+		return x.Recompute
+	}
+	return false
+}
+
+func (x *ImportOptions) GetOnMismatch() string {
+	if x != nil {
+		return x.OnMismatch
+	}
+	return ""
+}
+
+func (x *ImportOptions) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// ImportReport summarizes an import.
+type ImportReport struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Total      int32                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Accepted   int32                  `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Rejected   int32                  `protobuf:"varint,3,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	Mismatched int32                  `protobuf:"varint,4,opt,name=mismatched,proto3" json:"mismatched,omitempty"`
+	// issues lists the first rejected and mismatched rows.
+	Issues          []*ImportIssue `protobuf:"bytes,5,rep,name=issues,proto3" json:"issues,omitempty"`
+	IssuesTruncated bool           `protobuf:"varint,6,opt,name=issues_truncated,json=issuesTruncated,proto3" json:"issues_truncated,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ImportReport) Reset() {
+	*x = ImportReport{}
+	mi := &file_calculator_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportReport) ProtoMessage() {}
+
+func (x *ImportReport) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportReport.ProtoReflect.Descriptor instead.
+func (*ImportReport) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ImportReport) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ImportReport) GetAccepted() int32 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *ImportReport) GetRejected() int32 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
+func (x *ImportReport) GetMismatched() int32 {
+	if x != nil {
+		return x.Mismatched
+	}
+	return 0
+}
+
+func (x *ImportReport) GetIssues() []*ImportIssue {
+	if x != nil {
+		return x.Issues
+	}
+	return nil
+}
+
+func (x *ImportReport) GetIssuesTruncated() bool {
+	if x != nil {
+		return x.IssuesTruncated
+	}
+	return false
+}
+
+// ImportIssue describes a rejected or mismatched row of an import file.
+type ImportIssue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Line  int32                  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	// kind is "rejected" or "mismatched".
+	Kind          string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportIssue) Reset() {
+	*x = ImportIssue{}
+	mi := &file_calculator_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportIssue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportIssue) ProtoMessage() {}
+
+func (x *ImportIssue) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportIssue.ProtoReflect.Descriptor instead.
+func (*ImportIssue) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ImportIssue) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ImportIssue) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ImportIssue) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// GetStatisticsRequest selects the time range and histogram buckets of the
+// statistics. The range defaults to the last seven days.
+type GetStatisticsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// from is inclusive and to is exclusive.
+	From *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// bucket is "hour" or "day"; by default ranges of up to two days use hours.
+	Bucket string `protobuf:"bytes,3,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// operation limits the statistics to one operation, e.g. "add".
+	Operation string `protobuf:"bytes,4,opt,name=operation,proto3" json:"operation,omitempty"`
+	// top_pairs is the number of most frequent operand pairs; it defaults to 10.
+	TopPairs int32 `protobuf:"varint,5,opt,name=top_pairs,json=topPairs,proto3" json:"top_pairs,omitempty"`
+	// include_deleted also counts soft-deleted calculations.
+	IncludeDeleted bool `protobuf:"varint,6,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetStatisticsRequest) Reset() {
+	*x = GetStatisticsRequest{}
+	mi := &file_calculator_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatisticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatisticsRequest) ProtoMessage() {}
+
+func (x *GetStatisticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatisticsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatisticsRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetStatisticsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetStatisticsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetStatisticsRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *GetStatisticsRequest) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *GetStatisticsRequest) GetTopPairs() int32 {
+	if x != nil {
+		return x.TopPairs
+	}
+	return 0
+}
+
+func (x *GetStatisticsRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+// Statistics aggregates the calculation history over a time range.
+type Statistics struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	From       *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Bucket     string                 `protobuf:"bytes,3,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Total      int64                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	Operations []*OperationStats      `protobuf:"bytes,5,rep,name=operations,proto3" json:"operations,omitempty"`
+	// histogram lists every bucket of the range, oldest first.
+	Histogram []*HistogramBucket  `protobuf:"bytes,6,rep,name=histogram,proto3" json:"histogram,omitempty"`
+	TopPairs  []*OperandPairStats `protobuf:"bytes,7,rep,name=top_pairs,json=topPairs,proto3" json:"top_pairs,omitempty"`
+	// errors holds the failure rates of asynchronous jobs.
+	Errors        []*ErrorStats `protobuf:"bytes,8,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Statistics) Reset() {
+	*x = Statistics{}
+	mi := &file_calculator_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Statistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Statistics) ProtoMessage() {}
+
+func (x *Statistics) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Statistics.ProtoReflect.Descriptor instead.
+func (*Statistics) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Statistics) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *Statistics) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *Statistics) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *Statistics) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *Statistics) GetOperations() []*OperationStats {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *Statistics) GetHistogram() []*HistogramBucket {
+	if x != nil {
+		return x.Histogram
+	}
+	return nil
+}
+
+func (x *Statistics) GetTopPairs() []*OperandPairStats {
+	if x != nil {
+		return x.TopPairs
+	}
+	return nil
+}
+
+func (x *Statistics) GetErrors() []*ErrorStats {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+// OperationStats summarizes the results of one operation.
+type OperationStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operation     string                 `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	MinResult     int32                  `protobuf:"varint,3,opt,name=min_result,json=minResult,proto3" json:"min_result,omitempty"`
+	MaxResult     int32                  `protobuf:"varint,4,opt,name=max_result,json=maxResult,proto3" json:"max_result,omitempty"`
+	AvgResult     float64                `protobuf:"fixed64,5,opt,name=avg_result,json=avgResult,proto3" json:"avg_result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperationStats) Reset() {
+	*x = OperationStats{}
+	mi := &file_calculator_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationStats) ProtoMessage() {}
+
+func (x *OperationStats) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationStats.ProtoReflect.Descriptor instead.
+func (*OperationStats) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *OperationStats) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *OperationStats) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *OperationStats) GetMinResult() int32 {
+	if x != nil {
+		return x.MinResult
+	}
+	return 0
+}
+
+func (x *OperationStats) GetMaxResult() int32 {
+	if x != nil {
+		return x.MaxResult
+	}
+	return 0
+}
+
+func (x *OperationStats) GetAvgResult() float64 {
+	if x != nil {
+		return x.AvgResult
+	}
+	return 0
+}
+
+// HistogramBucket counts the calculations created in one bucket.
+type HistogramBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistogramBucket) Reset() {
+	*x = HistogramBucket{}
+	mi := &file_calculator_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistogramBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistogramBucket) ProtoMessage() {}
+
+func (x *HistogramBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistogramBucket.ProtoReflect.Descriptor instead.
+func (*HistogramBucket) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *HistogramBucket) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *HistogramBucket) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// OperandPairStats counts how often an operation ran with the same operands.
+type OperandPairStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operation     string                 `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	A             int32                  `protobuf:"varint,2,opt,name=a,proto3" json:"a,omitempty"`
+	B             int32                  `protobuf:"varint,3,opt,name=b,proto3" json:"b,omitempty"`
+	Count         int64                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperandPairStats) Reset() {
+	*x = OperandPairStats{}
+	mi := &file_calculator_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperandPairStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperandPairStats) ProtoMessage() {}
+
+func (x *OperandPairStats) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperandPairStats.ProtoReflect.Descriptor instead.
+func (*OperandPairStats) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *OperandPairStats) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *OperandPairStats) GetA() int32 {
+	if x != nil {
+		return x.A
+	}
+	return 0
+}
+
+func (x *OperandPairStats) GetB() int32 {
+	if x != nil {
+		return x.B
+	}
+	return 0
+}
+
+func (x *OperandPairStats) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// ErrorStats counts finished and failed jobs of one operation.
+type ErrorStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operation     string                 `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	Finished      int64                  `protobuf:"varint,2,opt,name=finished,proto3" json:"finished,omitempty"`
+	Failed        int64                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	Rate          float64                `protobuf:"fixed64,4,opt,name=rate,proto3" json:"rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErrorStats) Reset() {
+	*x = ErrorStats{}
+	mi := &file_calculator_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorStats) ProtoMessage() {}
+
+func (x *ErrorStats) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorStats.ProtoReflect.Descriptor instead.
+func (*ErrorStats) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ErrorStats) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *ErrorStats) GetFinished() int64 {
+	if x != nil {
+		return x.Finished
+	}
+	return 0
+}
+
+func (x *ErrorStats) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ErrorStats) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+// Tenant is a team whose calculations and jobs are isolated from other teams.
+type Tenant struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is a lowercase slug, sent in the X-Tenant-ID header to select the tenant.
+	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// allowed_operations lists the operations the tenant may run; empty allows all.
+	AllowedOperations []string `protobuf:"bytes,3,rep,name=allowed_operations,json=allowedOperations,proto3" json:"allowed_operations,omitempty"`
+	// max_operand bounds the absolute value of operands; 0 means no limit.
+	MaxOperand int32 `protobuf:"varint,4,opt,name=max_operand,json=maxOperand,proto3" json:"max_operand,omitempty"`
+	// max_pending_jobs caps the tenant's unfinished jobs; 0 means no limit.
+	MaxPendingJobs int32                  `protobuf:"varint,5,opt,name=max_pending_jobs,json=maxPendingJobs,proto3" json:"max_pending_jobs,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// disabled_at is set while the tenant is disabled.
+	DisabledAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=disabled_at,json=disabledAt,proto3" json:"disabled_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tenant) Reset() {
+	*x = Tenant{}
+	mi := &file_calculator_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tenant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tenant) ProtoMessage() {}
+
+func (x *Tenant) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tenant.ProtoReflect.Descriptor instead.
+func (*Tenant) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *Tenant) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Tenant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tenant) GetAllowedOperations() []string {
+	if x != nil {
+		return x.AllowedOperations
+	}
+	return nil
+}
+
+func (x *Tenant) GetMaxOperand() int32 {
+	if x != nil {
+		return x.MaxOperand
+	}
+	return 0
+}
+
+func (x *Tenant) GetMaxPendingJobs() int32 {
+	if x != nil {
+		return x.MaxPendingJobs
+	}
+	return 0
+}
+
+func (x *Tenant) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Tenant) GetDisabledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DisabledAt
+	}
+	return nil
+}
+
+// CreateTenantRequest carries the tenant to create.
+type CreateTenantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tenant        *Tenant                `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTenantRequest) Reset() {
+	*x = CreateTenantRequest{}
+	mi := &file_calculator_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTenantRequest) ProtoMessage() {}
+
+func (x *CreateTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTenantRequest.ProtoReflect.Descriptor instead.
+func (*CreateTenantRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CreateTenantRequest) GetTenant() *Tenant {
+	if x != nil {
+		return x.Tenant
+	}
+	return nil
+}
+
+// GetTenantRequest identifies the tenant to return.
+type GetTenantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTenantRequest) Reset() {
+	*x = GetTenantRequest{}
+	mi := &file_calculator_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTenantRequest) ProtoMessage() {}
+
+func (x *GetTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTenantRequest.ProtoReflect.Descriptor instead.
+func (*GetTenantRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetTenantRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// ListTenantsRequest lists every tenant.
+type ListTenantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTenantsRequest) Reset() {
+	*x = ListTenantsRequest{}
+	mi := &file_calculator_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTenantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTenantsRequest) ProtoMessage() {}
+
+func (x *ListTenantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTenantsRequest.ProtoReflect.Descriptor instead.
+func (*ListTenantsRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{44}
+}
+
+// ListTenantsResponse contains every tenant, ordered by ID.
+type ListTenantsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tenants       []*Tenant              `protobuf:"bytes,1,rep,name=tenants,proto3" json:"tenants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTenantsResponse) Reset() {
+	*x = ListTenantsResponse{}
+	mi := &file_calculator_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTenantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTenantsResponse) ProtoMessage() {}
+
+func (x *ListTenantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTenantsResponse.ProtoReflect.Descriptor instead.
+func (*ListTenantsResponse) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListTenantsResponse) GetTenants() []*Tenant {
+	if x != nil {
+		return x.Tenants
+	}
+	return nil
+}
+
+// UpdateTenantRequest replaces the name and configuration of a tenant.
+type UpdateTenantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tenant        *Tenant                `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTenantRequest) Reset() {
+	*x = UpdateTenantRequest{}
+	mi := &file_calculator_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTenantRequest) ProtoMessage() {}
+
+func (x *UpdateTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTenantRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTenantRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *UpdateTenantRequest) GetTenant() *Tenant {
+	if x != nil {
+		return x.Tenant
+	}
+	return nil
+}
+
+// DisableTenantRequest identifies the tenant to disable.
+type DisableTenantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableTenantRequest) Reset() {
+	*x = DisableTenantRequest{}
+	mi := &file_calculator_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTenantRequest) ProtoMessage() {}
+
+func (x *DisableTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTenantRequest.ProtoReflect.Descriptor instead.
+func (*DisableTenantRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *DisableTenantRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// EnableTenantRequest identifies the tenant to enable again.
+type EnableTenantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnableTenantRequest) Reset() {
+	*x = EnableTenantRequest{}
+	mi := &file_calculator_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnableTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnableTenantRequest) ProtoMessage() {}
+
+func (x *EnableTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnableTenantRequest.ProtoReflect.Descriptor instead.
+func (*EnableTenantRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *EnableTenantRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// VerifyChainRequest verifies the audit chain of the caller's tenant.
+type VerifyChainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyChainRequest) Reset() {
+	*x = VerifyChainRequest{}
+	mi := &file_calculator_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyChainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyChainRequest) ProtoMessage() {}
+
+func (x *VerifyChainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyChainRequest.ProtoReflect.Descriptor instead.
+func (*VerifyChainRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{49}
+}
+
+// ChainBreak is the first record of an audit chain that does not verify.
+type ChainBreak struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Seq   int64                  `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	// reason is hash_mismatch when the record was altered, link_mismatch when
+	// a record was removed without a tombstone or inserted before it, or
+	// missing_hash.
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Expected      string `protobuf:"bytes,4,opt,name=expected,proto3" json:"expected,omitempty"`
+	Actual        string `protobuf:"bytes,5,opt,name=actual,proto3" json:"actual,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChainBreak) Reset() {
+	*x = ChainBreak{}
+	mi := &file_calculator_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChainBreak) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChainBreak) ProtoMessage() {}
+
+func (x *ChainBreak) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChainBreak.ProtoReflect.Descriptor instead.
+func (*ChainBreak) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ChainBreak) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ChainBreak) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *ChainBreak) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ChainBreak) GetExpected() string {
+	if x != nil {
+		return x.Expected
+	}
+	return ""
+}
+
+func (x *ChainBreak) GetActual() string {
+	if x != nil {
+		return x.Actual
+	}
+	return ""
+}
+
+// ChainVerification is the result of walking an audit chain.
+type ChainVerification struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TenantId string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Valid    bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	// checked counts the records that verified.
+	Checked int64 `protobuf:"varint,3,opt,name=checked,proto3" json:"checked,omitempty"`
+	// unchained counts the records saved before the chain was introduced.
+	Unchained int64 `protobuf:"varint,4,opt,name=unchained,proto3" json:"unchained,omitempty"`
+	// truncated is set when the oldest chained record links to a record that
+	// was removed without a tombstone.
+	Truncated bool `protobuf:"varint,5,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	// head is the hash of the last record that verified.
+	Head   string      `protobuf:"bytes,6,opt,name=head,proto3" json:"head,omitempty"`
+	Broken *ChainBreak `protobuf:"bytes,7,opt,name=broken,proto3" json:"broken,omitempty"`
+	// removed counts the purged or expired records that the chain was verified
+	// across by their tombstones.
+	Removed       int64 `protobuf:"varint,8,opt,name=removed,proto3" json:"removed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChainVerification) Reset() {
+	*x = ChainVerification{}
+	mi := &file_calculator_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChainVerification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChainVerification) ProtoMessage() {}
+
+func (x *ChainVerification) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChainVerification.ProtoReflect.Descriptor instead.
+func (*ChainVerification) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ChainVerification) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ChainVerification) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ChainVerification) GetChecked() int64 {
+	if x != nil {
+		return x.Checked
+	}
+	return 0
+}
+
+func (x *ChainVerification) GetUnchained() int64 {
+	if x != nil {
+		return x.Unchained
+	}
+	return 0
+}
+
+func (x *ChainVerification) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *ChainVerification) GetHead() string {
+	if x != nil {
+		return x.Head
+	}
+	return ""
+}
+
+func (x *ChainVerification) GetBroken() *ChainBreak {
+	if x != nil {
+		return x.Broken
+	}
+	return nil
+}
+
+func (x *ChainVerification) GetRemoved() int64 {
+	if x != nil {
+		return x.Removed
+	}
+	return 0
+}
+
+var File_calculator_proto protoreflect.FileDescriptor
+
+const file_calculator_proto_rawDesc = "" +
+	"\n" +
+	"\x10calculator.proto\x12\x05proto\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"(\n" +
+	"\n" +
+	"AddRequest\x12\f\n" +
+	"\x01a\x18\x01 \x01(\x05R\x01a\x12\f\n" +
+	"\x01b\x18\x02 \x01(\x05R\x01b\"\x86\x01\n" +
+	"\rDivideRequest\x12\x1a\n" +
+	"\bdividend\x18\x01 \x01(\x05R\bdividend\x12\x18\n" +
+	"\adivisor\x18\x02 \x01(\x05R\adivisor\x12\x1a\n" +
+	"\brounding\x18\x03 \x01(\tR\brounding\x12\x19\n" +
+	"\x05scale\x18\x04 \x01(\x05H\x00R\x05scale\x88\x01\x01B\b\n" +
+	"\x06_scale\"L\n" +
+	"\x10CalculateRequest\x12\x1c\n" +
+	"\toperation\x18\x01 \x01(\tR\toperation\x12\x1a\n" +
+	"\boperands\x18\x02 \x03(\x05R\boperands\"\x17\n" +
+	"\x15ListOperationsRequest\"S\n" +
+	"\x16ListOperationsResponse\x129\n" +
+	"\n" +
+	"operations\x18\x01 \x03(\v2\x19.proto.OperationSignatureR\n" +
+	"operations\"\x9e\x01\n" +
+	"\x12OperationSignature\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05arity\x18\x02 \x01(\x05R\x05arity\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1a\n" +
+	"\boperands\x18\x04 \x03(\tR\boperands\x12 \n" +
+	"\vcommutative\x18\x05 \x01(\bR\vcommutative\"\xe1\x02\n" +
+	"\x0fEvaluateRequest\x12\x1a\n" +
+	"\bfunction\x18\x01 \x01(\tR\bfunction\x12\x1a\n" +
+	"\boperands\x18\x02 \x03(\tR\boperands\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x14\n" +
+	"\x05angle\x18\x04 \x01(\tR\x05angle\x12\x1c\n" +
+	"\tprecision\x18\x05 \x01(\x05R\tprecision\x12\x19\n" +
+	"\x05scale\x18\x06 \x01(\x05H\x00R\x05scale\x88\x01\x01\x12\x1a\n" +
+	"\bdivision\x18\a \x01(\tR\bdivision\x12\x1a\n" +
+	"\brounding\x18\b \x01(\tR\brounding\x129\n" +
+	"\x10complex_operands\x18\t \x03(\v2\x0e.proto.ComplexR\x0fcomplexOperands\x126\n" +
+	"\x0fmatrix_operands\x18\n" +
+	" \x03(\v2\r.proto.MatrixR\x0ematrixOperandsB\b\n" +
+	"\x06_scale\"1\n" +
+	"\aComplex\x12\x12\n" +
+	"\x04real\x18\x01 \x01(\x01R\x04real\x12\x12\n" +
+	"\x04imag\x18\x02 \x01(\x01R\x04imag\".\n" +
+	"\x06Matrix\x12$\n" +
+	"\x04rows\x18\x01 \x03(\v2\x10.proto.MatrixRowR\x04rows\"#\n" +
+	"\tMatrixRow\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\x01R\x06values\"\xb8\x05\n" +
+	"\x12EvaluationResponse\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12(\n" +
+	"\areceipt\x18\x04 \x01(\v2\x0e.proto.ReceiptR\areceipt\x12\x12\n" +
+	"\x04kind\x18\x05 \x01(\tR\x04kind\x12\x1a\n" +
+	"\boperands\x18\x06 \x03(\tR\boperands\x12\x14\n" +
+	"\x05angle\x18\a \x01(\tR\x05angle\x12\x1c\n" +
+	"\tprecision\x18\b \x01(\x05R\tprecision\x12@\n" +
+	"\adetails\x18\t \x03(\v2&.proto.EvaluationResponse.DetailsEntryR\adetails\x12\x19\n" +
+	"\x05scale\x18\n" +
+	" \x01(\x05H\x00R\x05scale\x88\x01\x01\x12\x1a\n" +
+	"\bdivision\x18\v \x01(\tR\bdivision\x12\x1a\n" +
+	"\brounding\x18\f \x01(\tR\brounding\x123\n" +
+	"\rcomplex_value\x18\r \x01(\v2\x0e.proto.ComplexR\fcomplexValue\x129\n" +
+	"\x10complex_operands\x18\x0e \x03(\v2\x0e.proto.ComplexR\x0fcomplexOperands\x120\n" +
+	"\fmatrix_value\x18\x0f \x01(\v2\r.proto.MatrixR\vmatrixValue\x126\n" +
+	"\x0fmatrix_operands\x18\x10 \x03(\v2\r.proto.MatrixR\x0ematrixOperands\x1a:\n" +
+	"\fDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\b\n" +
+	"\x06_scale\"\x16\n" +
+	"\x14ListFunctionsRequest\"O\n" +
+	"\x15ListFunctionsResponse\x126\n" +
+	"\tfunctions\x18\x01 \x03(\v2\x18.proto.FunctionSignatureR\tfunctions\"\xcb\x01\n" +
+	"\x11FunctionSignature\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05arity\x18\x03 \x01(\x05R\x05arity\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1a\n" +
+	"\boperands\x18\x05 \x03(\tR\boperands\x12 \n" +
+	"\vcommutative\x18\x06 \x01(\bR\vcommutative\x12\x18\n" +
+	"\aoptions\x18\a \x03(\tR\aoptions\"\xc8\x01\n" +
+	"\x13CalculationResponse\x12\x1b\n" +
+	"\x06result\x18\x01 \x01(\x05H\x00R\x06result\x88\x01\x01\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12(\n" +
+	"\areceipt\x18\x04 \x01(\v2\x0e.proto.ReceiptR\areceipt\x12\x14\n" +
+	"\x05value\x18\x05 \x01(\tR\x05valueB\t\n" +
+	"\a_result\"\\\n" +
+	"\aReceipt\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\tR\x05keyId\x12\x1c\n" +
+	"\talgorithm\x18\x02 \x01(\tR\talgorithm\x12\x1c\n" +
+	"\tsignature\x18\x03 \x01(\fR\tsignature\"L\n" +
+	"\x10SubmitJobRequest\x12\x1c\n" +
+	"\toperation\x18\x01 \x01(\tR\toperation\x12\f\n" +
+	"\x01a\x18\x02 \x01(\x05R\x01a\x12\f\n" +
+	"\x01b\x18\x03 \x01(\x05R\x01b\"\x1f\n" +
+	"\rGetJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\"\n" +
+	"\x10CancelJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"W\n" +
+	"\x0fListJobsRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"2\n" +
+	"\x10ListJobsResponse\x12\x1e\n" +
+	"\x04jobs\x18\x01 \x03(\v2\n" +
+	".proto.JobR\x04jobs\"\xff\x02\n" +
+	"\x03Job\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
+	"\toperation\x18\x02 \x01(\tR\toperation\x12\f\n" +
+	"\x01a\x18\x03 \x01(\x05R\x01a\x12\f\n" +
+	"\x01b\x18\x04 \x01(\x05R\x01b\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1b\n" +
+	"\x06result\x18\x06 \x01(\x05H\x00R\x06result\x88\x01\x01\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"started_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
+	"\vfinished_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\x12%\n" +
+	"\x0ecalculation_id\x18\v \x01(\tR\rcalculationIdB\t\n" +
+	"\a_result\"'\n" +
+	"\x15GetCalculationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x8e\x01\n" +
+	"\x17ListCalculationsRequest\x12\x1c\n" +
+	"\toperation\x18\x01 \x01(\tR\toperation\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12'\n" +
+	"\x0finclude_deleted\x18\x04 \x01(\bR\x0eincludeDeleted\"R\n" +
+	"\x18ListCalculationsResponse\x126\n" +
+	"\fcalculations\x18\x01 \x03(\v2\x12.proto.CalculationR\fcalculations\"\xbe\x06\n" +
+	"\vCalculation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
+	"\toperation\x18\x02 \x01(\tR\toperation\x12\f\n" +
+	"\x01a\x18\x03 \x01(\x05R\x01a\x12\f\n" +
+	"\x01b\x18\x04 \x01(\x05R\x01b\x12\x16\n" +
+	"\x06result\x18\x05 \x01(\x05R\x06result\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"deleted_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12\x12\n" +
+	"\x04hash\x18\b \x01(\tR\x04hash\x12\x1b\n" +
+	"\tprev_hash\x18\t \x01(\tR\bprevHash\x12\x12\n" +
+	"\x04kind\x18\n" +
+	" \x01(\tR\x04kind\x12\x1a\n" +
+	"\boperands\x18\v \x03(\tR\boperands\x12\x14\n" +
+	"\x05value\x18\f \x01(\tR\x05value\x12\x14\n" +
+	"\x05angle\x18\r \x01(\tR\x05angle\x12\x1c\n" +
+	"\tprecision\x18\x0e \x01(\x05R\tprecision\x129\n" +
+	"\adetails\x18\x0f \x03(\v2\x1f.proto.Calculation.DetailsEntryR\adetails\x12\x19\n" +
+	"\x05scale\x18\x10 \x01(\x05H\x00R\x05scale\x88\x01\x01\x12\x1a\n" +
+	"\bdivision\x18\x11 \x01(\tR\bdivision\x12\x1a\n" +
+	"\brounding\x18\x12 \x01(\tR\brounding\x123\n" +
+	"\rcomplex_value\x18\x13 \x01(\v2\x0e.proto.ComplexR\fcomplexValue\x129\n" +
+	"\x10complex_operands\x18\x14 \x03(\v2\x0e.proto.ComplexR\x0fcomplexOperands\x120\n" +
+	"\fmatrix_value\x18\x15 \x01(\v2\r.proto.MatrixR\vmatrixValue\x126\n" +
+	"\x0fmatrix_operands\x18\x16 \x03(\v2\r.proto.MatrixR\x0ematrixOperands\x1a:\n" +
+	"\fDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\b\n" +
+	"\x06_scale\"*\n" +
+	"\x18DeleteCalculationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"+\n" +
+	"\x19RestoreCalculationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\")\n" +
+	"\x17PurgeCalculationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xa8\x01\n" +
+	"\x19ExportCalculationsRequest\x12\x1c\n" +
+	"\toperation\x18\x01 \x01(\tR\toperation\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12'\n" +
+	"\x0finclude_deleted\x18\x04 \x01(\bR\x0eincludeDeleted\x12\x16\n" +
+	"\x06format\x18\x05 \x01(\tR\x06format\"D\n" +
+	"\vExportChunk\x12!\n" +
+	"\fcontent_type\x18\x01 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"n\n" +
+	"\x19ImportCalculationsRequest\x120\n" +
+	"\aoptions\x18\x01 \x01(\v2\x14.proto.ImportOptionsH\x00R\aoptions\x12\x14\n" +
+	"\x04data\x18\x02 \x01(\fH\x00R\x04dataB\t\n" +
+	"\apayload\"\x7f\n" +
+	"\rImportOptions\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x1c\n" +
+	"\trecompute\x18\x02 \x01(\bR\trecompute\x12\x1f\n" +
+	"\von_mismatch\x18\x03 \x01(\tR\n" +
+	"onMismatch\x12\x17\n" +
+	"\adry_run\x18\x04 \x01(\bR\x06dryRun\"\xd3\x01\n" +
+	"\fImportReport\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x05R\x05total\x12\x1a\n" +
+	"\baccepted\x18\x02 \x01(\x05R\baccepted\x12\x1a\n" +
+	"\brejected\x18\x03 \x01(\x05R\brejected\x12\x1e\n" +
+	"\n" +
+	"mismatched\x18\x04 \x01(\x05R\n" +
+	"mismatched\x12*\n" +
+	"\x06issues\x18\x05 \x03(\v2\x12.proto.ImportIssueR\x06issues\x12)\n" +
+	"\x10issues_truncated\x18\x06 \x01(\bR\x0fissuesTruncated\"M\n" +
+	"\vImportIssue\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x05R\x04line\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\xee\x01\n" +
+	"\x14GetStatisticsRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x16\n" +
+	"\x06bucket\x18\x03 \x01(\tR\x06bucket\x12\x1c\n" +
+	"\toperation\x18\x04 \x01(\tR\toperation\x12\x1b\n" +
+	"\ttop_pairs\x18\x05 \x01(\x05R\btopPairs\x12'\n" +
+	"\x0finclude_deleted\x18\x06 \x01(\bR\x0eincludeDeleted\"\xe4\x02\n" +
+	"\n" +
+	"Statistics\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x16\n" +
+	"\x06bucket\x18\x03 \x01(\tR\x06bucket\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x03R\x05total\x125\n" +
+	"\n" +
+	"operations\x18\x05 \x03(\v2\x15.proto.OperationStatsR\n" +
+	"operations\x124\n" +
+	"\thistogram\x18\x06 \x03(\v2\x16.proto.HistogramBucketR\thistogram\x124\n" +
+	"\ttop_pairs\x18\a \x03(\v2\x17.proto.OperandPairStatsR\btopPairs\x12)\n" +
+	"\x06errors\x18\b \x03(\v2\x11.proto.ErrorStatsR\x06errors\"\xa1\x01\n" +
+	"\x0eOperationStats\x12\x1c\n" +
+	"\toperation\x18\x01 \x01(\tR\toperation\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\x12\x1d\n" +
+	"\n" +
+	"min_result\x18\x03 \x01(\x05R\tminResult\x12\x1d\n" +
+	"\n" +
+	"max_result\x18\x04 \x01(\x05R\tmaxResult\x12\x1d\n" +
+	"\n" +
+	"avg_result\x18\x05 \x01(\x01R\tavgResult\"Y\n" +
+	"\x0fHistogramBucket\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"b\n" +
+	"\x10OperandPairStats\x12\x1c\n" +
+	"\toperation\x18\x01 \x01(\tR\toperation\x12\f\n" +
+	"\x01a\x18\x02 \x01(\x05R\x01a\x12\f\n" +
+	"\x01b\x18\x03 \x01(\x05R\x01b\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x03R\x05count\"r\n" +
+	"\n" +
+	"ErrorStats\x12\x1c\n" +
+	"\toperation\x18\x01 \x01(\tR\toperation\x12\x1a\n" +
+	"\bfinished\x18\x02 \x01(\x03R\bfinished\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x03R\x06failed\x12\x12\n" +
+	"\x04rate\x18\x04 \x01(\x01R\x04rate\"\x9e\x02\n" +
+	"\x06Tenant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12-\n" +
+	"\x12allowed_operations\x18\x03 \x03(\tR\x11allowedOperations\x12\x1f\n" +
+	"\vmax_operand\x18\x04 \x01(\x05R\n" +
+	"maxOperand\x12(\n" +
+	"\x10max_pending_jobs\x18\x05 \x01(\x05R\x0emaxPendingJobs\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vdisabled_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"disabledAt\"<\n" +
+	"\x13CreateTenantRequest\x12%\n" +
+	"\x06tenant\x18\x01 \x01(\v2\r.proto.TenantR\x06tenant\"\"\n" +
+	"\x10GetTenantRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x14\n" +
+	"\x12ListTenantsRequest\">\n" +
+	"\x13ListTenantsResponse\x12'\n" +
+	"\atenants\x18\x01 \x03(\v2\r.proto.TenantR\atenants\"<\n" +
+	"\x13UpdateTenantRequest\x12%\n" +
+	"\x06tenant\x18\x01 \x01(\v2\r.proto.TenantR\x06tenant\"&\n" +
+	"\x14DisableTenantRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"%\n" +
+	"\x13EnableTenantRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x14\n" +
+	"\x12VerifyChainRequest\"z\n" +
+	"\n" +
+	"ChainBreak\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03seq\x18\x02 \x01(\x03R\x03seq\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x1a\n" +
+	"\bexpected\x18\x04 \x01(\tR\bexpected\x12\x16\n" +
+	"\x06actual\x18\x05 \x01(\tR\x06actual\"\xf5\x01\n" +
+	"\x11ChainVerification\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\x12\x18\n" +
+	"\achecked\x18\x03 \x01(\x03R\achecked\x12\x1c\n" +
+	"\tunchained\x18\x04 \x01(\x03R\tunchained\x12\x1c\n" +
+	"\ttruncated\x18\x05 \x01(\bR\ttruncated\x12\x12\n" +
+	"\x04head\x18\x06 \x01(\tR\x04head\x12)\n" +
+	"\x06broken\x18\a \x01(\v2\x11.proto.ChainBreakR\x06broken\x12\x18\n" +
+	"\aremoved\x18\b \x01(\x03R\aremoved2\xa6\x12\n" +
+	"\x11CalculatorService\x12H\n" +
+	"\x03Add\x12\x11.proto.AddRequest\x1a\x1a.proto.CalculationResponse\"\x12\x82\xd3\xe4\x93\x02\f:\x01*\"\a/v1/add\x12Q\n" +
+	"\x06Divide\x12\x14.proto.DivideRequest\x1a\x1a.proto.CalculationResponse\"\x15\x82\xd3\xe4\x93\x02\x0f:\x01*\"\n" +
+	"/v1/divide\x12Z\n" +
+	"\tCalculate\x12\x17.proto.CalculateRequest\x1a\x1a.proto.CalculationResponse\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/v1/calculate\x12e\n" +
+	"\x0eListOperations\x12\x1c.proto.ListOperationsRequest\x1a\x1d.proto.ListOperationsResponse\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/v1/operations\x12b\n" +
+	"\bEvaluate\x12\x16.proto.EvaluateRequest\x1a\x19.proto.EvaluationResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/v1/functions/{function}\x12a\n" +
+	"\rListFunctions\x12\x1b.proto.ListFunctionsRequest\x1a\x1c.proto.ListFunctionsResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/functions\x12E\n" +
+	"\tSubmitJob\x12\x17.proto.SubmitJobRequest\x1a\n" +
+	".proto.Job\"\x13\x82\xd3\xe4\x93\x02\r:\x01*\"\b/v1/jobs\x12A\n" +
+	"\x06GetJob\x12\x14.proto.GetJobRequest\x1a\n" +
+	".proto.Job\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/jobs/{id}\x12Q\n" +
+	"\tCancelJob\x12\x17.proto.CancelJobRequest\x1a\n" +
+	".proto.Job\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/jobs/{id}:cancel\x12M\n" +
+	"\bListJobs\x12\x16.proto.ListJobsRequest\x1a\x17.proto.ListJobsResponse\"\x10\x82\xd3\xe4\x93\x02\n" +
+	"\x12\b/v1/jobs\x12a\n" +
+	"\x0eGetCalculation\x12\x1c.proto.GetCalculationRequest\x1a\x12.proto.Calculation\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/v1/calculations/{id}\x12m\n" +
+	"\x10ListCalculations\x12\x1e.proto.ListCalculationsRequest\x1a\x1f.proto.ListCalculationsResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/calculations\x12k\n" +
+	"\x11DeleteCalculation\x12\x1f.proto.DeleteCalculationRequest\x1a\x16.google.protobuf.Empty\"\x1d\x82\xd3\xe4\x93\x02\x17*\x15/v1/calculations/{id}\x12t\n" +
+	"\x12RestoreCalculation\x12 .proto.RestoreCalculationRequest\x1a\x12.proto.Calculation\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/v1/calculations/{id}:restore\x12m\n" +
+	"\x12ExportCalculations\x12 .proto.ExportCalculationsRequest\x1a\x12.proto.ExportChunk\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/v1/calculations:export0\x01\x12M\n" +
+	"\x12ImportCalculations\x12 .proto.ImportCalculationsRequest\x1a\x13.proto.ImportReport(\x01\x12r\n" +
+	"\x10PurgeCalculation\x12\x1e.proto.PurgeCalculationRequest\x1a\x16.google.protobuf.Empty\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/v1/calculations/{id}:purge\x12R\n" +
+	"\rGetStatistics\x12\x1b.proto.GetStatisticsRequest\x1a\x11.proto.Statistics\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/stats\x12\\\n" +
+	"\vVerifyChain\x12\x19.proto.VerifyChainRequest\x1a\x18.proto.ChainVerification\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/audit/verify\x12V\n" +
+	"\fCreateTenant\x12\x1a.proto.CreateTenantRequest\x1a\r.proto.Tenant\"\x1b\x82\xd3\xe4\x93\x02\x15:\x06tenant\"\v/v1/tenants\x12M\n" +
+	"\tGetTenant\x12\x17.proto.GetTenantRequest\x1a\r.proto.Tenant\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/tenants/{id}\x12Y\n" +
+	"\vListTenants\x12\x19.proto.ListTenantsRequest\x1a\x1a.proto.ListTenantsResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/v1/tenants\x12b\n" +
+	"\fUpdateTenant\x12\x1a.proto.UpdateTenantRequest\x1a\r.proto.Tenant\"'\x82\xd3\xe4\x93\x02!:\x06tenant\x1a\x17/v1/tenants/{tenant.id}\x12`\n" +
+	"\rDisableTenant\x12\x1b.proto.DisableTenantRequest\x1a\r.proto.Tenant\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/v1/tenants/{id}:disable\x12]\n" +
+	"\fEnableTenant\x12\x1a.proto.EnableTenantRequest\x1a\r.proto.Tenant\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/tenants/{id}:enableB&Z$go-prisma-calculator/generated/protob\x06proto3"
+
+var (
+	file_calculator_proto_rawDescOnce sync.Once
+	file_calculator_proto_rawDescData []byte
+)
+
+func file_calculator_proto_rawDescGZIP() []byte {
+	file_calculator_proto_rawDescOnce.Do(func() {
+		file_calculator_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_calculator_proto_rawDesc), len(file_calculator_proto_rawDesc)))
+	})
+	return file_calculator_proto_rawDescData
+}
+
+var file_calculator_proto_msgTypes = make([]protoimpl.MessageInfo, 54)
+var file_calculator_proto_goTypes = []any{
+	(*AddRequest)(nil),                // 0: proto.AddRequest
+	(*DivideRequest)(nil),             // 1: proto.DivideRequest
+	(*CalculateRequest)(nil),          // 2: proto.CalculateRequest
+	(*ListOperationsRequest)(nil),     // 3: proto.ListOperationsRequest
+	(*ListOperationsResponse)(nil),    // 4: proto.ListOperationsResponse
+	(*OperationSignature)(nil),        // 5: proto.OperationSignature
+	(*EvaluateRequest)(nil),           // 6: proto.EvaluateRequest
+	(*Complex)(nil),                   // 7: proto.Complex
+	(*Matrix)(nil),                    // 8: proto.Matrix
+	(*MatrixRow)(nil),                 // 9: proto.MatrixRow
+	(*EvaluationResponse)(nil),        // 10: proto.EvaluationResponse
+	(*ListFunctionsRequest)(nil),      // 11: proto.ListFunctionsRequest
+	(*ListFunctionsResponse)(nil),     // 12: proto.ListFunctionsResponse
+	(*FunctionSignature)(nil),         // 13: proto.FunctionSignature
+	(*CalculationResponse)(nil),       // 14: proto.CalculationResponse
+	(*Receipt)(nil),                   // 15: proto.Receipt
+	(*SubmitJobRequest)(nil),          // 16: proto.SubmitJobRequest
+	(*GetJobRequest)(nil),             // 17: proto.GetJobRequest
+	(*CancelJobRequest)(nil),          // 18: proto.CancelJobRequest
+	(*ListJobsRequest)(nil),           // 19: proto.ListJobsRequest
+	(*ListJobsResponse)(nil),          // 20: proto.ListJobsResponse
+	(*Job)(nil),                       // 21: proto.Job
+	(*GetCalculationRequest)(nil),     // 22: proto.GetCalculationRequest
+	(*ListCalculationsRequest)(nil),   // 23: proto.ListCalculationsRequest
+	(*ListCalculationsResponse)(nil),  // 24: proto.ListCalculationsResponse
+	(*Calculation)(nil),               // 25: proto.Calculation
+	(*DeleteCalculationRequest)(nil),  // 26: proto.DeleteCalculationRequest
+	(*RestoreCalculationRequest)(nil), // 27: proto.RestoreCalculationRequest
+	(*PurgeCalculationRequest)(nil),   // 28: proto.PurgeCalculationRequest
+	(*ExportCalculationsRequest)(nil), // 29: proto.ExportCalculationsRequest
+	(*ExportChunk)(nil),               // 30: proto.ExportChunk
+	(*ImportCalculationsRequest)(nil), // 31: proto.ImportCalculationsRequest
+	(*ImportOptions)(nil),             // 32: proto.ImportOptions
+	(*ImportReport)(nil),              // 33: proto.ImportReport
+	(*ImportIssue)(nil),               // 34: proto.ImportIssue
+	(*GetStatisticsRequest)(nil),      // 35: proto.GetStatisticsRequest
+	(*Statistics)(nil),                // 36: proto.Statistics
+	(*OperationStats)(nil),            // 37: proto.OperationStats
+	(*HistogramBucket)(nil),           // 38: proto.HistogramBucket
+	(*OperandPairStats)(nil),          // 39: proto.OperandPairStats
+	(*ErrorStats)(nil),                // 40: proto.ErrorStats
+	(*Tenant)(nil),                    // 41: proto.Tenant
+	(*CreateTenantRequest)(nil),       // 42: proto.CreateTenantRequest
+	(*GetTenantRequest)(nil),          // 43: proto.GetTenantRequest
+	(*ListTenantsRequest)(nil),        // 44: proto.ListTenantsRequest
+	(*ListTenantsResponse)(nil),       // 45: proto.ListTenantsResponse
+	(*UpdateTenantRequest)(nil),       // 46: proto.UpdateTenantRequest
+	(*DisableTenantRequest)(nil),      // 47: proto.DisableTenantRequest
+	(*EnableTenantRequest)(nil),       // 48: proto.EnableTenantRequest
+	(*VerifyChainRequest)(nil),        // 49: proto.VerifyChainRequest
+	(*ChainBreak)(nil),                // 50: proto.ChainBreak
+	(*ChainVerification)(nil),         // 51: proto.ChainVerification
+	nil,                               // 52: proto.EvaluationResponse.DetailsEntry
+	nil,                               // 53: proto.Calculation.DetailsEntry
+	(*timestamppb.Timestamp)(nil),     // 54: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),             // 55: google.protobuf.Empty
+}
+var file_calculator_proto_depIdxs = []int32{
+	5,  // 0: proto.ListOperationsResponse.operations:type_name -> proto.OperationSignature
+	7,  // 1: proto.EvaluateRequest.complex_operands:type_name -> proto.Complex
+	8,  // 2: proto.EvaluateRequest.matrix_operands:type_name -> proto.Matrix
+	9,  // 3: proto.Matrix.rows:type_name -> proto.MatrixRow
+	54, // 4: proto.EvaluationResponse.created_at:type_name -> google.protobuf.Timestamp
+	15, // 5: proto.EvaluationResponse.receipt:type_name -> proto.Receipt
+	52, // 6: proto.EvaluationResponse.details:type_name -> proto.EvaluationResponse.DetailsEntry
+	7,  // 7: proto.EvaluationResponse.complex_value:type_name -> proto.Complex
+	7,  // 8: proto.EvaluationResponse.complex_operands:type_name -> proto.Complex
+	8,  // 9: proto.EvaluationResponse.matrix_value:type_name -> proto.Matrix
+	8,  // 10: proto.EvaluationResponse.matrix_operands:type_name -> proto.Matrix
+	13, // 11: proto.ListFunctionsResponse.functions:type_name -> proto.FunctionSignature
+	54, // 12: proto.CalculationResponse.created_at:type_name -> google.protobuf.Timestamp
+	15, // 13: proto.CalculationResponse.receipt:type_name -> proto.Receipt
+	21, // 14: proto.ListJobsResponse.jobs:type_name -> proto.Job
+	54, // 15: proto.Job.created_at:type_name -> google.protobuf.Timestamp
+	54, // 16: proto.Job.started_at:type_name -> google.protobuf.Timestamp
+	54, // 17: proto.Job.finished_at:type_name -> google.protobuf.Timestamp
+	25, // 18: proto.ListCalculationsResponse.calculations:type_name -> proto.Calculation
+	54, // 19: proto.Calculation.created_at:type_name -> google.protobuf.Timestamp
+	54, // 20: proto.Calculation.deleted_at:type_name -> google.protobuf.Timestamp
+	53, // 21: proto.Calculation.details:type_name -> proto.Calculation.DetailsEntry
+	7,  // 22: proto.Calculation.complex_value:type_name -> proto.Complex
+	7,  // 23: proto.Calculation.complex_operands:type_name -> proto.Complex
+	8,  // 24: proto.Calculation.matrix_value:type_name -> proto.Matrix
+	8,  // 25: proto.Calculation.matrix_operands:type_name -> proto.Matrix
+	32, // 26: proto.ImportCalculationsRequest.options:type_name -> proto.ImportOptions
+	34, // 27: proto.ImportReport.issues:type_name -> proto.ImportIssue
+	54, // 28: proto.GetStatisticsRequest.from:type_name -> google.protobuf.Timestamp
+	54, // 29: proto.GetStatisticsRequest.to:type_name -> google.protobuf.Timestamp
+	54, // 30: proto.Statistics.from:type_name -> google.protobuf.Timestamp
+	54, // 31: proto.Statistics.to:type_name -> google.protobuf.Timestamp
+	37, // 32: proto.Statistics.operations:type_name -> proto.OperationStats
+	38, // 33: proto.Statistics.histogram:type_name -> proto.HistogramBucket
+	39, // 34: proto.Statistics.top_pairs:type_name -> proto.OperandPairStats
+	40, // 35: proto.Statistics.errors:type_name -> proto.ErrorStats
+	54, // 36: proto.HistogramBucket.start:type_name -> google.protobuf.Timestamp
+	54, // 37: proto.Tenant.created_at:type_name -> google.protobuf.Timestamp
+	54, // 38: proto.Tenant.disabled_at:type_name -> google.protobuf.Timestamp
+	41, // 39: proto.CreateTenantRequest.tenant:type_name -> proto.Tenant
+	41, // 40: proto.ListTenantsResponse.tenants:type_name -> proto.Tenant
+	41, // 41: proto.UpdateTenantRequest.tenant:type_name -> proto.Tenant
+	50, // 42: proto.ChainVerification.broken:type_name -> proto.ChainBreak
+	0,  // 43: proto.CalculatorService.Add:input_type -> proto.AddRequest
+	1,  // 44: proto.CalculatorService.Divide:input_type -> proto.DivideRequest
+	2,  // 45: proto.CalculatorService.Calculate:input_type -> proto.CalculateRequest
+	3,  // 46: proto.CalculatorService.ListOperations:input_type -> proto.ListOperationsRequest
+	6,  // 47: proto.CalculatorService.Evaluate:input_type -> proto.EvaluateRequest
+	11, // 48: proto.CalculatorService.ListFunctions:input_type -> proto.ListFunctionsRequest
+	16, // 49: proto.CalculatorService.SubmitJob:input_type -> proto.SubmitJobRequest
+	17, // 50: proto.CalculatorService.GetJob:input_type -> proto.GetJobRequest
+	18, // 51: proto.CalculatorService.CancelJob:input_type -> proto.CancelJobRequest
+	19, // 52: proto.CalculatorService.ListJobs:input_type -> proto.ListJobsRequest
+	22, // 53: proto.CalculatorService.GetCalculation:input_type -> proto.GetCalculationRequest
+	23, // 54: proto.CalculatorService.ListCalculations:input_type -> proto.ListCalculationsRequest
+	26, // 55: proto.CalculatorService.DeleteCalculation:input_type -> proto.DeleteCalculationRequest
+	27, // 56: proto.CalculatorService.RestoreCalculation:input_type -> proto.RestoreCalculationRequest
+	29, // 57: proto.CalculatorService.ExportCalculations:input_type -> proto.ExportCalculationsRequest
+	31, // 58: proto.CalculatorService.ImportCalculations:input_type -> proto.ImportCalculationsRequest
+	28, // 59: proto.CalculatorService.PurgeCalculation:input_type -> proto.PurgeCalculationRequest
+	35, // 60: proto.CalculatorService.GetStatistics:input_type -> proto.GetStatisticsRequest
+	49, // 61: proto.CalculatorService.VerifyChain:input_type -> proto.VerifyChainRequest
+	42, // 62: proto.CalculatorService.CreateTenant:input_type -> proto.CreateTenantRequest
+	43, // 63: proto.CalculatorService.GetTenant:input_type -> proto.GetTenantRequest
+	44, // 64: proto.CalculatorService.ListTenants:input_type -> proto.ListTenantsRequest
+	46, // 65: proto.CalculatorService.UpdateTenant:input_type -> proto.UpdateTenantRequest
+	47, // 66: proto.CalculatorService.DisableTenant:input_type -> proto.DisableTenantRequest
+	48, // 67: proto.CalculatorService.EnableTenant:input_type -> proto.EnableTenantRequest
+	14, // 68: proto.CalculatorService.Add:output_type -> proto.CalculationResponse
+	14, // 69: proto.CalculatorService.Divide:output_type -> proto.CalculationResponse
+	14, // 70: proto.CalculatorService.Calculate:output_type -> proto.CalculationResponse
+	4,  // 71: proto.CalculatorService.ListOperations:output_type -> proto.ListOperationsResponse
+	10, // 72: proto.CalculatorService.Evaluate:output_type -> proto.EvaluationResponse
+	12, // 73: proto.CalculatorService.ListFunctions:output_type -> proto.ListFunctionsResponse
+	21, // 74: proto.CalculatorService.SubmitJob:output_type -> proto.Job
+	21, // 75: proto.CalculatorService.GetJob:output_type -> proto.Job
+	21, // 76: proto.CalculatorService.CancelJob:output_type -> proto.Job
+	20, // 77: proto.CalculatorService.ListJobs:output_type -> proto.ListJobsResponse
+	25, // 78: proto.CalculatorService.GetCalculation:output_type -> proto.Calculation
+	24, // 79: proto.CalculatorService.ListCalculations:output_type -> proto.ListCalculationsResponse
+	55, // 80: proto.CalculatorService.DeleteCalculation:output_type -> google.protobuf.Empty
+	25, // 81: proto.CalculatorService.RestoreCalculation:output_type -> proto.Calculation
+	30, // 82: proto.CalculatorService.ExportCalculations:output_type -> proto.ExportChunk
+	33, // 83: proto.CalculatorService.ImportCalculations:output_type -> proto.ImportReport
+	55, // 84: proto.CalculatorService.PurgeCalculation:output_type -> google.protobuf.Empty
+	36, // 85: proto.CalculatorService.GetStatistics:output_type -> proto.Statistics
+	51, // 86: proto.CalculatorService.VerifyChain:output_type -> proto.ChainVerification
+	41, // 87: proto.CalculatorService.CreateTenant:output_type -> proto.Tenant
+	41, // 88: proto.CalculatorService.GetTenant:output_type -> proto.Tenant
+	45, // 89: proto.CalculatorService.ListTenants:output_type -> proto.ListTenantsResponse
+	41, // 90: proto.CalculatorService.UpdateTenant:output_type -> proto.Tenant
+	41, // 91: proto.CalculatorService.DisableTenant:output_type -> proto.Tenant
+	41, // 92: proto.CalculatorService.EnableTenant:output_type -> proto.Tenant
+	68, // [68:93] is the sub-list for method output_type
+	43, // [43:68] is the sub-list for method input_type
+	43, // [43:43] is the sub-list for extension type_name
+	43, // [43:43] is the sub-list for extension extendee
+	0,  // [0:43] is the sub-list for field type_name
+}
+
+func init() { file_calculator_proto_init() }
+func file_calculator_proto_init() {
+	if File_calculator_proto != nil {
+		return
+	}
+	file_calculator_proto_msgTypes[1].OneofWrappers = []any{}
+	file_calculator_proto_msgTypes[6].OneofWrappers = []any{}
+	file_calculator_proto_msgTypes[10].OneofWrappers = []any{}
+	file_calculator_proto_msgTypes[14].OneofWrappers = []any{}
+	file_calculator_proto_msgTypes[21].OneofWrappers = []any{}
+	file_calculator_proto_msgTypes[25].OneofWrappers = []any{}
+	file_calculator_proto_msgTypes[31].OneofWrappers = []any{
+		(*ImportCalculationsRequest_Options)(nil),
+		(*ImportCalculationsRequest_Data)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -235,7 +4107,7 @@ func file_calculator_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_calculator_proto_rawDesc), len(file_calculator_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   54,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
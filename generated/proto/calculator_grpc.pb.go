@@ -4,8 +4,6 @@
 // - protoc             v6.32.0
 // source: calculator.proto
 
-// The package name should match the Go package for consistency.
-
 package proto
 
 import (
@@ -13,6 +11,7 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -21,8 +20,31 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CalculatorService_Add_FullMethodName    = "/proto.CalculatorService/Add"
-	CalculatorService_Divide_FullMethodName = "/proto.CalculatorService/Divide"
+	CalculatorService_Add_FullMethodName                = "/proto.CalculatorService/Add"
+	CalculatorService_Divide_FullMethodName             = "/proto.CalculatorService/Divide"
+	CalculatorService_Calculate_FullMethodName          = "/proto.CalculatorService/Calculate"
+	CalculatorService_ListOperations_FullMethodName     = "/proto.CalculatorService/ListOperations"
+	CalculatorService_Evaluate_FullMethodName           = "/proto.CalculatorService/Evaluate"
+	CalculatorService_ListFunctions_FullMethodName      = "/proto.CalculatorService/ListFunctions"
+	CalculatorService_SubmitJob_FullMethodName          = "/proto.CalculatorService/SubmitJob"
+	CalculatorService_GetJob_FullMethodName             = "/proto.CalculatorService/GetJob"
+	CalculatorService_CancelJob_FullMethodName          = "/proto.CalculatorService/CancelJob"
+	CalculatorService_ListJobs_FullMethodName           = "/proto.CalculatorService/ListJobs"
+	CalculatorService_GetCalculation_FullMethodName     = "/proto.CalculatorService/GetCalculation"
+	CalculatorService_ListCalculations_FullMethodName   = "/proto.CalculatorService/ListCalculations"
+	CalculatorService_DeleteCalculation_FullMethodName  = "/proto.CalculatorService/DeleteCalculation"
+	CalculatorService_RestoreCalculation_FullMethodName = "/proto.CalculatorService/RestoreCalculation"
+	CalculatorService_ExportCalculations_FullMethodName = "/proto.CalculatorService/ExportCalculations"
+	CalculatorService_ImportCalculations_FullMethodName = "/proto.CalculatorService/ImportCalculations"
+	CalculatorService_PurgeCalculation_FullMethodName   = "/proto.CalculatorService/PurgeCalculation"
+	CalculatorService_GetStatistics_FullMethodName      = "/proto.CalculatorService/GetStatistics"
+	CalculatorService_VerifyChain_FullMethodName        = "/proto.CalculatorService/VerifyChain"
+	CalculatorService_CreateTenant_FullMethodName       = "/proto.CalculatorService/CreateTenant"
+	CalculatorService_GetTenant_FullMethodName          = "/proto.CalculatorService/GetTenant"
+	CalculatorService_ListTenants_FullMethodName        = "/proto.CalculatorService/ListTenants"
+	CalculatorService_UpdateTenant_FullMethodName       = "/proto.CalculatorService/UpdateTenant"
+	CalculatorService_DisableTenant_FullMethodName      = "/proto.CalculatorService/DisableTenant"
+	CalculatorService_EnableTenant_FullMethodName       = "/proto.CalculatorService/EnableTenant"
 )
 
 // CalculatorServiceClient is the client API for CalculatorService service.
@@ -31,8 +53,60 @@ const (
 type CalculatorServiceClient interface {
 	// Add performs addition and maps to a RESTful POST endpoint.
 	Add(ctx context.Context, in *AddRequest, opts ...grpc.CallOption) (*CalculationResponse, error)
-	// Divide performs division and maps to a RESTful POST endpoint.
+	// Divide performs division and maps to a RESTful POST endpoint. A rounded
+	// division is recorded as a "divide" calculation of kind "rational".
 	Divide(ctx context.Context, in *DivideRequest, opts ...grpc.CallOption) (*CalculationResponse, error)
+	// Calculate runs any registered operation.
+	Calculate(ctx context.Context, in *CalculateRequest, opts ...grpc.CallOption) (*CalculationResponse, error)
+	// ListOperations describes the operations the caller may run.
+	ListOperations(ctx context.Context, in *ListOperationsRequest, opts ...grpc.CallOption) (*ListOperationsResponse, error)
+	// Evaluate computes a scientific function of float or decimal operands,
+	// an exact operation of bigint or rational operands, or an operation of
+	// complex or matrix operands.
+	Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluationResponse, error)
+	// ListFunctions describes the functions the caller may evaluate.
+	ListFunctions(ctx context.Context, in *ListFunctionsRequest, opts ...grpc.CallOption) (*ListFunctionsResponse, error)
+	// SubmitJob queues a calculation and returns immediately with the job ID.
+	SubmitJob(ctx context.Context, in *SubmitJobRequest, opts ...grpc.CallOption) (*Job, error)
+	// GetJob reports the status and, when finished, the result of a job.
+	GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*Job, error)
+	// CancelJob stops a pending or running job.
+	CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*Job, error)
+	// ListJobs pages through submitted jobs.
+	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error)
+	// GetCalculation returns a single calculation from the history.
+	GetCalculation(ctx context.Context, in *GetCalculationRequest, opts ...grpc.CallOption) (*Calculation, error)
+	// ListCalculations pages through the calculation history.
+	ListCalculations(ctx context.Context, in *ListCalculationsRequest, opts ...grpc.CallOption) (*ListCalculationsResponse, error)
+	// DeleteCalculation hides a calculation from the history; it can be restored.
+	DeleteCalculation(ctx context.Context, in *DeleteCalculationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// RestoreCalculation brings back a soft-deleted calculation.
+	RestoreCalculation(ctx context.Context, in *RestoreCalculationRequest, opts ...grpc.CallOption) (*Calculation, error)
+	// ExportCalculations streams the calculation history, newest first, as a
+	// CSV, NDJSON or Parquet file split into chunks.
+	ExportCalculations(ctx context.Context, in *ExportCalculationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportChunk], error)
+	// ImportCalculations loads past calculations from a CSV or NDJSON file
+	// streamed in chunks after an ImportOptions message.
+	ImportCalculations(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportCalculationsRequest, ImportReport], error)
+	// PurgeCalculation permanently removes a calculation. Admin only.
+	PurgeCalculation(ctx context.Context, in *PurgeCalculationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetStatistics aggregates the calculation history over a time range.
+	GetStatistics(ctx context.Context, in *GetStatisticsRequest, opts ...grpc.CallOption) (*Statistics, error)
+	// VerifyChain walks the audit chain of the caller's tenant and reports the
+	// first broken link. Admin only.
+	VerifyChain(ctx context.Context, in *VerifyChainRequest, opts ...grpc.CallOption) (*ChainVerification, error)
+	// CreateTenant adds a tenant. Admin only.
+	CreateTenant(ctx context.Context, in *CreateTenantRequest, opts ...grpc.CallOption) (*Tenant, error)
+	// GetTenant returns a tenant. Admin only.
+	GetTenant(ctx context.Context, in *GetTenantRequest, opts ...grpc.CallOption) (*Tenant, error)
+	// ListTenants returns every tenant. Admin only.
+	ListTenants(ctx context.Context, in *ListTenantsRequest, opts ...grpc.CallOption) (*ListTenantsResponse, error)
+	// UpdateTenant replaces the name and configuration of a tenant. Admin only.
+	UpdateTenant(ctx context.Context, in *UpdateTenantRequest, opts ...grpc.CallOption) (*Tenant, error)
+	// DisableTenant rejects further requests to a tenant. Admin only.
+	DisableTenant(ctx context.Context, in *DisableTenantRequest, opts ...grpc.CallOption) (*Tenant, error)
+	// EnableTenant accepts requests to a disabled tenant again. Admin only.
+	EnableTenant(ctx context.Context, in *EnableTenantRequest, opts ...grpc.CallOption) (*Tenant, error)
 }
 
 type calculatorServiceClient struct {
@@ -63,14 +137,308 @@ func (c *calculatorServiceClient) Divide(ctx context.Context, in *DivideRequest,
 	return out, nil
 }
 
+func (c *calculatorServiceClient) Calculate(ctx context.Context, in *CalculateRequest, opts ...grpc.CallOption) (*CalculationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CalculationResponse)
+	err := c.cc.Invoke(ctx, CalculatorService_Calculate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) ListOperations(ctx context.Context, in *ListOperationsRequest, opts ...grpc.CallOption) (*ListOperationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOperationsResponse)
+	err := c.cc.Invoke(ctx, CalculatorService_ListOperations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EvaluationResponse)
+	err := c.cc.Invoke(ctx, CalculatorService_Evaluate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) ListFunctions(ctx context.Context, in *ListFunctionsRequest, opts ...grpc.CallOption) (*ListFunctionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFunctionsResponse)
+	err := c.cc.Invoke(ctx, CalculatorService_ListFunctions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) SubmitJob(ctx context.Context, in *SubmitJobRequest, opts ...grpc.CallOption) (*Job, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Job)
+	err := c.cc.Invoke(ctx, CalculatorService_SubmitJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*Job, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Job)
+	err := c.cc.Invoke(ctx, CalculatorService_GetJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*Job, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Job)
+	err := c.cc.Invoke(ctx, CalculatorService_CancelJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListJobsResponse)
+	err := c.cc.Invoke(ctx, CalculatorService_ListJobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) GetCalculation(ctx context.Context, in *GetCalculationRequest, opts ...grpc.CallOption) (*Calculation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Calculation)
+	err := c.cc.Invoke(ctx, CalculatorService_GetCalculation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) ListCalculations(ctx context.Context, in *ListCalculationsRequest, opts ...grpc.CallOption) (*ListCalculationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCalculationsResponse)
+	err := c.cc.Invoke(ctx, CalculatorService_ListCalculations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) DeleteCalculation(ctx context.Context, in *DeleteCalculationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CalculatorService_DeleteCalculation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) RestoreCalculation(ctx context.Context, in *RestoreCalculationRequest, opts ...grpc.CallOption) (*Calculation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Calculation)
+	err := c.cc.Invoke(ctx, CalculatorService_RestoreCalculation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) ExportCalculations(ctx context.Context, in *ExportCalculationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CalculatorService_ServiceDesc.Streams[0], CalculatorService_ExportCalculations_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportCalculationsRequest, ExportChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalculatorService_ExportCalculationsClient = grpc.ServerStreamingClient[ExportChunk]
+
+func (c *calculatorServiceClient) ImportCalculations(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportCalculationsRequest, ImportReport], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CalculatorService_ServiceDesc.Streams[1], CalculatorService_ImportCalculations_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportCalculationsRequest, ImportReport]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalculatorService_ImportCalculationsClient = grpc.ClientStreamingClient[ImportCalculationsRequest, ImportReport]
+
+func (c *calculatorServiceClient) PurgeCalculation(ctx context.Context, in *PurgeCalculationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CalculatorService_PurgeCalculation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) GetStatistics(ctx context.Context, in *GetStatisticsRequest, opts ...grpc.CallOption) (*Statistics, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Statistics)
+	err := c.cc.Invoke(ctx, CalculatorService_GetStatistics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) VerifyChain(ctx context.Context, in *VerifyChainRequest, opts ...grpc.CallOption) (*ChainVerification, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChainVerification)
+	err := c.cc.Invoke(ctx, CalculatorService_VerifyChain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) CreateTenant(ctx context.Context, in *CreateTenantRequest, opts ...grpc.CallOption) (*Tenant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tenant)
+	err := c.cc.Invoke(ctx, CalculatorService_CreateTenant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) GetTenant(ctx context.Context, in *GetTenantRequest, opts ...grpc.CallOption) (*Tenant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tenant)
+	err := c.cc.Invoke(ctx, CalculatorService_GetTenant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) ListTenants(ctx context.Context, in *ListTenantsRequest, opts ...grpc.CallOption) (*ListTenantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTenantsResponse)
+	err := c.cc.Invoke(ctx, CalculatorService_ListTenants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) UpdateTenant(ctx context.Context, in *UpdateTenantRequest, opts ...grpc.CallOption) (*Tenant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tenant)
+	err := c.cc.Invoke(ctx, CalculatorService_UpdateTenant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) DisableTenant(ctx context.Context, in *DisableTenantRequest, opts ...grpc.CallOption) (*Tenant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tenant)
+	err := c.cc.Invoke(ctx, CalculatorService_DisableTenant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) EnableTenant(ctx context.Context, in *EnableTenantRequest, opts ...grpc.CallOption) (*Tenant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tenant)
+	err := c.cc.Invoke(ctx, CalculatorService_EnableTenant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CalculatorServiceServer is the server API for CalculatorService service.
 // All implementations must embed UnimplementedCalculatorServiceServer
 // for forward compatibility.
 type CalculatorServiceServer interface {
 	// Add performs addition and maps to a RESTful POST endpoint.
 	Add(context.Context, *AddRequest) (*CalculationResponse, error)
-	// Divide performs division and maps to a RESTful POST endpoint.
+	// Divide performs division and maps to a RESTful POST endpoint. A rounded
+	// division is recorded as a "divide" calculation of kind "rational".
 	Divide(context.Context, *DivideRequest) (*CalculationResponse, error)
+	// Calculate runs any registered operation.
+	Calculate(context.Context, *CalculateRequest) (*CalculationResponse, error)
+	// ListOperations describes the operations the caller may run.
+	ListOperations(context.Context, *ListOperationsRequest) (*ListOperationsResponse, error)
+	// Evaluate computes a scientific function of float or decimal operands,
+	// an exact operation of bigint or rational operands, or an operation of
+	// complex or matrix operands.
+	Evaluate(context.Context, *EvaluateRequest) (*EvaluationResponse, error)
+	// ListFunctions describes the functions the caller may evaluate.
+	ListFunctions(context.Context, *ListFunctionsRequest) (*ListFunctionsResponse, error)
+	// SubmitJob queues a calculation and returns immediately with the job ID.
+	SubmitJob(context.Context, *SubmitJobRequest) (*Job, error)
+	// GetJob reports the status and, when finished, the result of a job.
+	GetJob(context.Context, *GetJobRequest) (*Job, error)
+	// CancelJob stops a pending or running job.
+	CancelJob(context.Context, *CancelJobRequest) (*Job, error)
+	// ListJobs pages through submitted jobs.
+	ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error)
+	// GetCalculation returns a single calculation from the history.
+	GetCalculation(context.Context, *GetCalculationRequest) (*Calculation, error)
+	// ListCalculations pages through the calculation history.
+	ListCalculations(context.Context, *ListCalculationsRequest) (*ListCalculationsResponse, error)
+	// DeleteCalculation hides a calculation from the history; it can be restored.
+	DeleteCalculation(context.Context, *DeleteCalculationRequest) (*emptypb.Empty, error)
+	// RestoreCalculation brings back a soft-deleted calculation.
+	RestoreCalculation(context.Context, *RestoreCalculationRequest) (*Calculation, error)
+	// ExportCalculations streams the calculation history, newest first, as a
+	// CSV, NDJSON or Parquet file split into chunks.
+	ExportCalculations(*ExportCalculationsRequest, grpc.ServerStreamingServer[ExportChunk]) error
+	// ImportCalculations loads past calculations from a CSV or NDJSON file
+	// streamed in chunks after an ImportOptions message.
+	ImportCalculations(grpc.ClientStreamingServer[ImportCalculationsRequest, ImportReport]) error
+	// PurgeCalculation permanently removes a calculation. Admin only.
+	PurgeCalculation(context.Context, *PurgeCalculationRequest) (*emptypb.Empty, error)
+	// GetStatistics aggregates the calculation history over a time range.
+	GetStatistics(context.Context, *GetStatisticsRequest) (*Statistics, error)
+	// VerifyChain walks the audit chain of the caller's tenant and reports the
+	// first broken link. Admin only.
+	VerifyChain(context.Context, *VerifyChainRequest) (*ChainVerification, error)
+	// CreateTenant adds a tenant. Admin only.
+	CreateTenant(context.Context, *CreateTenantRequest) (*Tenant, error)
+	// GetTenant returns a tenant. Admin only.
+	GetTenant(context.Context, *GetTenantRequest) (*Tenant, error)
+	// ListTenants returns every tenant. Admin only.
+	ListTenants(context.Context, *ListTenantsRequest) (*ListTenantsResponse, error)
+	// UpdateTenant replaces the name and configuration of a tenant. Admin only.
+	UpdateTenant(context.Context, *UpdateTenantRequest) (*Tenant, error)
+	// DisableTenant rejects further requests to a tenant. Admin only.
+	DisableTenant(context.Context, *DisableTenantRequest) (*Tenant, error)
+	// EnableTenant accepts requests to a disabled tenant again. Admin only.
+	EnableTenant(context.Context, *EnableTenantRequest) (*Tenant, error)
 	mustEmbedUnimplementedCalculatorServiceServer()
 }
 
@@ -87,6 +455,75 @@ func (UnimplementedCalculatorServiceServer) Add(context.Context, *AddRequest) (*
 func (UnimplementedCalculatorServiceServer) Divide(context.Context, *DivideRequest) (*CalculationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Divide not implemented")
 }
+func (UnimplementedCalculatorServiceServer) Calculate(context.Context, *CalculateRequest) (*CalculationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Calculate not implemented")
+}
+func (UnimplementedCalculatorServiceServer) ListOperations(context.Context, *ListOperationsRequest) (*ListOperationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOperations not implemented")
+}
+func (UnimplementedCalculatorServiceServer) Evaluate(context.Context, *EvaluateRequest) (*EvaluationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Evaluate not implemented")
+}
+func (UnimplementedCalculatorServiceServer) ListFunctions(context.Context, *ListFunctionsRequest) (*ListFunctionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFunctions not implemented")
+}
+func (UnimplementedCalculatorServiceServer) SubmitJob(context.Context, *SubmitJobRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitJob not implemented")
+}
+func (UnimplementedCalculatorServiceServer) GetJob(context.Context, *GetJobRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJob not implemented")
+}
+func (UnimplementedCalculatorServiceServer) CancelJob(context.Context, *CancelJobRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelJob not implemented")
+}
+func (UnimplementedCalculatorServiceServer) ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobs not implemented")
+}
+func (UnimplementedCalculatorServiceServer) GetCalculation(context.Context, *GetCalculationRequest) (*Calculation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCalculation not implemented")
+}
+func (UnimplementedCalculatorServiceServer) ListCalculations(context.Context, *ListCalculationsRequest) (*ListCalculationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCalculations not implemented")
+}
+func (UnimplementedCalculatorServiceServer) DeleteCalculation(context.Context, *DeleteCalculationRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCalculation not implemented")
+}
+func (UnimplementedCalculatorServiceServer) RestoreCalculation(context.Context, *RestoreCalculationRequest) (*Calculation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreCalculation not implemented")
+}
+func (UnimplementedCalculatorServiceServer) ExportCalculations(*ExportCalculationsRequest, grpc.ServerStreamingServer[ExportChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportCalculations not implemented")
+}
+func (UnimplementedCalculatorServiceServer) ImportCalculations(grpc.ClientStreamingServer[ImportCalculationsRequest, ImportReport]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportCalculations not implemented")
+}
+func (UnimplementedCalculatorServiceServer) PurgeCalculation(context.Context, *PurgeCalculationRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeCalculation not implemented")
+}
+func (UnimplementedCalculatorServiceServer) GetStatistics(context.Context, *GetStatisticsRequest) (*Statistics, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatistics not implemented")
+}
+func (UnimplementedCalculatorServiceServer) VerifyChain(context.Context, *VerifyChainRequest) (*ChainVerification, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyChain not implemented")
+}
+func (UnimplementedCalculatorServiceServer) CreateTenant(context.Context, *CreateTenantRequest) (*Tenant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTenant not implemented")
+}
+func (UnimplementedCalculatorServiceServer) GetTenant(context.Context, *GetTenantRequest) (*Tenant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTenant not implemented")
+}
+func (UnimplementedCalculatorServiceServer) ListTenants(context.Context, *ListTenantsRequest) (*ListTenantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTenants not implemented")
+}
+func (UnimplementedCalculatorServiceServer) UpdateTenant(context.Context, *UpdateTenantRequest) (*Tenant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTenant not implemented")
+}
+func (UnimplementedCalculatorServiceServer) DisableTenant(context.Context, *DisableTenantRequest) (*Tenant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableTenant not implemented")
+}
+func (UnimplementedCalculatorServiceServer) EnableTenant(context.Context, *EnableTenantRequest) (*Tenant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableTenant not implemented")
+}
 func (UnimplementedCalculatorServiceServer) mustEmbedUnimplementedCalculatorServiceServer() {}
 func (UnimplementedCalculatorServiceServer) testEmbeddedByValue()                           {}
 
@@ -144,22 +581,513 @@ func _CalculatorService_Divide_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
-// CalculatorService_ServiceDesc is the grpc.ServiceDesc for CalculatorService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var CalculatorService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "proto.CalculatorService",
-	HandlerType: (*CalculatorServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Add",
-			Handler:    _CalculatorService_Add_Handler,
-		},
-		{
-			MethodName: "Divide",
-			Handler:    _CalculatorService_Divide_Handler,
+func _CalculatorService_Calculate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalculateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).Calculate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalculatorService_Calculate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).Calculate(ctx, req.(*CalculateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_ListOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOperationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).ListOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalculatorService_ListOperations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).ListOperations(ctx, req.(*ListOperationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_Evaluate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).Evaluate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalculatorService_Evaluate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).Evaluate(ctx, req.(*EvaluateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_ListFunctions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFunctionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).ListFunctions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalculatorService_ListFunctions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).ListFunctions(ctx, req.(*ListFunctionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_SubmitJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).SubmitJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalculatorService_SubmitJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).SubmitJob(ctx, req.(*SubmitJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).GetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalculatorService_GetJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).GetJob(ctx, req.(*GetJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_CancelJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).CancelJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalculatorService_CancelJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).CancelJob(ctx, req.(*CancelJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalculatorService_ListJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).ListJobs(ctx, req.(*ListJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_GetCalculation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCalculationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).GetCalculation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalculatorService_GetCalculation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).GetCalculation(ctx, req.(*GetCalculationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_ListCalculations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCalculationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).ListCalculations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalculatorService_ListCalculations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).ListCalculations(ctx, req.(*ListCalculationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_DeleteCalculation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCalculationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).DeleteCalculation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalculatorService_DeleteCalculation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).DeleteCalculation(ctx, req.(*DeleteCalculationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_RestoreCalculation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreCalculationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).RestoreCalculation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalculatorService_RestoreCalculation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).RestoreCalculation(ctx, req.(*RestoreCalculationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_ExportCalculations_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportCalculationsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CalculatorServiceServer).ExportCalculations(m, &grpc.GenericServerStream[ExportCalculationsRequest, ExportChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalculatorService_ExportCalculationsServer = grpc.ServerStreamingServer[ExportChunk]
+
+func _CalculatorService_ImportCalculations_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CalculatorServiceServer).ImportCalculations(&grpc.GenericServerStream[ImportCalculationsRequest, ImportReport]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalculatorService_ImportCalculationsServer = grpc.ClientStreamingServer[ImportCalculationsRequest, ImportReport]
+
+func _CalculatorService_PurgeCalculation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeCalculationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).PurgeCalculation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalculatorService_PurgeCalculation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).PurgeCalculation(ctx, req.(*PurgeCalculationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_GetStatistics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatisticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).GetStatistics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalculatorService_GetStatistics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).GetStatistics(ctx, req.(*GetStatisticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_VerifyChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyChainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).VerifyChain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalculatorService_VerifyChain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).VerifyChain(ctx, req.(*VerifyChainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_CreateTenant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTenantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).CreateTenant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalculatorService_CreateTenant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).CreateTenant(ctx, req.(*CreateTenantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_GetTenant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTenantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).GetTenant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalculatorService_GetTenant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).GetTenant(ctx, req.(*GetTenantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_ListTenants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTenantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).ListTenants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalculatorService_ListTenants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).ListTenants(ctx, req.(*ListTenantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_UpdateTenant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTenantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).UpdateTenant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalculatorService_UpdateTenant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).UpdateTenant(ctx, req.(*UpdateTenantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_DisableTenant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableTenantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).DisableTenant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalculatorService_DisableTenant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).DisableTenant(ctx, req.(*DisableTenantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_EnableTenant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnableTenantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).EnableTenant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalculatorService_EnableTenant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).EnableTenant(ctx, req.(*EnableTenantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CalculatorService_ServiceDesc is the grpc.ServiceDesc for CalculatorService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CalculatorService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.CalculatorService",
+	HandlerType: (*CalculatorServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Add",
+			Handler:    _CalculatorService_Add_Handler,
+		},
+		{
+			MethodName: "Divide",
+			Handler:    _CalculatorService_Divide_Handler,
+		},
+		{
+			MethodName: "Calculate",
+			Handler:    _CalculatorService_Calculate_Handler,
+		},
+		{
+			MethodName: "ListOperations",
+			Handler:    _CalculatorService_ListOperations_Handler,
+		},
+		{
+			MethodName: "Evaluate",
+			Handler:    _CalculatorService_Evaluate_Handler,
+		},
+		{
+			MethodName: "ListFunctions",
+			Handler:    _CalculatorService_ListFunctions_Handler,
+		},
+		{
+			MethodName: "SubmitJob",
+			Handler:    _CalculatorService_SubmitJob_Handler,
+		},
+		{
+			MethodName: "GetJob",
+			Handler:    _CalculatorService_GetJob_Handler,
+		},
+		{
+			MethodName: "CancelJob",
+			Handler:    _CalculatorService_CancelJob_Handler,
+		},
+		{
+			MethodName: "ListJobs",
+			Handler:    _CalculatorService_ListJobs_Handler,
+		},
+		{
+			MethodName: "GetCalculation",
+			Handler:    _CalculatorService_GetCalculation_Handler,
+		},
+		{
+			MethodName: "ListCalculations",
+			Handler:    _CalculatorService_ListCalculations_Handler,
+		},
+		{
+			MethodName: "DeleteCalculation",
+			Handler:    _CalculatorService_DeleteCalculation_Handler,
+		},
+		{
+			MethodName: "RestoreCalculation",
+			Handler:    _CalculatorService_RestoreCalculation_Handler,
+		},
+		{
+			MethodName: "PurgeCalculation",
+			Handler:    _CalculatorService_PurgeCalculation_Handler,
+		},
+		{
+			MethodName: "GetStatistics",
+			Handler:    _CalculatorService_GetStatistics_Handler,
+		},
+		{
+			MethodName: "VerifyChain",
+			Handler:    _CalculatorService_VerifyChain_Handler,
+		},
+		{
+			MethodName: "CreateTenant",
+			Handler:    _CalculatorService_CreateTenant_Handler,
+		},
+		{
+			MethodName: "GetTenant",
+			Handler:    _CalculatorService_GetTenant_Handler,
+		},
+		{
+			MethodName: "ListTenants",
+			Handler:    _CalculatorService_ListTenants_Handler,
+		},
+		{
+			MethodName: "UpdateTenant",
+			Handler:    _CalculatorService_UpdateTenant_Handler,
+		},
+		{
+			MethodName: "DisableTenant",
+			Handler:    _CalculatorService_DisableTenant_Handler,
+		},
+		{
+			MethodName: "EnableTenant",
+			Handler:    _CalculatorService_EnableTenant_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportCalculations",
+			Handler:       _CalculatorService_ExportCalculations_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportCalculations",
+			Handler:       _CalculatorService_ImportCalculations_Handler,
+			ClientStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "calculator.proto",
 }
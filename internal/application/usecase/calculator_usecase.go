@@ -19,12 +19,27 @@ func NewCalculatorUseCase(calcService *service.CalculatorService) in.CalculatorP
 	return &CalculatorUseCase{calcService: calcService}
 }
 
-// Add orchestrates the 'add' operation by calling the domain service.
-func (uc *CalculatorUseCase) Add(ctx context.Context, a, b int32) (*domain.Calculation, error) {
-	return uc.calcService.Add(ctx, a, b)
+// Calculate orchestrates an operation by calling the domain service.
+func (uc *CalculatorUseCase) Calculate(ctx context.Context, operation string, operands ...int32) (*domain.Calculation, error) {
+	return uc.calcService.Calculate(ctx, operation, operands...)
 }
 
-// Divide orchestrates the 'divide' operation by calling the domain service.
-func (uc *CalculatorUseCase) Divide(ctx context.Context, dividend, divisor int32) (*domain.Calculation, error) {
-	return uc.calcService.Divide(ctx, dividend, divisor)
+// ListOperations describes every registered operation.
+func (uc *CalculatorUseCase) ListOperations(ctx context.Context) ([]domain.OperationSignature, error) {
+	return uc.calcService.Operations(), nil
+}
+
+// Divide orchestrates a rounded division by calling the domain service.
+func (uc *CalculatorUseCase) Divide(ctx context.Context, d domain.RoundedDivision) (*domain.Calculation, error) {
+	return uc.calcService.Divide(ctx, d)
+}
+
+// Evaluate orchestrates a scientific function by calling the domain service.
+func (uc *CalculatorUseCase) Evaluate(ctx context.Context, e domain.Evaluation) (*domain.Calculation, error) {
+	return uc.calcService.Evaluate(ctx, e)
+}
+
+// ListFunctions describes the functions of every kind.
+func (uc *CalculatorUseCase) ListFunctions(ctx context.Context) ([]domain.FunctionSignature, error) {
+	return uc.calcService.Functions(), nil
 }
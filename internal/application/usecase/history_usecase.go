@@ -0,0 +1,309 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/ports/in"
+	"go-prisma-calculator/internal/domain/ports/out"
+)
+
+const (
+	// defaultHistoryPageSize is used when a listing does not ask for a page size.
+	defaultHistoryPageSize = 100
+	// maxHistoryPageSize caps how many calculations a single listing returns.
+	maxHistoryPageSize = 1000
+	// exportPageSize is the number of calculations fetched per query while exporting.
+	exportPageSize = 500
+	// chainPageSize is the number of calculations fetched per query while
+	// verifying the audit chain.
+	chainPageSize = 500
+
+	// defaultStatsRange is the time range of statistics queries without a start.
+	defaultStatsRange = 7 * 24 * time.Hour
+	// maxStatsBuckets caps the number of histogram buckets of a statistics query.
+	maxStatsBuckets = 2000
+	// defaultTopPairs and maxTopPairs bound the operand pairs returned.
+	defaultTopPairs = 10
+	maxTopPairs     = 100
+)
+
+// HistoryUseCase implements the inbound port (in.HistoryPort).
+type HistoryUseCase struct {
+	repo out.CalculationRepositoryPort
+}
+
+// NewHistoryUseCase is the constructor that fx uses to create an instance.
+func NewHistoryUseCase(repo out.CalculationRepositoryPort) in.HistoryPort {
+	return &HistoryUseCase{repo: repo}
+}
+
+// GetCalculation loads a single past calculation.
+func (uc *HistoryUseCase) GetCalculation(ctx context.Context, id string) (*domain.Calculation, error) {
+	return uc.repo.FindByID(ctx, id)
+}
+
+// ListCalculations returns a page of past calculations, newest first.
+func (uc *HistoryUseCase) ListCalculations(ctx context.Context, filter domain.CalculationFilter) ([]domain.Calculation, error) {
+	switch {
+	case filter.Limit <= 0:
+		filter.Limit = defaultHistoryPageSize
+	case filter.Limit > maxHistoryPageSize:
+		filter.Limit = maxHistoryPageSize
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	return uc.repo.List(ctx, filter)
+}
+
+// DeleteCalculation hides a calculation from the history until it is restored.
+func (uc *HistoryUseCase) DeleteCalculation(ctx context.Context, id string) error {
+	return uc.repo.Delete(ctx, id)
+}
+
+// RestoreCalculation brings back a soft-deleted calculation.
+func (uc *HistoryUseCase) RestoreCalculation(ctx context.Context, id string) (*domain.Calculation, error) {
+	return uc.repo.Restore(ctx, id)
+}
+
+// PurgeCalculation permanently removes a calculation. Only admins may purge.
+func (uc *HistoryUseCase) PurgeCalculation(ctx context.Context, id string) error {
+	if !domain.PrincipalFromContext(ctx).IsAdmin() {
+		return domain.ErrForbidden
+	}
+	return uc.repo.Purge(ctx, id)
+}
+
+// ExportCalculations pages through the history with a cursor, so exports of
+// any size use constant memory and stay consistent while new calculations
+// are recorded.
+func (uc *HistoryUseCase) ExportCalculations(ctx context.Context, filter domain.CalculationFilter, fn func(domain.Calculation) error) error {
+	remaining := filter.Limit
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	filter.After = ""
+
+	for {
+		filter.Limit = exportPageSize
+		if remaining > 0 {
+			filter.Limit = min(remaining, exportPageSize)
+		}
+
+		page, err := uc.repo.List(ctx, filter)
+		if err != nil {
+			return err
+		}
+		for _, calc := range page {
+			if err := fn(calc); err != nil {
+				return err
+			}
+		}
+
+		if remaining > 0 {
+			remaining -= len(page)
+			if remaining <= 0 {
+				return nil
+			}
+		}
+		if len(page) < filter.Limit {
+			return nil
+		}
+		filter.After = page[len(page)-1].ID
+		filter.Offset = 0
+	}
+}
+
+// GetStatistics validates the query, fills in defaults and aggregates the
+// history. Without a bucket, ranges of up to two days use hourly buckets
+// and longer ones daily buckets. Empty histogram buckets are filled in.
+func (uc *HistoryUseCase) GetStatistics(ctx context.Context, query domain.StatsQuery) (*domain.Statistics, error) {
+	if query.To.IsZero() {
+		query.To = time.Now()
+	}
+	if query.From.IsZero() {
+		query.From = query.To.Add(-defaultStatsRange)
+	}
+	query.From, query.To = query.From.UTC(), query.To.UTC()
+	if !query.From.Before(query.To) {
+		return nil, fmt.Errorf("%w: from must be before to", domain.ErrInvalidStatsQuery)
+	}
+
+	switch query.Bucket {
+	case "":
+		query.Bucket = domain.StatsBucketDay
+		if query.To.Sub(query.From) <= 48*time.Hour {
+			query.Bucket = domain.StatsBucketHour
+		}
+	case domain.StatsBucketHour, domain.StatsBucketDay:
+	default:
+		return nil, fmt.Errorf("%w: bucket must be hour or day", domain.ErrInvalidStatsQuery)
+	}
+	if query.To.Sub(query.From)/query.Bucket.Duration() > maxStatsBuckets {
+		return nil, fmt.Errorf("%w: more than %d %s buckets", domain.ErrInvalidStatsQuery, maxStatsBuckets, query.Bucket)
+	}
+
+	switch {
+	case query.TopPairs <= 0:
+		query.TopPairs = defaultTopPairs
+	case query.TopPairs > maxTopPairs:
+		query.TopPairs = maxTopPairs
+	}
+
+	stats, err := uc.repo.Stats(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	stats.From, stats.To, stats.Bucket = query.From, query.To, query.Bucket
+	stats.Histogram = fillHistogram(stats.Histogram, query)
+	return stats, nil
+}
+
+// VerifyChain walks the audit chain of the tenant in the context, oldest
+// record first, and stops at the first broken link. Records saved before the
+// chain was introduced are skipped. Records that were purged or removed by
+// retention are bridged by their tombstones. The oldest chained record may
+// link to a record that was removed without one; that is reported as
+// truncation rather than as a break.
+func (uc *HistoryUseCase) VerifyChain(ctx context.Context) (*domain.ChainVerification, error) {
+	if !domain.PrincipalFromContext(ctx).IsAdmin() {
+		return nil, domain.ErrForbidden
+	}
+
+	report := &domain.ChainVerification{TenantID: domain.TenantFromContext(ctx).ID}
+	after := 0
+	for {
+		page, err := uc.repo.Chain(ctx, after, chainPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, calc := range page {
+			gap := chainGap{link: report.Head}
+			if calc.Hash != "" && calc.PrevHash != report.Head {
+				if gap, err = uc.removedBefore(ctx, report.Head, after, calc.Seq); err != nil {
+					return nil, err
+				}
+			}
+			after = calc.Seq
+			if broken := verifyLink(report, calc, gap); broken != nil {
+				report.Broken = broken
+				return report, nil
+			}
+		}
+		if len(page) < chainPageSize {
+			return report, nil
+		}
+	}
+}
+
+// removedBefore follows the tombstones recorded between the record at
+// afterSeq, whose hash is head, and the record at beforeSeq.
+func (uc *HistoryUseCase) removedBefore(ctx context.Context, head string, afterSeq, beforeSeq int) (chainGap, error) {
+	gap := chainGap{link: head}
+	for {
+		page, err := uc.repo.Tombstones(ctx, afterSeq, beforeSeq, chainPageSize)
+		if err != nil {
+			return gap, err
+		}
+		for _, tombstone := range page {
+			afterSeq = tombstone.Seq
+			gap.follow(tombstone)
+		}
+		if len(page) < chainPageSize {
+			return gap, nil
+		}
+	}
+}
+
+// chainGap is a run of removed records between two records of an audit
+// chain, as far as their tombstones link up.
+type chainGap struct {
+	// link is the hash the record after the gap must link to.
+	link string
+	// removed counts the tombstones followed.
+	removed int
+	// detached is set when the first tombstone does not link to the record
+	// before the gap, because records were removed without a tombstone.
+	detached bool
+}
+
+// follow extends the gap by the next tombstone in chain order. Tombstones
+// that do not link to the gap, e.g. a second one for a record that was
+// removed twice concurrently, are skipped.
+func (g *chainGap) follow(tombstone domain.ChainTombstone) {
+	switch {
+	case tombstone.PrevHash == g.link:
+	case g.removed == 0:
+		g.detached = true
+	default:
+		return
+	}
+	g.link = tombstone.Hash
+	g.removed++
+}
+
+// verifyLink checks one record against the chain verified so far and the
+// gap of removed records before it, and advances the report, or returns the
+// break. Without removed records, gap.link is the head of the report.
+func verifyLink(report *domain.ChainVerification, calc domain.Calculation, gap chainGap) *domain.ChainBreak {
+	started := report.Checked > 0
+	if calc.Hash == "" {
+		if !started {
+			report.Unchained++
+			return nil
+		}
+		return &domain.ChainBreak{ID: calc.ID, Seq: calc.Seq, Reason: domain.ChainBreakMissing}
+	}
+
+	linked := calc.PrevHash == gap.link && !gap.detached
+	if started && !linked {
+		expected := gap.link
+		if gap.detached {
+			expected = report.Head
+		}
+		return &domain.ChainBreak{
+			ID:       calc.ID,
+			Seq:      calc.Seq,
+			Reason:   domain.ChainBreakLink,
+			Expected: expected,
+			Actual:   calc.PrevHash,
+		}
+	}
+	if want := calc.ChainHash(calc.PrevHash); want != calc.Hash {
+		return &domain.ChainBreak{
+			ID:       calc.ID,
+			Seq:      calc.Seq,
+			Reason:   domain.ChainBreakHash,
+			Expected: want,
+			Actual:   calc.Hash,
+		}
+	}
+
+	if !started {
+		report.Truncated = !linked
+	}
+	if calc.PrevHash == gap.link {
+		report.Removed += gap.removed
+	}
+	report.Checked++
+	report.Head = calc.Hash
+	return nil
+}
+
+// fillHistogram adds the empty buckets between From and To.
+func fillHistogram(buckets []domain.HistogramBucket, query domain.StatsQuery) []domain.HistogramBucket {
+	counts := make(map[time.Time]int, len(buckets))
+	for _, b := range buckets {
+		counts[b.Start.UTC()] += b.Count
+	}
+
+	width := query.Bucket.Duration()
+	var filled []domain.HistogramBucket
+	for start := query.From.Truncate(width); start.Before(query.To); start = start.Add(width) {
+		filled = append(filled, domain.HistogramBucket{Start: start, Count: counts[start]})
+	}
+	return filled
+}
@@ -0,0 +1,368 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/ports/in"
+	"go-prisma-calculator/internal/domain/ports/out"
+)
+
+// chainRepo serves an audit chain and its tombstones from memory. Other
+// methods of the port are not used by VerifyChain.
+type chainRepo struct {
+	out.CalculationRepositoryPort
+	calcs      []domain.Calculation
+	tombstones []domain.ChainTombstone
+}
+
+func (r *chainRepo) Chain(_ context.Context, afterSeq, limit int) ([]domain.Calculation, error) {
+	var page []domain.Calculation
+	for _, c := range r.calcs {
+		if c.Seq > afterSeq && len(page) < limit {
+			page = append(page, c)
+		}
+	}
+	return page, nil
+}
+
+func (r *chainRepo) Tombstones(_ context.Context, afterSeq, beforeSeq, limit int) ([]domain.ChainTombstone, error) {
+	var page []domain.ChainTombstone
+	for _, t := range r.tombstones {
+		if t.Seq > afterSeq && t.Seq < beforeSeq && len(page) < limit {
+			page = append(page, t)
+		}
+	}
+	return page, nil
+}
+
+// chained returns n hashed calculations with Seq 1 to n, each linked to the
+// one before it.
+func chained(n int) []domain.Calculation {
+	calcs := make([]domain.Calculation, n)
+	prev := ""
+	for i := range calcs {
+		c := domain.Calculation{
+			ID:        fmt.Sprintf("c%d", i+1),
+			Seq:       i + 1,
+			TenantID:  domain.DefaultTenantID,
+			Operation: "add",
+			Principal: "alice",
+			A:         i,
+			B:         1,
+			Result:    i + 1,
+			CreatedAt: time.Date(2024, 5, 1, 0, 0, i, 0, time.UTC),
+			PrevHash:  prev,
+		}
+		c.Hash = c.ChainHash(prev)
+		prev = c.Hash
+		calcs[i] = c
+	}
+	return calcs
+}
+
+// tombstone records calc as removed.
+func tombstone(calc domain.Calculation) domain.ChainTombstone {
+	return domain.ChainTombstone{
+		CalculationID: calc.ID,
+		Seq:           calc.Seq,
+		Hash:          calc.Hash,
+		PrevHash:      calc.PrevHash,
+		Reason:        domain.TombstonePurged,
+	}
+}
+
+func TestVerifyChain(t *testing.T) {
+	full := chained(6)
+	tampered := chained(4)
+	tampered[2].Result = 99
+	unhashed := chained(4)
+	unhashed[2].Hash = ""
+	// Seq is not part of the hash, so the chain can be moved behind a record
+	// saved before the chain was introduced.
+	legacy := append([]domain.Calculation{{ID: "old"}}, chained(3)...)
+	for i := range legacy {
+		legacy[i].Seq = i + 1
+	}
+
+	tests := []struct {
+		name       string
+		calcs      []domain.Calculation
+		tombstones []domain.ChainTombstone
+		want       domain.ChainVerification
+		wantBreak  *domain.ChainBreak
+	}{
+		{
+			name:  "intact",
+			calcs: full,
+			want:  domain.ChainVerification{Checked: 6, Head: full[5].Hash},
+		},
+		{
+			name:  "empty",
+			calcs: nil,
+			want:  domain.ChainVerification{},
+		},
+		{
+			name:  "unchained records first",
+			calcs: legacy,
+			want:  domain.ChainVerification{Checked: 3, Unchained: 1, Head: legacy[3].Hash},
+		},
+		{
+			name:       "removed records bridged by tombstones",
+			calcs:      []domain.Calculation{full[0], full[1], full[4], full[5]},
+			tombstones: []domain.ChainTombstone{tombstone(full[2]), tombstone(full[2]), tombstone(full[3])},
+			want:       domain.ChainVerification{Checked: 4, Removed: 2, Head: full[5].Hash},
+		},
+		{
+			// The record saved after the head was purged links to the
+			// head's tombstone.
+			name:       "record appended after the head was purged",
+			calcs:      []domain.Calculation{full[0], full[1], full[3]},
+			tombstones: []domain.ChainTombstone{tombstone(full[2])},
+			want:       domain.ChainVerification{Checked: 3, Removed: 1, Head: full[3].Hash},
+		},
+		{
+			name:       "removed record without a tombstone",
+			calcs:      []domain.Calculation{full[0], full[1], full[4], full[5]},
+			tombstones: []domain.ChainTombstone{tombstone(full[2])},
+			want:       domain.ChainVerification{Checked: 2, Head: full[1].Hash},
+			wantBreak: &domain.ChainBreak{
+				ID: "c5", Seq: 5, Reason: domain.ChainBreakLink,
+				Expected: full[2].Hash, Actual: full[3].Hash,
+			},
+		},
+		{
+			name:       "tombstones that do not link to the chain",
+			calcs:      []domain.Calculation{full[0], full[3], full[4], full[5]},
+			tombstones: []domain.ChainTombstone{tombstone(full[2])},
+			want:       domain.ChainVerification{Checked: 1, Head: full[0].Hash},
+			wantBreak: &domain.ChainBreak{
+				ID: "c4", Seq: 4, Reason: domain.ChainBreakLink,
+				Expected: full[0].Hash, Actual: full[2].Hash,
+			},
+		},
+		{
+			name:       "prefix removed with tombstones",
+			calcs:      full[2:],
+			tombstones: []domain.ChainTombstone{tombstone(full[0]), tombstone(full[1])},
+			want:       domain.ChainVerification{Checked: 4, Removed: 2, Head: full[5].Hash},
+		},
+		{
+			name:       "prefix partly removed with tombstones",
+			calcs:      full[2:],
+			tombstones: []domain.ChainTombstone{tombstone(full[1])},
+			want:       domain.ChainVerification{Checked: 4, Removed: 1, Truncated: true, Head: full[5].Hash},
+		},
+		{
+			name:  "prefix removed without tombstones",
+			calcs: full[2:],
+			want:  domain.ChainVerification{Checked: 4, Truncated: true, Head: full[5].Hash},
+		},
+		{
+			name:  "tampered content",
+			calcs: tampered,
+			want:  domain.ChainVerification{Checked: 2, Head: tampered[1].Hash},
+			wantBreak: &domain.ChainBreak{
+				ID: "c3", Seq: 3, Reason: domain.ChainBreakHash,
+				Expected: tampered[2].ChainHash(tampered[2].PrevHash), Actual: tampered[2].Hash,
+			},
+		},
+		{
+			name:  "missing hash",
+			calcs: unhashed,
+			want:  domain.ChainVerification{Checked: 2, Head: unhashed[1].Hash},
+			wantBreak: &domain.ChainBreak{
+				ID: "c3", Seq: 3, Reason: domain.ChainBreakMissing,
+			},
+		},
+	}
+
+	ctx := domain.WithPrincipal(context.Background(), domain.Principal{Name: "root", Role: domain.RoleAdmin})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewHistoryUseCase(&chainRepo{calcs: tt.calcs, tombstones: tt.tombstones})
+			got, err := uc.VerifyChain(ctx)
+			if err != nil {
+				t.Fatalf("VerifyChain error = %v", err)
+			}
+			broken := got.Broken
+			got.Broken, got.TenantID = nil, ""
+			if *got != tt.want {
+				t.Errorf("VerifyChain = %+v, want %+v", *got, tt.want)
+			}
+			switch {
+			case (broken == nil) != (tt.wantBreak == nil):
+				t.Errorf("break = %+v, want %+v", broken, tt.wantBreak)
+			case broken != nil && *broken != *tt.wantBreak:
+				t.Errorf("break = %+v, want %+v", *broken, *tt.wantBreak)
+			}
+		})
+	}
+}
+
+func TestVerifyChainForbidden(t *testing.T) {
+	uc := NewHistoryUseCase(&chainRepo{})
+	ctx := domain.WithPrincipal(context.Background(), domain.Principal{Name: "alice", Role: domain.RoleUser})
+	if _, err := uc.VerifyChain(ctx); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("VerifyChain error = %v, want %v", err, domain.ErrForbidden)
+	}
+}
+
+// historyRepo keeps calculations in memory with the soft-delete rules of
+// the Prisma repository: deleted calculations are hidden unless a listing
+// includes them, deleting one twice is ErrNotFound, restoring one that is
+// not deleted is a no-op and purging removes it whether or not it was
+// deleted.
+type historyRepo struct {
+	out.CalculationRepositoryPort
+	calcs  []domain.Calculation
+	purged []string
+}
+
+func (r *historyRepo) find(id string) *domain.Calculation {
+	for i := range r.calcs {
+		if r.calcs[i].ID == id {
+			return &r.calcs[i]
+		}
+	}
+	return nil
+}
+
+func (r *historyRepo) FindByID(_ context.Context, id string) (*domain.Calculation, error) {
+	calc := r.find(id)
+	if calc == nil || calc.DeletedAt != nil {
+		return nil, domain.ErrNotFound
+	}
+	found := *calc
+	return &found, nil
+}
+
+func (r *historyRepo) List(_ context.Context, filter domain.CalculationFilter) ([]domain.Calculation, error) {
+	var page []domain.Calculation
+	for _, calc := range r.calcs {
+		if (calc.DeletedAt == nil || filter.IncludeDeleted) && len(page) < filter.Limit {
+			page = append(page, calc)
+		}
+	}
+	return page, nil
+}
+
+func (r *historyRepo) Delete(_ context.Context, id string) error {
+	calc := r.find(id)
+	if calc == nil || calc.DeletedAt != nil {
+		return domain.ErrNotFound
+	}
+	now := time.Now()
+	calc.DeletedAt = &now
+	return nil
+}
+
+func (r *historyRepo) Restore(ctx context.Context, id string) (*domain.Calculation, error) {
+	calc := r.find(id)
+	if calc == nil {
+		return nil, domain.ErrNotFound
+	}
+	calc.DeletedAt = nil
+	return r.FindByID(ctx, id)
+}
+
+func (r *historyRepo) Purge(_ context.Context, id string) error {
+	for i := range r.calcs {
+		if r.calcs[i].ID == id {
+			r.calcs = append(r.calcs[:i], r.calcs[i+1:]...)
+			r.purged = append(r.purged, id)
+			return nil
+		}
+	}
+	return domain.ErrNotFound
+}
+
+// listedIDs lists the IDs of the history, with or without deleted ones.
+func listedIDs(t *testing.T, ctx context.Context, uc in.HistoryPort, includeDeleted bool) []string {
+	t.Helper()
+	calcs, err := uc.ListCalculations(ctx, domain.CalculationFilter{IncludeDeleted: includeDeleted})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make([]string, 0, len(calcs))
+	for _, calc := range calcs {
+		ids = append(ids, calc.ID)
+	}
+	return ids
+}
+
+func TestHistorySoftDeleteAndRestore(t *testing.T) {
+	ctx := domain.WithPrincipal(context.Background(), domain.Principal{Name: "alice", Role: domain.RoleUser})
+	uc := NewHistoryUseCase(&historyRepo{calcs: chained(3)})
+
+	if err := uc.DeleteCalculation(ctx, "c2"); err != nil {
+		t.Fatalf("DeleteCalculation error = %v", err)
+	}
+	if _, err := uc.GetCalculation(ctx, "c2"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("GetCalculation of a deleted calculation error = %v, want %v", err, domain.ErrNotFound)
+	}
+	if got := listedIDs(t, ctx, uc, false); !slices.Equal(got, []string{"c1", "c3"}) {
+		t.Errorf("listed %v, want the deleted calculation hidden", got)
+	}
+	if got := listedIDs(t, ctx, uc, true); !slices.Equal(got, []string{"c1", "c2", "c3"}) {
+		t.Errorf("listed %v with deleted ones, want all", got)
+	}
+	if err := uc.DeleteCalculation(ctx, "c2"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("second DeleteCalculation error = %v, want %v", err, domain.ErrNotFound)
+	}
+
+	restored, err := uc.RestoreCalculation(ctx, "c2")
+	if err != nil {
+		t.Fatalf("RestoreCalculation error = %v", err)
+	}
+	if restored.ID != "c2" || restored.DeletedAt != nil {
+		t.Errorf("RestoreCalculation = %+v, want c2 without deletedAt", restored)
+	}
+	if got := listedIDs(t, ctx, uc, false); !slices.Equal(got, []string{"c1", "c2", "c3"}) {
+		t.Errorf("listed %v after restoring, want all", got)
+	}
+	if _, err := uc.RestoreCalculation(ctx, "c2"); err != nil {
+		t.Errorf("restoring a calculation that is not deleted error = %v, want a no-op", err)
+	}
+	if _, err := uc.RestoreCalculation(ctx, "c9"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("RestoreCalculation of a missing calculation error = %v, want %v", err, domain.ErrNotFound)
+	}
+}
+
+func TestHistoryPurge(t *testing.T) {
+	admin := domain.WithPrincipal(context.Background(), domain.Principal{Name: "root", Role: domain.RoleAdmin})
+	user := domain.WithPrincipal(context.Background(), domain.Principal{Name: "alice", Role: domain.RoleUser})
+	repo := &historyRepo{calcs: chained(3)}
+	uc := NewHistoryUseCase(repo)
+
+	if err := uc.PurgeCalculation(user, "c1"); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("PurgeCalculation by a user error = %v, want %v", err, domain.ErrForbidden)
+	}
+	if len(repo.purged) != 0 {
+		t.Fatalf("a user purged %v", repo.purged)
+	}
+
+	// Purging removes soft-deleted calculations as well.
+	if err := uc.DeleteCalculation(admin, "c2"); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"c1", "c2"} {
+		if err := uc.PurgeCalculation(admin, id); err != nil {
+			t.Errorf("PurgeCalculation(%s) error = %v", id, err)
+		}
+	}
+	if got := listedIDs(t, admin, uc, true); !slices.Equal(got, []string{"c3"}) {
+		t.Errorf("listed %v after purging, want c3", got)
+	}
+	if _, err := uc.RestoreCalculation(admin, "c2"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("RestoreCalculation of a purged calculation error = %v, want %v", err, domain.ErrNotFound)
+	}
+	if err := uc.PurgeCalculation(admin, "c1"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("second PurgeCalculation error = %v, want %v", err, domain.ErrNotFound)
+	}
+}
@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"math/big"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/ports/in"
+	"go-prisma-calculator/internal/domain/ports/out"
+	"go-prisma-calculator/internal/domain/service"
+)
+
+const (
+	// importBatchSize is the number of calculations inserted per transaction.
+	importBatchSize = 200
+	// maxImportIssues caps how many problem rows an import report lists.
+	maxImportIssues = 100
+)
+
+// ImportUseCase implements the inbound port (in.ImportPort).
+type ImportUseCase struct {
+	calcService *service.CalculatorService
+	operations  *service.OperationRegistry
+	repo        out.CalculationRepositoryPort
+}
+
+// NewImportUseCase is the constructor that fx uses to create an instance.
+func NewImportUseCase(calcService *service.CalculatorService, operations *service.OperationRegistry, repo out.CalculationRepositoryPort) in.ImportPort {
+	return &ImportUseCase{calcService: calcService, operations: operations, repo: repo}
+}
+
+// ImportCalculations validates each row, optionally recomputes its result
+// and inserts the accepted rows in batches. Rows are stored under the
+// caller's name; only admins may keep the principal recorded in the file.
+func (uc *ImportUseCase) ImportCalculations(ctx context.Context, opts domain.ImportOptions, next func() (domain.ImportRow, error)) (*domain.ImportReport, error) {
+	switch opts.OnMismatch {
+	case "":
+		opts.OnMismatch = domain.MismatchReject
+	case domain.MismatchReject, domain.MismatchCorrect, domain.MismatchKeep:
+	default:
+		return nil, domain.ErrInvalidMismatchPolicy
+	}
+
+	caller := domain.PrincipalFromContext(ctx)
+	tenant := domain.TenantFromContext(ctx)
+	report := &domain.ImportReport{}
+	batch := make([]domain.Calculation, 0, importBatchSize)
+
+	flush := func() error {
+		if len(batch) == 0 || opts.DryRun {
+			batch = batch[:0]
+			return nil
+		}
+		if err := uc.repo.Import(ctx, batch); err != nil {
+			return err
+		}
+		report.Accepted += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	for {
+		row, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return report, err
+		}
+		report.Total++
+
+		calc, ok := uc.check(row, tenant, opts, report)
+		if !ok {
+			continue
+		}
+		if calc.Principal == "" || !caller.IsAdmin() {
+			calc.Principal = caller.Name
+		}
+		calc.ID = ""
+		calc.TenantID = tenant.ID
+
+		if opts.DryRun {
+			report.Accepted++
+			continue
+		}
+		batch = append(batch, calc)
+		if len(batch) >= importBatchSize {
+			if err := flush(); err != nil {
+				return report, err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// check validates a row and applies the mismatch policy. It records any
+// issue in the report and reports whether the row should be imported.
+func (uc *ImportUseCase) check(row domain.ImportRow, tenant domain.Tenant, opts domain.ImportOptions, report *domain.ImportReport) (domain.Calculation, bool) {
+	calc := row.Calculation
+	if row.Err != nil {
+		reject(report, row.Line, row.Err.Error())
+		return calc, false
+	}
+	if !calc.IsInteger() {
+		return uc.checkEvaluation(row, tenant, opts, report)
+	}
+	for _, v := range []struct {
+		name  string
+		value int
+	}{{"a", calc.A}, {"b", calc.B}, {"result", calc.Result}} {
+		if v.value < math.MinInt32 || v.value > math.MaxInt32 {
+			reject(report, row.Line, fmt.Sprintf("%s is out of range", v.name))
+			return calc, false
+		}
+	}
+
+	operands, err := uc.operations.Operands(calc.Operation, int32(calc.A), int32(calc.B))
+	if err != nil {
+		reject(report, row.Line, err.Error())
+		return calc, false
+	}
+	if err := tenant.Authorize(calc.Operation, calc.A, calc.B); err != nil {
+		reject(report, row.Line, err.Error())
+		return calc, false
+	}
+
+	expected, err := uc.calcService.Compute(calc.Operation, operands...)
+	if err != nil {
+		reject(report, row.Line, err.Error())
+		return calc, false
+	}
+	if !opts.Recompute || int(expected) == calc.Result {
+		return calc, true
+	}
+
+	corrected := calc
+	corrected.Result = int(expected)
+	return mismatch(row.Line, calc, corrected, fmt.Sprintf("recorded result %d, recomputed %d", calc.Result, expected), opts, report)
+}
+
+// checkEvaluation is check for calculations of kinds other than integers,
+// whose value is recomputed from their textual operands and options.
+func (uc *ImportUseCase) checkEvaluation(row domain.ImportRow, tenant domain.Tenant, opts domain.ImportOptions, report *domain.ImportReport) (domain.Calculation, bool) {
+	calc := row.Calculation
+	magnitude := func() (*big.Float, error) { return uc.calcService.Magnitude(calc.Evaluation()) }
+	if err := tenant.AuthorizeText(calc.Operation, magnitude); err != nil {
+		reject(report, row.Line, err.Error())
+		return calc, false
+	}
+
+	expected, err := uc.calcService.ComputeEvaluation(calc.Evaluation())
+	if err != nil {
+		reject(report, row.Line, err.Error())
+		return calc, false
+	}
+	// Store the operands and options in the canonical form a new
+	// calculation would have.
+	calc.Operands, calc.Options = expected.Operands, expected.Options
+	if !opts.Recompute || (expected.Value == calc.Value && maps.Equal(expected.Details, calc.Details)) {
+		return calc, true
+	}
+
+	corrected := calc
+	corrected.Value, corrected.Details = expected.Value, expected.Details
+	reason := fmt.Sprintf("recorded value %s, recomputed %s", calc.Value, expected.Value)
+	if expected.Value == calc.Value {
+		reason = fmt.Sprintf("recorded details %v, recomputed %v", calc.Details, expected.Details)
+	}
+	return mismatch(row.Line, calc, corrected, reason, opts, report)
+}
+
+// mismatch applies the mismatch policy to a row whose recorded result
+// differs from the recomputed one.
+func mismatch(line int, calc, corrected domain.Calculation, reason string, opts domain.ImportOptions, report *domain.ImportReport) (domain.Calculation, bool) {
+	report.Mismatched++
+	switch opts.OnMismatch {
+	case domain.MismatchCorrect:
+		addIssue(report, line, domain.ImportIssueMismatched, reason+", corrected")
+		return corrected, true
+	case domain.MismatchKeep:
+		addIssue(report, line, domain.ImportIssueMismatched, reason+", kept")
+		return calc, true
+	default:
+		report.Rejected++
+		addIssue(report, line, domain.ImportIssueMismatched, reason+", rejected")
+		return calc, false
+	}
+}
+
+func reject(report *domain.ImportReport, line int, reason string) {
+	report.Rejected++
+	addIssue(report, line, domain.ImportIssueRejected, reason)
+}
+
+func addIssue(report *domain.ImportReport, line int, kind domain.ImportIssueKind, reason string) {
+	if len(report.Issues) >= maxImportIssues {
+		report.IssuesTruncated = true
+		return
+	}
+	report.Issues = append(report.Issues, domain.ImportIssue{Line: line, Kind: kind, Reason: reason})
+}
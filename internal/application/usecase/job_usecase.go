@@ -0,0 +1,346 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/ports/in"
+	"go-prisma-calculator/internal/domain/ports/out"
+	"go-prisma-calculator/internal/domain/service"
+)
+
+// recoveryRetryInterval is the delay between attempts to recover unfinished jobs.
+const recoveryRetryInterval = 5 * time.Second
+
+// JobOptions controls the size of the worker pool that executes jobs.
+type JobOptions struct {
+	Workers   int
+	QueueSize int
+}
+
+// JobUseCase implements the inbound port (in.JobPort).
+// Jobs are persisted through the repository and executed by a bounded pool of
+// workers that call back into the synchronous calculator port.
+type JobUseCase struct {
+	calculator in.CalculatorPort
+	operations *service.OperationRegistry
+	repo       out.JobRepositoryPort
+	logger     *slog.Logger
+	workers    int
+	queue      chan queuedJob
+
+	mu      sync.Mutex
+	running map[string]context.CancelFunc
+
+	wg   sync.WaitGroup
+	stop context.CancelFunc
+}
+
+// NewJobUseCase is the constructor that fx uses to create an instance.
+func NewJobUseCase(calculator in.CalculatorPort, operations *service.OperationRegistry, repo out.JobRepositoryPort, opts JobOptions, logger *slog.Logger) *JobUseCase {
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 1
+	}
+	return &JobUseCase{
+		calculator: calculator,
+		operations: operations,
+		repo:       repo,
+		logger:     logger,
+		workers:    opts.Workers,
+		queue:      make(chan queuedJob, opts.QueueSize),
+		running:    make(map[string]context.CancelFunc),
+	}
+}
+
+// queuedJob identifies a job waiting for a worker. The tenant travels with
+// it, since the workers load and update jobs outside of any request.
+type queuedJob struct {
+	id     string
+	tenant string
+}
+
+// SubmitJob stores a new pending job and hands it to the worker pool. The
+// tenant's configuration is checked here, once, rather than when the job runs.
+//
+// A job stores two operands; for unary operations the second one is ignored.
+func (uc *JobUseCase) SubmitJob(ctx context.Context, operation string, a, b int32) (*domain.Job, error) {
+	operands, err := uc.operations.Operands(operation, a, b)
+	if err != nil {
+		return nil, err
+	}
+	if len(operands) < 2 {
+		b = 0
+	}
+	tenant := domain.TenantFromContext(ctx)
+	if err := tenant.Authorize(operation, int(a), int(b)); err != nil {
+		return nil, err
+	}
+	if tenant.MaxPendingJobs > 0 {
+		unfinished, err := uc.repo.CountUnfinished(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if unfinished >= tenant.MaxPendingJobs {
+			return nil, domain.ErrTenantJobLimit
+		}
+	}
+
+	job, err := uc.repo.Create(ctx, domain.Job{
+		TenantID:  tenant.ID,
+		Operation: operation,
+		A:         int(a),
+		B:         int(b),
+		Status:    domain.JobStatusPending,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case uc.queue <- queuedJob{id: job.ID, tenant: job.TenantID}:
+		return job, nil
+	default:
+		// The job was already persisted, so record why it will never run.
+		_ = uc.finish(ctx, job, domain.JobStatusFailed, nil, domain.ErrJobQueueFull)
+		return nil, domain.ErrJobQueueFull
+	}
+}
+
+// GetJob returns the current state of a job.
+func (uc *JobUseCase) GetJob(ctx context.Context, id string) (*domain.Job, error) {
+	return uc.repo.FindByID(ctx, id)
+}
+
+// CancelJob stops a pending or running job. Finished jobs cannot be cancelled.
+// The job is loaded first, so only jobs of the caller's tenant are stopped.
+// The cancellation is recorded only if the job has not finished meanwhile,
+// so a job that just succeeded keeps its result.
+func (uc *JobUseCase) CancelJob(ctx context.Context, id string) (*domain.Job, error) {
+	job, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status.IsTerminal() {
+		return nil, domain.ErrJobFinished
+	}
+
+	if err := uc.finish(ctx, job, domain.JobStatusCancelled, nil, nil); err != nil {
+		return nil, err
+	}
+
+	uc.mu.Lock()
+	if cancel, ok := uc.running[id]; ok {
+		cancel()
+	}
+	uc.mu.Unlock()
+	return job, nil
+}
+
+// ListJobs returns the jobs matching the filter, newest first.
+func (uc *JobUseCase) ListJobs(ctx context.Context, filter domain.JobFilter) ([]domain.Job, error) {
+	return uc.repo.List(ctx, filter)
+}
+
+// Start launches the workers and re-queues jobs left unfinished by a previous run.
+func (uc *JobUseCase) Start(ctx context.Context) error {
+	runCtx, stop := context.WithCancel(context.Background())
+	uc.stop = stop
+
+	for i := 0; i < uc.workers; i++ {
+		uc.wg.Add(1)
+		go uc.work(runCtx)
+	}
+
+	// Recover in the background so neither a large backlog nor an unavailable
+	// database can block startup.
+	uc.wg.Add(1)
+	go uc.recover(runCtx)
+
+	uc.logger.Info("Job workers started", slog.Int("workers", uc.workers))
+	return nil
+}
+
+// Stop cancels in-flight jobs and waits for the workers to exit.
+// Interrupted jobs stay in the running state and are picked up again on the next Start.
+func (uc *JobUseCase) Stop(ctx context.Context) error {
+	if uc.stop == nil {
+		return nil
+	}
+	uc.stop()
+
+	done := make(chan struct{})
+	go func() {
+		uc.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// recover re-queues unfinished jobs, retrying until the repository is reachable.
+func (uc *JobUseCase) recover(ctx context.Context) {
+	defer uc.wg.Done()
+
+	for {
+		pending, err := uc.recoverable(ctx)
+		if err == nil {
+			uc.logger.Info("Recovered unfinished jobs", slog.Int("jobs", len(pending)))
+			for _, job := range pending {
+				select {
+				case uc.queue <- job:
+				case <-ctx.Done():
+					return
+				}
+			}
+			return
+		}
+
+		uc.logger.Warn("Failed to recover unfinished jobs, retrying", slog.String("error", err.Error()))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(recoveryRetryInterval):
+		}
+	}
+}
+
+// recoverable returns the jobs of every tenant that were pending or running
+// when the process stopped.
+func (uc *JobUseCase) recoverable(ctx context.Context) ([]queuedJob, error) {
+	var queued []queuedJob
+	for _, status := range []domain.JobStatus{domain.JobStatusRunning, domain.JobStatusPending} {
+		jobs, err := uc.repo.List(ctx, domain.JobFilter{Status: status, AllTenants: true})
+		if err != nil {
+			return nil, err
+		}
+		for _, job := range jobs {
+			if job.Status == domain.JobStatusRunning {
+				job.Status = domain.JobStatusPending
+				job.StartedAt = nil
+				if err := uc.repo.Update(jobContext(ctx, job.TenantID), job); err != nil {
+					return nil, err
+				}
+			}
+			queued = append(queued, queuedJob{id: job.ID, tenant: job.TenantID})
+		}
+	}
+	return queued, nil
+}
+
+// jobContext returns a context scoped to the tenant of a job. The limits of
+// the tenant were checked on submission, so they are not carried along.
+func jobContext(ctx context.Context, tenant string) context.Context {
+	return domain.WithTenant(ctx, domain.Tenant{ID: tenant})
+}
+
+func (uc *JobUseCase) work(ctx context.Context) {
+	defer uc.wg.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case queued := <-uc.queue:
+			uc.run(jobContext(ctx, queued.tenant), queued.id)
+		}
+	}
+}
+
+func (uc *JobUseCase) run(ctx context.Context, id string) {
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	uc.mu.Lock()
+	uc.running[id] = cancel
+	uc.mu.Unlock()
+	defer func() {
+		uc.mu.Lock()
+		delete(uc.running, id)
+		uc.mu.Unlock()
+	}()
+
+	job, err := uc.repo.FindByID(jobCtx, id)
+	if err != nil {
+		uc.logger.Error("Failed to load job", slog.String("job_id", id), slog.String("error", err.Error()))
+		return
+	}
+	if job.Status != domain.JobStatusPending || jobCtx.Err() != nil {
+		return
+	}
+
+	started := time.Now()
+	job.Status = domain.JobStatusRunning
+	job.StartedAt = &started
+	if err := uc.repo.Update(jobCtx, *job); err != nil {
+		// ErrJobFinished means the job was cancelled after it was loaded.
+		if !errors.Is(err, domain.ErrJobFinished) {
+			uc.logger.Error("Failed to mark job as running", slog.String("job_id", id), slog.String("error", err.Error()))
+		}
+		return
+	}
+
+	calc, err := uc.execute(jobCtx, job)
+	if jobCtx.Err() != nil {
+		// Either CancelJob already recorded the cancellation or the pool is
+		// shutting down and the job will be recovered on the next start.
+		return
+	}
+	if err != nil {
+		_ = uc.finish(ctx, job, domain.JobStatusFailed, nil, err)
+		return
+	}
+	job.CalculationID = calc.ID
+	_ = uc.finish(ctx, job, domain.JobStatusSucceeded, &calc.Result, nil)
+}
+
+func (uc *JobUseCase) execute(ctx context.Context, job *domain.Job) (*domain.Calculation, error) {
+	operands, err := uc.operations.Operands(job.Operation, int32(job.A), int32(job.B))
+	if err != nil {
+		return nil, err
+	}
+	return uc.calculator.Calculate(ctx, job.Operation, operands...)
+}
+
+// finish moves a job into a terminal state and persists it, unless the job
+// was finished concurrently: then it returns ErrJobFinished and job is left
+// unchanged.
+func (uc *JobUseCase) finish(ctx context.Context, job *domain.Job, status domain.JobStatus, result *int, cause error) error {
+	finished := *job
+	now := time.Now()
+	finished.Status = status
+	finished.Result = result
+	finished.FinishedAt = &now
+	if cause != nil {
+		finished.Error = cause.Error()
+	}
+
+	err := uc.repo.Update(context.WithoutCancel(ctx), finished)
+	switch {
+	case errors.Is(err, domain.ErrJobFinished):
+		uc.logger.Info("Job finished concurrently, keeping its state",
+			slog.String("job_id", job.ID),
+			slog.String("status", string(status)),
+		)
+		return err
+	case err != nil:
+		uc.logger.Error("Failed to persist job state",
+			slog.String("job_id", job.ID),
+			slog.String("status", string(status)),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+	*job = finished
+	return nil
+}
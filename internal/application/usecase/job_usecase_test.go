@@ -0,0 +1,378 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/operations"
+	"go-prisma-calculator/internal/domain/ports/in"
+	"go-prisma-calculator/internal/domain/service"
+)
+
+// jobRepo keeps jobs in memory with the conditional update of the Prisma
+// repository: finished jobs are never overwritten.
+type jobRepo struct {
+	mu   sync.Mutex
+	jobs map[string]domain.Job
+	next int
+	// afterFind, if set, runs after FindByID loaded a job and before it
+	// returns, to interleave a concurrent change.
+	afterFind func(id string)
+}
+
+func newJobRepo() *jobRepo {
+	return &jobRepo{jobs: make(map[string]domain.Job)}
+}
+
+func (r *jobRepo) Create(_ context.Context, job domain.Job) (*domain.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.next++
+	job.ID = fmt.Sprintf("job%d", r.next)
+	job.CreatedAt = time.Now()
+	r.jobs[job.ID] = job
+	return &job, nil
+}
+
+func (r *jobRepo) Update(_ context.Context, job domain.Job) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.jobs[job.ID]
+	switch {
+	case !ok:
+		return domain.ErrNotFound
+	case stored.Status.IsTerminal():
+		return domain.ErrJobFinished
+	}
+	r.jobs[job.ID] = job
+	return nil
+}
+
+func (r *jobRepo) FindByID(_ context.Context, id string) (*domain.Job, error) {
+	r.mu.Lock()
+	job, ok := r.jobs[id]
+	r.mu.Unlock()
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	if r.afterFind != nil {
+		r.afterFind(id)
+	}
+	return &job, nil
+}
+
+func (r *jobRepo) List(_ context.Context, filter domain.JobFilter) ([]domain.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var jobs []domain.Job
+	for _, job := range r.jobs {
+		if filter.Status == "" || job.Status == filter.Status {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
+func (r *jobRepo) CountUnfinished(context.Context) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, job := range r.jobs {
+		if !job.Status.IsTerminal() {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// get returns the stored state of a job.
+func (r *jobRepo) get(id string) domain.Job {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.jobs[id]
+}
+
+// set overwrites the stored state of a job, as another process would.
+func (r *jobRepo) set(job domain.Job) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.jobs[job.ID] = job
+}
+
+// jobCalculator runs calculate for every job and records the result with
+// the ID "calc-" followed by the job operands.
+type jobCalculator struct {
+	in.CalculatorPort
+	calculate func(ctx context.Context, operands []int32) (int32, error)
+}
+
+func (c *jobCalculator) Calculate(ctx context.Context, operation string, operands ...int32) (*domain.Calculation, error) {
+	result, err := c.calculate(ctx, operands)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Calculation{
+		ID:        fmt.Sprintf("calc-%d-%d", operands[0], operands[1]),
+		Operation: operation,
+		Result:    int(result),
+	}, nil
+}
+
+func newJobUseCase(t *testing.T, repo *jobRepo, calculate func(context.Context, []int32) (int32, error), opts JobOptions) *JobUseCase {
+	t.Helper()
+	registry, err := service.NewOperationRegistry([]domain.Operation{operations.NewAdd(), operations.NewDivide()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewJobUseCase(&jobCalculator{calculate: calculate}, registry, repo, opts, logger)
+}
+
+// startJobs starts the worker pool and stops it when the test ends.
+func startJobs(t *testing.T, uc *JobUseCase) {
+	t.Helper()
+	if err := uc.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := uc.Stop(ctx); err != nil {
+			t.Errorf("Stop: %v", err)
+		}
+	})
+}
+
+// waitForJob polls the repository until the job is in a terminal state.
+func waitForJob(t *testing.T, repo *jobRepo, id string) domain.Job {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if job := repo.get(id); job.Status.IsTerminal() {
+			return job
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("job %s did not finish: %+v", id, repo.get(id))
+	return domain.Job{}
+}
+
+// waitIdle waits until no worker runs a job.
+func waitIdle(t *testing.T, uc *JobUseCase) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		uc.mu.Lock()
+		idle := len(uc.running) == 0
+		uc.mu.Unlock()
+		if idle {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("workers did not become idle")
+}
+
+func sum(_ context.Context, operands []int32) (int32, error) {
+	return operands[0] + operands[1], nil
+}
+
+func TestJobSucceeds(t *testing.T) {
+	repo := newJobRepo()
+	uc := newJobUseCase(t, repo, sum, JobOptions{Workers: 2, QueueSize: 4})
+	startJobs(t, uc)
+
+	job, err := uc.SubmitJob(context.Background(), "add", 2, 3)
+	if err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+	got := waitForJob(t, repo, job.ID)
+	if got.Status != domain.JobStatusSucceeded || got.Result == nil || *got.Result != 5 || got.CalculationID != "calc-2-3" {
+		t.Errorf("job = %+v, want succeeded with result 5 and calculation calc-2-3", got)
+	}
+	if got.StartedAt == nil || got.FinishedAt == nil {
+		t.Errorf("job = %+v, want start and finish times", got)
+	}
+}
+
+func TestJobFails(t *testing.T) {
+	repo := newJobRepo()
+	fail := func(context.Context, []int32) (int32, error) { return 0, domain.ErrDivisionByZero }
+	uc := newJobUseCase(t, repo, fail, JobOptions{})
+	startJobs(t, uc)
+
+	job, err := uc.SubmitJob(context.Background(), "divide", 1, 0)
+	if err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+	got := waitForJob(t, repo, job.ID)
+	if got.Status != domain.JobStatusFailed || got.Result != nil || got.Error != domain.ErrDivisionByZero.Error() {
+		t.Errorf("job = %+v, want failed with %q", got, domain.ErrDivisionByZero)
+	}
+}
+
+func TestSubmitJobQueueFull(t *testing.T) {
+	repo := newJobRepo()
+	// The workers are not started, so the first job fills the queue.
+	uc := newJobUseCase(t, repo, sum, JobOptions{QueueSize: 1})
+	if _, err := uc.SubmitJob(context.Background(), "add", 1, 1); err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+	if _, err := uc.SubmitJob(context.Background(), "add", 1, 2); !errors.Is(err, domain.ErrJobQueueFull) {
+		t.Fatalf("SubmitJob error = %v, want %v", err, domain.ErrJobQueueFull)
+	}
+	if got := repo.get("job2"); got.Status != domain.JobStatusFailed || got.Error != domain.ErrJobQueueFull.Error() {
+		t.Errorf("rejected job = %+v, want failed with %q", got, domain.ErrJobQueueFull)
+	}
+}
+
+// TestFinishKeepsConcurrentState covers a worker finishing a job whose
+// cancellation was recorded after the calculation completed but before the
+// worker's context was cancelled.
+func TestFinishKeepsConcurrentState(t *testing.T) {
+	repo := newJobRepo()
+	cancelDuringCalculation := func(ctx context.Context, operands []int32) (int32, error) {
+		// CancelJob has persisted the cancellation but not yet cancelled the
+		// worker's context.
+		job := repo.get("job1")
+		job.Status = domain.JobStatusCancelled
+		repo.set(job)
+		return sum(ctx, operands)
+	}
+	uc := newJobUseCase(t, repo, cancelDuringCalculation, JobOptions{})
+	startJobs(t, uc)
+
+	job, err := uc.SubmitJob(context.Background(), "add", 2, 3)
+	if err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+	waitForJob(t, repo, job.ID)
+	waitIdle(t, uc)
+	if got := repo.get(job.ID); got.Status != domain.JobStatusCancelled || got.Result != nil || got.CalculationID != "" {
+		t.Errorf("job = %+v, want cancelled without a result", got)
+	}
+
+	running := domain.Job{ID: job.ID, Status: domain.JobStatusRunning}
+	local := running
+	result := 5
+	if err := uc.finish(context.Background(), &local, domain.JobStatusSucceeded, &result, nil); !errors.Is(err, domain.ErrJobFinished) {
+		t.Fatalf("finish error = %v, want %v", err, domain.ErrJobFinished)
+	}
+	if local != running {
+		t.Errorf("finish changed the job to %+v after losing the race", local)
+	}
+}
+
+// TestCancelJobRacingCompletion covers a job that succeeds between
+// CancelJob loading it and recording the cancellation.
+func TestCancelJobRacingCompletion(t *testing.T) {
+	repo := newJobRepo()
+	uc := newJobUseCase(t, repo, sum, JobOptions{})
+	job, err := uc.SubmitJob(context.Background(), "add", 2, 3)
+	if err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+	running := repo.get(job.ID)
+	running.Status = domain.JobStatusRunning
+	repo.set(running)
+
+	repo.afterFind = func(id string) {
+		repo.afterFind = nil
+		finished := repo.get(id)
+		result := 5
+		finished.Status = domain.JobStatusSucceeded
+		finished.Result = &result
+		finished.CalculationID = "calc-2-3"
+		repo.set(finished)
+	}
+	if _, err := uc.CancelJob(context.Background(), job.ID); !errors.Is(err, domain.ErrJobFinished) {
+		t.Fatalf("CancelJob error = %v, want %v", err, domain.ErrJobFinished)
+	}
+	if got := repo.get(job.ID); got.Status != domain.JobStatusSucceeded || got.Result == nil || *got.Result != 5 {
+		t.Errorf("job = %+v, want it to keep its result", got)
+	}
+}
+
+func TestCancelJob(t *testing.T) {
+	repo := newJobRepo()
+	started := make(chan struct{})
+	block := func(ctx context.Context, _ []int32) (int32, error) {
+		close(started)
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}
+	uc := newJobUseCase(t, repo, block, JobOptions{})
+	startJobs(t, uc)
+
+	job, err := uc.SubmitJob(context.Background(), "add", 2, 3)
+	if err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+	<-started
+	cancelled, err := uc.CancelJob(context.Background(), job.ID)
+	if err != nil {
+		t.Fatalf("CancelJob: %v", err)
+	}
+	if cancelled.Status != domain.JobStatusCancelled {
+		t.Errorf("CancelJob = %+v, want cancelled", cancelled)
+	}
+	if _, err := uc.CancelJob(context.Background(), job.ID); !errors.Is(err, domain.ErrJobFinished) {
+		t.Errorf("second CancelJob error = %v, want %v", err, domain.ErrJobFinished)
+	}
+	waitIdle(t, uc)
+	if got := repo.get(job.ID); got.Status != domain.JobStatusCancelled || got.Error != "" {
+		t.Errorf("job = %+v, want cancelled", got)
+	}
+}
+
+// TestCancelJobsWhileRunning cancels jobs while the pool completes them and
+// checks that each ends in exactly one consistent terminal state.
+func TestCancelJobsWhileRunning(t *testing.T) {
+	repo := newJobRepo()
+	uc := newJobUseCase(t, repo, sum, JobOptions{Workers: 4, QueueSize: 100})
+	startJobs(t, uc)
+
+	var ids []string
+	for i := range 100 {
+		job, err := uc.SubmitJob(context.Background(), "add", int32(i), 1)
+		if err != nil {
+			t.Fatalf("SubmitJob: %v", err)
+		}
+		ids = append(ids, job.ID)
+	}
+
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := uc.CancelJob(context.Background(), id); err != nil && !errors.Is(err, domain.ErrJobFinished) {
+				t.Errorf("CancelJob(%s): %v", id, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, id := range ids {
+		got := waitForJob(t, repo, id)
+		switch got.Status {
+		case domain.JobStatusSucceeded:
+			if got.Result == nil || *got.Result != i+1 || got.CalculationID == "" {
+				t.Errorf("succeeded job = %+v, want result %d", got, i+1)
+			}
+		case domain.JobStatusCancelled:
+			if got.Result != nil || got.CalculationID != "" {
+				t.Errorf("cancelled job = %+v, want no result", got)
+			}
+		default:
+			t.Errorf("job = %+v, want succeeded or cancelled", got)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"math/big"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/ports/in"
+	"go-prisma-calculator/internal/domain/service"
+)
+
+// TenantCalculator is a decorator around in.CalculatorPort that checks every
+// calculation against the configuration of the request's tenant. It wraps
+// the result cache, so cached results are checked as well.
+type TenantCalculator struct {
+	next        in.CalculatorPort
+	calcService *service.CalculatorService
+}
+
+// NewTenantCalculator wraps next with the tenant policy. calcService sizes
+// the operands of evaluations, which depends on their kind.
+func NewTenantCalculator(next in.CalculatorPort, calcService *service.CalculatorService) in.CalculatorPort {
+	return &TenantCalculator{next: next, calcService: calcService}
+}
+
+// Calculate checks the operation and its operands against the tenant's
+// configuration before running it.
+func (c *TenantCalculator) Calculate(ctx context.Context, operation string, operands ...int32) (*domain.Calculation, error) {
+	values := make([]int, len(operands))
+	for i, v := range operands {
+		values[i] = int(v)
+	}
+	if err := domain.TenantFromContext(ctx).Authorize(operation, values...); err != nil {
+		return nil, err
+	}
+	return c.next.Calculate(ctx, operation, operands...)
+}
+
+// ListOperations only lists the operations the tenant may run.
+func (c *TenantCalculator) ListOperations(ctx context.Context) ([]domain.OperationSignature, error) {
+	signatures, err := c.next.ListOperations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tenant := domain.TenantFromContext(ctx)
+	allowed := signatures[:0:0]
+	for _, sig := range signatures {
+		if tenant.Allows(sig.Name) {
+			allowed = append(allowed, sig)
+		}
+	}
+	return allowed, nil
+}
+
+// Divide checks the divide operation and its operands against the tenant's
+// configuration before running it.
+func (c *TenantCalculator) Divide(ctx context.Context, d domain.RoundedDivision) (*domain.Calculation, error) {
+	if err := domain.TenantFromContext(ctx).Authorize("divide", int(d.Dividend), int(d.Divisor)); err != nil {
+		return nil, err
+	}
+	return c.next.Divide(ctx, d)
+}
+
+// Evaluate checks the function and its operands against the tenant's
+// configuration before evaluating it.
+func (c *TenantCalculator) Evaluate(ctx context.Context, e domain.Evaluation) (*domain.Calculation, error) {
+	magnitude := func() (*big.Float, error) { return c.calcService.Magnitude(e) }
+	if err := domain.TenantFromContext(ctx).AuthorizeText(e.Function, magnitude); err != nil {
+		return nil, err
+	}
+	return c.next.Evaluate(ctx, e)
+}
+
+// ListFunctions only lists the functions the tenant may evaluate.
+func (c *TenantCalculator) ListFunctions(ctx context.Context) ([]domain.FunctionSignature, error) {
+	signatures, err := c.next.ListFunctions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tenant := domain.TenantFromContext(ctx)
+	allowed := signatures[:0:0]
+	for _, sig := range signatures {
+		if tenant.Allows(sig.Name) {
+			allowed = append(allowed, sig)
+		}
+	}
+	return allowed, nil
+}
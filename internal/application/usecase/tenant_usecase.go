@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/ports/in"
+	"go-prisma-calculator/internal/domain/ports/out"
+	"go-prisma-calculator/internal/domain/service"
+)
+
+// TenantOptions controls how long resolved tenants are cached.
+type TenantOptions struct {
+	// CacheTTL bounds how long a change made on another instance takes to apply.
+	CacheTTL time.Duration
+}
+
+// TenantUseCase implements the inbound port (in.TenantPort). Every request
+// resolves its tenant, so tenants are cached in memory for CacheTTL.
+type TenantUseCase struct {
+	repo       out.TenantRepositoryPort
+	operations *service.OperationRegistry
+	functions  *service.EvaluatorRegistry
+	logger     *slog.Logger
+	ttl        time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cachedTenant
+}
+
+type cachedTenant struct {
+	tenant  domain.Tenant
+	expires time.Time
+}
+
+// NewTenantUseCase is the constructor that fx uses to create an instance.
+func NewTenantUseCase(repo out.TenantRepositoryPort, operations *service.OperationRegistry, functions *service.EvaluatorRegistry, opts TenantOptions, logger *slog.Logger) in.TenantPort {
+	return &TenantUseCase{
+		repo:       repo,
+		operations: operations,
+		functions:  functions,
+		logger:     logger,
+		ttl:        opts.CacheTTL,
+		cache:      make(map[string]cachedTenant),
+	}
+}
+
+// ResolveTenant picks the tenant of a request. Credentials use the tenant
+// they are bound to, or the default tenant when they are bound to none, and
+// only admins may select another one. Disabled tenants are only open to
+// admins, e.g. to export their history.
+func (uc *TenantUseCase) ResolveTenant(ctx context.Context, requested string) (*domain.Tenant, error) {
+	principal := domain.PrincipalFromContext(ctx)
+	id := principal.Tenant
+	if id == "" {
+		id = domain.DefaultTenantID
+	}
+	switch {
+	case requested == "" || requested == id:
+	case principal.IsAdmin():
+		id = requested
+	default:
+		return nil, fmt.Errorf("%w: credentials are bound to tenant %q", domain.ErrForbidden, id)
+	}
+
+	tenant, err := uc.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if tenant.Disabled() && !principal.IsAdmin() {
+		return nil, fmt.Errorf("%w: %q", domain.ErrTenantDisabled, id)
+	}
+	return tenant, nil
+}
+
+// load returns a tenant from the cache or the repository. While the
+// repository fails, an expired entry is served rather than rejecting every
+// request; without one the request fails, since the restrictions of the
+// tenant are unknown. A default tenant that does not exist, as before the
+// tenant migration has run, has no restrictions.
+func (uc *TenantUseCase) load(ctx context.Context, id string) (*domain.Tenant, error) {
+	uc.mu.Lock()
+	entry, cached := uc.cache[id]
+	uc.mu.Unlock()
+	if cached && time.Now().Before(entry.expires) {
+		return &entry.tenant, nil
+	}
+
+	tenant, err := uc.repo.FindByID(ctx, id)
+	switch {
+	case errors.Is(err, domain.ErrNotFound) && id != domain.DefaultTenantID:
+		return nil, fmt.Errorf("%w: %q", domain.ErrUnknownTenant, id)
+	case err != nil && cached:
+		uc.logger.Warn("Failed to reload tenant, serving cached entry", slog.String("tenant", id), slog.String("error", err.Error()))
+		return &entry.tenant, nil
+	case errors.Is(err, domain.ErrNotFound):
+		tenant := domain.DefaultTenant
+		return &tenant, nil
+	case err != nil:
+		return nil, err
+	}
+
+	uc.store(*tenant)
+	return tenant, nil
+}
+
+func (uc *TenantUseCase) store(tenant domain.Tenant) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	uc.cache[tenant.ID] = cachedTenant{tenant: tenant, expires: time.Now().Add(uc.ttl)}
+}
+
+// CreateTenant adds a new, enabled tenant.
+func (uc *TenantUseCase) CreateTenant(ctx context.Context, tenant domain.Tenant) (*domain.Tenant, error) {
+	if !domain.PrincipalFromContext(ctx).IsAdmin() {
+		return nil, domain.ErrForbidden
+	}
+	if err := uc.validate(tenant); err != nil {
+		return nil, err
+	}
+
+	tenant.DisabledAt = nil
+	created, err := uc.repo.Create(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+	uc.store(*created)
+	return created, nil
+}
+
+// GetTenant loads a single tenant.
+func (uc *TenantUseCase) GetTenant(ctx context.Context, id string) (*domain.Tenant, error) {
+	if !domain.PrincipalFromContext(ctx).IsAdmin() {
+		return nil, domain.ErrForbidden
+	}
+	return uc.repo.FindByID(ctx, id)
+}
+
+// ListTenants returns every tenant, including disabled ones.
+func (uc *TenantUseCase) ListTenants(ctx context.Context) ([]domain.Tenant, error) {
+	if !domain.PrincipalFromContext(ctx).IsAdmin() {
+		return nil, domain.ErrForbidden
+	}
+	return uc.repo.List(ctx)
+}
+
+// UpdateTenant replaces the name and configuration of a tenant, keeping
+// whether it is disabled.
+func (uc *TenantUseCase) UpdateTenant(ctx context.Context, tenant domain.Tenant) (*domain.Tenant, error) {
+	if !domain.PrincipalFromContext(ctx).IsAdmin() {
+		return nil, domain.ErrForbidden
+	}
+	if err := uc.validate(tenant); err != nil {
+		return nil, err
+	}
+
+	current, err := uc.repo.FindByID(ctx, tenant.ID)
+	if err != nil {
+		return nil, err
+	}
+	current.Name = tenant.Name
+	current.AllowedOperations = tenant.AllowedOperations
+	current.MaxOperand = tenant.MaxOperand
+	current.MaxPendingJobs = tenant.MaxPendingJobs
+	return uc.update(ctx, *current)
+}
+
+// DisableTenant rejects further requests to a tenant. Its history is kept.
+// The default tenant cannot be disabled.
+func (uc *TenantUseCase) DisableTenant(ctx context.Context, id string) (*domain.Tenant, error) {
+	if !domain.PrincipalFromContext(ctx).IsAdmin() {
+		return nil, domain.ErrForbidden
+	}
+	if id == domain.DefaultTenantID {
+		return nil, fmt.Errorf("%w: the default tenant cannot be disabled", domain.ErrInvalidTenant)
+	}
+
+	tenant, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if tenant.Disabled() {
+		return tenant, nil
+	}
+	now := time.Now()
+	tenant.DisabledAt = &now
+	return uc.update(ctx, *tenant)
+}
+
+// EnableTenant lifts a previous DisableTenant.
+func (uc *TenantUseCase) EnableTenant(ctx context.Context, id string) (*domain.Tenant, error) {
+	if !domain.PrincipalFromContext(ctx).IsAdmin() {
+		return nil, domain.ErrForbidden
+	}
+
+	tenant, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !tenant.Disabled() {
+		return tenant, nil
+	}
+	tenant.DisabledAt = nil
+	return uc.update(ctx, *tenant)
+}
+
+func (uc *TenantUseCase) update(ctx context.Context, tenant domain.Tenant) (*domain.Tenant, error) {
+	updated, err := uc.repo.Update(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+	uc.store(*updated)
+	return updated, nil
+}
+
+// validate checks a tenant and the registered operations and functions it
+// may run.
+func (uc *TenantUseCase) validate(tenant domain.Tenant) error {
+	if err := tenant.Validate(); err != nil {
+		return err
+	}
+	for _, operation := range tenant.AllowedOperations {
+		if _, err := uc.operations.Lookup(operation); err != nil && !uc.functions.Has(operation) {
+			return fmt.Errorf("%w: unsupported operation %q", domain.ErrInvalidTenant, operation)
+		}
+	}
+	return nil
+}
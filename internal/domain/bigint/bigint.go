@@ -0,0 +1,249 @@
+// Package bigint provides arbitrary-precision integer arithmetic with
+// math/big. Operands are decimal or hexadecimal ("0x") strings, results
+// are decimal strings, and every operand and result is bounded in size so
+// that a single request cannot exhaust the server.
+package bigint
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	domain "go-prisma-calculator/internal/domain/models"
+)
+
+// DefaultMaxBits is the size limit used when none is configured.
+const DefaultMaxBits = 4096
+
+// function is a big integer operation.
+type function struct {
+	name  string
+	arity int
+	doc   domain.OperationDoc
+	eval  func(e *evaluator, x []*big.Int) (*big.Int, error)
+}
+
+// functions is the operation set, ordered by name.
+var functions = []function{
+	{name: "add", arity: 2, eval: add,
+		doc: domain.OperationDoc{Description: "Sum of two integers.", Operands: []string{"a", "b"}, Commutative: true}},
+	{name: "div", arity: 2, eval: div,
+		doc: domain.OperationDoc{Description: "Quotient truncated toward zero.", Operands: []string{"dividend", "divisor"}}},
+	{name: "mod", arity: 2, eval: mod,
+		doc: domain.OperationDoc{Description: "Euclidean modulus, between 0 and |divisor| - 1.", Operands: []string{"dividend", "divisor"}}},
+	{name: "modinverse", arity: 2, eval: modInverse,
+		doc: domain.OperationDoc{Description: "Inverse of a modulo a positive modulus; a and the modulus must be coprime.", Operands: []string{"a", "modulus"}}},
+	{name: "modpow", arity: 3, eval: modPow,
+		doc: domain.OperationDoc{Description: "base^exponent modulo a positive modulus; a negative exponent needs base and modulus to be coprime.", Operands: []string{"base", "exponent", "modulus"}}},
+	{name: "mul", arity: 2, eval: mul,
+		doc: domain.OperationDoc{Description: "Product of two integers.", Operands: []string{"a", "b"}, Commutative: true}},
+	{name: "pow", arity: 2, eval: pow,
+		doc: domain.OperationDoc{Description: "base raised to a non-negative exponent.", Operands: []string{"base", "exponent"}}},
+	{name: "sub", arity: 2, eval: sub,
+		doc: domain.OperationDoc{Description: "Difference of two integers.", Operands: []string{"a", "b"}}},
+}
+
+// evaluator evaluates the operations on operands and results of at most
+// maxBits bits.
+type evaluator struct {
+	maxBits int
+}
+
+// NewEvaluator returns the evaluator of KindBigInt. maxBits bounds the
+// size of operands and results; 0 selects DefaultMaxBits.
+func NewEvaluator(maxBits int) domain.Evaluator {
+	if maxBits <= 0 {
+		maxBits = DefaultMaxBits
+	}
+	return &evaluator{maxBits: maxBits}
+}
+
+func (e *evaluator) Kind() domain.Kind { return domain.KindBigInt }
+
+func (e *evaluator) Functions() []domain.FunctionSignature {
+	sigs := make([]domain.FunctionSignature, 0, len(functions))
+	for _, f := range functions {
+		sigs = append(sigs, domain.FunctionSignature{
+			Kind:         domain.KindBigInt,
+			Name:         f.name,
+			Arity:        f.arity,
+			OperationDoc: f.doc,
+			Options:      []string{},
+		})
+	}
+	return sigs
+}
+
+func (e *evaluator) Evaluate(name string, operands []string, opts domain.EvaluationOptions) (*domain.EvaluationResult, error) {
+	i := slices.IndexFunc(functions, func(f function) bool { return f.name == name })
+	if i < 0 {
+		return nil, fmt.Errorf("%w: %q", domain.ErrUnsupportedOperation, name)
+	}
+	f := functions[i]
+	if len(operands) != f.arity {
+		return nil, fmt.Errorf("%w: %s takes %d operands, got %d", domain.ErrInvalidOperands, name, f.arity, len(operands))
+	}
+
+	x := make([]*big.Int, len(operands))
+	canonical := make([]string, len(operands))
+	for i, s := range operands {
+		v, err := e.parse(s)
+		if err != nil {
+			return nil, err
+		}
+		x[i], canonical[i] = v, v.String()
+	}
+
+	v, err := f.eval(e, x)
+	if err != nil {
+		var de *domain.DomainError
+		if errors.As(err, &de) {
+			de.Function = name
+		}
+		return nil, err
+	}
+	if err := e.check("the result", v); err != nil {
+		return nil, err
+	}
+	// No option affects integer results.
+	return &domain.EvaluationResult{Operands: canonical, Value: v.String()}, nil
+}
+
+func (e *evaluator) Magnitude(operands []string) (*big.Float, error) {
+	return domain.LargestMagnitude(operands, func(s string) (*big.Float, error) {
+		v, err := e.parse(s)
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Float).SetInt(v.Abs(v)), nil
+	})
+}
+
+// parse reads a decimal or "0x"-prefixed hexadecimal integer with an
+// optional sign.
+func (e *evaluator) parse(s string) (*big.Int, error) {
+	text := strings.TrimSpace(s)
+	// Reject oversized text before parsing it; a hex digit carries 4 bits
+	// and a decimal one more than 3.
+	if len(text) > e.maxBits/3+4 {
+		return nil, e.tooLarge("an operand")
+	}
+
+	digits, negative := text, false
+	if rest, ok := strings.CutPrefix(digits, "-"); ok {
+		digits, negative = rest, true
+	} else {
+		digits = strings.TrimPrefix(digits, "+")
+	}
+	base := 10
+	if rest, ok := cutHexPrefix(digits); ok {
+		digits, base = rest, 16
+	}
+
+	v, ok := new(big.Int).SetString(digits, base)
+	// SetString accepts a sign and underscores of its own; neither may
+	// follow the one handled above.
+	if !ok || digits == "" || strings.ContainsAny(digits, "+-_") {
+		return nil, fmt.Errorf("%w: %q is not a decimal or hexadecimal integer", domain.ErrInvalidOperands, s)
+	}
+	if negative {
+		v.Neg(v)
+	}
+	if err := e.check("an operand", v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func cutHexPrefix(s string) (string, bool) {
+	if rest, ok := strings.CutPrefix(s, "0x"); ok {
+		return rest, true
+	}
+	return strings.CutPrefix(s, "0X")
+}
+
+// check bounds the size of v.
+func (e *evaluator) check(what string, v *big.Int) error {
+	if v.BitLen() > e.maxBits {
+		return e.tooLarge(what)
+	}
+	return nil
+}
+
+func (e *evaluator) tooLarge(what string) error {
+	return fmt.Errorf("%w: %s exceeds %d bits", domain.ErrOperandOutOfRange, what, e.maxBits)
+}
+
+func add(_ *evaluator, x []*big.Int) (*big.Int, error) {
+	return new(big.Int).Add(x[0], x[1]), nil
+}
+
+func sub(_ *evaluator, x []*big.Int) (*big.Int, error) {
+	return new(big.Int).Sub(x[0], x[1]), nil
+}
+
+func mul(_ *evaluator, x []*big.Int) (*big.Int, error) {
+	return new(big.Int).Mul(x[0], x[1]), nil
+}
+
+func div(_ *evaluator, x []*big.Int) (*big.Int, error) {
+	if x[1].Sign() == 0 {
+		return nil, domain.ErrDivisionByZero
+	}
+	return new(big.Int).Quo(x[0], x[1]), nil
+}
+
+func mod(_ *evaluator, x []*big.Int) (*big.Int, error) {
+	if x[1].Sign() == 0 {
+		return nil, domain.ErrDivisionByZero
+	}
+	return new(big.Int).Mod(x[0], x[1]), nil
+}
+
+func pow(e *evaluator, x []*big.Int) (*big.Int, error) {
+	base, exp := x[0], x[1]
+	if exp.Sign() < 0 {
+		return nil, undefined("the exponent must not be negative")
+	}
+	// Estimate the size of the result before computing it; bases 0, 1
+	// and -1 have small powers of any exponent.
+	if base.CmpAbs(big.NewInt(1)) > 0 {
+		bits := new(big.Int).Mul(big.NewInt(int64(base.BitLen()-1)), exp)
+		if bits.Cmp(big.NewInt(int64(e.maxBits))) > 0 {
+			return nil, e.tooLarge("the result")
+		}
+	}
+	return new(big.Int).Exp(base, exp, nil), nil
+}
+
+func modPow(_ *evaluator, x []*big.Int) (*big.Int, error) {
+	base, exp, m := x[0], x[1], x[2]
+	if m.Sign() <= 0 {
+		return nil, undefined("the modulus must be positive")
+	}
+	v := new(big.Int).Exp(base, exp, m)
+	if v == nil {
+		return nil, undefined("the base has no inverse modulo the modulus, so the exponent must not be negative")
+	}
+	return v, nil
+}
+
+func modInverse(_ *evaluator, x []*big.Int) (*big.Int, error) {
+	a, m := x[0], x[1]
+	if m.Sign() <= 0 {
+		return nil, undefined("the modulus must be positive")
+	}
+	v := new(big.Int).ModInverse(a, m)
+	if v == nil {
+		return nil, undefined("a and the modulus are not coprime")
+	}
+	return v, nil
+}
+
+// undefined reports operands outside the domain of the operation being
+// evaluated, which Evaluate fills in.
+func undefined(reason string) error {
+	return &domain.DomainError{Reason: reason}
+}
@@ -0,0 +1,220 @@
+package bigint
+
+import (
+	"errors"
+	"math/big"
+	"slices"
+	"strings"
+	"testing"
+
+	domain "go-prisma-calculator/internal/domain/models"
+)
+
+func TestParse(t *testing.T) {
+	// 64 bits allow operands of up to 25 characters.
+	e := &evaluator{maxBits: 64}
+	tests := []struct {
+		in      string
+		want    string
+		wantErr error
+	}{
+		{in: "42", want: "42"},
+		{in: "  -42 ", want: "-42"},
+		{in: "+7", want: "7"},
+		{in: "0x1f", want: "31"},
+		{in: "0X1F", want: "31"},
+		{in: "-0x10", want: "-16"},
+		{in: "18446744073709551615", want: "18446744073709551615"},
+		{in: "0xffffffffffffffff", want: "18446744073709551615"},
+		{in: "-0xffffffffffffffff", want: "-18446744073709551615"},
+		{in: "18446744073709551616", wantErr: domain.ErrOperandOutOfRange},
+		{in: "0x10000000000000000", wantErr: domain.ErrOperandOutOfRange},
+		// The length of the text is checked before it is parsed.
+		{in: strings.Repeat("0", 25) + "1", wantErr: domain.ErrOperandOutOfRange},
+		{in: "", wantErr: domain.ErrInvalidOperands},
+		{in: "-", wantErr: domain.ErrInvalidOperands},
+		{in: "0x", wantErr: domain.ErrInvalidOperands},
+		{in: "--1", wantErr: domain.ErrInvalidOperands},
+		{in: "+-1", wantErr: domain.ErrInvalidOperands},
+		{in: "0x-1", wantErr: domain.ErrInvalidOperands},
+		{in: "1_000", wantErr: domain.ErrInvalidOperands},
+		{in: "1.5", wantErr: domain.ErrInvalidOperands},
+		{in: "0b101", wantErr: domain.ErrInvalidOperands},
+		{in: "0o17", wantErr: domain.ErrInvalidOperands},
+		{in: "12abc", wantErr: domain.ErrInvalidOperands},
+	}
+	for _, tt := range tests {
+		v, err := e.parse(tt.in)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("parse(%q) = %v, %v, want error %v", tt.in, v, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parse(%q) error = %v", tt.in, err)
+			continue
+		}
+		if got := v.String(); got != tt.want {
+			t.Errorf("parse(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	e := NewEvaluator(0)
+	tests := []struct {
+		name     string
+		operands []string
+		want     string
+	}{
+		{"add", []string{"0xff", "1"}, "256"},
+		{"sub", []string{"1", "123456789012345678901234567890"}, "-123456789012345678901234567889"},
+		{"mul", []string{"-0x100000000", "0x100000000"}, "-18446744073709551616"},
+		{"div", []string{"-7", "2"}, "-3"},
+		{"mod", []string{"-7", "2"}, "1"},
+		{"mod", []string{"7", "-2"}, "1"},
+		{"pow", []string{"2", "100"}, "1267650600228229401496703205376"},
+		{"pow", []string{"-1", "99999999999999999999"}, "-1"},
+		{"pow", []string{"0", "99999999999999999999"}, "0"},
+		{"modinverse", []string{"3", "7"}, "5"},
+		{"modpow", []string{"4", "13", "497"}, "445"},
+		{"modpow", []string{"-2", "3", "5"}, "2"},
+		// A negative exponent uses the inverse of the base.
+		{"modpow", []string{"3", "-1", "7"}, "5"},
+		{"modpow", []string{"3", "-2", "7"}, "4"},
+	}
+	for _, tt := range tests {
+		res, err := e.Evaluate(tt.name, tt.operands, domain.EvaluationOptions{})
+		if err != nil {
+			t.Errorf("%s%v error = %v", tt.name, tt.operands, err)
+			continue
+		}
+		if res.Value != tt.want {
+			t.Errorf("%s%v = %s, want %s", tt.name, tt.operands, res.Value, tt.want)
+		}
+	}
+}
+
+func TestEvaluateCanonicalOperands(t *testing.T) {
+	res, err := NewEvaluator(0).Evaluate("modpow", []string{"0x10", " +3 ", "0X0A"}, domain.EvaluationOptions{})
+	if err != nil {
+		t.Fatalf("modpow error = %v", err)
+	}
+	if want := []string{"16", "3", "10"}; !slices.Equal(res.Operands, want) || res.Value != "6" {
+		t.Errorf("modpow = %v %s, want %v 6", res.Operands, res.Value, want)
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	e := NewEvaluator(64)
+	tests := []struct {
+		name     string
+		operands []string
+		wantErr  error
+	}{
+		{"div", []string{"1", "0"}, domain.ErrDivisionByZero},
+		{"mod", []string{"1", "-0x0"}, domain.ErrDivisionByZero},
+		{"pow", []string{"2", "-1"}, domain.ErrOutOfDomain},
+		{"modinverse", []string{"2", "4"}, domain.ErrOutOfDomain},
+		{"modinverse", []string{"3", "0"}, domain.ErrOutOfDomain},
+		{"modpow", []string{"2", "3", "0"}, domain.ErrOutOfDomain},
+		{"modpow", []string{"2", "3", "-5"}, domain.ErrOutOfDomain},
+		{"modpow", []string{"2", "-1", "4"}, domain.ErrOutOfDomain},
+		{"modpow", []string{"2", "3"}, domain.ErrInvalidOperands},
+		{"modpow", []string{"2", "3", "5", "7"}, domain.ErrInvalidOperands},
+		{"add", []string{"1", "2", "3"}, domain.ErrInvalidOperands},
+		{"add", []string{"1", "x"}, domain.ErrInvalidOperands},
+		{"mul", []string{"0xffffffffff", "0xffffffffff"}, domain.ErrOperandOutOfRange},
+		{"add", []string{"0xffffffffffffffff", "1"}, domain.ErrOperandOutOfRange},
+		{"sqrt", []string{"4"}, domain.ErrUnsupportedOperation},
+	}
+	for _, tt := range tests {
+		if _, err := e.Evaluate(tt.name, tt.operands, domain.EvaluationOptions{}); !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s%v error = %v, want %v", tt.name, tt.operands, err, tt.wantErr)
+		}
+	}
+
+	_, err := e.Evaluate("modpow", []string{"2", "-1", "4"}, domain.EvaluationOptions{})
+	var de *domain.DomainError
+	if !errors.As(err, &de) || de.Function != "modpow" {
+		t.Errorf("modpow error = %v, want a domain error of modpow", err)
+	}
+}
+
+// TestPowSizeEstimate checks that pow rejects results that would exceed the
+// size limit from (bits of base - 1) * exponent, before computing them, and
+// that the computed result is checked as well where the estimate is low.
+func TestPowSizeEstimate(t *testing.T) {
+	e := NewEvaluator(64)
+	tests := []struct {
+		base, exp string
+		want      string
+		wantErr   bool
+	}{
+		{base: "2", exp: "63", want: "9223372036854775808"},
+		{base: "-2", exp: "63", want: "-9223372036854775808"},
+		{base: "3", exp: "40", want: "12157665459056928801"},
+		// The estimate, 64 bits, passes, but 2^64 takes 65 bits.
+		{base: "2", exp: "64", wantErr: true},
+		{base: "2", exp: "65", wantErr: true},
+		{base: "3", exp: "64", wantErr: true},
+		// Without the estimate this exponent would not finish.
+		{base: "2", exp: "0xffffffffffffffff", wantErr: true},
+	}
+	for _, tt := range tests {
+		res, err := e.Evaluate("pow", []string{tt.base, tt.exp}, domain.EvaluationOptions{})
+		if tt.wantErr {
+			if !errors.Is(err, domain.ErrOperandOutOfRange) {
+				t.Errorf("pow(%s, %s) = %v, %v, want %v", tt.base, tt.exp, res, err, domain.ErrOperandOutOfRange)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("pow(%s, %s) error = %v", tt.base, tt.exp, err)
+			continue
+		}
+		if res.Value != tt.want {
+			t.Errorf("pow(%s, %s) = %s, want %s", tt.base, tt.exp, res.Value, tt.want)
+		}
+	}
+}
+
+func TestFunctions(t *testing.T) {
+	for _, sig := range NewEvaluator(0).Functions() {
+		want := 2
+		if sig.Name == "modpow" {
+			want = 3
+		}
+		if sig.Arity != want || len(sig.Operands) != want || sig.Kind != domain.KindBigInt {
+			t.Errorf("signature of %s = %+v, want %d operands", sig.Name, sig, want)
+		}
+	}
+}
+
+func TestMagnitude(t *testing.T) {
+	e := NewEvaluator(64)
+	tests := []struct {
+		operands []string
+		want     float64
+		wantErr  error
+	}{
+		{[]string{"3", "-0x10"}, 16, nil},
+		{[]string{"-7"}, 7, nil},
+		{nil, 0, nil},
+		{[]string{"1", "1/2"}, 0, domain.ErrInvalidOperands},
+		{[]string{"0x1ffffffffffffffff"}, 0, domain.ErrOperandOutOfRange},
+	}
+	for _, tt := range tests {
+		v, err := e.Magnitude(tt.operands)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Magnitude(%v) error = %v, want %v", tt.operands, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || v.Cmp(big.NewFloat(tt.want)) != 0 {
+			t.Errorf("Magnitude(%v) = %v, %v, want %v", tt.operands, v, err, tt.want)
+		}
+	}
+}
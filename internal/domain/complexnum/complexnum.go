@@ -0,0 +1,177 @@
+// Package complexnum provides complex arithmetic on complex128. Operands
+// and results are written as "real+imagi", e.g. "1-2.5i"; functions with a
+// real result, such as abs and arg, return it with an imaginary part of 0.
+package complexnum
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/cmplx"
+	"slices"
+
+	domain "go-prisma-calculator/internal/domain/models"
+)
+
+// function is a complex operation.
+type function struct {
+	name  string
+	arity int
+	doc   domain.OperationDoc
+	eval  func(x []complex128) (complex128, error)
+}
+
+// functions is the operation set, ordered by name.
+var functions = []function{
+	{name: "abs", arity: 1, eval: abs,
+		doc: domain.OperationDoc{Description: "Modulus |z|, as a real number.", Operands: []string{"z"}}},
+	{name: "add", arity: 2, eval: add,
+		doc: domain.OperationDoc{Description: "Sum of two complex numbers.", Operands: []string{"a", "b"}, Commutative: true}},
+	{name: "arg", arity: 1, eval: arg,
+		doc: domain.OperationDoc{Description: "Argument of z in radians, in (-π, π], as a real number.", Operands: []string{"z"}}},
+	{name: "conj", arity: 1, eval: conj,
+		doc: domain.OperationDoc{Description: "Complex conjugate.", Operands: []string{"z"}}},
+	{name: "div", arity: 2, eval: div,
+		doc: domain.OperationDoc{Description: "Quotient of two complex numbers.", Operands: []string{"dividend", "divisor"}}},
+	{name: "exp", arity: 1, eval: exp,
+		doc: domain.OperationDoc{Description: "e raised to z.", Operands: []string{"z"}}},
+	{name: "mul", arity: 2, eval: mul,
+		doc: domain.OperationDoc{Description: "Product of two complex numbers.", Operands: []string{"a", "b"}, Commutative: true}},
+	{name: "pow", arity: 2, eval: pow,
+		doc: domain.OperationDoc{Description: "Principal value of base raised to exponent.", Operands: []string{"base", "exponent"}}},
+	{name: "sub", arity: 2, eval: sub,
+		doc: domain.OperationDoc{Description: "Difference of two complex numbers.", Operands: []string{"a", "b"}}},
+}
+
+// evaluator evaluates the operations on complex128.
+type evaluator struct{}
+
+// NewEvaluator returns the evaluator of KindComplex.
+func NewEvaluator() domain.Evaluator {
+	return evaluator{}
+}
+
+func (evaluator) Kind() domain.Kind { return domain.KindComplex }
+
+func (evaluator) Functions() []domain.FunctionSignature {
+	sigs := make([]domain.FunctionSignature, 0, len(functions))
+	for _, f := range functions {
+		sigs = append(sigs, domain.FunctionSignature{
+			Kind:         domain.KindComplex,
+			Name:         f.name,
+			Arity:        f.arity,
+			OperationDoc: f.doc,
+			Options:      []string{},
+		})
+	}
+	return sigs
+}
+
+func (evaluator) Evaluate(name string, operands []string, _ domain.EvaluationOptions) (*domain.EvaluationResult, error) {
+	i := slices.IndexFunc(functions, func(f function) bool { return f.name == name })
+	if i < 0 {
+		return nil, fmt.Errorf("%w: %q", domain.ErrUnsupportedOperation, name)
+	}
+	f := functions[i]
+	if len(operands) != f.arity {
+		return nil, fmt.Errorf("%w: %s takes %d operands, got %d", domain.ErrInvalidOperands, name, f.arity, len(operands))
+	}
+
+	x := make([]complex128, len(operands))
+	canonical := make([]string, len(operands))
+	for i, s := range operands {
+		v, err := domain.ParseComplex(s)
+		if err != nil {
+			return nil, err
+		}
+		// Compute with the canonical operand, which has no negative zeros,
+		// so that e.g. arg(-1-0i) is π like arg of the recorded -1+0i.
+		v = complex(real(v)+0, imag(v)+0)
+		x[i], canonical[i] = v, domain.FormatComplex(v)
+	}
+
+	v, err := f.eval(x)
+	if err == nil && (cmplx.IsInf(v) || cmplx.IsNaN(v)) {
+		err = undefined("the result does not fit in a complex128")
+	}
+	if err != nil {
+		var de *domain.DomainError
+		if errors.As(err, &de) {
+			de.Function = name
+		}
+		return nil, err
+	}
+	// No option affects complex results.
+	return &domain.EvaluationResult{Operands: canonical, Value: domain.FormatComplex(v)}, nil
+}
+
+// Magnitude returns the largest modulus of the operands.
+func (evaluator) Magnitude(operands []string) (*big.Float, error) {
+	return domain.LargestMagnitude(operands, func(s string) (*big.Float, error) {
+		v, err := domain.ParseComplex(s)
+		if err != nil {
+			return nil, err
+		}
+		return big.NewFloat(cmplx.Abs(v)), nil
+	})
+}
+
+func add(x []complex128) (complex128, error) { return x[0] + x[1], nil }
+
+func sub(x []complex128) (complex128, error) { return x[0] - x[1], nil }
+
+func mul(x []complex128) (complex128, error) { return x[0] * x[1], nil }
+
+func div(x []complex128) (complex128, error) {
+	if x[1] == 0 {
+		return 0, domain.ErrDivisionByZero
+	}
+	return x[0] / x[1], nil
+}
+
+func abs(x []complex128) (complex128, error) { return complex(cmplx.Abs(x[0]), 0), nil }
+
+func arg(x []complex128) (complex128, error) { return complex(cmplx.Phase(x[0]), 0), nil }
+
+func conj(x []complex128) (complex128, error) { return cmplx.Conj(x[0]), nil }
+
+func exp(x []complex128) (complex128, error) { return cmplx.Exp(x[0]), nil }
+
+func pow(x []complex128) (complex128, error) {
+	// cmplx.Pow returns Inf for 0 raised to an exponent with a negative or
+	// non-zero imaginary part; report it as undefined instead.
+	if x[0] == 0 && (real(x[1]) < 0 || imag(x[1]) != 0) {
+		return 0, undefined("zero raised to an exponent with a negative real part or an imaginary part")
+	}
+	// Small integer exponents are computed by repeated squaring, which
+	// keeps results such as i² = -1 exact.
+	if n := real(x[1]); imag(x[1]) == 0 && n == math.Trunc(n) && math.Abs(n) <= maxIntegerExponent {
+		return powInt(x[0], int(n)), nil
+	}
+	return cmplx.Pow(x[0], x[1]), nil
+}
+
+// maxIntegerExponent bounds the exponents powInt is used for.
+const maxIntegerExponent = 1024
+
+// powInt returns z raised to n by repeated squaring.
+func powInt(z complex128, n int) complex128 {
+	if n < 0 {
+		return 1 / powInt(z, -n)
+	}
+	v := complex128(1)
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			v *= z
+		}
+		z *= z
+	}
+	return v
+}
+
+// undefined reports operands outside the domain of the operation being
+// evaluated, which Evaluate fills in.
+func undefined(reason string) error {
+	return &domain.DomainError{Reason: reason}
+}
@@ -0,0 +1,148 @@
+package complexnum
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"slices"
+	"testing"
+
+	domain "go-prisma-calculator/internal/domain/models"
+)
+
+func TestEvaluate(t *testing.T) {
+	e := NewEvaluator()
+	tests := []struct {
+		name     string
+		operands []string
+		want     string
+	}{
+		{"add", []string{"1+2i", "3-4i"}, "4-2i"},
+		{"sub", []string{"1+2i", "1+2i"}, "0+0i"},
+		{"mul", []string{"1+2i", "3-4i"}, "11+2i"},
+		{"div", []string{"11+2i", "3-4i"}, "1+2i"},
+		{"conj", []string{"1+2i"}, "1-2i"},
+		// Real results have an imaginary part of 0.
+		{"abs", []string{"3-4i"}, "5+0i"},
+		{"arg", []string{"-1"}, "3.141592653589793+0i"},
+		{"arg", []string{"-1-0i"}, "3.141592653589793+0i"},
+		{"exp", []string{"0"}, "1+0i"},
+		// Integer exponents are exact.
+		{"pow", []string{"0+1i", "2"}, "-1+0i"},
+		{"pow", []string{"1+1i", "4"}, "-4+0i"},
+		{"pow", []string{"0+2i", "-1"}, "0-0.5i"},
+		{"pow", []string{"0", "0"}, "1+0i"},
+		// Negative zeros are written without a sign.
+		{"mul", []string{"-1", "0"}, "0+0i"},
+		{"conj", []string{"5"}, "5+0i"},
+	}
+	for _, tt := range tests {
+		res, err := e.Evaluate(tt.name, tt.operands, domain.EvaluationOptions{})
+		if err != nil {
+			t.Errorf("%s%v error = %v", tt.name, tt.operands, err)
+			continue
+		}
+		if res.Value != tt.want {
+			t.Errorf("%s%v = %s, want %s", tt.name, tt.operands, res.Value, tt.want)
+		}
+	}
+}
+
+func TestEvaluateCanonicalOperands(t *testing.T) {
+	res, err := NewEvaluator().Evaluate("add", []string{" (1-1e3i) ", "2i"}, domain.EvaluationOptions{})
+	if err != nil {
+		t.Fatalf("add error = %v", err)
+	}
+	if want := []string{"1-1000i", "0+2i"}; !slices.Equal(res.Operands, want) {
+		t.Errorf("operands = %v, want %v", res.Operands, want)
+	}
+	if res.Value != "1-998i" {
+		t.Errorf("value = %s, want 1-998i", res.Value)
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	e := NewEvaluator()
+	tests := []struct {
+		name     string
+		operands []string
+		wantErr  error
+	}{
+		{"div", []string{"1", "0+0i"}, domain.ErrDivisionByZero},
+		{"pow", []string{"0", "-1"}, domain.ErrOutOfDomain},
+		{"pow", []string{"0", "1i"}, domain.ErrOutOfDomain},
+		{"exp", []string{"1000"}, domain.ErrOutOfDomain},
+		{"mul", []string{"1e300+1e300i", "1e300"}, domain.ErrOutOfDomain},
+		{"add", []string{"1", "Inf"}, domain.ErrInvalidOperands},
+		{"add", []string{"1", "NaN"}, domain.ErrInvalidOperands},
+		{"add", []string{"1", "1+i+1"}, domain.ErrInvalidOperands},
+		{"add", []string{"1"}, domain.ErrInvalidOperands},
+		{"conj", []string{"1", "2"}, domain.ErrInvalidOperands},
+		{"sqrt", []string{"4"}, domain.ErrUnsupportedOperation},
+	}
+	for _, tt := range tests {
+		if _, err := e.Evaluate(tt.name, tt.operands, domain.EvaluationOptions{}); !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s%v error = %v, want %v", tt.name, tt.operands, err, tt.wantErr)
+		}
+	}
+}
+
+// TestParts covers the real/imag pairs of the gRPC and REST APIs, which
+// are converted to the text form with FormatComplex and back with
+// ComplexParts.
+func TestParts(t *testing.T) {
+	tests := []struct {
+		re, im float64
+		text   string
+	}{
+		{1, 2, "1+2i"},
+		{-1.5, -0.25, "-1.5-0.25i"},
+		{0, 1, "0+1i"},
+		{3, 0, "3+0i"},
+		{math.Copysign(0, -1), math.Copysign(0, -1), "0+0i"},
+		{1e21, 1e-7, "1e+21+1e-07i"},
+		{0.1, 0.2, "0.1+0.2i"},
+		{math.MaxFloat64, -math.SmallestNonzeroFloat64, "1.7976931348623157e+308-5e-324i"},
+	}
+	for _, tt := range tests {
+		text := domain.FormatComplex(complex(tt.re, tt.im))
+		if text != tt.text {
+			t.Errorf("FormatComplex(%g, %g) = %s, want %s", tt.re, tt.im, text, tt.text)
+		}
+		re, im, ok := domain.ComplexParts(text)
+		if !ok || re != tt.re || im != tt.im {
+			t.Errorf("ComplexParts(%s) = %g, %g, %v, want %g, %g", text, re, im, ok, tt.re, tt.im)
+		}
+	}
+
+	for _, text := range []string{"", "i", "1+", "1+2j", "(1+2i", "Inf+0i"} {
+		if _, _, ok := domain.ComplexParts(text); ok {
+			t.Errorf("ComplexParts(%q) succeeded", text)
+		}
+	}
+}
+
+func TestMagnitude(t *testing.T) {
+	tests := []struct {
+		operands []string
+		want     float64
+		wantErr  error
+	}{
+		{[]string{"3-4i", "2"}, 5, nil},
+		{[]string{"-6"}, 6, nil},
+		{[]string{"1", "Inf"}, 0, domain.ErrInvalidOperands},
+		{[]string{"[[1]]"}, 0, domain.ErrInvalidOperands},
+	}
+	for _, tt := range tests {
+		v, err := NewEvaluator().Magnitude(tt.operands)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Magnitude(%v) error = %v, want %v", tt.operands, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || v.Cmp(big.NewFloat(tt.want)) != 0 {
+			t.Errorf("Magnitude(%v) = %v, %v, want %v", tt.operands, v, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,408 @@
+// Package matrix provides small dense linear algebra on float64 matrices.
+// Operands and results are matrices in their JSON form, e.g. "[[1,2],[3,4]]";
+// vectors are matrices of one row or one column, and scalar results such
+// as determinants and dot products are 1×1 matrices. Every operand is
+// bounded in size so that a single request cannot exhaust the server.
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+
+	domain "go-prisma-calculator/internal/domain/models"
+)
+
+// DefaultMaxDimension is the size limit used when none is configured.
+const DefaultMaxDimension = 32
+
+// function is a matrix operation.
+type function struct {
+	name  string
+	arity int
+	doc   domain.OperationDoc
+	eval  func(x []domain.Matrix) (domain.Matrix, error)
+}
+
+// functions is the operation set, ordered by name.
+var functions = []function{
+	{name: "add", arity: 2, eval: add,
+		doc: domain.OperationDoc{Description: "Sum of two matrices of the same dimensions.", Operands: []string{"a", "b"}, Commutative: true}},
+	{name: "cross", arity: 2, eval: cross,
+		doc: domain.OperationDoc{Description: "Cross product of two vectors of length 3, shaped like u.", Operands: []string{"u", "v"}}},
+	{name: "det", arity: 1, eval: det,
+		doc: domain.OperationDoc{Description: "Determinant of a square matrix, as a 1×1 matrix.", Operands: []string{"a"}}},
+	{name: "dot", arity: 2, eval: dot,
+		doc: domain.OperationDoc{Description: "Dot product of two vectors of the same length, as a 1×1 matrix.", Operands: []string{"u", "v"}, Commutative: true}},
+	{name: "inverse", arity: 1, eval: inverse,
+		doc: domain.OperationDoc{Description: "Inverse of a non-singular square matrix.", Operands: []string{"a"}}},
+	{name: "mul", arity: 2, eval: mul,
+		doc: domain.OperationDoc{Description: "Matrix product; a needs as many columns as b has rows.", Operands: []string{"a", "b"}}},
+	{name: "solve", arity: 2, eval: solve,
+		doc: domain.OperationDoc{Description: "Solution x of a·x = b for a non-singular square a and a b with as many rows.", Operands: []string{"a", "b"}}},
+	{name: "sub", arity: 2, eval: sub,
+		doc: domain.OperationDoc{Description: "Difference of two matrices of the same dimensions.", Operands: []string{"a", "b"}}},
+	{name: "transpose", arity: 1, eval: transpose,
+		doc: domain.OperationDoc{Description: "Transpose of a matrix.", Operands: []string{"a"}}},
+}
+
+// evaluator evaluates the operations on matrices of at most maxDimension
+// rows and columns.
+type evaluator struct {
+	maxDimension int
+}
+
+// NewEvaluator returns the evaluator of KindMatrix. maxDimension bounds
+// the number of rows and columns of operands; 0 selects
+// DefaultMaxDimension.
+func NewEvaluator(maxDimension int) domain.Evaluator {
+	if maxDimension <= 0 {
+		maxDimension = DefaultMaxDimension
+	}
+	return &evaluator{maxDimension: maxDimension}
+}
+
+func (e *evaluator) Kind() domain.Kind { return domain.KindMatrix }
+
+func (e *evaluator) Functions() []domain.FunctionSignature {
+	sigs := make([]domain.FunctionSignature, 0, len(functions))
+	for _, f := range functions {
+		sigs = append(sigs, domain.FunctionSignature{
+			Kind:         domain.KindMatrix,
+			Name:         f.name,
+			Arity:        f.arity,
+			OperationDoc: f.doc,
+			Options:      []string{},
+		})
+	}
+	return sigs
+}
+
+func (e *evaluator) Evaluate(name string, operands []string, _ domain.EvaluationOptions) (*domain.EvaluationResult, error) {
+	i := slices.IndexFunc(functions, func(f function) bool { return f.name == name })
+	if i < 0 {
+		return nil, fmt.Errorf("%w: %q", domain.ErrUnsupportedOperation, name)
+	}
+	f := functions[i]
+	if len(operands) != f.arity {
+		return nil, fmt.Errorf("%w: %s takes %d operands, got %d", domain.ErrInvalidOperands, name, f.arity, len(operands))
+	}
+
+	x := make([]domain.Matrix, len(operands))
+	canonical := make([]string, len(operands))
+	for i, s := range operands {
+		m, err := e.parse(s)
+		if err != nil {
+			return nil, err
+		}
+		x[i], canonical[i] = m, domain.FormatMatrix(m)
+	}
+
+	v, err := f.eval(x)
+	if err == nil && !finite(v) {
+		err = undefined("the result does not fit in a float64")
+	}
+	if err != nil {
+		var de *domain.DomainError
+		if errors.As(err, &de) {
+			de.Function = name
+		}
+		return nil, err
+	}
+	// No option affects matrix results.
+	return &domain.EvaluationResult{Operands: canonical, Value: domain.FormatMatrix(normalize(v))}, nil
+}
+
+// Magnitude returns the largest absolute entry of the operands.
+func (e *evaluator) Magnitude(operands []string) (*big.Float, error) {
+	return domain.LargestMagnitude(operands, func(s string) (*big.Float, error) {
+		m, err := e.parse(s)
+		if err != nil {
+			return nil, err
+		}
+		largest := 0.0
+		for _, row := range m {
+			for _, v := range row {
+				largest = max(largest, math.Abs(v))
+			}
+		}
+		return big.NewFloat(largest), nil
+	})
+}
+
+// parse reads a matrix operand and bounds its dimensions.
+func (e *evaluator) parse(s string) (domain.Matrix, error) {
+	// Reject oversized text before parsing it; an entry takes at most 25
+	// bytes in its shortest form with its separator, which leaves some room
+	// for whitespace.
+	if len(s) > 32*e.maxDimension*e.maxDimension+2*e.maxDimension+2 {
+		return nil, e.tooLarge()
+	}
+	m, err := domain.ParseMatrix(s)
+	if err != nil {
+		return nil, err
+	}
+	if rows, cols := m.Dims(); rows > e.maxDimension || cols > e.maxDimension {
+		return nil, e.tooLarge()
+	}
+	return normalize(m), nil
+}
+
+func (e *evaluator) tooLarge() error {
+	return fmt.Errorf("%w: a matrix exceeds %d rows or columns", domain.ErrOperandOutOfRange, e.maxDimension)
+}
+
+// finite reports whether every entry of m is finite.
+func finite(m domain.Matrix) bool {
+	for _, row := range m {
+		for _, v := range row {
+			if math.IsInf(v, 0) || math.IsNaN(v) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// normalize drops the sign of negative zeros, so that they are written as 0.
+func normalize(m domain.Matrix) domain.Matrix {
+	for _, row := range m {
+		for j, v := range row {
+			if v == 0 {
+				row[j] = 0
+			}
+		}
+	}
+	return m
+}
+
+// filled returns a rows×cols matrix whose entries are f(i, j).
+func filled(rows, cols int, f func(i, j int) float64) domain.Matrix {
+	m := make(domain.Matrix, rows)
+	for i := range m {
+		m[i] = make([]float64, cols)
+		for j := range m[i] {
+			m[i][j] = f(i, j)
+		}
+	}
+	return m
+}
+
+// sameDims rejects matrices of different dimensions.
+func sameDims(a, b domain.Matrix) error {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		return fmt.Errorf("%w: dimensions %d×%d and %d×%d differ", domain.ErrInvalidOperands, ar, ac, br, bc)
+	}
+	return nil
+}
+
+// square rejects matrices that are not square.
+func square(a domain.Matrix) error {
+	if rows, cols := a.Dims(); rows != cols {
+		return fmt.Errorf("%w: a %d×%d matrix is not square", domain.ErrInvalidOperands, rows, cols)
+	}
+	return nil
+}
+
+// vector returns the entries of a matrix of one row or one column.
+func vector(m domain.Matrix) ([]float64, error) {
+	rows, cols := m.Dims()
+	switch {
+	case rows == 1:
+		return m[0], nil
+	case cols == 1:
+		return filled(1, rows, func(_, j int) float64 { return m[j][0] })[0], nil
+	}
+	return nil, fmt.Errorf("%w: a %d×%d matrix is not a vector", domain.ErrInvalidOperands, rows, cols)
+}
+
+func add(x []domain.Matrix) (domain.Matrix, error) {
+	a, b := x[0], x[1]
+	if err := sameDims(a, b); err != nil {
+		return nil, err
+	}
+	rows, cols := a.Dims()
+	return filled(rows, cols, func(i, j int) float64 { return a[i][j] + b[i][j] }), nil
+}
+
+func sub(x []domain.Matrix) (domain.Matrix, error) {
+	a, b := x[0], x[1]
+	if err := sameDims(a, b); err != nil {
+		return nil, err
+	}
+	rows, cols := a.Dims()
+	return filled(rows, cols, func(i, j int) float64 { return a[i][j] - b[i][j] }), nil
+}
+
+func mul(x []domain.Matrix) (domain.Matrix, error) {
+	a, b := x[0], x[1]
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ac != br {
+		return nil, fmt.Errorf("%w: cannot multiply %d×%d by %d×%d", domain.ErrInvalidOperands, ar, ac, br, bc)
+	}
+	return filled(ar, bc, func(i, j int) float64 {
+		var sum float64
+		for k := range ac {
+			sum += a[i][k] * b[k][j]
+		}
+		return sum
+	}), nil
+}
+
+func transpose(x []domain.Matrix) (domain.Matrix, error) {
+	a := x[0]
+	rows, cols := a.Dims()
+	return filled(cols, rows, func(i, j int) float64 { return a[j][i] }), nil
+}
+
+func dot(x []domain.Matrix) (domain.Matrix, error) {
+	u, err := vector(x[0])
+	if err != nil {
+		return nil, err
+	}
+	v, err := vector(x[1])
+	if err != nil {
+		return nil, err
+	}
+	if len(u) != len(v) {
+		return nil, fmt.Errorf("%w: vectors of lengths %d and %d", domain.ErrInvalidOperands, len(u), len(v))
+	}
+	var sum float64
+	for i := range u {
+		sum += u[i] * v[i]
+	}
+	return domain.Matrix{{sum}}, nil
+}
+
+func cross(x []domain.Matrix) (domain.Matrix, error) {
+	u, err := vector(x[0])
+	if err != nil {
+		return nil, err
+	}
+	v, err := vector(x[1])
+	if err != nil {
+		return nil, err
+	}
+	if len(u) != 3 || len(v) != 3 {
+		return nil, fmt.Errorf("%w: the cross product takes vectors of length 3, got %d and %d", domain.ErrInvalidOperands, len(u), len(v))
+	}
+	w := []float64{
+		u[1]*v[2] - u[2]*v[1],
+		u[2]*v[0] - u[0]*v[2],
+		u[0]*v[1] - u[1]*v[0],
+	}
+	// Keep the shape of u: a row or a column.
+	if rows, _ := x[0].Dims(); rows == 1 {
+		return domain.Matrix{w}, nil
+	}
+	return filled(3, 1, func(i, _ int) float64 { return w[i] }), nil
+}
+
+func det(x []domain.Matrix) (domain.Matrix, error) {
+	a := x[0]
+	if err := square(a); err != nil {
+		return nil, err
+	}
+	_, d, ok := eliminate(a, filled(len(a), 0, nil))
+	if !ok {
+		d = 0
+	}
+	return domain.Matrix{{d}}, nil
+}
+
+func inverse(x []domain.Matrix) (domain.Matrix, error) {
+	a := x[0]
+	if err := square(a); err != nil {
+		return nil, err
+	}
+	identity := filled(len(a), len(a), func(i, j int) float64 {
+		if i == j {
+			return 1
+		}
+		return 0
+	})
+	inv, _, ok := eliminate(a, identity)
+	if !ok {
+		return nil, fmt.Errorf("%w: it has no inverse", domain.ErrSingularMatrix)
+	}
+	return inv, nil
+}
+
+func solve(x []domain.Matrix) (domain.Matrix, error) {
+	a, b := x[0], x[1]
+	if err := square(a); err != nil {
+		return nil, err
+	}
+	if rows, _ := b.Dims(); rows != len(a) {
+		return nil, fmt.Errorf("%w: b has %d rows, want %d", domain.ErrInvalidOperands, rows, len(a))
+	}
+	sol, _, ok := eliminate(a, b)
+	if !ok {
+		return nil, fmt.Errorf("%w: a·x = b has no unique solution", domain.ErrSingularMatrix)
+	}
+	return sol, nil
+}
+
+// eliminate reduces the augmented matrix [a | b] by Gauss-Jordan elimination
+// with partial pivoting, for a square a. It returns the solution x of
+// a·x = b and the determinant of a, or ok false when a is singular: when a
+// pivot is negligible next to the largest entry of a.
+func eliminate(a, b domain.Matrix) (x domain.Matrix, det float64, ok bool) {
+	n := len(a)
+	_, w := b.Dims()
+	m := filled(n, n+w, func(i, j int) float64 {
+		if j < n {
+			return a[i][j]
+		}
+		return b[i][j-n]
+	})
+
+	var largest float64
+	for _, row := range a {
+		for _, v := range row {
+			largest = math.Max(largest, math.Abs(v))
+		}
+	}
+	tolerance := float64(n) * largest * 0x1p-52
+
+	det = 1
+	for k := range n {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(m[i][k]) > math.Abs(m[p][k]) {
+				p = i
+			}
+		}
+		if math.Abs(m[p][k]) <= tolerance {
+			return nil, 0, false
+		}
+		if p != k {
+			m[p], m[k] = m[k], m[p]
+			det = -det
+		}
+		pivot := m[k][k]
+		det *= pivot
+		for j := k; j < n+w; j++ {
+			m[k][j] /= pivot
+		}
+		for i := range n {
+			if f := m[i][k]; i != k && f != 0 {
+				for j := k; j < n+w; j++ {
+					m[i][j] -= f * m[k][j]
+				}
+			}
+		}
+	}
+	return filled(n, w, func(i, j int) float64 { return m[i][n+j] }), det, true
+}
+
+// undefined reports operands outside the domain of the operation being
+// evaluated, which Evaluate fills in.
+func undefined(reason string) error {
+	return &domain.DomainError{Reason: reason}
+}
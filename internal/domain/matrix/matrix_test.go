@@ -0,0 +1,204 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	domain "go-prisma-calculator/internal/domain/models"
+)
+
+// near reports whether a and b have the same dimensions and entries that
+// differ by at most 1e-9.
+func near(a, b domain.Matrix) bool {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		return false
+	}
+	for i := range a {
+		for j := range a[i] {
+			if math.Abs(a[i][j]-b[i][j]) > 1e-9 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestEliminate(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    domain.Matrix
+		wantX   domain.Matrix
+		wantDet float64
+	}{
+		{
+			name:    "identity",
+			a:       domain.Matrix{{1, 0}, {0, 1}},
+			b:       domain.Matrix{{3}, {4}},
+			wantX:   domain.Matrix{{3}, {4}},
+			wantDet: 1,
+		},
+		{
+			name:    "2x2",
+			a:       domain.Matrix{{2, 1}, {1, 3}},
+			b:       domain.Matrix{{3}, {5}},
+			wantX:   domain.Matrix{{0.8}, {1.4}},
+			wantDet: 5,
+		},
+		{
+			// The first pivot is 0, so the rows are swapped, which flips
+			// the sign of the determinant.
+			name:    "zero pivot",
+			a:       domain.Matrix{{0, 1}, {1, 0}},
+			b:       domain.Matrix{{2}, {3}},
+			wantX:   domain.Matrix{{3}, {2}},
+			wantDet: -1,
+		},
+		{
+			name:    "3x3 with two right-hand sides",
+			a:       domain.Matrix{{2, -1, 0}, {-1, 2, -1}, {0, -1, 2}},
+			b:       domain.Matrix{{1, 0}, {0, 0}, {1, 4}},
+			wantX:   domain.Matrix{{1, 1}, {1, 2}, {1, 3}},
+			wantDet: 4,
+		},
+		{
+			name:    "tiny but regular",
+			a:       domain.Matrix{{1e-20, 0}, {0, 1e-20}},
+			b:       domain.Matrix{{1e-20}, {2e-20}},
+			wantX:   domain.Matrix{{1}, {2}},
+			wantDet: 1e-40,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, d, ok := eliminate(tt.a, tt.b)
+			if !ok {
+				t.Fatalf("eliminate reported a singular matrix")
+			}
+			if !near(x, tt.wantX) {
+				t.Errorf("x = %v, want %v", x, tt.wantX)
+			}
+			if math.Abs(d-tt.wantDet) > 1e-9*math.Abs(tt.wantDet) {
+				t.Errorf("det = %g, want %g", d, tt.wantDet)
+			}
+		})
+	}
+}
+
+func TestEliminateSingular(t *testing.T) {
+	tests := []struct {
+		name string
+		a    domain.Matrix
+	}{
+		{"zero", domain.Matrix{{0, 0}, {0, 0}}},
+		{"equal rows", domain.Matrix{{1, 2}, {1, 2}}},
+		{"dependent rows", domain.Matrix{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}},
+		// The rows differ only by rounding errors of float64.
+		{"nearly singular", domain.Matrix{{1, 1}, {1, 1 + 1e-17}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, ok := eliminate(tt.a, filled(len(tt.a), 1, func(int, int) float64 { return 1 })); ok {
+				t.Errorf("eliminate(%v) did not report a singular matrix", tt.a)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	e := NewEvaluator(0)
+	tests := []struct {
+		name     string
+		operands []string
+		want     string
+	}{
+		{"add", []string{"[[1,2],[3,4]]", "[[4,3],[2,1]]"}, "[[5,5],[5,5]]"},
+		{"sub", []string{"[[1,2]]", "[[1,2]]"}, "[[0,0]]"},
+		{"mul", []string{"[[1,2],[3,4]]", "[[5],[6]]"}, "[[17],[39]]"},
+		{"transpose", []string{"[[1,2,3]]"}, "[[1],[2],[3]]"},
+		{"dot", []string{"[[1,2,3]]", "[[4],[5],[6]]"}, "[[32]]"},
+		{"cross", []string{"[[1,0,0]]", "[[0,1,0]]"}, "[[0,0,1]]"},
+		{"cross", []string{"[[0],[1],[0]]", "[[1,0,0]]"}, "[[0],[0],[-1]]"},
+		{"det", []string{"[[1,2],[3,4]]"}, "[[-2]]"},
+		{"det", []string{"[[1,2],[2,4]]"}, "[[0]]"},
+		{"inverse", []string{"[[4,7],[2,6]]"}, "[[0.6,-0.7],[-0.2,0.4]]"},
+		{"solve", []string{"[[2,0],[0,4]]", "[[2],[2]]"}, "[[1],[0.5]]"},
+		// Negative zeros are written as 0.
+		{"mul", []string{"[[-1]]", "[[0]]"}, "[[0]]"},
+	}
+	for _, tt := range tests {
+		res, err := e.Evaluate(tt.name, tt.operands, domain.EvaluationOptions{})
+		if err != nil {
+			t.Errorf("%s%v error = %v", tt.name, tt.operands, err)
+			continue
+		}
+		got, err := domain.ParseMatrix(res.Value)
+		if err != nil {
+			t.Fatalf("%s%v value %q: %v", tt.name, tt.operands, res.Value, err)
+		}
+		want, _ := domain.ParseMatrix(tt.want)
+		if !near(got, want) || strings.Contains(res.Value, "-0,") || strings.Contains(res.Value, "-0]") {
+			t.Errorf("%s%v = %s, want %s", tt.name, tt.operands, res.Value, tt.want)
+		}
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	e := NewEvaluator(2)
+	tests := []struct {
+		name     string
+		operands []string
+		wantErr  error
+	}{
+		{"inverse", []string{"[[1,2],[2,4]]"}, domain.ErrSingularMatrix},
+		{"solve", []string{"[[0,0],[0,0]]", "[[1],[1]]"}, domain.ErrSingularMatrix},
+		{"det", []string{"[[1,2]]"}, domain.ErrInvalidOperands},
+		{"add", []string{"[[1,2]]", "[[1],[2]]"}, domain.ErrInvalidOperands},
+		{"mul", []string{"[[1,2]]", "[[1,2]]"}, domain.ErrInvalidOperands},
+		{"dot", []string{"[[1,2],[3,4]]", "[[1,2]]"}, domain.ErrInvalidOperands},
+		{"cross", []string{"[[1,2]]", "[[1,2]]"}, domain.ErrInvalidOperands},
+		{"solve", []string{"[[1,0],[0,1]]", "[[1]]"}, domain.ErrInvalidOperands},
+		{"add", []string{"[[1,2],[3]]", "[[1,2],[3,4]]"}, domain.ErrInvalidOperands},
+		{"add", []string{"[]", "[]"}, domain.ErrInvalidOperands},
+		{"transpose", []string{"not a matrix"}, domain.ErrInvalidOperands},
+		{"transpose", []string{"[[1,2,3]]"}, domain.ErrOperandOutOfRange},
+		{"pow", []string{"[[1]]"}, domain.ErrUnsupportedOperation},
+		{"det", []string{"[[1]]", "[[1]]"}, domain.ErrInvalidOperands},
+		{"mul", []string{"[[1e300]]", "[[1e300]]"}, domain.ErrOutOfDomain},
+	}
+	for _, tt := range tests {
+		if _, err := e.Evaluate(tt.name, tt.operands, domain.EvaluationOptions{}); !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s%v error = %v, want %v", tt.name, tt.operands, err, tt.wantErr)
+		}
+	}
+}
+
+func TestMagnitude(t *testing.T) {
+	e := NewEvaluator(2)
+	tests := []struct {
+		operands []string
+		want     float64
+		wantErr  error
+	}{
+		{[]string{"[[1, -5], [2, 3]]", "[[4]]"}, 5, nil},
+		{[]string{"[[1, 2], [3]]"}, 0, domain.ErrInvalidOperands},
+		{[]string{"[[1, 2, 3]]"}, 0, domain.ErrOperandOutOfRange},
+		{[]string{"7"}, 0, domain.ErrInvalidOperands},
+	}
+	for _, tt := range tests {
+		v, err := e.Magnitude(tt.operands)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Magnitude(%v) error = %v, want %v", tt.operands, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || v.Cmp(big.NewFloat(tt.want)) != 0 {
+			t.Errorf("Magnitude(%v) = %v, %v, want %v", tt.operands, v, err, tt.want)
+		}
+	}
+}
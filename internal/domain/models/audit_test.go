@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChainHash(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	base := Calculation{
+		TenantID:  "default",
+		Operation: "add",
+		Principal: "alice",
+		A:         2,
+		B:         3,
+		Result:    5,
+		CreatedAt: created,
+	}
+	decimal := Calculation{
+		TenantID:  "default",
+		Operation: "sqrt",
+		Principal: "alice",
+		Kind:      KindDecimal,
+		Operands:  []string{"2"},
+		Value:     "1.414",
+		CreatedAt: created,
+	}
+	want := base.ChainHash("")
+
+	tests := []struct {
+		name   string
+		calc   Calculation
+		prev   string
+		stable bool
+	}{
+		{"same content", base, "", true},
+		{"integer kind", with(base, func(c *Calculation) { c.Kind = KindInteger }), "", true},
+		{"id", with(base, func(c *Calculation) { c.ID = "other" }), "", true},
+		{"seq", with(base, func(c *Calculation) { c.Seq = 7 }), "", true},
+		{"time zone", with(base, func(c *Calculation) { c.CreatedAt = created.In(time.FixedZone("X", 3600)) }), "", true},
+		{"sub-millisecond time", with(base, func(c *Calculation) { c.CreatedAt = created.Add(time.Microsecond) }), "", true},
+		{"previous hash", base, "abc", false},
+		{"tenant", with(base, func(c *Calculation) { c.TenantID = "acme" }), "", false},
+		{"operation", with(base, func(c *Calculation) { c.Operation = "divide" }), "", false},
+		{"principal", with(base, func(c *Calculation) { c.Principal = "bob" }), "", false},
+		{"operand", with(base, func(c *Calculation) { c.B = 4 }), "", false},
+		{"result", with(base, func(c *Calculation) { c.Result = 6 }), "", false},
+		{"millisecond", with(base, func(c *Calculation) { c.CreatedAt = created.Add(time.Millisecond) }), "", false},
+	}
+	for _, tt := range tests {
+		if got := tt.calc.ChainHash(tt.prev); (got == want) != tt.stable {
+			t.Errorf("%s: hash changed = %v, want %v", tt.name, got != want, !tt.stable)
+		}
+	}
+
+	want = decimal.ChainHash("")
+	versioned := []struct {
+		name string
+		calc Calculation
+	}{
+		{"kind", with(decimal, func(c *Calculation) { c.Kind = KindFloat })},
+		{"operands", with(decimal, func(c *Calculation) { c.Operands = []string{"2.0"} })},
+		{"value", with(decimal, func(c *Calculation) { c.Value = "1.4142" })},
+		{"details", with(decimal, func(c *Calculation) { c.Details = map[string]string{"decimal": "1"} })},
+		{"options", with(decimal, func(c *Calculation) { c.Options = EvaluationOptions{Precision: 4} })},
+	}
+	for _, tt := range versioned {
+		if tt.calc.ChainHash("") == want {
+			t.Errorf("%s: hash of a %s calculation did not change", tt.name, decimal.Kind)
+		}
+	}
+	if got := with(decimal, func(c *Calculation) { c.Details = map[string]string{} }).ChainHash(""); got != want {
+		t.Errorf("empty details changed the hash")
+	}
+}
+
+// with returns a copy of c changed by change.
+func with(c Calculation, change func(*Calculation)) Calculation {
+	change(&c)
+	return c
+}
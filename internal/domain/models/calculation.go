@@ -1,12 +1,83 @@
 package domain
 
-import "time"
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"strings"
+	"time"
+)
 
 type Calculation struct {
 	ID        string
 	Operation string
-	A         int
-	B         int
-	Result    int
+	// Principal is the name of the caller that requested the calculation.
+	Principal string
+	// TenantID is the tenant the calculation belongs to.
+	TenantID string
+	A        int
+	B        int
+	Result   int
+	// Kind is the number system of the calculation; empty means
+	// KindInteger. Calculations of other kinds keep A, B and Result at zero
+	// and record their operands, value, details and options as text.
+	Kind      Kind
+	Operands  []string
+	Value     string
+	Details   map[string]string
+	Options   EvaluationOptions
 	CreatedAt time.Time
+	// DeletedAt is set once the calculation has been soft-deleted.
+	DeletedAt *time.Time
+	// Seq orders the calculations of the audit chain.
+	Seq int
+	// Hash links the calculation into its tenant's audit chain; it covers
+	// the content of the calculation and PrevHash, the hash of the record
+	// saved before it. Both are empty for calculations saved before the
+	// chain was introduced.
+	Hash     string
+	PrevHash string
+	// Receipt is the signed receipt returned with a new calculation when
+	// receipt signing is enabled. It is never stored.
+	Receipt *Receipt
+	// HistorySkipped is set on a new calculation that was answered without
+	// being recorded because the history was unavailable. It has no ID and
+	// no receipt, and is never stored.
+	HistorySkipped bool
+}
+
+// IsInteger reports whether the calculation is a 32-bit integer one.
+func (c Calculation) IsInteger() bool {
+	return c.Kind == "" || c.Kind == KindInteger
+}
+
+// Evaluation returns the evaluation that computes a calculation of a kind
+// other than integers. Rounded divisions are computed by the rational div
+// function.
+func (c Calculation) Evaluation() Evaluation {
+	e := Evaluation{Function: c.Operation, Kind: c.Kind, Operands: c.Operands, Options: c.Options}
+	if c.IsRoundedDivision() {
+		e.Function = "div"
+	}
+	return e
+}
+
+// NewCalculationID returns a random ID for a new calculation, in the shape
+// of the IDs the database assigns.
+func NewCalculationID() string {
+	var b [15]byte
+	_, _ = rand.Read(b[:])
+	return "c" + strings.ToLower(base32.StdEncoding.EncodeToString(b[:]))
+}
+
+// CalculationFilter selects a page of calculation history.
+type CalculationFilter struct {
+	// Operation limits the listing to one operation, e.g. "add".
+	Operation string
+	Limit     int
+	Offset    int
+	// IncludeDeleted also lists soft-deleted calculations.
+	IncludeDeleted bool
+	// After continues a listing after the calculation with this ID. Offset
+	// is then counted from that calculation.
+	After string
 }
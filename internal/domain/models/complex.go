@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"fmt"
+	"math/cmplx"
+	"strconv"
+	"strings"
+)
+
+// ParseComplex reads a complex operand in text form, e.g. "1+2i", "-3.5",
+// "2i" or "(1-1e3i)". Infinite and NaN parts are rejected.
+func ParseComplex(s string) (complex128, error) {
+	v, err := strconv.ParseComplex(strings.TrimSpace(s), 128)
+	if err != nil || cmplx.IsInf(v) || cmplx.IsNaN(v) {
+		return 0, fmt.Errorf("%w: %q is not a finite complex number", ErrInvalidOperands, s)
+	}
+	return v, nil
+}
+
+// FormatComplex writes v in the canonical text form of complex operands
+// and values, e.g. "1+2i" or "0-1i", with the shortest parts that read
+// back exactly.
+func FormatComplex(v complex128) string {
+	re, im := real(v), imag(v)
+	// Drop the sign of negative zeros.
+	if re == 0 {
+		re = 0
+	}
+	if im == 0 {
+		im = 0
+	}
+	s := strconv.FormatComplex(complex(re, im), 'g', -1, 128)
+	return strings.TrimSuffix(strings.TrimPrefix(s, "("), ")")
+}
+
+// ComplexParts returns the real and imaginary parts of a complex value in
+// text form, for adapters that represent complex numbers as pairs.
+func ComplexParts(s string) (re, im float64, ok bool) {
+	v, err := ParseComplex(s)
+	if err != nil {
+		return 0, 0, false
+	}
+	return real(v), imag(v), true
+}
@@ -0,0 +1,51 @@
+package domain
+
+import "strconv"
+
+// RoundedDivision asks for the quotient of two integers rounded to Scale
+// digits after the decimal point, instead of the truncated integer
+// quotient of the divide operation. It is recorded as a divide calculation
+// of KindRational, with the rounding mode and scale in its options.
+type RoundedDivision struct {
+	Dividend int32
+	Divisor  int32
+	// Rounding defaults to RoundTruncate, which matches divide.
+	Rounding RoundingMode
+	// Scale is the number of digits after the decimal point, from 0 to
+	// MaxScale.
+	Scale int
+}
+
+// Evaluation returns the exact rational division that computes d. The
+// rounded quotient is the "decimal" detail of its result.
+func (d RoundedDivision) Evaluation() Evaluation {
+	rounding := d.Rounding
+	if rounding == "" {
+		rounding = RoundTruncate
+	}
+	scale := d.Scale
+	return Evaluation{
+		Function: "div",
+		Kind:     KindRational,
+		Operands: []string{strconv.Itoa(int(d.Dividend)), strconv.Itoa(int(d.Divisor))},
+		Options:  EvaluationOptions{Scale: &scale, Rounding: rounding},
+	}
+}
+
+// IsRoundedDivision reports whether calc was recorded for a RoundedDivision.
+func (c Calculation) IsRoundedDivision() bool {
+	return c.Operation == "divide" && c.Kind == KindRational
+}
+
+// RoundedQuotient returns the rounded quotient of a calculation recorded
+// for a RoundedDivision, and the quotient as an integer when the scale is
+// 0 and it fits into 32 bits. Otherwise, e.g. for MinInt32 / -1, result is
+// nil.
+func RoundedQuotient(calc *Calculation) (value string, result *int) {
+	value = calc.Details["decimal"]
+	if n, err := strconv.ParseInt(value, 10, 32); err == nil {
+		quotient := int(n)
+		result = &quotient
+	}
+	return value, result
+}
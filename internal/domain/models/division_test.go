@@ -0,0 +1,58 @@
+package domain
+
+import "testing"
+
+func TestRoundedQuotient(t *testing.T) {
+	tests := []struct {
+		decimal    string
+		wantResult *int
+	}{
+		{"3", intPtr(3)},
+		{"-3", intPtr(-3)},
+		{"2147483647", intPtr(2147483647)},
+		{"-2147483648", intPtr(-2147483648)},
+		{"2147483648", nil},
+		{"333.33", nil},
+		{"3.00", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		calc := &Calculation{Details: map[string]string{"decimal": tt.decimal}}
+		value, result := RoundedQuotient(calc)
+		if value != tt.decimal {
+			t.Errorf("RoundedQuotient(%q) value = %q", tt.decimal, value)
+		}
+		switch {
+		case (result == nil) != (tt.wantResult == nil):
+			t.Errorf("RoundedQuotient(%q) result = %v, want %v", tt.decimal, result, tt.wantResult)
+		case result != nil && *result != *tt.wantResult:
+			t.Errorf("RoundedQuotient(%q) result = %d, want %d", tt.decimal, *result, *tt.wantResult)
+		}
+	}
+}
+
+func intPtr(n int) *int { return &n }
+
+func TestCalculationEvaluation(t *testing.T) {
+	scale := 2
+	options := EvaluationOptions{Scale: &scale, Rounding: RoundHalfEven}
+	tests := []struct {
+		calc         Calculation
+		wantRounded  bool
+		wantFunction string
+	}{
+		{Calculation{Operation: "divide", Kind: KindRational, Operands: []string{"1000", "3"}, Options: options}, true, "div"},
+		{Calculation{Operation: "div", Kind: KindRational, Operands: []string{"1000", "3"}, Options: options}, false, "div"},
+		{Calculation{Operation: "divide", A: 1000, B: 3}, false, "divide"},
+		{Calculation{Operation: "sqrt", Kind: KindFloat, Operands: []string{"2"}}, false, "sqrt"},
+	}
+	for _, tt := range tests {
+		if got := tt.calc.IsRoundedDivision(); got != tt.wantRounded {
+			t.Errorf("%s of kind %q: IsRoundedDivision = %v, want %v", tt.calc.Operation, tt.calc.Kind, got, tt.wantRounded)
+		}
+		e := tt.calc.Evaluation()
+		if e.Function != tt.wantFunction || e.Kind != tt.calc.Kind || e.Options.Rounding != tt.calc.Options.Rounding {
+			t.Errorf("%s of kind %q: Evaluation = %+v, want function %s", tt.calc.Operation, tt.calc.Kind, e, tt.wantFunction)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package domain
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when a requested record does not exist.
+	ErrNotFound = errors.New("not found")
+
+	// ErrDivisionByZero is returned when dividing by zero.
+	ErrDivisionByZero = errors.New("cannot divide by zero")
+
+	// ErrUnsupportedOperation is returned for operations the calculator does not know.
+	ErrUnsupportedOperation = errors.New("unsupported operation")
+
+	// ErrInvalidOperands is returned when the number of operands does not
+	// match the arity of an operation.
+	ErrInvalidOperands = errors.New("invalid operands")
+
+	// ErrOperationFailed is returned when an operation loaded at runtime,
+	// such as a plugin, fails or exceeds its limits while evaluating.
+	ErrOperationFailed = errors.New("operation failed")
+
+	// ErrSingularMatrix is returned for matrix operations that need an
+	// invertible matrix, such as inverse and solve.
+	ErrSingularMatrix = errors.New("matrix is singular")
+
+	// ErrJobQueueFull is returned when the job queue cannot accept more work.
+	ErrJobQueueFull = errors.New("job queue is full")
+
+	// ErrUnavailable is returned when a dependency is temporarily unavailable
+	// and the caller may retry later.
+	ErrUnavailable = errors.New("service temporarily unavailable")
+
+	// ErrHistorySkipped is returned by repositories that did not record a
+	// calculation because the history is unavailable. The calculation
+	// itself succeeded and can still be answered, without an ID.
+	ErrHistorySkipped = errors.New("calculation not recorded: history unavailable")
+
+	// ErrJobFinished is returned when trying to cancel a job that already completed.
+	ErrJobFinished = errors.New("job has already finished")
+
+	// ErrInvalidMismatchPolicy is returned for import mismatch policies other
+	// than reject, correct and keep.
+	ErrInvalidMismatchPolicy = errors.New("invalid mismatch policy: want reject, correct or keep")
+
+	// ErrInvalidStatsQuery is returned for statistics queries with an invalid
+	// time range or bucket.
+	ErrInvalidStatsQuery = errors.New("invalid statistics query")
+
+	// ErrInvalidTenant is returned for tenants with an invalid ID or configuration.
+	ErrInvalidTenant = errors.New("invalid tenant")
+
+	// ErrTenantExists is returned when creating a tenant whose ID is taken.
+	ErrTenantExists = errors.New("tenant already exists")
+
+	// ErrUnknownTenant is returned when a request selects a tenant that does not exist.
+	ErrUnknownTenant = errors.New("unknown tenant")
+
+	// ErrTenantDisabled is returned for requests to a disabled tenant.
+	ErrTenantDisabled = errors.New("tenant is disabled")
+
+	// ErrOperationNotAllowed is returned for operations a tenant may not run.
+	ErrOperationNotAllowed = errors.New("operation not allowed")
+
+	// ErrOperandOutOfRange is returned for operands above a tenant's limit.
+	ErrOperandOutOfRange = errors.New("operand out of range")
+
+	// ErrTenantJobLimit is returned when a tenant has too many unfinished jobs.
+	ErrTenantJobLimit = errors.New("too many unfinished jobs for this tenant")
+
+	// ErrForbidden is returned when the caller is not allowed to perform an operation.
+	ErrForbidden = errors.New("permission denied")
+)
@@ -0,0 +1,216 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+)
+
+// Kind is the number system a calculation is carried out in.
+type Kind string
+
+const (
+	// KindInteger calculations take 32-bit integer operands, kept with the
+	// result in Calculation.A, B and Result.
+	KindInteger Kind = "integer"
+	// KindFloat calculations use IEEE 754 double precision.
+	KindFloat Kind = "float"
+	// KindDecimal calculations use arbitrary-precision decimals.
+	KindDecimal Kind = "decimal"
+	// KindBigInt calculations use integers of unbounded size, written in
+	// decimal or in "0x"-prefixed hexadecimal.
+	KindBigInt Kind = "bigint"
+	// KindRational calculations use exact fractions of integers of
+	// unbounded size.
+	KindRational Kind = "rational"
+	// KindComplex calculations use complex128, written as "real+imagi".
+	KindComplex Kind = "complex"
+	// KindMatrix calculations use matrices of float64, written as JSON
+	// arrays of rows, e.g. "[[1,2],[3,4]]".
+	KindMatrix Kind = "matrix"
+)
+
+// AngleUnit is the unit of the operands of trigonometric functions and of
+// the results of their inverses.
+type AngleUnit string
+
+const (
+	AngleRadians AngleUnit = "radians"
+	AngleDegrees AngleUnit = "degrees"
+)
+
+// Precision bounds of decimal results.
+const (
+	DefaultPrecision = 20
+	MaxPrecision     = 100
+)
+
+// Scale bounds of decimal renderings, in digits after the decimal point.
+const (
+	DefaultScale = 20
+	MaxScale     = 100
+)
+
+// DivisionMode selects how a quotient is rounded to an integer, and so the
+// sign of the remainder.
+type DivisionMode string
+
+const (
+	// DivisionTruncated rounds the quotient toward zero; the remainder has
+	// the sign of the dividend, as with the integer divide operation.
+	DivisionTruncated DivisionMode = "truncated"
+	// DivisionFloored rounds the quotient toward negative infinity; the
+	// remainder has the sign of the divisor.
+	DivisionFloored DivisionMode = "floored"
+	// DivisionEuclidean makes the remainder non-negative.
+	DivisionEuclidean DivisionMode = "euclidean"
+)
+
+// RoundingMode selects how a result is rounded to its scale.
+type RoundingMode string
+
+const (
+	// RoundTruncate rounds toward zero.
+	RoundTruncate RoundingMode = "truncate"
+	// RoundFloor rounds toward negative infinity.
+	RoundFloor RoundingMode = "floor"
+	// RoundCeiling rounds toward positive infinity.
+	RoundCeiling RoundingMode = "ceiling"
+	// RoundHalfUp rounds to the nearest value and ties toward positive
+	// infinity.
+	RoundHalfUp RoundingMode = "half-up"
+	// RoundHalfEven rounds to the nearest value and ties to the even
+	// neighbour, as banker's rounding does.
+	RoundHalfEven RoundingMode = "half-even"
+	// RoundHalfAwayFromZero rounds to the nearest value and ties away from
+	// zero.
+	RoundHalfAwayFromZero RoundingMode = "half-away-from-zero"
+)
+
+// roundingModes lists every rounding mode, for validation.
+var roundingModes = []RoundingMode{RoundTruncate, RoundFloor, RoundCeiling, RoundHalfUp, RoundHalfEven, RoundHalfAwayFromZero}
+
+// Evaluation asks for a function of operands that are not 32-bit integers.
+// Operands are kept as text so that no kind loses precision in transit.
+type Evaluation struct {
+	Function string
+	// Kind defaults to KindFloat.
+	Kind     Kind
+	Operands []string
+	Options  EvaluationOptions
+}
+
+// EvaluationOptions tune how a function is evaluated. Evaluators clear the
+// options a function does not use, so that only those are recorded.
+type EvaluationOptions struct {
+	// Angle defaults to radians.
+	Angle AngleUnit `json:"angle,omitempty"`
+	// Precision is the number of significant digits of a decimal result; it
+	// defaults to DefaultPrecision.
+	Precision int `json:"precision,omitempty"`
+	// Scale is the number of digits after the decimal point of the decimal
+	// rendering of an exact result; nil selects DefaultScale.
+	Scale *int `json:"scale,omitempty"`
+	// Division defaults to DivisionTruncated.
+	Division DivisionMode `json:"division,omitempty"`
+	// Rounding is how the decimal rendering of an exact result is rounded
+	// to its scale; it defaults to RoundHalfAwayFromZero.
+	Rounding RoundingMode `json:"rounding,omitempty"`
+}
+
+// Validate rejects unknown angle units, division and rounding modes, and
+// out-of-range precisions and scales.
+func (o EvaluationOptions) Validate() error {
+	switch o.Angle {
+	case "", AngleRadians, AngleDegrees:
+	default:
+		return fmt.Errorf("%w: angle unit %q, want %q or %q", ErrInvalidOperands, o.Angle, AngleRadians, AngleDegrees)
+	}
+	if o.Precision < 0 || o.Precision > MaxPrecision {
+		return fmt.Errorf("%w: precision %d, want 0 to %d", ErrInvalidOperands, o.Precision, MaxPrecision)
+	}
+	if o.Scale != nil && (*o.Scale < 0 || *o.Scale > MaxScale) {
+		return fmt.Errorf("%w: scale %d, want 0 to %d", ErrInvalidOperands, *o.Scale, MaxScale)
+	}
+	switch o.Division {
+	case "", DivisionTruncated, DivisionFloored, DivisionEuclidean:
+	default:
+		return fmt.Errorf("%w: division mode %q, want %q, %q or %q", ErrInvalidOperands, o.Division, DivisionTruncated, DivisionFloored, DivisionEuclidean)
+	}
+	if o.Rounding != "" && !slices.Contains(roundingModes, o.Rounding) {
+		return fmt.Errorf("%w: rounding mode %q, want one of %q", ErrInvalidOperands, o.Rounding, roundingModes)
+	}
+	return nil
+}
+
+// EvaluationResult is the outcome of an evaluation in canonical form.
+type EvaluationResult struct {
+	// Operands are the operands as the evaluator understood them, e.g.
+	// "0.5" for ".50".
+	Operands []string
+	Value    string
+	// Details are further results next to Value, e.g. the decimal
+	// rendering of a fraction or the remainder of a division.
+	Details map[string]string
+	// Options are the options that affected the value.
+	Options EvaluationOptions
+}
+
+// Evaluator evaluates the functions of one kind of number. Evaluators are
+// registered with the evaluator registry.
+type Evaluator interface {
+	Kind() Kind
+	// Functions describes the functions the evaluator provides.
+	Functions() []FunctionSignature
+	// Evaluate computes a function of operands in their textual form.
+	Evaluate(function string, operands []string, opts EvaluationOptions) (*EvaluationResult, error)
+	// Magnitude returns the largest absolute value of operands, read as
+	// Evaluate reads them, e.g. the modulus of a complex number or the
+	// largest entry of a matrix. It fails for operands Evaluate rejects.
+	Magnitude(operands []string) (*big.Float, error)
+}
+
+// LargestMagnitude returns the largest of the absolute values abs reads
+// from operands, or 0 when there are none. It fails when abs fails.
+func LargestMagnitude(operands []string, abs func(s string) (*big.Float, error)) (*big.Float, error) {
+	largest := new(big.Float)
+	for _, s := range operands {
+		v, err := abs(s)
+		if err != nil {
+			return nil, err
+		}
+		if v.Cmp(largest) > 0 {
+			largest = v
+		}
+	}
+	return largest, nil
+}
+
+// FunctionSignature describes a function of a kind of number.
+type FunctionSignature struct {
+	Kind  Kind
+	Name  string
+	Arity int
+	OperationDoc
+	// Options lists the options the function uses, e.g. "angle".
+	Options []string
+}
+
+// ErrOutOfDomain is returned for operands a function is not defined for,
+// such as the logarithm of a negative number, and for results that cannot
+// be represented.
+var ErrOutOfDomain = errors.New("operand out of domain")
+
+// DomainError reports operands outside the domain of a function. It
+// matches ErrOutOfDomain with errors.Is.
+type DomainError struct {
+	Function string
+	Reason   string
+}
+
+func (e *DomainError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", ErrOutOfDomain, e.Function, e.Reason)
+}
+
+func (e *DomainError) Unwrap() error { return ErrOutOfDomain }
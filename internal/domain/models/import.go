@@ -0,0 +1,63 @@
+package domain
+
+// MismatchPolicy decides what happens to an imported calculation whose
+// recorded result differs from the recomputed one.
+type MismatchPolicy string
+
+const (
+	// MismatchReject skips the calculation.
+	MismatchReject MismatchPolicy = "reject"
+	// MismatchCorrect imports the calculation with the recomputed result.
+	MismatchCorrect MismatchPolicy = "correct"
+	// MismatchKeep imports the calculation with its recorded result.
+	MismatchKeep MismatchPolicy = "keep"
+)
+
+// ImportOptions controls how calculations are imported.
+type ImportOptions struct {
+	// Recompute checks every result with the calculator before importing it.
+	Recompute bool
+	// OnMismatch applies when Recompute finds a different result; it defaults to MismatchReject.
+	OnMismatch MismatchPolicy
+	// DryRun validates the rows without storing anything.
+	DryRun bool
+}
+
+// ImportRow is one decoded row of an import file. Err is set when the row
+// could not be decoded.
+type ImportRow struct {
+	Line        int
+	Calculation Calculation
+	Err         error
+}
+
+// ImportIssueKind classifies the rows listed in an import report.
+type ImportIssueKind string
+
+const (
+	ImportIssueRejected   ImportIssueKind = "rejected"
+	ImportIssueMismatched ImportIssueKind = "mismatched"
+)
+
+// ImportIssue describes a row that was rejected or did not match its recomputed result.
+type ImportIssue struct {
+	Line   int
+	Kind   ImportIssueKind
+	Reason string
+}
+
+// ImportReport summarizes an import.
+type ImportReport struct {
+	// Total is the number of rows read.
+	Total int
+	// Accepted is the number of calculations stored, or that would be in a dry run.
+	Accepted int
+	// Rejected is the number of rows that were invalid, or mismatched and rejected.
+	Rejected int
+	// Mismatched is the number of rows whose result differs from the recomputed one.
+	Mismatched int
+	// Issues lists the first rejected and mismatched rows.
+	Issues []ImportIssue
+	// IssuesTruncated is set when there were more issues than listed.
+	IssuesTruncated bool
+}
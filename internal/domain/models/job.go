@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// JobStatus describes where a Job is in its lifecycle.
+type JobStatus string
+
+const (
+	JobStatusPending   JobStatus = "pending"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusSucceeded JobStatus = "succeeded"
+	JobStatusFailed    JobStatus = "failed"
+	JobStatusCancelled JobStatus = "cancelled"
+)
+
+// IsTerminal reports whether a job in this status will never change again.
+func (s JobStatus) IsTerminal() bool {
+	return s == JobStatusSucceeded || s == JobStatusFailed || s == JobStatusCancelled
+}
+
+// Job is a calculation that is executed asynchronously by the worker pool.
+type Job struct {
+	ID string
+	// TenantID is the tenant that submitted the job.
+	TenantID      string
+	Operation     string
+	A             int
+	B             int
+	Status        JobStatus
+	Result        *int
+	CalculationID string
+	Error         string
+	CreatedAt     time.Time
+	StartedAt     *time.Time
+	FinishedAt    *time.Time
+}
+
+// JobFilter narrows down the jobs returned by a listing.
+type JobFilter struct {
+	Status JobStatus
+	Limit  int
+	Offset int
+	// AllTenants lists the jobs of every tenant instead of the current one.
+	// It is only set internally, e.g. to recover unfinished jobs on startup.
+	AllTenants bool
+}
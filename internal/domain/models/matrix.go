@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// Matrix is a matrix of float64 in row-major order, written in JSON as an
+// array of rows, e.g. [[1,2],[3,4]]. Vectors are matrices of one row or
+// one column, and scalar results are 1×1 matrices.
+type Matrix [][]float64
+
+// Dims returns the number of rows and columns of m.
+func (m Matrix) Dims() (rows, cols int) {
+	if len(m) == 0 {
+		return 0, 0
+	}
+	return len(m), len(m[0])
+}
+
+// Validate rejects empty and ragged matrices and non-finite entries.
+func (m Matrix) Validate() error {
+	if len(m) == 0 || len(m[0]) == 0 {
+		return fmt.Errorf("%w: a matrix needs at least one row and one column", ErrInvalidOperands)
+	}
+	for i, row := range m {
+		if len(row) != len(m[0]) {
+			return fmt.Errorf("%w: row %d has %d columns, want %d", ErrInvalidOperands, i, len(row), len(m[0]))
+		}
+		for _, v := range row {
+			if math.IsInf(v, 0) || math.IsNaN(v) {
+				return fmt.Errorf("%w: matrix entries must be finite", ErrInvalidOperands)
+			}
+		}
+	}
+	return nil
+}
+
+// ParseMatrix reads a matrix operand in its JSON form and validates it.
+func ParseMatrix(s string) (Matrix, error) {
+	var m Matrix
+	if err := json.Unmarshal([]byte(s), &m); err != nil {
+		return nil, fmt.Errorf("%w: %q is not a JSON array of rows of numbers", ErrInvalidOperands, s)
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// FormatMatrix writes m in the canonical text form of matrix operands and
+// values: compact JSON with the shortest numbers that read back exactly.
+func FormatMatrix(m Matrix) string {
+	b, _ := json.Marshal(m)
+	return string(b)
+}
+
+// MatrixCalculation returns the evaluation of a matrix operation, whose
+// operands are kept in their JSON form like those of other kinds.
+func MatrixCalculation(operation string, operands ...Matrix) Evaluation {
+	text := make([]string, len(operands))
+	for i, m := range operands {
+		text[i] = FormatMatrix(m)
+	}
+	return Evaluation{Function: operation, Kind: KindMatrix, Operands: text}
+}
+
+// Matrices returns the operands and value of a matrix calculation in
+// matrix form; ok is false for other calculations.
+func (c Calculation) Matrices() (operands []Matrix, value Matrix, ok bool) {
+	if c.Kind != KindMatrix {
+		return nil, nil, false
+	}
+	for _, s := range c.Operands {
+		m, err := ParseMatrix(s)
+		if err != nil {
+			return nil, nil, false
+		}
+		operands = append(operands, m)
+	}
+	value, err := ParseMatrix(c.Value)
+	if err != nil {
+		return nil, nil, false
+	}
+	return operands, value, true
+}
@@ -0,0 +1,34 @@
+package domain
+
+// Operation is a calculation the calculator can run. Operations are
+// registered with the operation registry; adding one needs no changes to
+// the ports, the adapters or the API.
+type Operation interface {
+	// Name identifies the operation in requests and in the history, e.g. "add".
+	Name() string
+	// Arity is the number of operands. Calculations record at most two.
+	Arity() int
+	// Validate rejects operands the operation is not defined for. It is
+	// called with exactly Arity operands.
+	Validate(operands []int32) error
+	// Evaluate computes the result of operands that passed Validate.
+	Evaluate(operands []int32) (int32, error)
+	// Describe documents the operation for clients.
+	Describe() OperationDoc
+}
+
+// OperationDoc documents an operation.
+type OperationDoc struct {
+	Description string
+	// Operands names each operand, e.g. "dividend" and "divisor".
+	Operands []string
+	// Commutative operations give the same result for swapped operands.
+	Commutative bool
+}
+
+// OperationSignature describes a registered operation.
+type OperationSignature struct {
+	Name  string
+	Arity int
+	OperationDoc
+}
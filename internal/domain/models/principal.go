@@ -0,0 +1,43 @@
+package domain
+
+import "context"
+
+// Role is the set of permissions granted to a principal.
+type Role string
+
+const (
+	RoleUser  Role = "user"
+	RoleAdmin Role = "admin"
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	Name string
+	Role Role
+	// Tenant is the tenant the credentials are bound to; empty lets the
+	// request select one.
+	Tenant string
+}
+
+// Anonymous is the principal of unauthenticated requests when authentication is optional.
+var Anonymous = Principal{Name: "anonymous", Role: RoleUser}
+
+// IsAdmin reports whether the principal may perform administrative operations.
+func (p Principal) IsAdmin() bool {
+	return p.Role == RoleAdmin
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a context carrying the principal of the request.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the principal of the request, or Anonymous.
+func PrincipalFromContext(ctx context.Context) Principal {
+	if p, ok := ctx.Value(principalKey{}).(Principal); ok {
+		return p
+	}
+	return Anonymous
+}
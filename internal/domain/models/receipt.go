@@ -0,0 +1,10 @@
+package domain
+
+// Receipt is a detached signature over a calculation's operation, operands,
+// result, ID and creation time, which clients can verify offline with the
+// published public key named by KeyID.
+type Receipt struct {
+	KeyID     string
+	Algorithm string
+	Signature []byte
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// RetentionQuery selects calculations that a retention policy expires.
+type RetentionQuery struct {
+	// CreatedBefore limits the selection to older calculations; zero selects any age.
+	CreatedBefore time.Time
+	// Operations limits the selection to these operations; empty selects all of them.
+	Operations []string
+	// ExcludeOperations skips these operations.
+	ExcludeOperations []string
+	// TenantID limits the selection to one tenant when set.
+	TenantID string
+	// Principal limits the selection to one caller when set.
+	Principal *string
+	// KeepNewest skips the newest matching calculations, which selects only
+	// the rows beyond a per-principal row limit.
+	KeepNewest int
+	Offset     int
+	Limit      int
+}
+
+// RetentionOwner is a caller of one tenant. The row limit applies to each
+// owner separately, so callers with the same name in different tenants do
+// not share it.
+type RetentionOwner struct {
+	TenantID  string
+	Principal string
+}
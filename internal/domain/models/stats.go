@@ -0,0 +1,87 @@
+package domain
+
+import "time"
+
+// StatsBucket is the width of the histogram buckets of a statistics query.
+type StatsBucket string
+
+const (
+	StatsBucketHour StatsBucket = "hour"
+	StatsBucketDay  StatsBucket = "day"
+)
+
+// Duration returns the width of a bucket.
+func (b StatsBucket) Duration() time.Duration {
+	if b == StatsBucketHour {
+		return time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// StatsQuery selects the calculations to aggregate. From is inclusive and To
+// is exclusive.
+type StatsQuery struct {
+	From   time.Time
+	To     time.Time
+	Bucket StatsBucket
+	// Operation limits the statistics to one operation, e.g. "add".
+	Operation string
+	// TopPairs is the number of most frequent operand pairs to return.
+	TopPairs int
+	// IncludeDeleted also counts soft-deleted calculations.
+	IncludeDeleted bool
+}
+
+// Statistics aggregates the calculation history over a time range.
+type Statistics struct {
+	From       time.Time
+	To         time.Time
+	Bucket     StatsBucket
+	Total      int
+	Operations []OperationStats
+	// Histogram counts calculations per bucket, oldest first, including empty buckets.
+	Histogram []HistogramBucket
+	// TopPairs lists the most frequent operand pairs, most frequent first.
+	TopPairs []OperandPairStats
+	// Errors holds the failure rates of asynchronous jobs per operation.
+	// Failed synchronous calculations are not recorded, so they are not included.
+	Errors []ErrorStats
+}
+
+// OperationStats summarizes the results of one operation.
+type OperationStats struct {
+	Operation string
+	Count     int
+	MinResult int
+	MaxResult int
+	AvgResult float64
+}
+
+// HistogramBucket counts the calculations created in [Start, Start+bucket width).
+type HistogramBucket struct {
+	Start time.Time
+	Count int
+}
+
+// OperandPairStats counts how often an operation was run with the same operands.
+type OperandPairStats struct {
+	Operation string
+	A         int
+	B         int
+	Count     int
+}
+
+// ErrorStats counts finished and failed jobs of one operation.
+type ErrorStats struct {
+	Operation string
+	Finished  int
+	Failed    int
+}
+
+// Rate returns the share of finished jobs that failed.
+func (e ErrorStats) Rate() float64 {
+	if e.Finished == 0 {
+		return 0
+	}
+	return float64(e.Failed) / float64(e.Finished)
+}
@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"regexp"
+	"slices"
+	"time"
+)
+
+// DefaultTenantID is the tenant of requests that neither carry credentials
+// bound to a tenant nor select one. It always exists and cannot be disabled.
+const DefaultTenantID = "default"
+
+// tenantIDPattern restricts tenant IDs to short lowercase slugs, since they
+// appear in headers, URLs and credentials.
+var tenantIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+// Tenant is a team whose calculations are isolated from everybody else's.
+type Tenant struct {
+	ID   string
+	Name string
+	// AllowedOperations lists the operations the tenant may run; empty allows all.
+	AllowedOperations []string
+	// MaxOperand bounds the absolute value of every operand; 0 means no limit.
+	MaxOperand int
+	// MaxPendingJobs caps the jobs the tenant may have queued or running; 0 means no limit.
+	MaxPendingJobs int
+	CreatedAt      time.Time
+	// DisabledAt is set while the tenant is disabled.
+	DisabledAt *time.Time
+}
+
+// DefaultTenant is the tenant used when none has been resolved, e.g. by the
+// CLI or while the tenant cannot be loaded.
+var DefaultTenant = Tenant{ID: DefaultTenantID, Name: "Default"}
+
+// Disabled reports whether the tenant has been disabled.
+func (t Tenant) Disabled() bool {
+	return t.DisabledAt != nil
+}
+
+// Validate checks the ID and the configuration of a tenant.
+func (t Tenant) Validate() error {
+	if !tenantIDPattern.MatchString(t.ID) {
+		return fmt.Errorf("%w: id must be a lowercase slug of at most 63 characters", ErrInvalidTenant)
+	}
+	if t.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidTenant)
+	}
+	if t.MaxOperand < 0 || t.MaxPendingJobs < 0 {
+		return fmt.Errorf("%w: limits must not be negative", ErrInvalidTenant)
+	}
+	return nil
+}
+
+// Allows reports whether the tenant may run an operation.
+func (t Tenant) Allows(operation string) bool {
+	return len(t.AllowedOperations) == 0 || slices.Contains(t.AllowedOperations, operation)
+}
+
+// Authorize checks an operation and its operands against the tenant's configuration.
+func (t Tenant) Authorize(operation string, operands ...int) error {
+	if !t.Allows(operation) {
+		return fmt.Errorf("%w: %q for tenant %q", ErrOperationNotAllowed, operation, t.ID)
+	}
+	if t.MaxOperand > 0 {
+		for _, v := range operands {
+			if abs(v) > t.MaxOperand {
+				return fmt.Errorf("%w: operands of tenant %q are limited to ±%d", ErrOperandOutOfRange, t.ID, t.MaxOperand)
+			}
+		}
+	}
+	return nil
+}
+
+// AuthorizeText is Authorize for operands in textual form, whose size
+// depends on their kind. magnitude returns the largest absolute value of
+// the operands, as read by the evaluator of their kind; it is only called
+// when the tenant limits operands, and its error is returned, so operands
+// that cannot be sized are refused rather than let through.
+func (t Tenant) AuthorizeText(operation string, magnitude func() (*big.Float, error)) error {
+	if !t.Allows(operation) {
+		return fmt.Errorf("%w: %q for tenant %q", ErrOperationNotAllowed, operation, t.ID)
+	}
+	if t.MaxOperand > 0 {
+		v, err := magnitude()
+		if err != nil {
+			return err
+		}
+		if v.Cmp(new(big.Float).SetInt64(int64(t.MaxOperand))) > 0 {
+			return fmt.Errorf("%w: operands of tenant %q are limited to ±%d", ErrOperandOutOfRange, t.ID, t.MaxOperand)
+		}
+	}
+	return nil
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+type tenantKey struct{}
+
+// WithTenant returns a context carrying the tenant of the request.
+func WithTenant(ctx context.Context, t Tenant) context.Context {
+	return context.WithValue(ctx, tenantKey{}, t)
+}
+
+// TenantFromContext returns the tenant of the request, or DefaultTenant.
+// Repositories scope every query to the ID of this tenant.
+func TenantFromContext(ctx context.Context) Tenant {
+	if t, ok := ctx.Value(tenantKey{}).(Tenant); ok {
+		return t
+	}
+	return DefaultTenant
+}
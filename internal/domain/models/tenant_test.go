@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestTenantAuthorizeText(t *testing.T) {
+	errUnreadable := errors.New("unreadable operand")
+	tests := []struct {
+		name       string
+		tenant     Tenant
+		operation  string
+		magnitude  *big.Float
+		err        error
+		wantErr    error
+		wantSizing bool
+	}{
+		{name: "no limit", tenant: Tenant{}, operation: "sqrt", err: errUnreadable},
+		{name: "within the limit", tenant: Tenant{MaxOperand: 100}, operation: "sqrt", magnitude: big.NewFloat(100), wantSizing: true},
+		{name: "over the limit", tenant: Tenant{MaxOperand: 100}, operation: "sqrt", magnitude: big.NewFloat(100.5), wantErr: ErrOperandOutOfRange, wantSizing: true},
+		{name: "unreadable operands", tenant: Tenant{MaxOperand: 100}, operation: "sqrt", err: errUnreadable, wantErr: errUnreadable, wantSizing: true},
+		{name: "operation not allowed", tenant: Tenant{AllowedOperations: []string{"add"}, MaxOperand: 100}, operation: "sqrt", wantErr: ErrOperationNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sized := false
+			err := tt.tenant.AuthorizeText(tt.operation, func() (*big.Float, error) {
+				sized = true
+				return tt.magnitude, tt.err
+			})
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if sized != tt.wantSizing {
+				t.Errorf("operands sized = %v, want %v", sized, tt.wantSizing)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Package operations contains the operations built into the calculator.
+// Each one is registered with the operation registry through the
+// "operations" fx group.
+package operations
+
+import (
+	domain "go-prisma-calculator/internal/domain/models"
+)
+
+// add is integer addition; it wraps around on overflow.
+type add struct{}
+
+// NewAdd returns the "add" operation.
+func NewAdd() domain.Operation { return add{} }
+
+func (add) Name() string                    { return "add" }
+func (add) Arity() int                      { return 2 }
+func (add) Validate(operands []int32) error { return nil }
+
+func (add) Evaluate(operands []int32) (int32, error) {
+	return operands[0] + operands[1], nil
+}
+
+func (add) Describe() domain.OperationDoc {
+	return domain.OperationDoc{
+		Description: "Adds two integers.",
+		Operands:    []string{"a", "b"},
+		Commutative: true,
+	}
+}
+
+// divide is integer division, truncated towards zero.
+type divide struct{}
+
+// NewDivide returns the "divide" operation.
+func NewDivide() domain.Operation { return divide{} }
+
+func (divide) Name() string { return "divide" }
+func (divide) Arity() int   { return 2 }
+
+func (divide) Validate(operands []int32) error {
+	if operands[1] == 0 {
+		return domain.ErrDivisionByZero
+	}
+	return nil
+}
+
+func (divide) Evaluate(operands []int32) (int32, error) {
+	return operands[0] / operands[1], nil
+}
+
+func (divide) Describe() domain.OperationDoc {
+	return domain.OperationDoc{
+		Description: "Divides two integers, truncating towards zero.",
+		Operands:    []string{"dividend", "divisor"},
+	}
+}
@@ -7,6 +7,16 @@ import (
 
 // CalculatorPort is the driving port for our application.
 type CalculatorPort interface {
-	Add(ctx context.Context, a, b int32) (*domain.Calculation, error)
-	Divide(ctx context.Context, a, b int32) (*domain.Calculation, error)
+	// Calculate runs a registered operation on its operands and records it.
+	Calculate(ctx context.Context, operation string, operands ...int32) (*domain.Calculation, error)
+	// ListOperations describes the operations the caller may run.
+	ListOperations(ctx context.Context) ([]domain.OperationSignature, error)
+	// Divide divides two integers, rounding the quotient to a scale with a
+	// rounding mode, and records it as an exact rational division.
+	Divide(ctx context.Context, d domain.RoundedDivision) (*domain.Calculation, error)
+	// Evaluate computes a function of operands of a kind other than
+	// integers and records it.
+	Evaluate(ctx context.Context, e domain.Evaluation) (*domain.Calculation, error)
+	// ListFunctions describes the functions the caller may evaluate.
+	ListFunctions(ctx context.Context) ([]domain.FunctionSignature, error)
 }
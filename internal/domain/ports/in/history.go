@@ -0,0 +1,24 @@
+package in
+
+import (
+	"context"
+	domain "go-prisma-calculator/internal/domain/models"
+)
+
+// HistoryPort is the driving port for browsing past calculations.
+type HistoryPort interface {
+	GetCalculation(ctx context.Context, id string) (*domain.Calculation, error)
+	ListCalculations(ctx context.Context, filter domain.CalculationFilter) ([]domain.Calculation, error)
+	DeleteCalculation(ctx context.Context, id string) error
+	RestoreCalculation(ctx context.Context, id string) (*domain.Calculation, error)
+	PurgeCalculation(ctx context.Context, id string) error
+	// ExportCalculations calls fn for every calculation matching the filter,
+	// newest first, without loading them all at once. A zero limit exports
+	// all of them.
+	ExportCalculations(ctx context.Context, filter domain.CalculationFilter, fn func(domain.Calculation) error) error
+	// GetStatistics aggregates the history over a time range.
+	GetStatistics(ctx context.Context, query domain.StatsQuery) (*domain.Statistics, error)
+	// VerifyChain walks the tenant's audit chain and reports the first
+	// broken link. Only admins may verify.
+	VerifyChain(ctx context.Context) (*domain.ChainVerification, error)
+}
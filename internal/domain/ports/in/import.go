@@ -0,0 +1,13 @@
+package in
+
+import (
+	"context"
+	domain "go-prisma-calculator/internal/domain/models"
+)
+
+// ImportPort is the driving port for loading past calculations in bulk.
+type ImportPort interface {
+	// ImportCalculations reads rows from next until it returns io.EOF. The
+	// report is returned even when the import fails part way through.
+	ImportCalculations(ctx context.Context, opts domain.ImportOptions, next func() (domain.ImportRow, error)) (*domain.ImportReport, error)
+}
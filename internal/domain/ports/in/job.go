@@ -0,0 +1,14 @@
+package in
+
+import (
+	"context"
+	domain "go-prisma-calculator/internal/domain/models"
+)
+
+// JobPort is the driving port for submitting and tracking asynchronous calculations.
+type JobPort interface {
+	SubmitJob(ctx context.Context, operation string, a, b int32) (*domain.Job, error)
+	GetJob(ctx context.Context, id string) (*domain.Job, error)
+	CancelJob(ctx context.Context, id string) (*domain.Job, error)
+	ListJobs(ctx context.Context, filter domain.JobFilter) ([]domain.Job, error)
+}
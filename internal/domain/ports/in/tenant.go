@@ -0,0 +1,21 @@
+package in
+
+import (
+	"context"
+	domain "go-prisma-calculator/internal/domain/models"
+)
+
+// TenantPort is the driving port for resolving the tenant of a request and
+// for administering tenants. All administrative methods require an admin.
+type TenantPort interface {
+	// ResolveTenant returns the tenant a request runs in, given the tenant it
+	// asked for, if any, and the principal in the context.
+	ResolveTenant(ctx context.Context, requested string) (*domain.Tenant, error)
+	CreateTenant(ctx context.Context, tenant domain.Tenant) (*domain.Tenant, error)
+	GetTenant(ctx context.Context, id string) (*domain.Tenant, error)
+	ListTenants(ctx context.Context) ([]domain.Tenant, error)
+	// UpdateTenant replaces the name and configuration of a tenant.
+	UpdateTenant(ctx context.Context, tenant domain.Tenant) (*domain.Tenant, error)
+	DisableTenant(ctx context.Context, id string) (*domain.Tenant, error)
+	EnableTenant(ctx context.Context, id string) (*domain.Tenant, error)
+}
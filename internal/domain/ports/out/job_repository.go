@@ -0,0 +1,19 @@
+package out
+
+import (
+	"context"
+	"go-prisma-calculator/internal/domain/models"
+)
+
+// JobRepositoryPort is the driven port for persisting asynchronous jobs.
+type JobRepositoryPort interface {
+	Create(ctx context.Context, job domain.Job) (*domain.Job, error)
+	// Update persists the state of a job that is still pending or running.
+	// It returns ErrJobFinished for a job that already is in a terminal
+	// state, so the first of a worker finishing and a caller cancelling wins.
+	Update(ctx context.Context, job domain.Job) error
+	FindByID(ctx context.Context, id string) (*domain.Job, error)
+	List(ctx context.Context, filter domain.JobFilter) ([]domain.Job, error)
+	// CountUnfinished counts the pending and running jobs of the current tenant.
+	CountUnfinished(ctx context.Context) (int, error)
+}
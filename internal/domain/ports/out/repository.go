@@ -9,4 +9,26 @@ import (
 // Our core logic will depend on this, not a concrete database implementation.
 type CalculationRepositoryPort interface {
 	Save(ctx context.Context, calc domain.Calculation) error
-}
\ No newline at end of file
+	SaveBatch(ctx context.Context, calcs []domain.Calculation) error
+	FindByID(ctx context.Context, id string) (*domain.Calculation, error)
+	List(ctx context.Context, filter domain.CalculationFilter) ([]domain.Calculation, error)
+	// Delete soft-deletes a calculation; it is hidden but can be restored.
+	Delete(ctx context.Context, id string) error
+	// Restore undoes a soft delete.
+	Restore(ctx context.Context, id string) (*domain.Calculation, error)
+	// Purge removes a calculation permanently, leaving a tombstone in its
+	// tenant's audit chain.
+	Purge(ctx context.Context, id string) error
+	// Import inserts past calculations in one transaction, keeping their
+	// creation and deletion times. Unlike SaveBatch it is never skipped or deferred.
+	Import(ctx context.Context, calcs []domain.Calculation) error
+	// Stats aggregates the calculations selected by the query. The histogram
+	// only contains non-empty buckets.
+	Stats(ctx context.Context, query domain.StatsQuery) (*domain.Statistics, error)
+	// Chain returns up to limit calculations of the tenant in audit chain
+	// order, soft-deleted ones included, starting after the given sequence number.
+	Chain(ctx context.Context, afterSeq, limit int) ([]domain.Calculation, error)
+	// Tombstones returns up to limit tombstones of the tenant's audit chain
+	// whose sequence numbers lie between afterSeq and beforeSeq, in chain order.
+	Tombstones(ctx context.Context, afterSeq, beforeSeq, limit int) ([]domain.ChainTombstone, error)
+}
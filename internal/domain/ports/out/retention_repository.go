@@ -0,0 +1,19 @@
+package out
+
+import (
+	"context"
+	"go-prisma-calculator/internal/domain/models"
+)
+
+// RetentionRepositoryPort is the driven port used to expire old calculations.
+type RetentionRepositoryPort interface {
+	// FindExpired returns the calculations selected by the query, including
+	// soft-deleted ones.
+	FindExpired(ctx context.Context, query domain.RetentionQuery) ([]domain.Calculation, error)
+	// PrincipalsOver returns the principals of every tenant owning more than
+	// maxRows calculations in that tenant.
+	PrincipalsOver(ctx context.Context, maxRows int) ([]domain.RetentionOwner, error)
+	// PurgeBatch permanently deletes the given calculations, leaving
+	// tombstones in their audit chains, and returns how many were removed.
+	PurgeBatch(ctx context.Context, calcs []domain.Calculation) (int, error)
+}
@@ -0,0 +1,17 @@
+package out
+
+import (
+	"context"
+	"go-prisma-calculator/internal/domain/models"
+)
+
+// TenantRepositoryPort is the driven port for persisting tenants. Tenants
+// themselves are not scoped to the tenant of the request.
+type TenantRepositoryPort interface {
+	// Create inserts a tenant, or returns domain.ErrTenantExists.
+	Create(ctx context.Context, tenant domain.Tenant) (*domain.Tenant, error)
+	FindByID(ctx context.Context, id string) (*domain.Tenant, error)
+	List(ctx context.Context) ([]domain.Tenant, error)
+	// Update stores the name, configuration and disabled state of a tenant.
+	Update(ctx context.Context, tenant domain.Tenant) (*domain.Tenant, error)
+}
@@ -0,0 +1,302 @@
+// Package rational provides exact arithmetic on fractions with math/big.
+// Results are exact fractions, written as "numerator/denominator", with a
+// decimal rendering of a configurable scale next to them.
+package rational
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	domain "go-prisma-calculator/internal/domain/models"
+)
+
+// DefaultMaxBits is the size limit used when none is configured.
+const DefaultMaxBits = 4096
+
+// function is a rational operation.
+type function struct {
+	name  string
+	arity int
+	doc   domain.OperationDoc
+	// divides is set for divmod, which uses the division mode instead of
+	// the scale.
+	divides bool
+	eval    func(x []*big.Rat, opts domain.EvaluationOptions) (*big.Rat, map[string]string, error)
+}
+
+// functions is the operation set, ordered by name.
+var functions = []function{
+	{name: "add", arity: 2, eval: add,
+		doc: domain.OperationDoc{Description: "Exact sum of two fractions.", Operands: []string{"a", "b"}, Commutative: true}},
+	{name: "div", arity: 2, eval: div,
+		doc: domain.OperationDoc{Description: "Exact quotient of two fractions.", Operands: []string{"dividend", "divisor"}}},
+	{name: "divmod", arity: 2, eval: divMod, divides: true,
+		doc: domain.OperationDoc{Description: "Integer quotient and remainder, rounded as the division mode selects.", Operands: []string{"dividend", "divisor"}}},
+	{name: "mul", arity: 2, eval: mul,
+		doc: domain.OperationDoc{Description: "Exact product of two fractions.", Operands: []string{"a", "b"}, Commutative: true}},
+	{name: "sub", arity: 2, eval: sub,
+		doc: domain.OperationDoc{Description: "Exact difference of two fractions.", Operands: []string{"a", "b"}}},
+}
+
+// evaluator evaluates the operations on fractions whose numerators and
+// denominators have at most maxBits bits.
+type evaluator struct {
+	maxBits int
+}
+
+// NewEvaluator returns the evaluator of KindRational. maxBits bounds the
+// size of the numerators and denominators of operands and results; 0
+// selects DefaultMaxBits.
+func NewEvaluator(maxBits int) domain.Evaluator {
+	if maxBits <= 0 {
+		maxBits = DefaultMaxBits
+	}
+	return &evaluator{maxBits: maxBits}
+}
+
+func (e *evaluator) Kind() domain.Kind { return domain.KindRational }
+
+func (e *evaluator) Functions() []domain.FunctionSignature {
+	sigs := make([]domain.FunctionSignature, 0, len(functions))
+	for _, f := range functions {
+		options := []string{"scale", "rounding"}
+		if f.divides {
+			options = []string{"division"}
+		}
+		sigs = append(sigs, domain.FunctionSignature{
+			Kind:         domain.KindRational,
+			Name:         f.name,
+			Arity:        f.arity,
+			OperationDoc: f.doc,
+			Options:      options,
+		})
+	}
+	return sigs
+}
+
+func (e *evaluator) Evaluate(name string, operands []string, opts domain.EvaluationOptions) (*domain.EvaluationResult, error) {
+	i := slices.IndexFunc(functions, func(f function) bool { return f.name == name })
+	if i < 0 {
+		return nil, fmt.Errorf("%w: %q", domain.ErrUnsupportedOperation, name)
+	}
+	f := functions[i]
+	if len(operands) != f.arity {
+		return nil, fmt.Errorf("%w: %s takes %d operands, got %d", domain.ErrInvalidOperands, name, f.arity, len(operands))
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Record only the option the function uses, with its default filled in.
+	var used domain.EvaluationOptions
+	if f.divides {
+		used.Division = opts.Division
+		if used.Division == "" {
+			used.Division = domain.DivisionTruncated
+		}
+	} else {
+		scale := domain.DefaultScale
+		if opts.Scale != nil {
+			scale = *opts.Scale
+		}
+		used.Scale = &scale
+		used.Rounding = opts.Rounding
+		if used.Rounding == "" {
+			used.Rounding = domain.RoundHalfAwayFromZero
+		}
+	}
+
+	x := make([]*big.Rat, len(operands))
+	canonical := make([]string, len(operands))
+	for i, s := range operands {
+		v, err := e.parse(s)
+		if err != nil {
+			return nil, err
+		}
+		x[i], canonical[i] = v, format(v)
+	}
+
+	v, details, err := f.eval(x, used)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.check("the result", v); err != nil {
+		return nil, err
+	}
+	return &domain.EvaluationResult{Operands: canonical, Value: format(v), Details: details, Options: used}, nil
+}
+
+func (e *evaluator) Magnitude(operands []string) (*big.Float, error) {
+	return domain.LargestMagnitude(operands, func(s string) (*big.Float, error) {
+		v, err := e.parse(s)
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Float).SetRat(v.Abs(v)), nil
+	})
+}
+
+// format writes a fraction in lowest terms as "numerator/denominator", or
+// as an integer when the denominator is 1.
+func format(v *big.Rat) string {
+	return v.RatString()
+}
+
+// parse reads an integer, a fraction such as "-7/2" or a decimal such as
+// "0.125". Exponents are not accepted, since a short exponent can ask for
+// a huge number.
+func (e *evaluator) parse(s string) (*big.Rat, error) {
+	text := strings.TrimSpace(s)
+	// Reject oversized text before parsing it; a decimal digit carries more
+	// than 3 bits, and a fraction has two numbers.
+	if len(text) > 2*(e.maxBits/3+2) {
+		return nil, e.tooLarge("an operand")
+	}
+
+	invalid := fmt.Errorf("%w: %q is not an integer, fraction or decimal", domain.ErrInvalidOperands, s)
+	unsigned := strings.TrimLeft(text, "+-")
+	if len(text)-len(unsigned) > 1 || strings.ContainsFunc(unsigned, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '/' && r != '.'
+	}) {
+		return nil, invalid
+	}
+	if num, den, ok := strings.Cut(unsigned, "/"); ok {
+		// A fraction takes integers; Rat.SetString would read "1.5/2" as well.
+		if strings.Contains(num, ".") || strings.Contains(den, ".") {
+			return nil, invalid
+		}
+		if den != "" && strings.Trim(den, "0") == "" {
+			return nil, fmt.Errorf("%w: %q has a zero denominator", domain.ErrDivisionByZero, s)
+		}
+	}
+	v, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return nil, invalid
+	}
+	if err := e.check("an operand", v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// check bounds the size of the numerator and denominator of v.
+func (e *evaluator) check(what string, v *big.Rat) error {
+	if v.Num().BitLen() > e.maxBits || v.Denom().BitLen() > e.maxBits {
+		return e.tooLarge(what)
+	}
+	return nil
+}
+
+func (e *evaluator) tooLarge(what string) error {
+	return fmt.Errorf("%w: %s exceeds %d bits", domain.ErrOperandOutOfRange, what, e.maxBits)
+}
+
+// decimal returns the details of a fraction: its decimal rendering.
+func decimal(v *big.Rat, opts domain.EvaluationOptions) map[string]string {
+	return map[string]string{"decimal": round(v, *opts.Scale, opts.Rounding)}
+}
+
+// round renders v in decimal with scale digits after the decimal point,
+// rounded as mode selects.
+func round(v *big.Rat, scale int, mode domain.RoundingMode) string {
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+	num := new(big.Int).Mul(v.Num(), pow)
+	// The denominator is positive, so q is truncated toward zero and r has
+	// the sign of v.
+	q, r := new(big.Int).QuoRem(num, v.Denom(), new(big.Int))
+	if r.Sign() != 0 {
+		sign := big.NewInt(int64(num.Sign()))
+		// half compares the discarded fraction with one half.
+		half := new(big.Int).Lsh(new(big.Int).Abs(r), 1).Cmp(v.Denom())
+		var away bool
+		switch mode {
+		case domain.RoundTruncate:
+		case domain.RoundFloor:
+			away = sign.Sign() < 0
+		case domain.RoundCeiling:
+			away = sign.Sign() > 0
+		case domain.RoundHalfUp:
+			away = half > 0 || half == 0 && sign.Sign() > 0
+		case domain.RoundHalfEven:
+			away = half > 0 || half == 0 && q.Bit(0) == 1
+		default:
+			away = half >= 0
+		}
+		if away {
+			q.Add(q, sign)
+		}
+	}
+
+	digits := new(big.Int).Abs(q).String()
+	if scale > 0 {
+		if len(digits) <= scale {
+			digits = strings.Repeat("0", scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+	}
+	if q.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+func add(x []*big.Rat, opts domain.EvaluationOptions) (*big.Rat, map[string]string, error) {
+	v := new(big.Rat).Add(x[0], x[1])
+	return v, decimal(v, opts), nil
+}
+
+func sub(x []*big.Rat, opts domain.EvaluationOptions) (*big.Rat, map[string]string, error) {
+	v := new(big.Rat).Sub(x[0], x[1])
+	return v, decimal(v, opts), nil
+}
+
+func mul(x []*big.Rat, opts domain.EvaluationOptions) (*big.Rat, map[string]string, error) {
+	v := new(big.Rat).Mul(x[0], x[1])
+	return v, decimal(v, opts), nil
+}
+
+func div(x []*big.Rat, opts domain.EvaluationOptions) (*big.Rat, map[string]string, error) {
+	if x[1].Sign() == 0 {
+		return nil, nil, domain.ErrDivisionByZero
+	}
+	v := new(big.Rat).Quo(x[0], x[1])
+	return v, decimal(v, opts), nil
+}
+
+// divMod returns the integer quotient of a / b, rounded as the division
+// mode selects, with the remainder a - quotient·b in the details.
+func divMod(x []*big.Rat, opts domain.EvaluationOptions) (*big.Rat, map[string]string, error) {
+	a, b := x[0], x[1]
+	if b.Sign() == 0 {
+		return nil, nil, domain.ErrDivisionByZero
+	}
+	q := quotient(a, b, opts.Division)
+	r := new(big.Rat).Sub(a, new(big.Rat).Mul(new(big.Rat).SetInt(q), b))
+	return new(big.Rat).SetInt(q), map[string]string{"remainder": format(r)}, nil
+}
+
+// quotient returns the integer quotient of a / b, for a non-zero b, rounded
+// toward zero, toward negative infinity or so that the remainder is not
+// negative.
+func quotient(a, b *big.Rat, mode domain.DivisionMode) *big.Int {
+	q := new(big.Rat).Quo(a, b)
+	// The denominator is positive, so Quo truncates and Div floors.
+	truncated := new(big.Int).Quo(q.Num(), q.Denom())
+	if q.IsInt() {
+		return truncated
+	}
+	floored := new(big.Int).Div(q.Num(), q.Denom())
+	switch mode {
+	case domain.DivisionFloored:
+		return floored
+	case domain.DivisionEuclidean:
+		if b.Sign() > 0 {
+			return floored
+		}
+		return floored.Add(floored, big.NewInt(1))
+	default:
+		return truncated
+	}
+}
@@ -0,0 +1,169 @@
+package rational
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	domain "go-prisma-calculator/internal/domain/models"
+)
+
+func TestRound(t *testing.T) {
+	modes := []domain.RoundingMode{
+		domain.RoundTruncate,
+		domain.RoundFloor,
+		domain.RoundCeiling,
+		domain.RoundHalfUp,
+		domain.RoundHalfEven,
+		domain.RoundHalfAwayFromZero,
+	}
+	tests := []struct {
+		value string
+		scale int
+		// want lists the result of each mode, in the order of modes.
+		want [6]string
+	}{
+		{"7/2", 0, [6]string{"3", "3", "4", "4", "4", "4"}},
+		{"-7/2", 0, [6]string{"-3", "-4", "-3", "-3", "-4", "-4"}},
+		{"5/2", 0, [6]string{"2", "2", "3", "3", "2", "3"}},
+		{"-5/2", 0, [6]string{"-2", "-3", "-2", "-2", "-2", "-3"}},
+		{"2/3", 0, [6]string{"0", "0", "1", "1", "1", "1"}},
+		{"-1/3", 0, [6]string{"0", "-1", "0", "0", "0", "0"}},
+		{"1000/3", 2, [6]string{"333.33", "333.33", "333.34", "333.33", "333.33", "333.33"}},
+		{"-1/3", 2, [6]string{"-0.33", "-0.34", "-0.33", "-0.33", "-0.33", "-0.33"}},
+		{"1/8", 2, [6]string{"0.12", "0.12", "0.13", "0.13", "0.12", "0.13"}},
+		{"-1/8", 2, [6]string{"-0.12", "-0.13", "-0.12", "-0.12", "-0.12", "-0.13"}},
+		{"3/8", 2, [6]string{"0.37", "0.37", "0.38", "0.38", "0.38", "0.38"}},
+		{"-1/200", 2, [6]string{"0.00", "-0.01", "0.00", "0.00", "0.00", "-0.01"}},
+		{"4", 3, [6]string{"4.000", "4.000", "4.000", "4.000", "4.000", "4.000"}},
+		{"1/3", 5, [6]string{"0.33333", "0.33333", "0.33334", "0.33333", "0.33333", "0.33333"}},
+	}
+	for _, tt := range tests {
+		v, ok := new(big.Rat).SetString(tt.value)
+		if !ok {
+			t.Fatalf("invalid test value %q", tt.value)
+		}
+		for i, mode := range modes {
+			if got := round(v, tt.scale, mode); got != tt.want[i] {
+				t.Errorf("round(%s, %d, %s) = %q, want %q", tt.value, tt.scale, mode, got, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	e := &evaluator{maxBits: 64}
+	tests := []struct {
+		in      string
+		want    string
+		wantErr error
+	}{
+		{in: "7/2", want: "7/2"},
+		{in: "-14/4", want: "-7/2"},
+		{in: "-0.125", want: "-1/8"},
+		{in: " +3 ", want: "3"},
+		{in: "1e5", wantErr: domain.ErrInvalidOperands},
+		{in: "1.5/2", wantErr: domain.ErrInvalidOperands},
+		{in: "--1", wantErr: domain.ErrInvalidOperands},
+		{in: "0x10", wantErr: domain.ErrInvalidOperands},
+		{in: "1/", wantErr: domain.ErrInvalidOperands},
+		{in: "", wantErr: domain.ErrInvalidOperands},
+		{in: "1/00", wantErr: domain.ErrDivisionByZero},
+		{in: "99999999999999999999999", wantErr: domain.ErrOperandOutOfRange},
+		{in: strings.Repeat("9", 100), wantErr: domain.ErrOperandOutOfRange},
+	}
+	for _, tt := range tests {
+		v, err := e.parse(tt.in)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("parse(%q) error = %v, want %v", tt.in, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parse(%q) error = %v", tt.in, err)
+			continue
+		}
+		if got := format(v); got != tt.want {
+			t.Errorf("parse(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDivMod(t *testing.T) {
+	e := NewEvaluator(0)
+	tests := []struct {
+		a, b          string
+		mode          domain.DivisionMode
+		quotient, rem string
+	}{
+		{"-7", "2", domain.DivisionTruncated, "-3", "-1"},
+		{"-7", "2", domain.DivisionFloored, "-4", "1"},
+		{"-7", "2", domain.DivisionEuclidean, "-4", "1"},
+		{"7", "-2", domain.DivisionTruncated, "-3", "1"},
+		{"7", "-2", domain.DivisionFloored, "-4", "-1"},
+		{"7", "-2", domain.DivisionEuclidean, "-3", "1"},
+		{"-7", "-2", domain.DivisionTruncated, "3", "-1"},
+		{"-7", "-2", domain.DivisionFloored, "3", "-1"},
+		{"-7", "-2", domain.DivisionEuclidean, "4", "1"},
+		{"6", "-3", domain.DivisionEuclidean, "-2", "0"},
+		{"7/2", "1/3", domain.DivisionFloored, "10", "1/6"},
+	}
+	for _, tt := range tests {
+		res, err := e.Evaluate("divmod", []string{tt.a, tt.b}, domain.EvaluationOptions{Division: tt.mode})
+		if err != nil {
+			t.Errorf("divmod(%s, %s, %s) error = %v", tt.a, tt.b, tt.mode, err)
+			continue
+		}
+		if res.Value != tt.quotient || res.Details["remainder"] != tt.rem {
+			t.Errorf("divmod(%s, %s, %s) = %s rem %s, want %s rem %s", tt.a, tt.b, tt.mode, res.Value, res.Details["remainder"], tt.quotient, tt.rem)
+		}
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	e := NewEvaluator(64)
+	tests := []struct {
+		name     string
+		operands []string
+		wantErr  error
+	}{
+		{"div", []string{"1", "0"}, domain.ErrDivisionByZero},
+		{"divmod", []string{"1", "0/5"}, domain.ErrDivisionByZero},
+		{"mod", []string{"1", "2"}, domain.ErrUnsupportedOperation},
+		{"add", []string{"1"}, domain.ErrInvalidOperands},
+		{"mul", []string{"4294967296", "4294967296"}, domain.ErrOperandOutOfRange},
+	}
+	for _, tt := range tests {
+		if _, err := e.Evaluate(tt.name, tt.operands, domain.EvaluationOptions{}); !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s%v error = %v, want %v", tt.name, tt.operands, err, tt.wantErr)
+		}
+	}
+}
+
+func TestMagnitude(t *testing.T) {
+	e := NewEvaluator(64)
+	tests := []struct {
+		operands []string
+		want     float64
+		wantErr  error
+	}{
+		{[]string{"-7/2", "3"}, 3.5, nil},
+		{[]string{"0.125"}, 0.125, nil},
+		{[]string{"1e5"}, 0, domain.ErrInvalidOperands},
+		{[]string{"1/0"}, 0, domain.ErrDivisionByZero},
+	}
+	for _, tt := range tests {
+		v, err := e.Magnitude(tt.operands)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Magnitude(%v) error = %v, want %v", tt.operands, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || v.Cmp(big.NewFloat(tt.want)) != 0 {
+			t.Errorf("Magnitude(%v) = %v, %v, want %v", tt.operands, v, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,460 @@
+package scientific
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	domain "go-prisma-calculator/internal/domain/models"
+
+	"github.com/shopspring/decimal"
+)
+
+// Limits of the decimal implementations, which would otherwise produce
+// results of unbounded size.
+const (
+	maxDecimalExp       = 1000
+	maxDecimalFactorial = 1000
+	maxRootDegree       = 1_000_000
+)
+
+// guardBits are computed beyond the requested precision and absorb the
+// rounding errors of the series below.
+const guardBits = 64
+
+// decimalEvaluator evaluates the functions with arbitrary-precision
+// decimals. Operands are exact decimals; transcendental functions are
+// computed in binary floating point with guard bits and rounded to the
+// requested number of significant digits.
+type decimalEvaluator struct{}
+
+// NewDecimalEvaluator returns the evaluator of KindDecimal.
+func NewDecimalEvaluator() domain.Evaluator { return decimalEvaluator{} }
+
+func (decimalEvaluator) Kind() domain.Kind { return domain.KindDecimal }
+
+func (decimalEvaluator) Functions() []domain.FunctionSignature {
+	return signatures(domain.KindDecimal)
+}
+
+func (decimalEvaluator) Evaluate(name string, operands []string, opts domain.EvaluationOptions) (*domain.EvaluationResult, error) {
+	f, used, err := prepare(name, operands, opts)
+	if err != nil {
+		return nil, err
+	}
+	if f.exact {
+		used.Precision = 0
+	}
+
+	x := make([]decimal.Decimal, len(operands))
+	canonical := make([]string, len(operands))
+	for i, s := range operands {
+		d, err := parseDecimal(s)
+		if err != nil {
+			return nil, err
+		}
+		x[i], canonical[i] = d, d.String()
+	}
+
+	v, err := f.evalDecimal(x, env{degrees: used.Angle == domain.AngleDegrees, precision: int32(used.Precision)})
+	if err != nil {
+		return nil, named(f, err)
+	}
+	return &domain.EvaluationResult{Operands: canonical, Value: v.String(), Options: used}, nil
+}
+
+func (decimalEvaluator) Magnitude(operands []string) (*big.Float, error) {
+	return domain.LargestMagnitude(operands, func(s string) (*big.Float, error) {
+		d, err := parseDecimal(s)
+		if err != nil {
+			return nil, err
+		}
+		return toFloat(d.Abs(), 64), nil
+	})
+}
+
+// parseDecimal reads a decimal such as "-0.125". Exponents are not
+// accepted, since a short exponent such as "1e999999999" asks for a number
+// with that many digits; the length of the text bounds the digits instead.
+func parseDecimal(s string) (decimal.Decimal, error) {
+	text := strings.TrimSpace(s)
+	if len(text) > maxOperandLength {
+		return decimal.Decimal{}, fmt.Errorf("%w: an operand is longer than %d characters", domain.ErrInvalidOperands, maxOperandLength)
+	}
+	unsigned := strings.TrimLeft(text, "+-")
+	if len(text)-len(unsigned) > 1 || strings.ContainsFunc(unsigned, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	}) {
+		return decimal.Decimal{}, fmt.Errorf("%w: %q is not a decimal number without an exponent", domain.ErrInvalidOperands, s)
+	}
+	d, err := decimal.NewFromString(text)
+	if err != nil {
+		return decimal.Decimal{}, fmt.Errorf("%w: %q is not a decimal number", domain.ErrInvalidOperands, s)
+	}
+	return d, nil
+}
+
+// bits is the binary precision that carries e.precision significant digits.
+func (e env) bits() uint {
+	return uint(math.Ceil(float64(e.precision)*math.Log2(10))) + guardBits
+}
+
+func newFloat(prec uint) *big.Float {
+	return new(big.Float).SetPrec(prec)
+}
+
+func toFloat(d decimal.Decimal, prec uint) *big.Float {
+	v, _, _ := newFloat(prec).Parse(d.String(), 10)
+	return v
+}
+
+// fromFloat rounds v to the significant digits of e.
+func fromFloat(v *big.Float, e env) decimal.Decimal {
+	d, _ := decimal.NewFromString(v.Text('e', int(e.precision)-1))
+	return d
+}
+
+// converged reports whether term no longer changes sum at prec bits.
+func converged(term, sum *big.Float, prec uint) bool {
+	return term.Sign() == 0 || sum.Sign() != 0 && term.MantExp(nil) < sum.MantExp(nil)-int(prec)
+}
+
+// atanhSeries returns atanh(x) for |x| well below 1.
+func atanhSeries(x *big.Float, prec uint) *big.Float {
+	x2 := newFloat(prec).Mul(x, x)
+	power := newFloat(prec).Set(x)
+	sum := newFloat(prec).Set(x)
+	for k := int64(3); ; k += 2 {
+		power.Mul(power, x2)
+		term := newFloat(prec).Quo(power, newFloat(prec).SetInt64(k))
+		sum.Add(sum, term)
+		if converged(term, sum, prec) {
+			return sum
+		}
+	}
+}
+
+// atanSeries returns atan(x) for |x| well below 1.
+func atanSeries(x *big.Float, prec uint) *big.Float {
+	x2 := newFloat(prec).Mul(x, x)
+	x2.Neg(x2)
+	power := newFloat(prec).Set(x)
+	sum := newFloat(prec).Set(x)
+	for k := int64(3); ; k += 2 {
+		power.Mul(power, x2)
+		term := newFloat(prec).Quo(power, newFloat(prec).SetInt64(k))
+		sum.Add(sum, term)
+		if converged(term, sum, prec) {
+			return sum
+		}
+	}
+}
+
+// ln2 is 2·atanh(1/3).
+func ln2(prec uint) *big.Float {
+	third := newFloat(prec).Quo(newFloat(prec).SetInt64(1), newFloat(prec).SetInt64(3))
+	v := atanhSeries(third, prec)
+	return v.Add(v, v)
+}
+
+// pi uses Machin's formula, 16·atan(1/5) - 4·atan(1/239).
+func pi(prec uint) *big.Float {
+	one := newFloat(prec).SetInt64(1)
+	a := atanSeries(newFloat(prec).Quo(one, newFloat(prec).SetInt64(5)), prec)
+	b := atanSeries(newFloat(prec).Quo(one, newFloat(prec).SetInt64(239)), prec)
+	a.Mul(a, newFloat(prec).SetInt64(16))
+	b.Mul(b, newFloat(prec).SetInt64(4))
+	return a.Sub(a, b)
+}
+
+// lnFloat returns ln(x) for x > 0 by splitting x into m·2^e with m in
+// [0.5, 1) and ln(m) = 2·atanh((m-1)/(m+1)).
+func lnFloat(x *big.Float, prec uint) *big.Float {
+	m := newFloat(prec)
+	exp := x.MantExp(m)
+	one := newFloat(prec).SetInt64(1)
+	z := newFloat(prec).Quo(newFloat(prec).Sub(m, one), newFloat(prec).Add(m, one))
+	v := atanhSeries(z, prec)
+	v.Add(v, v)
+	return v.Add(v, newFloat(prec).Mul(ln2(prec), newFloat(prec).SetInt64(int64(exp))))
+}
+
+// expHalvings is how often the argument of exp is halved before the
+// series, whose result is then squared as often.
+const expHalvings = 16
+
+// expFloat returns e^x by splitting x into n·ln2 + r.
+func expFloat(x *big.Float, prec uint) *big.Float {
+	p := prec + expHalvings + 16
+	l2 := ln2(p)
+	n, _ := newFloat(p).Quo(x, l2).Int64()
+	r := newFloat(p).Sub(x, newFloat(p).Mul(l2, newFloat(p).SetInt64(n)))
+	r.SetMantExp(r, -expHalvings)
+
+	term := newFloat(p).SetInt64(1)
+	sum := newFloat(p).SetInt64(1)
+	for k := int64(1); ; k++ {
+		term.Mul(term, r)
+		term.Quo(term, newFloat(p).SetInt64(k))
+		sum.Add(sum, term)
+		if converged(term, sum, p) {
+			break
+		}
+	}
+	for range expHalvings {
+		sum.Mul(sum, sum)
+	}
+	return sum.SetMantExp(sum, int(n))
+}
+
+// sinCos returns the sine and cosine of x in radians.
+func sinCos(x *big.Float, prec uint) (*big.Float, *big.Float) {
+	// Reducing a large x modulo 2π needs as many more bits as x has.
+	p := prec
+	if exp := x.MantExp(nil); exp > 0 {
+		p += uint(exp)
+	}
+	twoPi := pi(p)
+	twoPi.Add(twoPi, twoPi)
+	k, _ := newFloat(p).Quo(x, twoPi).Int(nil)
+	r := newFloat(p).Sub(x, newFloat(p).Mul(twoPi, newFloat(p).SetInt(k)))
+
+	r2 := newFloat(p).Mul(r, r)
+	r2.Neg(r2)
+	sin, sinTerm := newFloat(p).Set(r), newFloat(p).Set(r)
+	cos, cosTerm := newFloat(p).SetInt64(1), newFloat(p).SetInt64(1)
+	for n := int64(1); ; n++ {
+		cosTerm.Mul(cosTerm, r2)
+		cosTerm.Quo(cosTerm, newFloat(p).SetInt64((2*n-1)*(2*n)))
+		cos.Add(cos, cosTerm)
+		sinTerm.Mul(sinTerm, r2)
+		sinTerm.Quo(sinTerm, newFloat(p).SetInt64((2*n)*(2*n+1)))
+		sin.Add(sin, sinTerm)
+		if sinTerm.Sign() == 0 || sinTerm.MantExp(nil) < -int(p) {
+			return sin, cos
+		}
+	}
+}
+
+// atanFloat returns atan(x) in radians.
+func atanFloat(x *big.Float, prec uint) *big.Float {
+	one := newFloat(prec).SetInt64(1)
+	ax := newFloat(prec).Abs(x)
+	invert := ax.Cmp(one) > 0
+	if invert {
+		ax.Quo(one, ax)
+	}
+	// atan(x) = 2·atan(x / (1 + sqrt(1 + x²))) brings x below 0.1.
+	const halvings = 3
+	for range halvings {
+		s := newFloat(prec).Mul(ax, ax)
+		s.Add(s, one)
+		s.Sqrt(s)
+		ax.Quo(ax, s.Add(s, one))
+	}
+	v := atanSeries(ax, prec)
+	v.SetMantExp(v, halvings)
+	if invert {
+		half := pi(prec)
+		half.SetMantExp(half, -1)
+		v.Sub(half, v)
+	}
+	if x.Sign() < 0 {
+		v.Neg(v)
+	}
+	return v
+}
+
+var (
+	ninety       = decimal.NewFromInt(90)
+	threeSixty   = decimal.NewFromInt(360)
+	oneEighty    = decimal.NewFromInt(180)
+	decimalOne   = decimal.NewFromInt(1)
+	maxExpDigits = decimal.NewFromInt(maxDecimalExp)
+)
+
+// decimalQuadrant is quadrant for exact decimals.
+func decimalQuadrant(degrees decimal.Decimal) (int, bool) {
+	if !degrees.Mod(ninety).IsZero() {
+		return 0, false
+	}
+	q := degrees.Mod(threeSixty).Div(ninety).IntPart()
+	return int((q + 4) % 4), true
+}
+
+// decimalRadians converts an angle operand to radians.
+func decimalRadians(x decimal.Decimal, e env, prec uint) *big.Float {
+	if !e.degrees {
+		return toFloat(x, prec)
+	}
+	v := toFloat(x.Mod(threeSixty), prec)
+	v.Mul(v, pi(prec))
+	return v.Quo(v, toFloat(oneEighty, prec))
+}
+
+// decimalAngle converts a result in radians to the unit of e.
+func decimalAngle(v *big.Float, e env, prec uint) decimal.Decimal {
+	if e.degrees {
+		v.Mul(v, toFloat(oneEighty, prec))
+		v.Quo(v, pi(prec))
+	}
+	return fromFloat(v, e)
+}
+
+func decimalSin(x []decimal.Decimal, e env) (decimal.Decimal, error) {
+	if q, ok := decimalQuadrant(x[0]); e.degrees && ok {
+		return decimal.NewFromInt([]int64{0, 1, 0, -1}[q]), nil
+	}
+	sin, _ := sinCos(decimalRadians(x[0], e, e.bits()), e.bits())
+	return fromFloat(sin, e), nil
+}
+
+func decimalCos(x []decimal.Decimal, e env) (decimal.Decimal, error) {
+	if q, ok := decimalQuadrant(x[0]); e.degrees && ok {
+		return decimal.NewFromInt([]int64{1, 0, -1, 0}[q]), nil
+	}
+	_, cos := sinCos(decimalRadians(x[0], e, e.bits()), e.bits())
+	return fromFloat(cos, e), nil
+}
+
+func decimalTan(x []decimal.Decimal, e env) (decimal.Decimal, error) {
+	if q, ok := decimalQuadrant(x[0]); e.degrees && ok {
+		if q%2 == 1 {
+			return decimal.Zero, undefined("the tangent of an odd multiple of 90 degrees is undefined")
+		}
+		return decimal.Zero, nil
+	}
+	sin, cos := sinCos(decimalRadians(x[0], e, e.bits()), e.bits())
+	return fromFloat(sin.Quo(sin, cos), e), nil
+}
+
+func decimalAsin(x []decimal.Decimal, e env) (decimal.Decimal, error) {
+	if x[0].Abs().GreaterThan(decimalOne) {
+		return decimal.Zero, undefined("x must be between -1 and 1")
+	}
+	return decimalAngle(asinFloat(x[0], e.bits()), e, e.bits()), nil
+}
+
+func decimalAcos(x []decimal.Decimal, e env) (decimal.Decimal, error) {
+	if x[0].Abs().GreaterThan(decimalOne) {
+		return decimal.Zero, undefined("x must be between -1 and 1")
+	}
+	half := pi(e.bits())
+	half.SetMantExp(half, -1)
+	return decimalAngle(half.Sub(half, asinFloat(x[0], e.bits())), e, e.bits()), nil
+}
+
+// asinFloat returns asin(x) = atan(x / sqrt(1 - x²)) for |x| <= 1.
+func asinFloat(x decimal.Decimal, prec uint) *big.Float {
+	return asinBig(toFloat(x, prec), prec)
+}
+
+func asinBig(x *big.Float, prec uint) *big.Float {
+	one := newFloat(prec).SetInt64(1)
+	if newFloat(prec).Abs(x).Cmp(one) == 0 {
+		half := pi(prec)
+		half.SetMantExp(half, -1)
+		if x.Sign() < 0 {
+			half.Neg(half)
+		}
+		return half
+	}
+	v := newFloat(prec).Set(x)
+	s := newFloat(prec).Mul(v, v)
+	s.Sub(newFloat(prec).SetInt64(1), s)
+	s.Sqrt(s)
+	return atanFloat(v.Quo(v, s), prec)
+}
+
+func decimalAtan(x []decimal.Decimal, e env) (decimal.Decimal, error) {
+	return decimalAngle(atanFloat(toFloat(x[0], e.bits()), e.bits()), e, e.bits()), nil
+}
+
+func decimalLn(x []decimal.Decimal, e env) (decimal.Decimal, error) {
+	if !x[0].IsPositive() {
+		return decimal.Zero, undefined("the logarithm of a non-positive number is undefined")
+	}
+	return fromFloat(lnFloat(toFloat(x[0], e.bits()), e.bits()), e), nil
+}
+
+func decimalLog10(x []decimal.Decimal, e env) (decimal.Decimal, error) {
+	return decimalLog([]decimal.Decimal{x[0], decimal.NewFromInt(10)}, e)
+}
+
+func decimalLog(x []decimal.Decimal, e env) (decimal.Decimal, error) {
+	switch {
+	case !x[0].IsPositive():
+		return decimal.Zero, undefined("the logarithm of a non-positive number is undefined")
+	case !x[1].IsPositive() || x[1].Equal(decimalOne):
+		return decimal.Zero, undefined("the base must be positive and not 1")
+	}
+	prec := e.bits()
+	v := lnFloat(toFloat(x[0], prec), prec)
+	return fromFloat(v.Quo(v, lnFloat(toFloat(x[1], prec), prec)), e), nil
+}
+
+func decimalSqrt(x []decimal.Decimal, e env) (decimal.Decimal, error) {
+	if x[0].IsNegative() {
+		return decimal.Zero, undefined("the square root of a negative number is undefined")
+	}
+	v := toFloat(x[0], e.bits())
+	return fromFloat(v.Sqrt(v), e), nil
+}
+
+func decimalRoot(x []decimal.Decimal, e env) (decimal.Decimal, error) {
+	v, n := x[0], x[1]
+	switch {
+	case !n.IsInteger() || n.LessThan(decimalOne):
+		return decimal.Zero, undefined("n must be a positive integer")
+	case n.GreaterThan(decimal.NewFromInt(maxRootDegree)):
+		return decimal.Zero, undefined("n must be at most %d", maxRootDegree)
+	case v.IsNegative() && n.Mod(decimal.NewFromInt(2)).IsZero():
+		return decimal.Zero, undefined("an even root of a negative number is undefined")
+	case v.IsZero():
+		return decimal.Zero, nil
+	}
+	prec := e.bits()
+	r := lnFloat(toFloat(v.Abs(), prec), prec)
+	r = expFloat(r.Quo(r, toFloat(n, prec)), prec)
+	if v.IsNegative() {
+		r.Neg(r)
+	}
+	return fromFloat(r, e), nil
+}
+
+func decimalExp(x []decimal.Decimal, e env) (decimal.Decimal, error) {
+	if x[0].Abs().GreaterThan(maxExpDigits) {
+		return decimal.Zero, undefined("x must be between -%d and %d in decimal mode", maxDecimalExp, maxDecimalExp)
+	}
+	return fromFloat(expFloat(toFloat(x[0], e.bits()), e.bits()), e), nil
+}
+
+func decimalFactorial(x []decimal.Decimal, _ env) (decimal.Decimal, error) {
+	n := x[0]
+	switch {
+	case !n.IsInteger() || n.IsNegative():
+		return decimal.Zero, undefined("n must be a non-negative integer")
+	case n.GreaterThan(decimal.NewFromInt(maxDecimalFactorial)):
+		return decimal.Zero, undefined("n must be at most %d", maxDecimalFactorial)
+	}
+	return decimal.NewFromBigInt(new(big.Int).MulRange(1, n.IntPart()), 0), nil
+}
+
+func decimalGCD(x []decimal.Decimal, _ env) (decimal.Decimal, error) {
+	if !x[0].IsInteger() || !x[1].IsInteger() {
+		return decimal.Zero, undefined("operands must be integers")
+	}
+	return decimal.NewFromBigInt(gcd(x[0].BigInt(), x[1].BigInt()), 0), nil
+}
+
+func decimalLCM(x []decimal.Decimal, _ env) (decimal.Decimal, error) {
+	if !x[0].IsInteger() || !x[1].IsInteger() {
+		return decimal.Zero, undefined("operands must be integers")
+	}
+	return decimal.NewFromBigInt(lcm(x[0].BigInt(), x[1].BigInt()), 0), nil
+}
+
+func decimalAbs(x []decimal.Decimal, _ env) (decimal.Decimal, error) {
+	return x[0].Abs(), nil
+}
@@ -0,0 +1,303 @@
+package scientific
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	domain "go-prisma-calculator/internal/domain/models"
+)
+
+// maxExactFloat is the largest integer below which every integer is an
+// exact float64.
+const maxExactFloat = 1 << 53
+
+// floatEvaluator evaluates the functions in float64.
+type floatEvaluator struct{}
+
+// NewFloatEvaluator returns the evaluator of KindFloat.
+func NewFloatEvaluator() domain.Evaluator { return floatEvaluator{} }
+
+func (floatEvaluator) Kind() domain.Kind { return domain.KindFloat }
+
+func (floatEvaluator) Functions() []domain.FunctionSignature {
+	return signatures(domain.KindFloat)
+}
+
+func (floatEvaluator) Evaluate(name string, operands []string, opts domain.EvaluationOptions) (*domain.EvaluationResult, error) {
+	f, used, err := prepare(name, operands, opts)
+	if err != nil {
+		return nil, err
+	}
+	used.Precision = 0
+
+	x := make([]float64, len(operands))
+	canonical := make([]string, len(operands))
+	for i, s := range operands {
+		v, err := parseFloat(s)
+		if err != nil {
+			return nil, err
+		}
+		x[i], canonical[i] = v, formatFloat(v)
+	}
+
+	v, err := f.evalFloat(x, env{degrees: used.Angle == domain.AngleDegrees})
+	if err != nil {
+		return nil, named(f, err)
+	}
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return nil, named(f, undefined("the result does not fit in a float64"))
+	}
+	return &domain.EvaluationResult{Operands: canonical, Value: formatFloat(v), Options: used}, nil
+}
+
+func (floatEvaluator) Magnitude(operands []string) (*big.Float, error) {
+	return domain.LargestMagnitude(operands, func(s string) (*big.Float, error) {
+		v, err := parseFloat(s)
+		if err != nil {
+			return nil, err
+		}
+		return big.NewFloat(math.Abs(v)), nil
+	})
+}
+
+// parseFloat reads a finite float64 operand.
+func parseFloat(s string) (float64, error) {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || math.IsInf(v, 0) || math.IsNaN(v) || len(s) > maxOperandLength {
+		return 0, fmt.Errorf("%w: %q is not a finite number", domain.ErrInvalidOperands, s)
+	}
+	return v, nil
+}
+
+func formatFloat(v float64) string {
+	if v == 0 {
+		// Drop the sign of negative zero.
+		return "0"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// quadrant returns the quadrant of an angle in degrees that is an exact
+// multiple of 90 degrees, so that sin(180°) is 0 rather than 1.2e-16.
+func quadrant(degrees float64) (int, bool) {
+	q := degrees / 90
+	if q != math.Trunc(q) || math.Abs(q) >= maxExactFloat {
+		return 0, false
+	}
+	return int(math.Mod(math.Mod(q, 4)+4, 4)), true
+}
+
+// degreePrecision is the binary precision of trigonometry in degrees. Going
+// through radians in float64 would round twice, e.g. sin(30°) to
+// 0.49999999999999994, so it is computed with more bits and rounded once.
+const degreePrecision = 128
+
+// degreeSinCos returns the sine and cosine of an angle in degrees.
+func degreeSinCos(degrees float64) (sin, cos *big.Float) {
+	v := newFloat(degreePrecision).SetFloat64(math.Mod(degrees, 360))
+	v.Mul(v, pi(degreePrecision))
+	v.Quo(v, newFloat(degreePrecision).SetInt64(180))
+	return sinCos(v, degreePrecision)
+}
+
+// toDegrees converts an angle in radians to degrees, rounded once.
+func toDegrees(radians *big.Float) float64 {
+	radians.Mul(radians, newFloat(degreePrecision).SetInt64(180))
+	v, _ := radians.Quo(radians, pi(degreePrecision)).Float64()
+	return v
+}
+
+func floatSin(x []float64, e env) (float64, error) {
+	if !e.degrees {
+		return math.Sin(x[0]), nil
+	}
+	if q, ok := quadrant(x[0]); ok {
+		return []float64{0, 1, 0, -1}[q], nil
+	}
+	sin, _ := degreeSinCos(x[0])
+	v, _ := sin.Float64()
+	return v, nil
+}
+
+func floatCos(x []float64, e env) (float64, error) {
+	if !e.degrees {
+		return math.Cos(x[0]), nil
+	}
+	if q, ok := quadrant(x[0]); ok {
+		return []float64{1, 0, -1, 0}[q], nil
+	}
+	_, cos := degreeSinCos(x[0])
+	v, _ := cos.Float64()
+	return v, nil
+}
+
+func floatTan(x []float64, e env) (float64, error) {
+	if !e.degrees {
+		return math.Tan(x[0]), nil
+	}
+	if q, ok := quadrant(x[0]); ok {
+		if q%2 == 1 {
+			return 0, undefined("the tangent of an odd multiple of 90 degrees is undefined")
+		}
+		return 0, nil
+	}
+	sin, cos := degreeSinCos(x[0])
+	v, _ := sin.Quo(sin, cos).Float64()
+	return v, nil
+}
+
+func floatAsin(x []float64, e env) (float64, error) {
+	if math.Abs(x[0]) > 1 {
+		return 0, undefined("x must be between -1 and 1")
+	}
+	if !e.degrees {
+		return math.Asin(x[0]), nil
+	}
+	return toDegrees(asinBig(newFloat(degreePrecision).SetFloat64(x[0]), degreePrecision)), nil
+}
+
+func floatAcos(x []float64, e env) (float64, error) {
+	if math.Abs(x[0]) > 1 {
+		return 0, undefined("x must be between -1 and 1")
+	}
+	if !e.degrees {
+		return math.Acos(x[0]), nil
+	}
+	v, _ := floatAsin(x, e)
+	return 90 - v, nil
+}
+
+func floatAtan(x []float64, e env) (float64, error) {
+	if !e.degrees {
+		return math.Atan(x[0]), nil
+	}
+	return toDegrees(atanFloat(newFloat(degreePrecision).SetFloat64(x[0]), degreePrecision)), nil
+}
+
+func floatLn(x []float64, _ env) (float64, error) {
+	if x[0] <= 0 {
+		return 0, undefined("the logarithm of a non-positive number is undefined")
+	}
+	return math.Log(x[0]), nil
+}
+
+func floatLog10(x []float64, _ env) (float64, error) {
+	if x[0] <= 0 {
+		return 0, undefined("the logarithm of a non-positive number is undefined")
+	}
+	return math.Log10(x[0]), nil
+}
+
+func floatLog(x []float64, _ env) (float64, error) {
+	switch {
+	case x[0] <= 0:
+		return 0, undefined("the logarithm of a non-positive number is undefined")
+	case x[1] <= 0 || x[1] == 1:
+		return 0, undefined("the base must be positive and not 1")
+	case x[1] == 2:
+		return math.Log2(x[0]), nil
+	case x[1] == 10:
+		return math.Log10(x[0]), nil
+	}
+	return math.Log(x[0]) / math.Log(x[1]), nil
+}
+
+func floatSqrt(x []float64, _ env) (float64, error) {
+	if x[0] < 0 {
+		return 0, undefined("the square root of a negative number is undefined")
+	}
+	return math.Sqrt(x[0]), nil
+}
+
+func floatRoot(x []float64, _ env) (float64, error) {
+	v, n := x[0], x[1]
+	switch {
+	case n < 1 || n != math.Trunc(n):
+		return 0, undefined("n must be a positive integer")
+	case v < 0 && math.Mod(n, 2) == 0:
+		return 0, undefined("an even root of a negative number is undefined")
+	case n == 2:
+		return math.Sqrt(v), nil
+	case n == 3:
+		return math.Cbrt(v), nil
+	}
+	r := math.Copysign(math.Pow(math.Abs(v), 1/n), v)
+	// Prefer the exact root of perfect powers, e.g. 4 for root(1024, 5).
+	if rounded := math.Round(r); math.Pow(rounded, n) == v {
+		return rounded, nil
+	}
+	return r, nil
+}
+
+func floatExp(x []float64, _ env) (float64, error) {
+	return math.Exp(x[0]), nil
+}
+
+// maxFloatFactorial is the largest n whose factorial fits in a float64.
+const maxFloatFactorial = 170
+
+func floatFactorial(x []float64, _ env) (float64, error) {
+	n := x[0]
+	switch {
+	case n < 0 || n != math.Trunc(n):
+		return 0, undefined("n must be a non-negative integer")
+	case n > maxFloatFactorial:
+		return 0, undefined("n must be at most %d in float mode", maxFloatFactorial)
+	}
+	v := 1.0
+	for i := 2.0; i <= n; i++ {
+		v *= i
+	}
+	return v, nil
+}
+
+func floatGCD(x []float64, _ env) (float64, error) {
+	a, b, err := floatIntegers(x)
+	if err != nil {
+		return 0, err
+	}
+	v, _ := new(big.Float).SetInt(gcd(a, b)).Float64()
+	return v, nil
+}
+
+func floatLCM(x []float64, _ env) (float64, error) {
+	a, b, err := floatIntegers(x)
+	if err != nil {
+		return 0, err
+	}
+	v, _ := new(big.Float).SetInt(lcm(a, b)).Float64()
+	return v, nil
+}
+
+// floatIntegers converts two operands that must be exact integers.
+func floatIntegers(x []float64) (*big.Int, *big.Int, error) {
+	for _, v := range x {
+		if v != math.Trunc(v) || math.Abs(v) >= maxExactFloat {
+			return nil, nil, undefined("operands must be integers below 2^53 in float mode")
+		}
+	}
+	return big.NewInt(int64(x[0])), big.NewInt(int64(x[1])), nil
+}
+
+func floatAbs(x []float64, _ env) (float64, error) {
+	return math.Abs(x[0]), nil
+}
+
+// gcd returns the non-negative greatest common divisor of a and b.
+func gcd(a, b *big.Int) *big.Int {
+	return new(big.Int).GCD(nil, nil, new(big.Int).Abs(a), new(big.Int).Abs(b))
+}
+
+// lcm returns the non-negative least common multiple of a and b; it is 0
+// when either is 0.
+func lcm(a, b *big.Int) *big.Int {
+	if a.Sign() == 0 || b.Sign() == 0 {
+		return new(big.Int)
+	}
+	v := new(big.Int).Mul(a, b)
+	v.Abs(v)
+	return v.Quo(v, gcd(a, b))
+}
@@ -0,0 +1,161 @@
+// Package scientific provides the scientific functions of the calculator:
+// trigonometry and its inverses, logarithms, roots, exp, factorial, gcd,
+// lcm and abs. Every function has a float64 implementation and an
+// arbitrary-precision decimal one, offered by two evaluators that are
+// registered with the evaluator registry through the "evaluators" fx group.
+package scientific
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	domain "go-prisma-calculator/internal/domain/models"
+
+	"github.com/shopspring/decimal"
+)
+
+// angleUse tells how a function relates to angles.
+type angleUse int
+
+const (
+	noAngle angleUse = iota
+	// angleIn functions take an angle.
+	angleIn
+	// angleOut functions return an angle.
+	angleOut
+)
+
+// env carries the options of an evaluation to the implementations.
+type env struct {
+	degrees bool
+	// precision is the number of significant digits of decimal results.
+	precision int32
+}
+
+// function is a scientific function with both implementations.
+type function struct {
+	name  string
+	arity int
+	doc   domain.OperationDoc
+	angle angleUse
+	// exact decimal results are not rounded to the precision.
+	exact       bool
+	evalFloat   func(x []float64, e env) (float64, error)
+	evalDecimal func(x []decimal.Decimal, e env) (decimal.Decimal, error)
+}
+
+// functions is the scientific function set, ordered by name.
+var functions = []function{
+	{name: "abs", arity: 1, exact: true, evalFloat: floatAbs, evalDecimal: decimalAbs,
+		doc: doc("Absolute value.", "x")},
+	{name: "acos", arity: 1, angle: angleOut, evalFloat: floatAcos, evalDecimal: decimalAcos,
+		doc: doc("Inverse cosine; x must be between -1 and 1.", "x")},
+	{name: "asin", arity: 1, angle: angleOut, evalFloat: floatAsin, evalDecimal: decimalAsin,
+		doc: doc("Inverse sine; x must be between -1 and 1.", "x")},
+	{name: "atan", arity: 1, angle: angleOut, evalFloat: floatAtan, evalDecimal: decimalAtan,
+		doc: doc("Inverse tangent.", "x")},
+	{name: "cos", arity: 1, angle: angleIn, evalFloat: floatCos, evalDecimal: decimalCos,
+		doc: doc("Cosine.", "angle")},
+	{name: "exp", arity: 1, evalFloat: floatExp, evalDecimal: decimalExp,
+		doc: doc("e raised to x.", "x")},
+	{name: "factorial", arity: 1, exact: true, evalFloat: floatFactorial, evalDecimal: decimalFactorial,
+		doc: doc("Factorial of a non-negative integer.", "n")},
+	{name: "gcd", arity: 2, exact: true, evalFloat: floatGCD, evalDecimal: decimalGCD,
+		doc: commutative(doc("Greatest common divisor of two integers.", "a", "b"))},
+	{name: "lcm", arity: 2, exact: true, evalFloat: floatLCM, evalDecimal: decimalLCM,
+		doc: commutative(doc("Least common multiple of two integers.", "a", "b"))},
+	{name: "ln", arity: 1, evalFloat: floatLn, evalDecimal: decimalLn,
+		doc: doc("Natural logarithm of a positive number.", "x")},
+	{name: "log", arity: 2, evalFloat: floatLog, evalDecimal: decimalLog,
+		doc: doc("Logarithm of a positive number to a positive base other than 1.", "x", "base")},
+	{name: "log10", arity: 1, evalFloat: floatLog10, evalDecimal: decimalLog10,
+		doc: doc("Base-10 logarithm of a positive number.", "x")},
+	{name: "root", arity: 2, evalFloat: floatRoot, evalDecimal: decimalRoot,
+		doc: doc("The n-th root of x; n is a positive integer and must be odd for negative x.", "x", "n")},
+	{name: "sin", arity: 1, angle: angleIn, evalFloat: floatSin, evalDecimal: decimalSin,
+		doc: doc("Sine.", "angle")},
+	{name: "sqrt", arity: 1, evalFloat: floatSqrt, evalDecimal: decimalSqrt,
+		doc: doc("Square root of a non-negative number.", "x")},
+	{name: "tan", arity: 1, angle: angleIn, evalFloat: floatTan, evalDecimal: decimalTan,
+		doc: doc("Tangent; undefined at odd multiples of 90 degrees.", "angle")},
+}
+
+func doc(description string, operands ...string) domain.OperationDoc {
+	return domain.OperationDoc{Description: description, Operands: operands}
+}
+
+func commutative(d domain.OperationDoc) domain.OperationDoc {
+	d.Commutative = true
+	return d
+}
+
+func lookup(name string) (*function, error) {
+	i := slices.IndexFunc(functions, func(f function) bool { return f.name == name })
+	if i < 0 {
+		return nil, fmt.Errorf("%w: %q", domain.ErrUnsupportedOperation, name)
+	}
+	return &functions[i], nil
+}
+
+// signatures describes the functions for an evaluator of kind.
+func signatures(kind domain.Kind) []domain.FunctionSignature {
+	sigs := make([]domain.FunctionSignature, 0, len(functions))
+	for _, f := range functions {
+		sig := domain.FunctionSignature{Kind: kind, Name: f.name, Arity: f.arity, OperationDoc: f.doc, Options: []string{}}
+		if f.angle != noAngle {
+			sig.Options = append(sig.Options, "angle")
+		}
+		if kind == domain.KindDecimal && !f.exact {
+			sig.Options = append(sig.Options, "precision")
+		}
+		sigs = append(sigs, sig)
+	}
+	return sigs
+}
+
+// prepare looks up a function, checks the operand count and options and
+// returns the options the function uses.
+func prepare(name string, operands []string, opts domain.EvaluationOptions) (*function, domain.EvaluationOptions, error) {
+	f, err := lookup(name)
+	if err != nil {
+		return nil, opts, err
+	}
+	if len(operands) != f.arity {
+		return nil, opts, fmt.Errorf("%w: %s takes %d operands, got %d", domain.ErrInvalidOperands, name, f.arity, len(operands))
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, opts, err
+	}
+
+	var used domain.EvaluationOptions
+	if f.angle != noAngle {
+		used.Angle = opts.Angle
+		if used.Angle == "" {
+			used.Angle = domain.AngleRadians
+		}
+	}
+	used.Precision = opts.Precision
+	if used.Precision == 0 {
+		used.Precision = domain.DefaultPrecision
+	}
+	return f, used, nil
+}
+
+// maxOperandLength bounds the text of an operand.
+const maxOperandLength = 1000
+
+// undefined reports operands outside the domain of the function being
+// evaluated, which named fills in.
+func undefined(format string, args ...any) error {
+	return &domain.DomainError{Reason: fmt.Sprintf(format, args...)}
+}
+
+// named sets the function of a DomainError returned by an implementation.
+func named(f *function, err error) error {
+	var de *domain.DomainError
+	if errors.As(err, &de) && de.Function == "" {
+		de.Function = f.name
+	}
+	return err
+}
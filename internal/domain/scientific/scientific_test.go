@@ -0,0 +1,161 @@
+package scientific
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	domain "go-prisma-calculator/internal/domain/models"
+)
+
+func TestDomainErrors(t *testing.T) {
+	degrees := domain.EvaluationOptions{Angle: domain.AngleDegrees}
+	tests := []struct {
+		name     string
+		operands []string
+		opts     domain.EvaluationOptions
+	}{
+		{"sqrt", []string{"-1"}, domain.EvaluationOptions{}},
+		{"ln", []string{"0"}, domain.EvaluationOptions{}},
+		{"ln", []string{"-2"}, domain.EvaluationOptions{}},
+		{"log10", []string{"0"}, domain.EvaluationOptions{}},
+		{"log", []string{"-1", "10"}, domain.EvaluationOptions{}},
+		{"log", []string{"8", "1"}, domain.EvaluationOptions{}},
+		{"log", []string{"8", "0"}, domain.EvaluationOptions{}},
+		{"asin", []string{"1.5"}, domain.EvaluationOptions{}},
+		{"acos", []string{"-1.01"}, domain.EvaluationOptions{}},
+		{"tan", []string{"90"}, degrees},
+		{"tan", []string{"-270"}, degrees},
+		{"root", []string{"-8", "2"}, domain.EvaluationOptions{}},
+		{"root", []string{"8", "0"}, domain.EvaluationOptions{}},
+		{"root", []string{"8", "1.5"}, domain.EvaluationOptions{}},
+		{"factorial", []string{"-1"}, domain.EvaluationOptions{}},
+		{"factorial", []string{"2.5"}, domain.EvaluationOptions{}},
+		{"factorial", []string{"1001"}, domain.EvaluationOptions{}},
+		{"gcd", []string{"1.5", "3"}, domain.EvaluationOptions{}},
+		{"lcm", []string{"4", "0.5"}, domain.EvaluationOptions{}},
+		{"exp", []string{"1001"}, domain.EvaluationOptions{}},
+	}
+	for _, ev := range []domain.Evaluator{NewFloatEvaluator(), NewDecimalEvaluator()} {
+		for _, tt := range tests {
+			_, err := ev.Evaluate(tt.name, tt.operands, tt.opts)
+			var de *domain.DomainError
+			if !errors.Is(err, domain.ErrOutOfDomain) || !errors.As(err, &de) {
+				t.Errorf("%s %s%v error = %v, want a domain error", ev.Kind(), tt.name, tt.operands, err)
+				continue
+			}
+			if de.Function != tt.name {
+				t.Errorf("%s %s%v error names function %q", ev.Kind(), tt.name, tt.operands, de.Function)
+			}
+		}
+	}
+}
+
+func TestDomainBoundaries(t *testing.T) {
+	degrees := domain.EvaluationOptions{Angle: domain.AngleDegrees}
+	tests := []struct {
+		name     string
+		operands []string
+		opts     domain.EvaluationOptions
+		want     string
+	}{
+		{"sqrt", []string{"0"}, domain.EvaluationOptions{}, "0"},
+		{"asin", []string{"1"}, degrees, "90"},
+		{"acos", []string{"-1"}, degrees, "180"},
+		{"tan", []string{"180"}, degrees, "0"},
+		{"sin", []string{"30"}, degrees, "0.5"},
+		{"root", []string{"-8", "3"}, domain.EvaluationOptions{}, "-2"},
+		{"log", []string{"8", "2"}, domain.EvaluationOptions{}, "3"},
+		{"factorial", []string{"0"}, domain.EvaluationOptions{}, "1"},
+		{"gcd", []string{"-12", "18"}, domain.EvaluationOptions{}, "6"},
+		{"lcm", []string{"0", "5"}, domain.EvaluationOptions{}, "0"},
+	}
+	for _, ev := range []domain.Evaluator{NewFloatEvaluator(), NewDecimalEvaluator()} {
+		for _, tt := range tests {
+			res, err := ev.Evaluate(tt.name, tt.operands, tt.opts)
+			if err != nil {
+				t.Errorf("%s %s%v error = %v", ev.Kind(), tt.name, tt.operands, err)
+				continue
+			}
+			if res.Value != tt.want {
+				t.Errorf("%s %s%v = %s, want %s", ev.Kind(), tt.name, tt.operands, res.Value, tt.want)
+			}
+		}
+	}
+}
+
+func TestFloatFactorialLimit(t *testing.T) {
+	if _, err := NewFloatEvaluator().Evaluate("factorial", []string{"170"}, domain.EvaluationOptions{}); err != nil {
+		t.Errorf("factorial(170) error = %v", err)
+	}
+	if _, err := NewFloatEvaluator().Evaluate("factorial", []string{"171"}, domain.EvaluationOptions{}); !errors.Is(err, domain.ErrOutOfDomain) {
+		t.Errorf("factorial(171) error = %v, want %v", err, domain.ErrOutOfDomain)
+	}
+}
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "-0.125", want: "-0.125"},
+		{in: " +42 ", want: "42"},
+		{in: ".5", want: "0.5"},
+		{in: "1e5", wantErr: true},
+		{in: "1E999999999", wantErr: true},
+		{in: "--1", wantErr: true},
+		{in: "+-1", wantErr: true},
+		{in: "1-", wantErr: true},
+		{in: "0x10", wantErr: true},
+		{in: "1.2.3", wantErr: true},
+		{in: "", wantErr: true},
+		{in: "NaN", wantErr: true},
+		{in: strings.Repeat("9", maxOperandLength), want: strings.Repeat("9", maxOperandLength)},
+		{in: strings.Repeat("9", maxOperandLength+1), wantErr: true},
+	}
+	for _, tt := range tests {
+		d, err := parseDecimal(tt.in)
+		if tt.wantErr {
+			if !errors.Is(err, domain.ErrInvalidOperands) {
+				t.Errorf("parseDecimal(%.20q) error = %v, want %v", tt.in, err, domain.ErrInvalidOperands)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseDecimal(%.20q) error = %v", tt.in, err)
+			continue
+		}
+		if got := d.String(); got != tt.want {
+			t.Errorf("parseDecimal(%.20q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMagnitude(t *testing.T) {
+	tests := []struct {
+		evaluator domain.Evaluator
+		operands  []string
+		want      float64
+		wantErr   bool
+	}{
+		{evaluator: NewFloatEvaluator(), operands: []string{"-1e300", "2"}, want: 1e300},
+		{evaluator: NewFloatEvaluator(), operands: []string{"1e400"}, wantErr: true},
+		{evaluator: NewFloatEvaluator(), operands: []string{"1/2"}, wantErr: true},
+		{evaluator: NewDecimalEvaluator(), operands: []string{"-12.5", "3"}, want: 12.5},
+		{evaluator: NewDecimalEvaluator(), operands: []string{"1e5"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		v, err := tt.evaluator.Magnitude(tt.operands)
+		if tt.wantErr {
+			if !errors.Is(err, domain.ErrInvalidOperands) {
+				t.Errorf("%s Magnitude(%v) error = %v, want %v", tt.evaluator.Kind(), tt.operands, err, domain.ErrInvalidOperands)
+			}
+			continue
+		}
+		if err != nil || v.Cmp(big.NewFloat(tt.want)) != 0 {
+			t.Errorf("%s Magnitude(%v) = %v, %v, want %v", tt.evaluator.Kind(), tt.operands, v, err, tt.want)
+		}
+	}
+}
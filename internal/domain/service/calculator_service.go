@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"errors"
+	"math/big"
+	"time"
 
 	domain "go-prisma-calculator/internal/domain/models"
 	"go-prisma-calculator/internal/domain/ports/out"
@@ -11,50 +13,130 @@ import (
 // CalculatorService contains the pure business logic for calculations.
 type CalculatorService struct {
 	// It depends on the outbound repository port to save data.
-	repo out.CalculationRepositoryPort
+	repo       out.CalculationRepositoryPort
+	operations *OperationRegistry
+	evaluators *EvaluatorRegistry
 }
 
 // NewCalculatorService is the constructor that fx uses.
-// It receives the repository as a dependency.
-func NewCalculatorService(repo out.CalculationRepositoryPort) *CalculatorService {
-	return &CalculatorService{repo: repo}
+// It receives the repository and the operation and evaluator registries as
+// dependencies.
+func NewCalculatorService(repo out.CalculationRepositoryPort, operations *OperationRegistry, evaluators *EvaluatorRegistry) *CalculatorService {
+	return &CalculatorService{repo: repo, operations: operations, evaluators: evaluators}
 }
 
-// Add performs the addition, creates a domain model, and saves it.
-func (s *CalculatorService) Add(ctx context.Context, a, b int32) (*domain.Calculation, error) {
-	result := a + b
+// Compute evaluates an operation without recording it.
+func (s *CalculatorService) Compute(operation string, operands ...int32) (int32, error) {
+	return s.operations.Evaluate(operation, operands...)
+}
+
+// Calculate evaluates an operation, creates a domain model, and saves it.
+// Unary operations record a second operand of zero.
+func (s *CalculatorService) Calculate(ctx context.Context, operation string, operands ...int32) (*domain.Calculation, error) {
+	result, err := s.Compute(operation, operands...)
+	if err != nil {
+		return nil, err
+	}
+
 	calculation := domain.Calculation{
-		Operation: "add",
-		A:         int(a),
-		B:         int(b),
+		ID:        domain.NewCalculationID(),
+		Operation: operation,
+		Principal: domain.PrincipalFromContext(ctx).Name,
+		TenantID:  domain.TenantFromContext(ctx).ID,
+		A:         int(operands[0]),
 		Result:    int(result),
+		CreatedAt: domain.ChainTime(time.Now()),
+	}
+	if len(operands) > 1 {
+		calculation.B = int(operands[1])
 	}
 
 	// Use the repository port to save the data.
-	if err := s.repo.Save(ctx, calculation); err != nil {
+	if err := s.save(ctx, &calculation); err != nil {
 		return nil, err
 	}
 
 	return &calculation, nil
 }
 
-// Divide performs the division, creates a domain model, and saves it.
-func (s *CalculatorService) Divide(ctx context.Context, dividend, divisor int32) (*domain.Calculation, error) {
-	if divisor == 0 {
-		return nil, errors.New("cannot divide by zero")
+// Operations describes the operations the service can calculate.
+func (s *CalculatorService) Operations() []domain.OperationSignature {
+	return s.operations.Signatures()
+}
+
+// ComputeEvaluation evaluates a function of a kind other than integers
+// without recording it.
+func (s *CalculatorService) ComputeEvaluation(e domain.Evaluation) (*domain.EvaluationResult, error) {
+	if e.Kind == "" {
+		e.Kind = domain.KindFloat
 	}
+	return s.evaluators.Evaluate(e)
+}
 
-	result := dividend / divisor
-	calculation := domain.Calculation{
-		Operation: "divide",
-		A:         int(dividend),
-		B:         int(divisor),
-		Result:    int(result),
+// Magnitude returns the largest absolute value of the operands of e, for
+// limits on the size of operands.
+func (s *CalculatorService) Magnitude(e domain.Evaluation) (*big.Float, error) {
+	if e.Kind == "" {
+		e.Kind = domain.KindFloat
+	}
+	return s.evaluators.Magnitude(e)
+}
+
+// Evaluate computes a function of a kind other than integers and saves it
+// with its operands and options in canonical form.
+func (s *CalculatorService) Evaluate(ctx context.Context, e domain.Evaluation) (*domain.Calculation, error) {
+	return s.evaluate(ctx, e.Function, e)
+}
+
+// Divide computes a rounded division as the exact rational division it
+// stands for and saves it as a divide calculation of kind rational. The
+// rounded quotient is the "decimal" detail.
+func (s *CalculatorService) Divide(ctx context.Context, d domain.RoundedDivision) (*domain.Calculation, error) {
+	return s.evaluate(ctx, "divide", d.Evaluation())
+}
+
+// evaluate computes e and saves it as a calculation of operation.
+func (s *CalculatorService) evaluate(ctx context.Context, operation string, e domain.Evaluation) (*domain.Calculation, error) {
+	if e.Kind == "" {
+		e.Kind = domain.KindFloat
+	}
+	result, err := s.ComputeEvaluation(e)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.repo.Save(ctx, calculation); err != nil {
+	calculation := domain.Calculation{
+		ID:        domain.NewCalculationID(),
+		Operation: operation,
+		Principal: domain.PrincipalFromContext(ctx).Name,
+		TenantID:  domain.TenantFromContext(ctx).ID,
+		Kind:      e.Kind,
+		Operands:  result.Operands,
+		Value:     result.Value,
+		Details:   result.Details,
+		Options:   result.Options,
+		CreatedAt: domain.ChainTime(time.Now()),
+	}
+	if err := s.save(ctx, &calculation); err != nil {
 		return nil, err
 	}
 
 	return &calculation, nil
-}
\ No newline at end of file
+}
+
+// Functions describes the functions the service can evaluate.
+func (s *CalculatorService) Functions() []domain.FunctionSignature {
+	return s.evaluators.Signatures()
+}
+
+// save records a new calculation. A calculation the repository skipped
+// because the history is unavailable is still answered, but loses its ID
+// so that nobody refers to a record that does not exist.
+func (s *CalculatorService) save(ctx context.Context, calculation *domain.Calculation) error {
+	err := s.repo.Save(ctx, *calculation)
+	if errors.Is(err, domain.ErrHistorySkipped) {
+		calculation.ID, calculation.HistorySkipped = "", true
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/ports/out"
+	"go-prisma-calculator/internal/domain/rational"
+)
+
+// failingRepo fails every Save with err.
+type failingRepo struct {
+	out.CalculationRepositoryPort
+	err error
+}
+
+func (r failingRepo) Save(context.Context, domain.Calculation) error { return r.err }
+
+// capturingRepo keeps the calculations saved to it.
+type capturingRepo struct {
+	out.CalculationRepositoryPort
+	saved []domain.Calculation
+}
+
+func (r *capturingRepo) Save(_ context.Context, calc domain.Calculation) error {
+	r.saved = append(r.saved, calc)
+	return nil
+}
+
+func TestDivideRecordsDivide(t *testing.T) {
+	evaluators, err := NewEvaluatorRegistry([]domain.Evaluator{rational.NewEvaluator(4096)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &capturingRepo{}
+	s := NewCalculatorService(repo, newTestRegistry(t), evaluators)
+
+	calc, err := s.Divide(context.Background(), domain.RoundedDivision{Dividend: 1000, Divisor: 3, Rounding: domain.RoundHalfEven, Scale: 2})
+	if err != nil {
+		t.Fatalf("Divide: %v", err)
+	}
+	if len(repo.saved) != 1 {
+		t.Fatalf("saved %d calculations, want 1", len(repo.saved))
+	}
+	saved := repo.saved[0]
+	if saved.Operation != "divide" || saved.Kind != domain.KindRational || !saved.IsRoundedDivision() {
+		t.Errorf("saved %s of kind %q, want divide of kind rational", saved.Operation, saved.Kind)
+	}
+	if saved.Options.Rounding != domain.RoundHalfEven || saved.Options.Scale == nil || *saved.Options.Scale != 2 {
+		t.Errorf("saved options %+v, want half-even with scale 2", saved.Options)
+	}
+	if value, _ := domain.RoundedQuotient(calc); value != "333.33" {
+		t.Errorf("rounded quotient = %s, want 333.33", value)
+	}
+
+	// The recorded calculation recomputes to the same result, as on import.
+	recomputed, err := s.ComputeEvaluation(saved.Evaluation())
+	if err != nil || recomputed.Value != saved.Value || recomputed.Details["decimal"] != "333.33" {
+		t.Errorf("recomputed %+v, %v, want %s", recomputed, err, saved.Value)
+	}
+}
+
+func TestCalculateHistorySkipped(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantSkipped bool
+		wantErr     error
+	}{
+		{name: "recorded"},
+		{name: "skipped", err: domain.ErrHistorySkipped, wantSkipped: true},
+		{name: "failed", err: domain.ErrUnavailable, wantErr: domain.ErrUnavailable},
+	}
+	for _, tt := range tests {
+		s := NewCalculatorService(failingRepo{err: tt.err}, newTestRegistry(t), nil)
+		calc, err := s.Calculate(context.Background(), "add", 2, 3)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: Calculate error = %v, want %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if calc.Result != 5 || calc.HistorySkipped != tt.wantSkipped || (calc.ID == "") != tt.wantSkipped {
+			t.Errorf("%s: Calculate = %+v, want 5 with a skipped history %v", tt.name, calc, tt.wantSkipped)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+
+	domain "go-prisma-calculator/internal/domain/models"
+)
+
+// EvaluatorRegistry holds the evaluators of the number kinds other than
+// integers, keyed by kind.
+type EvaluatorRegistry struct {
+	evaluators map[domain.Kind]domain.Evaluator
+	kinds      []domain.Kind
+}
+
+// NewEvaluatorRegistry is the constructor that fx uses to create an
+// instance. It receives every evaluator of the "evaluators" group and
+// rejects duplicate kinds and evaluators for KindInteger, whose operations
+// live in the OperationRegistry.
+func NewEvaluatorRegistry(evaluators []domain.Evaluator) (*EvaluatorRegistry, error) {
+	r := &EvaluatorRegistry{evaluators: make(map[domain.Kind]domain.Evaluator, len(evaluators))}
+	for _, e := range evaluators {
+		kind := e.Kind()
+		switch {
+		case kind == "" || kind == domain.KindInteger:
+			return nil, fmt.Errorf("evaluator %T has unsupported kind %q", e, kind)
+		case r.evaluators[kind] != nil:
+			return nil, fmt.Errorf("kind %q has two evaluators", kind)
+		}
+		r.evaluators[kind] = e
+		r.kinds = append(r.kinds, kind)
+	}
+	slices.Sort(r.kinds)
+	return r, nil
+}
+
+// Lookup returns the evaluator of a kind.
+func (r *EvaluatorRegistry) Lookup(kind domain.Kind) (domain.Evaluator, error) {
+	e, ok := r.evaluators[kind]
+	if !ok {
+		return nil, fmt.Errorf("%w: kind %q", domain.ErrUnsupportedOperation, kind)
+	}
+	return e, nil
+}
+
+// Has reports whether any kind provides a function.
+func (r *EvaluatorRegistry) Has(function string) bool {
+	for _, e := range r.evaluators {
+		for _, sig := range e.Functions() {
+			if sig.Name == function {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Evaluate computes a function with the evaluator of its kind.
+func (r *EvaluatorRegistry) Evaluate(e domain.Evaluation) (*domain.EvaluationResult, error) {
+	evaluator, err := r.Lookup(e.Kind)
+	if err != nil {
+		return nil, err
+	}
+	return evaluator.Evaluate(e.Function, e.Operands, e.Options)
+}
+
+// Magnitude returns the largest absolute value of the operands of e, as
+// read by the evaluator of its kind.
+func (r *EvaluatorRegistry) Magnitude(e domain.Evaluation) (*big.Float, error) {
+	evaluator, err := r.Lookup(e.Kind)
+	if err != nil {
+		return nil, err
+	}
+	return evaluator.Magnitude(e.Operands)
+}
+
+// Signatures describes the functions of every kind, ordered by kind and
+// name.
+func (r *EvaluatorRegistry) Signatures() []domain.FunctionSignature {
+	var signatures []domain.FunctionSignature
+	for _, kind := range r.kinds {
+		signatures = append(signatures, r.evaluators[kind].Functions()...)
+	}
+	return signatures
+}
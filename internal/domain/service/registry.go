@@ -0,0 +1,174 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	domain "go-prisma-calculator/internal/domain/models"
+)
+
+// maxArity is the number of operands a calculation records.
+const maxArity = 2
+
+// OperationRegistry holds the operations the calculator can run, keyed by
+// name. The built-in operations are fixed when it is created; operations
+// loaded at runtime, such as plugins, are added and removed with Register
+// and Unregister.
+type OperationRegistry struct {
+	mu         sync.RWMutex
+	builtin    map[string]bool
+	operations map[string]domain.Operation
+	names      []string
+	listeners  []func(name string)
+}
+
+// NewOperationRegistry is the constructor that fx uses to create an
+// instance. It receives every operation of the "operations" group and
+// rejects unnamed or duplicate operations and unsupported arities.
+func NewOperationRegistry(operations []domain.Operation) (*OperationRegistry, error) {
+	r := &OperationRegistry{
+		builtin:    make(map[string]bool, len(operations)),
+		operations: make(map[string]domain.Operation, len(operations)),
+	}
+	for _, op := range operations {
+		if err := checkOperation(op); err != nil {
+			return nil, err
+		}
+		name := op.Name()
+		if r.operations[name] != nil {
+			return nil, fmt.Errorf("operation %q is registered twice", name)
+		}
+		r.builtin[name] = true
+		r.operations[name] = op
+		r.names = append(r.names, name)
+	}
+	slices.Sort(r.names)
+	return r, nil
+}
+
+// checkOperation rejects unnamed operations and unsupported arities.
+func checkOperation(op domain.Operation) error {
+	name := op.Name()
+	switch {
+	case name == "":
+		return fmt.Errorf("operation %T has no name", op)
+	case op.Arity() < 1 || op.Arity() > maxArity:
+		return fmt.Errorf("operation %q takes %d operands, want 1 to %d", name, op.Arity(), maxArity)
+	}
+	if operands := op.Describe().Operands; operands != nil && len(operands) != op.Arity() {
+		return fmt.Errorf("operation %q names %d operands but takes %d", name, len(operands), op.Arity())
+	}
+	return nil
+}
+
+// Register adds an operation at runtime, replacing an operation of the same
+// name that was registered this way. Built-in operations cannot be replaced.
+func (r *OperationRegistry) Register(op domain.Operation) error {
+	if err := checkOperation(op); err != nil {
+		return err
+	}
+	name := op.Name()
+
+	r.mu.Lock()
+	if r.builtin[name] {
+		r.mu.Unlock()
+		return fmt.Errorf("operation %q is built in", name)
+	}
+	if r.operations[name] == nil {
+		r.names = append(r.names, name)
+		slices.Sort(r.names)
+	}
+	r.operations[name] = op
+	r.mu.Unlock()
+
+	r.changed(name)
+	return nil
+}
+
+// Unregister removes an operation added with Register. It does nothing for
+// unknown and built-in operations.
+func (r *OperationRegistry) Unregister(name string) {
+	r.mu.Lock()
+	if r.builtin[name] || r.operations[name] == nil {
+		r.mu.Unlock()
+		return
+	}
+	delete(r.operations, name)
+	r.names = slices.DeleteFunc(r.names, func(n string) bool { return n == name })
+	r.mu.Unlock()
+
+	r.changed(name)
+}
+
+// OnChange registers fn to be called with the name of every operation that
+// is registered, replaced or unregistered at runtime, e.g. to drop cached
+// results.
+func (r *OperationRegistry) OnChange(fn func(name string)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.listeners = append(r.listeners, fn)
+}
+
+func (r *OperationRegistry) changed(name string) {
+	r.mu.RLock()
+	listeners := slices.Clone(r.listeners)
+	r.mu.RUnlock()
+
+	for _, fn := range listeners {
+		fn(name)
+	}
+}
+
+// Lookup returns the operation with the given name.
+func (r *OperationRegistry) Lookup(name string) (domain.Operation, error) {
+	r.mu.RLock()
+	op, ok := r.operations[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", domain.ErrUnsupportedOperation, name)
+	}
+	return op, nil
+}
+
+// Evaluate checks the operands of an operation and computes its result.
+func (r *OperationRegistry) Evaluate(name string, operands ...int32) (int32, error) {
+	op, err := r.Lookup(name)
+	if err != nil {
+		return 0, err
+	}
+	if len(operands) != op.Arity() {
+		return 0, fmt.Errorf("%w: %s takes %d operands, got %d", domain.ErrInvalidOperands, name, op.Arity(), len(operands))
+	}
+	if err := op.Validate(operands); err != nil {
+		return 0, err
+	}
+	return op.Evaluate(operands)
+}
+
+// Operands returns the operands of a stored calculation or job, which keep
+// two operand columns whatever the arity of the operation.
+func (r *OperationRegistry) Operands(name string, a, b int32) ([]int32, error) {
+	op, err := r.Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	return []int32{a, b}[:op.Arity()], nil
+}
+
+// Signatures describes every registered operation, ordered by name.
+func (r *OperationRegistry) Signatures() []domain.OperationSignature {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	signatures := make([]domain.OperationSignature, 0, len(r.names))
+	for _, name := range r.names {
+		op := r.operations[name]
+		signatures = append(signatures, domain.OperationSignature{
+			Name:         name,
+			Arity:        op.Arity(),
+			OperationDoc: op.Describe(),
+		})
+	}
+	return signatures
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/operations"
+)
+
+// testOperation is an operation with a configurable name and arity that
+// returns result.
+type testOperation struct {
+	name     string
+	arity    int
+	operands []string
+	result   int32
+}
+
+func (o testOperation) Name() string                    { return o.name }
+func (o testOperation) Arity() int                      { return o.arity }
+func (o testOperation) Validate([]int32) error          { return nil }
+func (o testOperation) Evaluate([]int32) (int32, error) { return o.result, nil }
+func (o testOperation) Describe() domain.OperationDoc {
+	return domain.OperationDoc{Operands: o.operands}
+}
+
+func newTestRegistry(t *testing.T) *OperationRegistry {
+	t.Helper()
+	r, err := NewOperationRegistry([]domain.Operation{operations.NewDivide(), operations.NewAdd()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func names(r *OperationRegistry) []string {
+	var names []string
+	for _, sig := range r.Signatures() {
+		names = append(names, sig.Name)
+	}
+	return names
+}
+
+func TestNewOperationRegistryRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  []domain.Operation
+	}{
+		{"unnamed", []domain.Operation{testOperation{arity: 1}}},
+		{"duplicate", []domain.Operation{operations.NewAdd(), operations.NewAdd()}},
+		{"no operands", []domain.Operation{testOperation{name: "zero", arity: 0}}},
+		{"too many operands", []domain.Operation{testOperation{name: "fma", arity: maxArity + 1}}},
+		{"misnamed operands", []domain.Operation{testOperation{name: "neg", arity: 1, operands: []string{"a", "b"}}}},
+	}
+	for _, tt := range tests {
+		if _, err := NewOperationRegistry(tt.ops); err == nil {
+			t.Errorf("%s: NewOperationRegistry succeeded", tt.name)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	r := newTestRegistry(t)
+	var changed []string
+	r.OnChange(func(name string) { changed = append(changed, name) })
+
+	if err := r.Register(testOperation{name: "neg", arity: 1, result: -1}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if got, err := r.Evaluate("neg", 1); err != nil || got != -1 {
+		t.Errorf("Evaluate(neg) = %d, %v, want -1", got, err)
+	}
+
+	// A plugin may replace its own operation.
+	if err := r.Register(testOperation{name: "neg", arity: 1, result: -2}); err != nil {
+		t.Fatalf("Register replacement: %v", err)
+	}
+	if got, _ := r.Evaluate("neg", 1); got != -2 {
+		t.Errorf("Evaluate(neg) = %d after replacement, want -2", got)
+	}
+	if want := []string{"add", "divide", "neg"}; !slices.Equal(names(r), want) {
+		t.Errorf("operations = %v, want %v", names(r), want)
+	}
+	if want := []string{"neg", "neg"}; !slices.Equal(changed, want) {
+		t.Errorf("changes = %v, want %v", changed, want)
+	}
+}
+
+func TestRegisterRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		op   domain.Operation
+	}{
+		{"built in", testOperation{name: "add", arity: 2}},
+		{"unnamed", testOperation{arity: 1}},
+		{"no operands", testOperation{name: "zero"}},
+		{"too many operands", testOperation{name: "fma", arity: maxArity + 1}},
+		{"misnamed operands", testOperation{name: "neg", arity: 1, operands: []string{"a", "b"}}},
+	}
+	for _, tt := range tests {
+		r := newTestRegistry(t)
+		changes := 0
+		r.OnChange(func(string) { changes++ })
+
+		if err := r.Register(tt.op); err == nil {
+			t.Errorf("%s: Register succeeded", tt.name)
+		}
+		if changes != 0 {
+			t.Errorf("%s: %d changes reported", tt.name, changes)
+		}
+		if got, _ := r.Evaluate("add", 2, 3); got != 5 {
+			t.Errorf("%s: add = %d after a rejected Register, want 5", tt.name, got)
+		}
+	}
+}
+
+func TestUnregister(t *testing.T) {
+	r := newTestRegistry(t)
+	if err := r.Register(testOperation{name: "neg", arity: 1}); err != nil {
+		t.Fatal(err)
+	}
+	var changed []string
+	r.OnChange(func(name string) { changed = append(changed, name) })
+
+	r.Unregister("unknown")
+	r.Unregister("add")
+	r.Unregister("neg")
+	r.Unregister("neg")
+
+	if want := []string{"neg"}; !slices.Equal(changed, want) {
+		t.Errorf("changes = %v, want %v", changed, want)
+	}
+	if want := []string{"add", "divide"}; !slices.Equal(names(r), want) {
+		t.Errorf("operations = %v, want %v", names(r), want)
+	}
+	if _, err := r.Lookup("neg"); !errors.Is(err, domain.ErrUnsupportedOperation) {
+		t.Errorf("Lookup(neg) error = %v, want %v", err, domain.ErrUnsupportedOperation)
+	}
+	if _, err := r.Lookup("add"); err != nil {
+		t.Errorf("Lookup(add) error = %v", err)
+	}
+}
+
+func TestOnChangeListeners(t *testing.T) {
+	r := newTestRegistry(t)
+	var first, second []string
+	r.OnChange(func(name string) { first = append(first, name) })
+	r.OnChange(func(name string) {
+		// Listeners run without the registry lock held.
+		_, _ = r.Lookup(name)
+		second = append(second, name)
+	})
+
+	_ = r.Register(testOperation{name: "neg", arity: 1})
+	r.Unregister("neg")
+
+	want := []string{"neg", "neg"}
+	if !slices.Equal(first, want) || !slices.Equal(second, want) {
+		t.Errorf("changes = %v and %v, want %v for both", first, second, want)
+	}
+}
+
+func TestEvaluateAndOperands(t *testing.T) {
+	r := newTestRegistry(t)
+	if err := r.Register(testOperation{name: "neg", arity: 1, result: -7}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		operands []int32
+		want     int32
+		wantErr  error
+	}{
+		{name: "add", operands: []int32{2, 3}, want: 5},
+		{name: "divide", operands: []int32{7, 2}, want: 3},
+		{name: "divide", operands: []int32{1, 0}, wantErr: domain.ErrDivisionByZero},
+		{name: "add", operands: []int32{1}, wantErr: domain.ErrInvalidOperands},
+		{name: "neg", operands: []int32{7, 0}, wantErr: domain.ErrInvalidOperands},
+		{name: "neg", operands: []int32{7}, want: -7},
+		{name: "pow", operands: []int32{2, 2}, wantErr: domain.ErrUnsupportedOperation},
+	}
+	for _, tt := range tests {
+		got, err := r.Evaluate(tt.name, tt.operands...)
+		if !errors.Is(err, tt.wantErr) || (err == nil && got != tt.want) {
+			t.Errorf("Evaluate(%s, %v) = %d, %v, want %d, %v", tt.name, tt.operands, got, err, tt.want, tt.wantErr)
+		}
+	}
+
+	// Stored calculations keep two operands whatever the arity.
+	if got, err := r.Operands("neg", 7, 0); err != nil || !slices.Equal(got, []int32{7}) {
+		t.Errorf("Operands(neg) = %v, %v, want [7]", got, err)
+	}
+	if got, err := r.Operands("add", 2, 3); err != nil || !slices.Equal(got, []int32{2, 3}) {
+		t.Errorf("Operands(add) = %v, %v, want [2 3]", got, err)
+	}
+	if _, err := r.Operands("pow", 2, 3); !errors.Is(err, domain.ErrUnsupportedOperation) {
+		t.Errorf("Operands(pow) error = %v, want %v", err, domain.ErrUnsupportedOperation)
+	}
+}
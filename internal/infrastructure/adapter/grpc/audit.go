@@ -0,0 +1,42 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+
+	pb "go-prisma-calculator/generated/proto"
+	domain "go-prisma-calculator/internal/domain/models"
+)
+
+// VerifyChain handles the gRPC request for the VerifyChain RPC.
+func (a *Adapter) VerifyChain(ctx context.Context, _ *pb.VerifyChainRequest) (*pb.ChainVerification, error) {
+	report, err := a.history.VerifyChain(ctx)
+	if err != nil {
+		a.logger.Error("Usecase failed for gRPC VerifyChain", slog.String("error", err.Error()))
+		return nil, historyError(err)
+	}
+
+	return toProtoChainVerification(report), nil
+}
+
+func toProtoChainVerification(report *domain.ChainVerification) *pb.ChainVerification {
+	resp := &pb.ChainVerification{
+		TenantId:  report.TenantID,
+		Valid:     report.Valid(),
+		Checked:   int64(report.Checked),
+		Unchained: int64(report.Unchained),
+		Truncated: report.Truncated,
+		Removed:   int64(report.Removed),
+		Head:      report.Head,
+	}
+	if b := report.Broken; b != nil {
+		resp.Broken = &pb.ChainBreak{
+			Id:       b.ID,
+			Seq:      int64(b.Seq),
+			Reason:   string(b.Reason),
+			Expected: b.Expected,
+			Actual:   b.Actual,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"errors"
+	"log/slog"
+
+	pb "go-prisma-calculator/generated/proto"
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/infrastructure/export"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exportChunkSize is the amount of file data sent per message.
+const exportChunkSize = 64 << 10
+
+// ExportCalculations handles the gRPC request for the ExportCalculations RPC.
+func (a *Adapter) ExportCalculations(req *pb.ExportCalculationsRequest, stream grpc.ServerStreamingServer[pb.ExportChunk]) error {
+	format, err := export.ParseFormat(req.GetFormat())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	a.logger.Info("Handling gRPC ExportCalculations request", slog.String("format", string(format)))
+
+	out := &chunkWriter{stream: stream, contentType: format.ContentType()}
+	w, err := export.NewWriter(format, out)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	count := 0
+	err = a.history.ExportCalculations(stream.Context(), domain.CalculationFilter{
+		Operation:      req.GetOperation(),
+		Limit:          int(req.GetLimit()),
+		Offset:         int(req.GetOffset()),
+		IncludeDeleted: req.GetIncludeDeleted(),
+	}, func(calc domain.Calculation) error {
+		count++
+		return w.Write(calc)
+	})
+	if err == nil {
+		err = w.Close()
+	}
+	if err == nil {
+		err = out.flush()
+	}
+	if err != nil {
+		a.logger.Error("Usecase failed for gRPC ExportCalculations", slog.Int("exported", count), slog.String("error", err.Error()))
+		var sendErr *sendError
+		if errors.As(err, &sendErr) {
+			return sendErr.err
+		}
+		return historyError(err)
+	}
+
+	a.logger.Info("Exported calculations", slog.String("format", string(format)), slog.Int("count", count))
+	return nil
+}
+
+// chunkWriter buffers the encoded file and sends it in exportChunkSize pieces.
+type chunkWriter struct {
+	stream      grpc.ServerStreamingServer[pb.ExportChunk]
+	contentType string
+	buf         []byte
+	sent        bool
+}
+
+func (c *chunkWriter) Write(p []byte) (int, error) {
+	c.buf = append(c.buf, p...)
+	for len(c.buf) >= exportChunkSize {
+		if err := c.send(c.buf[:exportChunkSize]); err != nil {
+			return 0, err
+		}
+		c.buf = c.buf[exportChunkSize:]
+	}
+	return len(p), nil
+}
+
+// flush sends whatever is buffered. An empty export still sends one chunk
+// so the client learns the content type.
+func (c *chunkWriter) flush() error {
+	if len(c.buf) == 0 && c.sent {
+		return nil
+	}
+	err := c.send(c.buf)
+	c.buf = nil
+	return err
+}
+
+func (c *chunkWriter) send(data []byte) error {
+	chunk := &pb.ExportChunk{Data: data}
+	if !c.sent {
+		chunk.ContentType = c.contentType
+	}
+	if err := c.stream.Send(chunk); err != nil {
+		return &sendError{err: err}
+	}
+	c.sent = true
+	return nil
+}
+
+// sendError marks failures to send to the client, which already carry a
+// gRPC status and must not be reported as internal errors.
+type sendError struct {
+	err error
+}
+
+func (e *sendError) Error() string { return e.err.Error() }
+func (e *sendError) Unwrap() error { return e.err }
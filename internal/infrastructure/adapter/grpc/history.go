@@ -0,0 +1,136 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	pb "go-prisma-calculator/generated/proto"
+	domain "go-prisma-calculator/internal/domain/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetCalculation handles the gRPC request for the GetCalculation RPC.
+func (a *Adapter) GetCalculation(ctx context.Context, req *pb.GetCalculationRequest) (*pb.Calculation, error) {
+	calc, err := a.history.GetCalculation(ctx, req.GetId())
+	if err != nil {
+		a.logger.Error("Usecase failed for gRPC GetCalculation", slog.String("calculation_id", req.GetId()), slog.String("error", err.Error()))
+		return nil, historyError(err)
+	}
+
+	return toProtoCalculation(calc), nil
+}
+
+// ListCalculations handles the gRPC request for the ListCalculations RPC.
+func (a *Adapter) ListCalculations(ctx context.Context, req *pb.ListCalculationsRequest) (*pb.ListCalculationsResponse, error) {
+	calcs, err := a.history.ListCalculations(ctx, domain.CalculationFilter{
+		Operation:      req.GetOperation(),
+		Limit:          int(req.GetLimit()),
+		Offset:         int(req.GetOffset()),
+		IncludeDeleted: req.GetIncludeDeleted(),
+	})
+	if err != nil {
+		a.logger.Error("Usecase failed for gRPC ListCalculations", slog.String("error", err.Error()))
+		return nil, historyError(err)
+	}
+
+	resp := &pb.ListCalculationsResponse{Calculations: make([]*pb.Calculation, 0, len(calcs))}
+	for i := range calcs {
+		resp.Calculations = append(resp.Calculations, toProtoCalculation(&calcs[i]))
+	}
+	return resp, nil
+}
+
+// DeleteCalculation handles the gRPC request for the DeleteCalculation RPC.
+func (a *Adapter) DeleteCalculation(ctx context.Context, req *pb.DeleteCalculationRequest) (*emptypb.Empty, error) {
+	a.logger.Info("Handling gRPC DeleteCalculation request", slog.String("calculation_id", req.GetId()))
+
+	if err := a.history.DeleteCalculation(ctx, req.GetId()); err != nil {
+		a.logger.Error("Usecase failed for gRPC DeleteCalculation", slog.String("calculation_id", req.GetId()), slog.String("error", err.Error()))
+		return nil, historyError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// RestoreCalculation handles the gRPC request for the RestoreCalculation RPC.
+func (a *Adapter) RestoreCalculation(ctx context.Context, req *pb.RestoreCalculationRequest) (*pb.Calculation, error) {
+	a.logger.Info("Handling gRPC RestoreCalculation request", slog.String("calculation_id", req.GetId()))
+
+	calc, err := a.history.RestoreCalculation(ctx, req.GetId())
+	if err != nil {
+		a.logger.Error("Usecase failed for gRPC RestoreCalculation", slog.String("calculation_id", req.GetId()), slog.String("error", err.Error()))
+		return nil, historyError(err)
+	}
+
+	return toProtoCalculation(calc), nil
+}
+
+// PurgeCalculation handles the gRPC request for the PurgeCalculation RPC.
+func (a *Adapter) PurgeCalculation(ctx context.Context, req *pb.PurgeCalculationRequest) (*emptypb.Empty, error) {
+	a.logger.Info("Handling gRPC PurgeCalculation request", slog.String("calculation_id", req.GetId()))
+
+	if err := a.history.PurgeCalculation(ctx, req.GetId()); err != nil {
+		a.logger.Error("Usecase failed for gRPC PurgeCalculation", slog.String("calculation_id", req.GetId()), slog.String("error", err.Error()))
+		return nil, historyError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// historyError maps history usecase errors onto gRPC status codes.
+func historyError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return status.Error(codes.NotFound, "calculation not found")
+	case errors.Is(err, domain.ErrInvalidStatsQuery):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, domain.ErrUnavailable):
+		return status.Error(codes.Unavailable, domain.ErrUnavailable.Error())
+	default:
+		return status.Error(codes.Internal, "an unexpected error occurred")
+	}
+}
+
+func toProtoCalculation(calc *domain.Calculation) *pb.Calculation {
+	resp := &pb.Calculation{
+		Id:        calc.ID,
+		Operation: calc.Operation,
+		A:         int32(calc.A),
+		B:         int32(calc.B),
+		Result:    int32(calc.Result),
+		CreatedAt: timestamppb.New(calc.CreatedAt),
+		Hash:      calc.Hash,
+		PrevHash:  calc.PrevHash,
+		Kind:      string(domain.KindInteger),
+	}
+	if !calc.IsInteger() {
+		resp.Kind = string(calc.Kind)
+		resp.Operands = calc.Operands
+		resp.Value = calc.Value
+		resp.Angle = string(calc.Options.Angle)
+		resp.Precision = int32(calc.Options.Precision)
+		resp.Details = calc.Details
+		resp.Scale = toProtoScale(calc.Options.Scale)
+		resp.Division = string(calc.Options.Division)
+		resp.Rounding = string(calc.Options.Rounding)
+	}
+	if calc.Kind == domain.KindComplex {
+		resp.ComplexValue = toProtoComplex(calc.Value)
+		resp.ComplexOperands = toProtoComplexes(calc.Operands)
+	}
+	if operands, value, ok := calc.Matrices(); ok {
+		resp.MatrixValue = toProtoMatrix(value)
+		resp.MatrixOperands = toProtoMatrices(operands)
+	}
+	if calc.DeletedAt != nil {
+		resp.DeletedAt = timestamppb.New(*calc.DeletedAt)
+	}
+	return resp
+}
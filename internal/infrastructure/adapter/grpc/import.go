@@ -0,0 +1,115 @@
+package grpc
+
+import (
+	"errors"
+	"log/slog"
+
+	pb "go-prisma-calculator/generated/proto"
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/infrastructure/export"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ImportCalculations handles the gRPC request for the ImportCalculations RPC.
+func (a *Adapter) ImportCalculations(stream grpc.ClientStreamingServer[pb.ImportCalculationsRequest, pb.ImportReport]) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	opts := first.GetOptions()
+	if opts == nil {
+		return status.Error(codes.InvalidArgument, "the first message must carry the import options")
+	}
+
+	format, err := export.ParseFormat(opts.GetFormat())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	reader, err := export.NewReader(format, &importStreamReader{stream: stream})
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	a.logger.Info("Handling gRPC ImportCalculations request",
+		slog.String("format", string(format)),
+		slog.Bool("recompute", opts.GetRecompute()),
+		slog.Bool("dry_run", opts.GetDryRun()),
+	)
+
+	report, err := a.imports.ImportCalculations(stream.Context(), domain.ImportOptions{
+		Recompute:  opts.GetRecompute(),
+		OnMismatch: domain.MismatchPolicy(opts.GetOnMismatch()),
+		DryRun:     opts.GetDryRun(),
+	}, reader.Read)
+	if err != nil {
+		a.logger.Error("Usecase failed for gRPC ImportCalculations", slog.String("error", err.Error()))
+		return importError(err)
+	}
+
+	a.logger.Info("Imported calculations",
+		slog.Int("total", report.Total),
+		slog.Int("accepted", report.Accepted),
+		slog.Int("rejected", report.Rejected),
+		slog.Int("mismatched", report.Mismatched),
+	)
+	return stream.SendAndClose(toProtoImportReport(report))
+}
+
+// importError maps import failures onto gRPC status codes. Errors that
+// already carry a status, such as a cancelled upload, are passed through.
+func importError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, domain.ErrInvalidMismatchPolicy), errors.Is(err, export.ErrInvalidFile):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrUnavailable):
+		return status.Error(codes.Unavailable, domain.ErrUnavailable.Error())
+	default:
+		return status.Error(codes.Internal, "an unexpected error occurred")
+	}
+}
+
+// importStreamReader reads the file carried by the data messages of an import stream.
+type importStreamReader struct {
+	stream grpc.ClientStreamingServer[pb.ImportCalculationsRequest, pb.ImportReport]
+	buf    []byte
+}
+
+func (r *importStreamReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if req.GetOptions() != nil {
+			return 0, status.Error(codes.InvalidArgument, "import options may only be sent once")
+		}
+		r.buf = req.GetData()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func toProtoImportReport(report *domain.ImportReport) *pb.ImportReport {
+	resp := &pb.ImportReport{
+		Total:           int32(report.Total),
+		Accepted:        int32(report.Accepted),
+		Rejected:        int32(report.Rejected),
+		Mismatched:      int32(report.Mismatched),
+		Issues:          make([]*pb.ImportIssue, 0, len(report.Issues)),
+		IssuesTruncated: report.IssuesTruncated,
+	}
+	for _, issue := range report.Issues {
+		resp.Issues = append(resp.Issues, &pb.ImportIssue{
+			Line:   int32(issue.Line),
+			Kind:   string(issue.Kind),
+			Reason: issue.Reason,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,113 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	pb "go-prisma-calculator/generated/proto"
+	domain "go-prisma-calculator/internal/domain/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SubmitJob handles the gRPC request for the SubmitJob RPC.
+func (a *Adapter) SubmitJob(ctx context.Context, req *pb.SubmitJobRequest) (*pb.Job, error) {
+	a.logger.Info("Handling gRPC SubmitJob request", slog.String("operation", req.GetOperation()))
+
+	job, err := a.jobs.SubmitJob(ctx, req.GetOperation(), req.GetA(), req.GetB())
+	if err != nil {
+		a.logger.Error("Usecase failed for gRPC SubmitJob", slog.String("error", err.Error()))
+		return nil, jobError(err)
+	}
+
+	a.logger.Info("gRPC SubmitJob request successful", slog.String("job_id", job.ID))
+	return toProtoJob(job), nil
+}
+
+// GetJob handles the gRPC request for the GetJob RPC.
+func (a *Adapter) GetJob(ctx context.Context, req *pb.GetJobRequest) (*pb.Job, error) {
+	job, err := a.jobs.GetJob(ctx, req.GetId())
+	if err != nil {
+		a.logger.Error("Usecase failed for gRPC GetJob", slog.String("job_id", req.GetId()), slog.String("error", err.Error()))
+		return nil, jobError(err)
+	}
+
+	return toProtoJob(job), nil
+}
+
+// CancelJob handles the gRPC request for the CancelJob RPC.
+func (a *Adapter) CancelJob(ctx context.Context, req *pb.CancelJobRequest) (*pb.Job, error) {
+	a.logger.Info("Handling gRPC CancelJob request", slog.String("job_id", req.GetId()))
+
+	job, err := a.jobs.CancelJob(ctx, req.GetId())
+	if err != nil {
+		a.logger.Error("Usecase failed for gRPC CancelJob", slog.String("job_id", req.GetId()), slog.String("error", err.Error()))
+		return nil, jobError(err)
+	}
+
+	return toProtoJob(job), nil
+}
+
+// ListJobs handles the gRPC request for the ListJobs RPC.
+func (a *Adapter) ListJobs(ctx context.Context, req *pb.ListJobsRequest) (*pb.ListJobsResponse, error) {
+	jobs, err := a.jobs.ListJobs(ctx, domain.JobFilter{
+		Status: domain.JobStatus(req.GetStatus()),
+		Limit:  int(req.GetLimit()),
+		Offset: int(req.GetOffset()),
+	})
+	if err != nil {
+		a.logger.Error("Usecase failed for gRPC ListJobs", slog.String("error", err.Error()))
+		return nil, jobError(err)
+	}
+
+	resp := &pb.ListJobsResponse{Jobs: make([]*pb.Job, 0, len(jobs))}
+	for i := range jobs {
+		resp.Jobs = append(resp.Jobs, toProtoJob(&jobs[i]))
+	}
+	return resp, nil
+}
+
+// jobError maps job usecase errors onto gRPC status codes.
+func jobError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return status.Error(codes.NotFound, "job not found")
+	case errors.Is(err, domain.ErrUnsupportedOperation), errors.Is(err, domain.ErrOperandOutOfRange):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrOperationNotAllowed):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, domain.ErrJobFinished):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrJobQueueFull), errors.Is(err, domain.ErrTenantJobLimit):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return status.Error(codes.Internal, "an unexpected error occurred")
+	}
+}
+
+func toProtoJob(job *domain.Job) *pb.Job {
+	resp := &pb.Job{
+		Id:            job.ID,
+		Operation:     job.Operation,
+		A:             int32(job.A),
+		B:             int32(job.B),
+		Status:        string(job.Status),
+		Error:         job.Error,
+		CreatedAt:     timestamppb.New(job.CreatedAt),
+		CalculationId: job.CalculationID,
+	}
+	if job.Result != nil {
+		result := int32(*job.Result)
+		resp.Result = &result
+	}
+	if job.StartedAt != nil {
+		resp.StartedAt = timestamppb.New(*job.StartedAt)
+	}
+	if job.FinishedAt != nil {
+		resp.FinishedAt = timestamppb.New(*job.FinishedAt)
+	}
+	return resp
+}
@@ -2,52 +2,327 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
 	pb "go-prisma-calculator/generated/proto"
+	domain "go-prisma-calculator/internal/domain/models"
 	"go-prisma-calculator/internal/domain/ports/in"
+	"go-prisma-calculator/internal/infrastructure/health"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Adapter is the gRPC adapter that connects to our application's core.
 type Adapter struct {
 	pb.UnimplementedCalculatorServiceServer
 	usecase in.CalculatorPort
+	jobs    in.JobPort
+	history in.HistoryPort
+	imports in.ImportPort
+	tenants in.TenantPort
 	logger  *slog.Logger
 }
 
 // NewAdapter is the constructor that fx uses to create an instance.
-// It receives the application port and logger as dependencies.
-func NewAdapter(usecase in.CalculatorPort, logger *slog.Logger) *Adapter {
-	return &Adapter{usecase: usecase, logger: logger}
+// It receives the application ports and logger as dependencies.
+func NewAdapter(usecase in.CalculatorPort, jobs in.JobPort, history in.HistoryPort, imports in.ImportPort, tenants in.TenantPort, logger *slog.Logger) *Adapter {
+	return &Adapter{usecase: usecase, jobs: jobs, history: history, imports: imports, tenants: tenants, logger: logger}
 }
 
 // Add handles the gRPC request for the Add RPC.
 func (a *Adapter) Add(ctx context.Context, req *pb.AddRequest) (*pb.CalculationResponse, error) {
 	a.logger.Info("Handling gRPC Add request", slog.Int("a", int(req.GetA())), slog.Int("b", int(req.GetB())))
 
-	calc, err := a.usecase.Add(ctx, req.GetA(), req.GetB())
+	calc, err := a.usecase.Calculate(ctx, "add", req.GetA(), req.GetB())
 	if err != nil {
 		a.logger.Error("Usecase failed for gRPC Add", slog.String("error", err.Error()))
-		return nil, status.Error(codes.Internal, "an unexpected error occurred")
+		return nil, calculationError(err)
 	}
 
 	a.logger.Info("gRPC Add request successful", slog.Int("result", calc.Result))
-	return &pb.CalculationResponse{Result: int32(calc.Result)}, nil
+	markHistorySkipped(ctx, calc)
+	return toProtoCalculationResponse(calc), nil
 }
 
 // Divide handles the gRPC request for the Divide RPC.
 func (a *Adapter) Divide(ctx context.Context, req *pb.DivideRequest) (*pb.CalculationResponse, error) {
 	a.logger.Info("Handling gRPC Divide request", slog.Int("dividend", int(req.GetDividend())), slog.Int("divisor", int(req.GetDivisor())))
 
-	calc, err := a.usecase.Divide(ctx, req.GetDividend(), req.GetDivisor())
+	if req.GetRounding() != "" || req.Scale != nil {
+		calc, err := a.usecase.Divide(ctx, domain.RoundedDivision{
+			Dividend: req.GetDividend(),
+			Divisor:  req.GetDivisor(),
+			Rounding: domain.RoundingMode(req.GetRounding()),
+			Scale:    int(req.GetScale()),
+		})
+		if err != nil {
+			a.logger.Error("Usecase failed for gRPC Divide", slog.String("error", err.Error()))
+			return nil, calculationError(err)
+		}
+
+		resp := toProtoCalculationResponse(calc)
+		value, result := domain.RoundedQuotient(calc)
+		resp.Value, resp.Result = value, nil
+		if result != nil {
+			quotient := int32(*result)
+			resp.Result = &quotient
+		}
+		a.logger.Info("gRPC Divide request successful", slog.String("value", value))
+		markHistorySkipped(ctx, calc)
+		return resp, nil
+	}
+
+	calc, err := a.usecase.Calculate(ctx, "divide", req.GetDividend(), req.GetDivisor())
 	if err != nil {
 		a.logger.Error("Usecase failed for gRPC Divide", slog.String("error", err.Error()))
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, calculationError(err)
 	}
 
 	a.logger.Info("gRPC Divide request successful", slog.Int("result", calc.Result))
-	return &pb.CalculationResponse{Result: int32(calc.Result)}, nil
-}
\ No newline at end of file
+	markHistorySkipped(ctx, calc)
+	return toProtoCalculationResponse(calc), nil
+}
+
+// Calculate handles the gRPC request for any registered operation.
+func (a *Adapter) Calculate(ctx context.Context, req *pb.CalculateRequest) (*pb.CalculationResponse, error) {
+	a.logger.Info("Handling gRPC Calculate request", slog.String("operation", req.GetOperation()), slog.Any("operands", req.GetOperands()))
+
+	calc, err := a.usecase.Calculate(ctx, req.GetOperation(), req.GetOperands()...)
+	if err != nil {
+		a.logger.Error("Usecase failed for gRPC Calculate", slog.String("error", err.Error()))
+		return nil, calculationError(err)
+	}
+
+	a.logger.Info("gRPC Calculate request successful", slog.Int("result", calc.Result))
+	markHistorySkipped(ctx, calc)
+	return toProtoCalculationResponse(calc), nil
+}
+
+// ListOperations describes the operations the caller may run.
+func (a *Adapter) ListOperations(ctx context.Context, _ *pb.ListOperationsRequest) (*pb.ListOperationsResponse, error) {
+	signatures, err := a.usecase.ListOperations(ctx)
+	if err != nil {
+		a.logger.Error("Usecase failed for gRPC ListOperations", slog.String("error", err.Error()))
+		return nil, calculationError(err)
+	}
+
+	resp := &pb.ListOperationsResponse{Operations: make([]*pb.OperationSignature, 0, len(signatures))}
+	for _, sig := range signatures {
+		resp.Operations = append(resp.Operations, &pb.OperationSignature{
+			Name:        sig.Name,
+			Arity:       int32(sig.Arity),
+			Description: sig.Description,
+			Operands:    sig.Operands,
+			Commutative: sig.Commutative,
+		})
+	}
+	return resp, nil
+}
+
+// Evaluate computes a scientific function.
+func (a *Adapter) Evaluate(ctx context.Context, req *pb.EvaluateRequest) (*pb.EvaluationResponse, error) {
+	a.logger.Info("Handling gRPC Evaluate request", slog.String("function", req.GetFunction()), slog.Any("operands", req.GetOperands()))
+
+	kind, operands := domain.Kind(req.GetKind()), req.GetOperands()
+	if len(req.GetComplexOperands()) > 0 {
+		if len(operands) > 0 {
+			return nil, status.Error(codes.InvalidArgument, "operands and complex_operands cannot be combined")
+		}
+		operands = fromProtoComplexes(req.GetComplexOperands())
+		if kind == "" {
+			kind = domain.KindComplex
+		}
+	}
+	if len(req.GetMatrixOperands()) > 0 {
+		if len(operands) > 0 {
+			return nil, status.Error(codes.InvalidArgument, "operands cannot be combined with matrix_operands")
+		}
+		operands = fromProtoMatrices(req.GetMatrixOperands())
+		if kind == "" {
+			kind = domain.KindMatrix
+		}
+	}
+	calc, err := a.usecase.Evaluate(ctx, domain.Evaluation{
+		Function: req.GetFunction(),
+		Kind:     kind,
+		Operands: operands,
+		Options: domain.EvaluationOptions{
+			Angle:     domain.AngleUnit(req.GetAngle()),
+			Precision: int(req.GetPrecision()),
+			Scale:     fromProtoScale(req.Scale),
+			Division:  domain.DivisionMode(req.GetDivision()),
+			Rounding:  domain.RoundingMode(req.GetRounding()),
+		},
+	})
+	if err != nil {
+		a.logger.Error("Usecase failed for gRPC Evaluate", slog.String("error", err.Error()))
+		return nil, calculationError(err)
+	}
+
+	a.logger.Info("gRPC Evaluate request successful", slog.String("value", calc.Value))
+	resp := &pb.EvaluationResponse{
+		Value:     calc.Value,
+		Id:        calc.ID,
+		CreatedAt: timestamppb.New(calc.CreatedAt),
+		Kind:      string(calc.Kind),
+		Operands:  calc.Operands,
+		Angle:     string(calc.Options.Angle),
+		Precision: int32(calc.Options.Precision),
+		Details:   calc.Details,
+		Scale:     toProtoScale(calc.Options.Scale),
+		Division:  string(calc.Options.Division),
+		Rounding:  string(calc.Options.Rounding),
+	}
+	if calc.Kind == domain.KindComplex {
+		resp.ComplexValue = toProtoComplex(calc.Value)
+		resp.ComplexOperands = toProtoComplexes(calc.Operands)
+	}
+	if operands, value, ok := calc.Matrices(); ok {
+		resp.MatrixValue = toProtoMatrix(value)
+		resp.MatrixOperands = toProtoMatrices(operands)
+	}
+	if r := calc.Receipt; r != nil {
+		resp.Receipt = &pb.Receipt{KeyId: r.KeyID, Algorithm: r.Algorithm, Signature: r.Signature}
+	}
+	markHistorySkipped(ctx, calc)
+	return resp, nil
+}
+
+// fromProtoScale and toProtoScale convert the optional scale option.
+func fromProtoScale(scale *int32) *int {
+	if scale == nil {
+		return nil
+	}
+	v := int(*scale)
+	return &v
+}
+
+func toProtoScale(scale *int) *int32 {
+	if scale == nil {
+		return nil
+	}
+	v := int32(*scale)
+	return &v
+}
+
+// fromProtoComplexes writes complex operands in their text form.
+func fromProtoComplexes(operands []*pb.Complex) []string {
+	texts := make([]string, len(operands))
+	for i, z := range operands {
+		texts[i] = domain.FormatComplex(complex(z.GetReal(), z.GetImag()))
+	}
+	return texts
+}
+
+// toProtoComplex and toProtoComplexes split complex numbers in text form
+// into real/imag pairs.
+func toProtoComplex(text string) *pb.Complex {
+	re, im, _ := domain.ComplexParts(text)
+	return &pb.Complex{Real: re, Imag: im}
+}
+
+func toProtoComplexes(texts []string) []*pb.Complex {
+	operands := make([]*pb.Complex, len(texts))
+	for i, text := range texts {
+		operands[i] = toProtoComplex(text)
+	}
+	return operands
+}
+
+// fromProtoMatrices writes matrix operands in their text form.
+func fromProtoMatrices(operands []*pb.Matrix) []string {
+	texts := make([]string, len(operands))
+	for i, m := range operands {
+		rows := make(domain.Matrix, len(m.GetRows()))
+		for j, row := range m.GetRows() {
+			rows[j] = row.GetValues()
+		}
+		texts[i] = domain.FormatMatrix(rows)
+	}
+	return texts
+}
+
+// toProtoMatrix and toProtoMatrices convert matrices to their messages.
+func toProtoMatrix(m domain.Matrix) *pb.Matrix {
+	rows := make([]*pb.MatrixRow, len(m))
+	for i, row := range m {
+		rows[i] = &pb.MatrixRow{Values: row}
+	}
+	return &pb.Matrix{Rows: rows}
+}
+
+func toProtoMatrices(ms []domain.Matrix) []*pb.Matrix {
+	matrices := make([]*pb.Matrix, len(ms))
+	for i, m := range ms {
+		matrices[i] = toProtoMatrix(m)
+	}
+	return matrices
+}
+
+// ListFunctions describes the functions the caller may evaluate.
+func (a *Adapter) ListFunctions(ctx context.Context, _ *pb.ListFunctionsRequest) (*pb.ListFunctionsResponse, error) {
+	signatures, err := a.usecase.ListFunctions(ctx)
+	if err != nil {
+		a.logger.Error("Usecase failed for gRPC ListFunctions", slog.String("error", err.Error()))
+		return nil, calculationError(err)
+	}
+
+	resp := &pb.ListFunctionsResponse{Functions: make([]*pb.FunctionSignature, 0, len(signatures))}
+	for _, sig := range signatures {
+		resp.Functions = append(resp.Functions, &pb.FunctionSignature{
+			Kind:        string(sig.Kind),
+			Name:        sig.Name,
+			Arity:       int32(sig.Arity),
+			Description: sig.Description,
+			Operands:    sig.Operands,
+			Commutative: sig.Commutative,
+			Options:     sig.Options,
+		})
+	}
+	return resp, nil
+}
+
+func toProtoCalculationResponse(calc *domain.Calculation) *pb.CalculationResponse {
+	result := int32(calc.Result)
+	resp := &pb.CalculationResponse{
+		Result:    &result,
+		Id:        calc.ID,
+		CreatedAt: timestamppb.New(calc.CreatedAt),
+	}
+	if r := calc.Receipt; r != nil {
+		resp.Receipt = &pb.Receipt{KeyId: r.KeyID, Algorithm: r.Algorithm, Signature: r.Signature}
+	}
+	return resp
+}
+
+// calculationError maps calculator usecase errors onto gRPC status codes.
+func calculationError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrDivisionByZero), errors.Is(err, domain.ErrOperandOutOfRange),
+		errors.Is(err, domain.ErrUnsupportedOperation), errors.Is(err, domain.ErrInvalidOperands),
+		errors.Is(err, domain.ErrOutOfDomain), errors.Is(err, domain.ErrSingularMatrix):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrOperationNotAllowed):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, domain.ErrUnavailable):
+		return status.Error(codes.Unavailable, domain.ErrUnavailable.Error())
+	case errors.Is(err, domain.ErrOperationFailed):
+		return status.Error(codes.Internal, err.Error())
+	default:
+		return status.Error(codes.Internal, "an unexpected error occurred")
+	}
+}
+
+// markHistorySkipped sets the degraded trailer on the response of a
+// calculation that was not recorded. A trailer is used because the degraded
+// header may have been set already when the request was received.
+func markHistorySkipped(ctx context.Context, calc *domain.Calculation) {
+	if calc.HistorySkipped {
+		_ = grpc.SetTrailer(ctx, metadata.Pairs(health.DegradedHeader, "no-history"))
+	}
+}
@@ -0,0 +1,76 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+
+	pb "go-prisma-calculator/generated/proto"
+	domain "go-prisma-calculator/internal/domain/models"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetStatistics handles the gRPC request for the GetStatistics RPC.
+func (a *Adapter) GetStatistics(ctx context.Context, req *pb.GetStatisticsRequest) (*pb.Statistics, error) {
+	query := domain.StatsQuery{
+		Bucket:         domain.StatsBucket(req.GetBucket()),
+		Operation:      req.GetOperation(),
+		TopPairs:       int(req.GetTopPairs()),
+		IncludeDeleted: req.GetIncludeDeleted(),
+	}
+	if req.From != nil {
+		query.From = req.GetFrom().AsTime()
+	}
+	if req.To != nil {
+		query.To = req.GetTo().AsTime()
+	}
+
+	stats, err := a.history.GetStatistics(ctx, query)
+	if err != nil {
+		a.logger.Error("Usecase failed for gRPC GetStatistics", slog.String("error", err.Error()))
+		return nil, historyError(err)
+	}
+
+	return toProtoStatistics(stats), nil
+}
+
+func toProtoStatistics(stats *domain.Statistics) *pb.Statistics {
+	resp := &pb.Statistics{
+		From:   timestamppb.New(stats.From),
+		To:     timestamppb.New(stats.To),
+		Bucket: string(stats.Bucket),
+		Total:  int64(stats.Total),
+	}
+	for _, op := range stats.Operations {
+		resp.Operations = append(resp.Operations, &pb.OperationStats{
+			Operation: op.Operation,
+			Count:     int64(op.Count),
+			MinResult: int32(op.MinResult),
+			MaxResult: int32(op.MaxResult),
+			AvgResult: op.AvgResult,
+		})
+	}
+	for _, b := range stats.Histogram {
+		resp.Histogram = append(resp.Histogram, &pb.HistogramBucket{
+			Start: timestamppb.New(b.Start),
+			Count: int64(b.Count),
+		})
+	}
+	for _, p := range stats.TopPairs {
+		resp.TopPairs = append(resp.TopPairs, &pb.OperandPairStats{
+			Operation: p.Operation,
+			A:         int32(p.A),
+			B:         int32(p.B),
+			Count:     int64(p.Count),
+		})
+	}
+	for _, e := range stats.Errors {
+		resp.Errors = append(resp.Errors, &pb.ErrorStats{
+			Operation: e.Operation,
+			Finished:  int64(e.Finished),
+			Failed:    int64(e.Failed),
+			Rate:      e.Rate(),
+		})
+	}
+	return resp
+}
@@ -0,0 +1,135 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	pb "go-prisma-calculator/generated/proto"
+	domain "go-prisma-calculator/internal/domain/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateTenant handles the gRPC request for the CreateTenant RPC.
+func (a *Adapter) CreateTenant(ctx context.Context, req *pb.CreateTenantRequest) (*pb.Tenant, error) {
+	a.logger.Info("Handling gRPC CreateTenant request", slog.String("tenant", req.GetTenant().GetId()))
+
+	tenant, err := a.tenants.CreateTenant(ctx, fromProtoTenant(req.GetTenant()))
+	if err != nil {
+		a.logger.Error("Usecase failed for gRPC CreateTenant", slog.String("error", err.Error()))
+		return nil, tenantError(err)
+	}
+
+	return toProtoTenant(tenant), nil
+}
+
+// GetTenant handles the gRPC request for the GetTenant RPC.
+func (a *Adapter) GetTenant(ctx context.Context, req *pb.GetTenantRequest) (*pb.Tenant, error) {
+	tenant, err := a.tenants.GetTenant(ctx, req.GetId())
+	if err != nil {
+		a.logger.Error("Usecase failed for gRPC GetTenant", slog.String("tenant", req.GetId()), slog.String("error", err.Error()))
+		return nil, tenantError(err)
+	}
+
+	return toProtoTenant(tenant), nil
+}
+
+// ListTenants handles the gRPC request for the ListTenants RPC.
+func (a *Adapter) ListTenants(ctx context.Context, _ *pb.ListTenantsRequest) (*pb.ListTenantsResponse, error) {
+	tenants, err := a.tenants.ListTenants(ctx)
+	if err != nil {
+		a.logger.Error("Usecase failed for gRPC ListTenants", slog.String("error", err.Error()))
+		return nil, tenantError(err)
+	}
+
+	resp := &pb.ListTenantsResponse{Tenants: make([]*pb.Tenant, 0, len(tenants))}
+	for i := range tenants {
+		resp.Tenants = append(resp.Tenants, toProtoTenant(&tenants[i]))
+	}
+	return resp, nil
+}
+
+// UpdateTenant handles the gRPC request for the UpdateTenant RPC.
+func (a *Adapter) UpdateTenant(ctx context.Context, req *pb.UpdateTenantRequest) (*pb.Tenant, error) {
+	a.logger.Info("Handling gRPC UpdateTenant request", slog.String("tenant", req.GetTenant().GetId()))
+
+	tenant, err := a.tenants.UpdateTenant(ctx, fromProtoTenant(req.GetTenant()))
+	if err != nil {
+		a.logger.Error("Usecase failed for gRPC UpdateTenant", slog.String("error", err.Error()))
+		return nil, tenantError(err)
+	}
+
+	return toProtoTenant(tenant), nil
+}
+
+// DisableTenant handles the gRPC request for the DisableTenant RPC.
+func (a *Adapter) DisableTenant(ctx context.Context, req *pb.DisableTenantRequest) (*pb.Tenant, error) {
+	a.logger.Info("Handling gRPC DisableTenant request", slog.String("tenant", req.GetId()))
+
+	tenant, err := a.tenants.DisableTenant(ctx, req.GetId())
+	if err != nil {
+		a.logger.Error("Usecase failed for gRPC DisableTenant", slog.String("tenant", req.GetId()), slog.String("error", err.Error()))
+		return nil, tenantError(err)
+	}
+
+	return toProtoTenant(tenant), nil
+}
+
+// EnableTenant handles the gRPC request for the EnableTenant RPC.
+func (a *Adapter) EnableTenant(ctx context.Context, req *pb.EnableTenantRequest) (*pb.Tenant, error) {
+	a.logger.Info("Handling gRPC EnableTenant request", slog.String("tenant", req.GetId()))
+
+	tenant, err := a.tenants.EnableTenant(ctx, req.GetId())
+	if err != nil {
+		a.logger.Error("Usecase failed for gRPC EnableTenant", slog.String("tenant", req.GetId()), slog.String("error", err.Error()))
+		return nil, tenantError(err)
+	}
+
+	return toProtoTenant(tenant), nil
+}
+
+// tenantError maps tenant usecase errors onto gRPC status codes.
+func tenantError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return status.Error(codes.NotFound, "tenant not found")
+	case errors.Is(err, domain.ErrInvalidTenant):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrTenantExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, domain.ErrUnavailable):
+		return status.Error(codes.Unavailable, domain.ErrUnavailable.Error())
+	default:
+		return status.Error(codes.Internal, "an unexpected error occurred")
+	}
+}
+
+func fromProtoTenant(tenant *pb.Tenant) domain.Tenant {
+	return domain.Tenant{
+		ID:                tenant.GetId(),
+		Name:              tenant.GetName(),
+		AllowedOperations: tenant.GetAllowedOperations(),
+		MaxOperand:        int(tenant.GetMaxOperand()),
+		MaxPendingJobs:    int(tenant.GetMaxPendingJobs()),
+	}
+}
+
+func toProtoTenant(tenant *domain.Tenant) *pb.Tenant {
+	resp := &pb.Tenant{
+		Id:                tenant.ID,
+		Name:              tenant.Name,
+		AllowedOperations: tenant.AllowedOperations,
+		MaxOperand:        int32(tenant.MaxOperand),
+		MaxPendingJobs:    int32(tenant.MaxPendingJobs),
+		CreatedAt:         timestamppb.New(tenant.CreatedAt),
+	}
+	if tenant.DisabledAt != nil {
+		resp.DisabledAt = timestamppb.New(*tenant.DisabledAt)
+	}
+	return resp
+}
@@ -0,0 +1,70 @@
+package rest
+
+import (
+	"log/slog"
+	"net/http"
+
+	domain "go-prisma-calculator/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// chainVerificationResponse is the JSON representation of an audit chain verification.
+type chainVerificationResponse struct {
+	TenantID  string              `json:"tenantId"`
+	Valid     bool                `json:"valid"`
+	Checked   int                 `json:"checked"`
+	Unchained int                 `json:"unchained"`
+	Truncated bool                `json:"truncated"`
+	Removed   int                 `json:"removed"`
+	Head      string              `json:"head"`
+	Broken    *chainBreakResponse `json:"broken,omitempty"`
+}
+
+type chainBreakResponse struct {
+	ID       string `json:"id"`
+	Seq      int    `json:"seq"`
+	Reason   string `json:"reason"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// VerifyChainHandler handles HTTP GET requests to the /audit/verify endpoint.
+// @Summary      Verify the audit chain
+// @Description  Walks the hash chain of the caller's tenant and reports the first broken link. Admin only.
+// @Produce      json
+// @Success      200  {object} rest.chainVerificationResponse
+// @Failure      403  {object} map[string]string
+// @Router       /audit/verify [get]
+func (a *Adapter) VerifyChainHandler(c *gin.Context) {
+	report, err := a.history.VerifyChain(c.Request.Context())
+	if err != nil {
+		a.logger.Error("Usecase failed for REST VerifyChain", slog.String("error", err.Error()))
+		c.JSON(historyError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, toChainVerificationResponse(report))
+}
+
+func toChainVerificationResponse(report *domain.ChainVerification) chainVerificationResponse {
+	resp := chainVerificationResponse{
+		TenantID:  report.TenantID,
+		Valid:     report.Valid(),
+		Checked:   report.Checked,
+		Unchained: report.Unchained,
+		Truncated: report.Truncated,
+		Removed:   report.Removed,
+		Head:      report.Head,
+	}
+	if b := report.Broken; b != nil {
+		resp.Broken = &chainBreakResponse{
+			ID:       b.ID,
+			Seq:      b.Seq,
+			Reason:   string(b.Reason),
+			Expected: b.Expected,
+			Actual:   b.Actual,
+		}
+	}
+	return resp
+}
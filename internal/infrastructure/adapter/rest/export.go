@@ -0,0 +1,81 @@
+package rest
+
+import (
+	"log/slog"
+	"net/http"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/infrastructure/export"
+
+	"github.com/gin-gonic/gin"
+)
+
+// exportCalculationsQuery defines the query parameters accepted when exporting calculations.
+type exportCalculationsQuery struct {
+	listCalculationsQuery
+	Format string `form:"format"`
+}
+
+// ExportCalculationsHandler handles HTTP GET requests to the /calculations/export endpoint.
+// The file is streamed while the history is read, so exports of any size use
+// constant memory. Errors after the first row has been sent can only be
+// logged; the client then receives a truncated file.
+// @Summary      Export calculations
+// @Description  Downloads the calculation history, newest first, as CSV, NDJSON or Parquet.
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Produce      application/vnd.apache.parquet
+// @Param        format     query  string  false  "csv (default), ndjson or parquet"
+// @Param        operation  query  string  false  "Filter by operation"
+// @Param        limit      query  int     false  "Maximum number of calculations (default all)"
+// @Param        offset     query  int     false  "Number of calculations to skip"
+// @Param        includeDeleted  query  bool  false  "Also export soft-deleted calculations"
+// @Success      200  {file}  file
+// @Router       /calculations/export [get]
+func (a *Adapter) ExportCalculationsHandler(c *gin.Context) {
+	var query exportCalculationsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+	format, err := export.ParseFormat(query.Format)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	a.logger.Info("Handling REST ExportCalculations request", slog.String("format", string(format)))
+
+	w, err := export.NewWriter(format, c.Writer)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", `attachment; filename="calculations.`+format.Extension()+`"`)
+
+	count := 0
+	err = a.history.ExportCalculations(c.Request.Context(), domain.CalculationFilter{
+		Operation:      query.Operation,
+		Limit:          query.Limit,
+		Offset:         query.Offset,
+		IncludeDeleted: query.IncludeDeleted,
+	}, func(calc domain.Calculation) error {
+		count++
+		return w.Write(calc)
+	})
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		a.logger.Error("Usecase failed for REST ExportCalculations", slog.Int("exported", count), slog.String("error", err.Error()))
+		if c.Writer.Written() {
+			c.Abort()
+			return
+		}
+		c.Writer.Header().Del("Content-Disposition")
+		c.JSON(historyError(err))
+		return
+	}
+
+	a.logger.Info("Exported calculations", slog.String("format", string(format)), slog.Int("count", count))
+}
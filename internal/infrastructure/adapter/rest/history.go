@@ -0,0 +1,192 @@
+package rest
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	domain "go-prisma-calculator/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// calculationResponse is the JSON representation of a recorded calculation.
+type calculationResponse struct {
+	ID        string     `json:"id"`
+	Operation string     `json:"operation"`
+	A         int        `json:"a"`
+	B         int        `json:"b"`
+	Result    int        `json:"result"`
+	CreatedAt time.Time  `json:"createdAt"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+	Hash      string     `json:"hash,omitempty"`
+	PrevHash  string     `json:"prevHash,omitempty"`
+	// Kind, Operands, Value, Details and Options are set for calculations
+	// that are not integer ones.
+	Kind     domain.Kind               `json:"kind,omitempty"`
+	Operands []string                  `json:"operands,omitempty"`
+	Value    string                    `json:"value,omitempty"`
+	Details  map[string]string         `json:"details,omitempty"`
+	Options  *domain.EvaluationOptions `json:"options,omitempty"`
+	// ComplexValue and ComplexOperands repeat Value and Operands as
+	// real/imag pairs for kind "complex".
+	ComplexValue    *complexNumber  `json:"complexValue,omitempty"`
+	ComplexOperands []complexNumber `json:"complexOperands,omitempty"`
+	// MatrixValue and MatrixOperands repeat Value and Operands as matrices
+	// for kind "matrix".
+	MatrixValue    domain.Matrix   `json:"matrixValue,omitempty"`
+	MatrixOperands []domain.Matrix `json:"matrixOperands,omitempty"`
+}
+
+// listCalculationsQuery defines the query parameters accepted when listing calculations.
+type listCalculationsQuery struct {
+	Operation      string `form:"operation"`
+	Limit          int    `form:"limit"`
+	Offset         int    `form:"offset"`
+	IncludeDeleted bool   `form:"includeDeleted"`
+}
+
+// GetCalculationHandler handles HTTP GET requests to the /calculations/:id endpoint.
+// @Summary      Get a calculation
+// @Description  Returns a single calculation from the history.
+// @Produce      json
+// @Param        id   path  string  true  "Calculation ID"
+// @Success      200  {object} rest.calculationResponse
+// @Router       /calculations/{id} [get]
+func (a *Adapter) GetCalculationHandler(c *gin.Context) {
+	calc, err := a.history.GetCalculation(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		a.logger.Error("Usecase failed for REST GetCalculation", slog.String("error", err.Error()))
+		c.JSON(historyError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, toCalculationResponse(calc))
+}
+
+// ListCalculationsHandler handles HTTP GET requests to the /calculations endpoint.
+// @Summary      List calculations
+// @Description  Pages through the calculation history, newest first.
+// @Produce      json
+// @Param        operation  query  string  false  "Filter by operation"
+// @Param        limit      query  int     false  "Page size"
+// @Param        offset     query  int     false  "Page offset"
+// @Param        includeDeleted  query  bool  false  "Also list soft-deleted calculations"
+// @Success      200  {array} rest.calculationResponse
+// @Router       /calculations [get]
+func (a *Adapter) ListCalculationsHandler(c *gin.Context) {
+	var query listCalculationsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	calcs, err := a.history.ListCalculations(c.Request.Context(), domain.CalculationFilter{
+		Operation:      query.Operation,
+		Limit:          query.Limit,
+		Offset:         query.Offset,
+		IncludeDeleted: query.IncludeDeleted,
+	})
+	if err != nil {
+		a.logger.Error("Usecase failed for REST ListCalculations", slog.String("error", err.Error()))
+		c.JSON(historyError(err))
+		return
+	}
+
+	resp := make([]calculationResponse, 0, len(calcs))
+	for i := range calcs {
+		resp = append(resp, toCalculationResponse(&calcs[i]))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteCalculationHandler handles HTTP DELETE requests to the /calculations/:id endpoint.
+// With ?purge=true the calculation is removed permanently, which requires an admin.
+// @Summary      Delete a calculation
+// @Description  Soft-deletes a calculation so it can be restored, or purges it for good.
+// @Param        id     path   string  true   "Calculation ID"
+// @Param        purge  query  bool    false  "Remove permanently (admin only)"
+// @Success      204
+// @Router       /calculations/{id} [delete]
+func (a *Adapter) DeleteCalculationHandler(c *gin.Context) {
+	id := c.Param("id")
+	purge := c.Query("purge") == "true"
+	a.logger.Info("Handling REST DeleteCalculation request", slog.String("calculation_id", id), slog.Bool("purge", purge))
+
+	var err error
+	if purge {
+		err = a.history.PurgeCalculation(c.Request.Context(), id)
+	} else {
+		err = a.history.DeleteCalculation(c.Request.Context(), id)
+	}
+	if err != nil {
+		a.logger.Error("Usecase failed for REST DeleteCalculation", slog.String("error", err.Error()))
+		c.JSON(historyError(err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RestoreCalculationHandler handles HTTP POST requests to the /calculations/:id/restore endpoint.
+// @Summary      Restore a calculation
+// @Description  Brings back a soft-deleted calculation.
+// @Produce      json
+// @Param        id   path  string  true  "Calculation ID"
+// @Success      200  {object} rest.calculationResponse
+// @Router       /calculations/{id}/restore [post]
+func (a *Adapter) RestoreCalculationHandler(c *gin.Context) {
+	a.logger.Info("Handling REST RestoreCalculation request", slog.String("calculation_id", c.Param("id")))
+
+	calc, err := a.history.RestoreCalculation(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		a.logger.Error("Usecase failed for REST RestoreCalculation", slog.String("error", err.Error()))
+		c.JSON(historyError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, toCalculationResponse(calc))
+}
+
+// historyError maps history usecase errors onto an HTTP status code and response body.
+func historyError(err error) (int, gin.H) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return http.StatusNotFound, gin.H{"error": "calculation not found"}
+	case errors.Is(err, domain.ErrInvalidStatsQuery):
+		return http.StatusBadRequest, gin.H{"error": err.Error()}
+	case errors.Is(err, domain.ErrForbidden):
+		return http.StatusForbidden, gin.H{"error": err.Error()}
+	case errors.Is(err, domain.ErrUnavailable):
+		return http.StatusServiceUnavailable, gin.H{"error": domain.ErrUnavailable.Error()}
+	default:
+		return http.StatusInternalServerError, gin.H{"error": "an unexpected error occurred"}
+	}
+}
+
+func toCalculationResponse(calc *domain.Calculation) calculationResponse {
+	resp := calculationResponse{
+		ID:        calc.ID,
+		Operation: calc.Operation,
+		A:         calc.A,
+		B:         calc.B,
+		Result:    calc.Result,
+		CreatedAt: calc.CreatedAt,
+		DeletedAt: calc.DeletedAt,
+		Hash:      calc.Hash,
+		PrevHash:  calc.PrevHash,
+	}
+	if !calc.IsInteger() {
+		resp.Kind, resp.Operands, resp.Value, resp.Options = calc.Kind, calc.Operands, calc.Value, &calc.Options
+		resp.Details = calc.Details
+	}
+	if calc.Kind == domain.KindComplex {
+		value := toComplexNumbers([]string{calc.Value})
+		resp.ComplexValue, resp.ComplexOperands = &value[0], toComplexNumbers(calc.Operands)
+	}
+	if operands, value, ok := calc.Matrices(); ok {
+		resp.MatrixValue, resp.MatrixOperands = value, operands
+	}
+	return resp
+}
@@ -0,0 +1,137 @@
+package rest
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/infrastructure/export"
+
+	"github.com/gin-gonic/gin"
+)
+
+// importForm defines the multipart form fields accepted when importing calculations.
+type importForm struct {
+	Format     string `form:"format"`
+	Recompute  bool   `form:"recompute"`
+	OnMismatch string `form:"onMismatch"`
+	DryRun     bool   `form:"dryRun"`
+}
+
+// importReportResponse is the JSON representation of an import report.
+type importReportResponse struct {
+	Total           int                   `json:"total"`
+	Accepted        int                   `json:"accepted"`
+	Rejected        int                   `json:"rejected"`
+	Mismatched      int                   `json:"mismatched"`
+	Issues          []importIssueResponse `json:"issues"`
+	IssuesTruncated bool                  `json:"issuesTruncated,omitempty"`
+}
+
+type importIssueResponse struct {
+	Line   int    `json:"line"`
+	Kind   string `json:"kind"`
+	Reason string `json:"reason"`
+}
+
+// ImportCalculationsHandler handles HTTP POST requests to the /calculations/import endpoint.
+// @Summary      Import calculations
+// @Description  Loads past calculations from an uploaded CSV or NDJSON file and reports accepted, rejected and mismatched rows.
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file        formData  file    true   "CSV or NDJSON file"
+// @Param        format      formData  string  false  "csv or ndjson (default: from the file name)"
+// @Param        recompute   formData  bool    false  "Check every result with the calculator"
+// @Param        onMismatch  formData  string  false  "reject (default), correct or keep"
+// @Param        dryRun      formData  bool    false  "Validate without storing anything"
+// @Success      200  {object} rest.importReportResponse
+// @Router       /calculations/import [post]
+func (a *Adapter) ImportCalculationsHandler(c *gin.Context) {
+	var form importForm
+	if err := c.ShouldBind(&form); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid form fields"})
+		return
+	}
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "a file must be uploaded in the \"file\" field"})
+		return
+	}
+
+	format := export.FormatFromFileName(header.Filename)
+	if form.Format != "" {
+		if format, err = export.ParseFormat(form.Format); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "could not read the uploaded file"})
+		return
+	}
+	defer file.Close()
+
+	reader, err := export.NewReader(format, file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	a.logger.Info("Handling REST ImportCalculations request",
+		slog.String("file", header.Filename),
+		slog.String("format", string(format)),
+		slog.Bool("recompute", form.Recompute),
+		slog.Bool("dry_run", form.DryRun),
+	)
+
+	report, err := a.imports.ImportCalculations(c.Request.Context(), domain.ImportOptions{
+		Recompute:  form.Recompute,
+		OnMismatch: domain.MismatchPolicy(form.OnMismatch),
+		DryRun:     form.DryRun,
+	}, reader.Read)
+	if err != nil {
+		a.logger.Error("Usecase failed for REST ImportCalculations", slog.String("error", err.Error()))
+		code, body := importError(err)
+		if report != nil && report.Total > 0 {
+			// Batches inserted before the failure stay imported.
+			body["report"] = toImportReportResponse(report)
+		}
+		c.JSON(code, body)
+		return
+	}
+
+	c.JSON(http.StatusOK, toImportReportResponse(report))
+}
+
+// importError maps import failures onto an HTTP status code and response body.
+func importError(err error) (int, gin.H) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidMismatchPolicy), errors.Is(err, export.ErrInvalidFile):
+		return http.StatusBadRequest, gin.H{"error": err.Error()}
+	case errors.Is(err, domain.ErrUnavailable):
+		return http.StatusServiceUnavailable, gin.H{"error": domain.ErrUnavailable.Error()}
+	default:
+		return http.StatusInternalServerError, gin.H{"error": "an unexpected error occurred"}
+	}
+}
+
+func toImportReportResponse(report *domain.ImportReport) importReportResponse {
+	resp := importReportResponse{
+		Total:           report.Total,
+		Accepted:        report.Accepted,
+		Rejected:        report.Rejected,
+		Mismatched:      report.Mismatched,
+		Issues:          make([]importIssueResponse, 0, len(report.Issues)),
+		IssuesTruncated: report.IssuesTruncated,
+	}
+	for _, issue := range report.Issues {
+		resp.Issues = append(resp.Issues, importIssueResponse{
+			Line:   issue.Line,
+			Kind:   string(issue.Kind),
+			Reason: issue.Reason,
+		})
+	}
+	return resp
+}
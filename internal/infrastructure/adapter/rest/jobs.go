@@ -0,0 +1,179 @@
+package rest
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	domain "go-prisma-calculator/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// submitJobRequest defines the structure for incoming job submissions.
+type submitJobRequest struct {
+	Operation string `json:"operation"`
+	A         int32  `json:"a"`
+	B         int32  `json:"b"`
+}
+
+// jobResponse is the JSON representation of a job.
+type jobResponse struct {
+	ID         string     `json:"id"`
+	Operation  string     `json:"operation"`
+	A          int        `json:"a"`
+	B          int        `json:"b"`
+	Status     string     `json:"status"`
+	Result     *int       `json:"result,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	StartedAt  *time.Time `json:"startedAt,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	// CalculationID is the calculation recorded by a succeeded job.
+	CalculationID string `json:"calculationId,omitempty"`
+}
+
+// listJobsQuery defines the query parameters accepted when listing jobs.
+type listJobsQuery struct {
+	Status string `form:"status"`
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+}
+
+// SubmitJobHandler handles HTTP POST requests to the /jobs endpoint.
+// @Summary      Submit an asynchronous calculation
+// @Description  Queues an operation and returns the job immediately.
+// @Accept       json
+// @Produce      json
+// @Param        request body rest.submitJobRequest true "Job Request"
+// @Success      202  {object} rest.jobResponse
+// @Router       /jobs [post]
+func (a *Adapter) SubmitJobHandler(c *gin.Context) {
+	var req submitJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		a.logger.Error("Failed to bind JSON request", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	a.logger.Info("Handling REST SubmitJob request", slog.String("operation", req.Operation))
+
+	job, err := a.jobs.SubmitJob(c.Request.Context(), req.Operation, req.A, req.B)
+	if err != nil {
+		a.logger.Error("Usecase failed for REST SubmitJob", slog.String("error", err.Error()))
+		c.JSON(jobError(err))
+		return
+	}
+
+	a.logger.Info("REST SubmitJob request successful", slog.String("job_id", job.ID))
+	c.JSON(http.StatusAccepted, toJobResponse(job))
+}
+
+// GetJobHandler handles HTTP GET requests to the /jobs/:id endpoint.
+// @Summary      Get a job
+// @Description  Returns the status and, once finished, the result of a job.
+// @Produce      json
+// @Param        id   path  string  true  "Job ID"
+// @Success      200  {object} rest.jobResponse
+// @Router       /jobs/{id} [get]
+func (a *Adapter) GetJobHandler(c *gin.Context) {
+	job, err := a.jobs.GetJob(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		a.logger.Error("Usecase failed for REST GetJob", slog.String("error", err.Error()))
+		c.JSON(jobError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, toJobResponse(job))
+}
+
+// CancelJobHandler handles HTTP POST requests to the /jobs/:id/cancel endpoint.
+// @Summary      Cancel a job
+// @Description  Stops a pending or running job.
+// @Produce      json
+// @Param        id   path  string  true  "Job ID"
+// @Success      200  {object} rest.jobResponse
+// @Router       /jobs/{id}/cancel [post]
+func (a *Adapter) CancelJobHandler(c *gin.Context) {
+	a.logger.Info("Handling REST CancelJob request", slog.String("job_id", c.Param("id")))
+
+	job, err := a.jobs.CancelJob(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		a.logger.Error("Usecase failed for REST CancelJob", slog.String("error", err.Error()))
+		c.JSON(jobError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, toJobResponse(job))
+}
+
+// ListJobsHandler handles HTTP GET requests to the /jobs endpoint.
+// @Summary      List jobs
+// @Description  Pages through submitted jobs, newest first.
+// @Produce      json
+// @Param        status  query  string  false  "Filter by status"
+// @Param        limit   query  int     false  "Page size"
+// @Param        offset  query  int     false  "Page offset"
+// @Success      200  {array} rest.jobResponse
+// @Router       /jobs [get]
+func (a *Adapter) ListJobsHandler(c *gin.Context) {
+	var query listJobsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	jobs, err := a.jobs.ListJobs(c.Request.Context(), domain.JobFilter{
+		Status: domain.JobStatus(query.Status),
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	})
+	if err != nil {
+		a.logger.Error("Usecase failed for REST ListJobs", slog.String("error", err.Error()))
+		c.JSON(jobError(err))
+		return
+	}
+
+	resp := make([]jobResponse, 0, len(jobs))
+	for i := range jobs {
+		resp = append(resp, toJobResponse(&jobs[i]))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// jobError maps job usecase errors onto an HTTP status code and response body.
+func jobError(err error) (int, gin.H) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return http.StatusNotFound, gin.H{"error": "job not found"}
+	case errors.Is(err, domain.ErrUnsupportedOperation), errors.Is(err, domain.ErrOperandOutOfRange):
+		return http.StatusBadRequest, gin.H{"error": err.Error()}
+	case errors.Is(err, domain.ErrOperationNotAllowed):
+		return http.StatusForbidden, gin.H{"error": err.Error()}
+	case errors.Is(err, domain.ErrTenantJobLimit):
+		return http.StatusTooManyRequests, gin.H{"error": err.Error()}
+	case errors.Is(err, domain.ErrJobFinished):
+		return http.StatusConflict, gin.H{"error": err.Error()}
+	case errors.Is(err, domain.ErrJobQueueFull):
+		return http.StatusServiceUnavailable, gin.H{"error": err.Error()}
+	default:
+		return http.StatusInternalServerError, gin.H{"error": "an unexpected error occurred"}
+	}
+}
+
+func toJobResponse(job *domain.Job) jobResponse {
+	return jobResponse{
+		ID:            job.ID,
+		Operation:     job.Operation,
+		A:             job.A,
+		B:             job.B,
+		Status:        string(job.Status),
+		Result:        job.Result,
+		Error:         job.Error,
+		CreatedAt:     job.CreatedAt,
+		StartedAt:     job.StartedAt,
+		FinishedAt:    job.FinishedAt,
+		CalculationID: job.CalculationID,
+	}
+}
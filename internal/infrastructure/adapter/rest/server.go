@@ -1,10 +1,14 @@
 package rest
 
 import (
+	"errors"
 	"log/slog"
 	"net/http"
+	"time"
 
+	domain "go-prisma-calculator/internal/domain/models"
 	"go-prisma-calculator/internal/domain/ports/in"
+	"go-prisma-calculator/internal/infrastructure/health"
 
 	"github.com/gin-gonic/gin"
 )
@@ -12,13 +16,17 @@ import (
 // Adapter is the REST API adapter.
 type Adapter struct {
 	usecase in.CalculatorPort
+	jobs    in.JobPort
+	history in.HistoryPort
+	imports in.ImportPort
+	tenants in.TenantPort
 	logger  *slog.Logger
 }
 
 // NewAdapter is the constructor that fx uses to create an instance.
-// It receives the application port and logger as dependencies.
-func NewAdapter(usecase in.CalculatorPort, logger *slog.Logger) *Adapter {
-	return &Adapter{usecase: usecase, logger: logger}
+// It receives the application ports and logger as dependencies.
+func NewAdapter(usecase in.CalculatorPort, jobs in.JobPort, history in.HistoryPort, imports in.ImportPort, tenants in.TenantPort, logger *slog.Logger) *Adapter {
+	return &Adapter{usecase: usecase, jobs: jobs, history: history, imports: imports, tenants: tenants, logger: logger}
 }
 
 // calcRequest defines the structure for incoming JSON requests.
@@ -27,13 +35,44 @@ type calcRequest struct {
 	B int32 `json:"b"`
 }
 
+// divideRequest is a calcRequest that may ask for a rounded quotient.
+type divideRequest struct {
+	calcRequest
+	// Rounding is "truncate" (the default), "floor", "ceiling", "half-up",
+	// "half-even" or "half-away-from-zero".
+	Rounding domain.RoundingMode `json:"rounding"`
+	// Scale is the number of digits after the decimal point.
+	Scale *int `json:"scale"`
+}
+
+// calcResponse is the JSON representation of a calculation result.
+type calcResponse struct {
+	// Result is always set, except for a division with a rounding mode or
+	// scale whose rounded quotient is not an integer of 32 bits.
+	Result *int `json:"result,omitempty"`
+	// Value is the rounded quotient of a division with a rounding mode or
+	// scale; Result then holds it when the scale is 0 and it fits into 32 bits.
+	Value     string           `json:"value,omitempty"`
+	ID        string           `json:"id,omitempty"`
+	CreatedAt *time.Time       `json:"createdAt,omitempty"`
+	Receipt   *receiptResponse `json:"receipt,omitempty"`
+}
+
+// receiptResponse is the detached signature of a calculation; the signature
+// is base64 encoded.
+type receiptResponse struct {
+	KeyID     string `json:"keyId"`
+	Algorithm string `json:"algorithm"`
+	Signature []byte `json:"signature"`
+}
+
 // AddHandler handles HTTP POST requests to the /add endpoint.
 // @Summary      Add two numbers
 // @Description  Takes two integers and returns their sum.
 // @Accept       json
 // @Produce      json
 // @Param        request body rest.calcRequest true "Add Request"
-// @Success      200  {object} map[string]int
+// @Success      200  {object} rest.calcResponse
 // @Router       /add [post]
 func (a *Adapter) AddHandler(c *gin.Context) {
 	var req calcRequest
@@ -45,27 +84,28 @@ func (a *Adapter) AddHandler(c *gin.Context) {
 
 	a.logger.Info("Handling REST Add request", slog.Int("a", int(req.A)), slog.Int("b", int(req.B)))
 
-	calculation, err := a.usecase.Add(c.Request.Context(), req.A, req.B)
+	calculation, err := a.usecase.Calculate(c.Request.Context(), "add", req.A, req.B)
 	if err != nil {
 		a.logger.Error("Usecase failed for REST Add", slog.String("error", err.Error()))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to perform addition"})
+		c.JSON(calculationError(err, "failed to perform addition"))
 		return
 	}
 
 	a.logger.Info("REST Add request successful", slog.Int("result", calculation.Result))
-	c.JSON(http.StatusOK, gin.H{"result": calculation.Result})
+	markHistorySkipped(c, calculation)
+	c.JSON(http.StatusOK, toCalcResponse(calculation))
 }
 
 // DivideHandler handles HTTP POST requests to the /divide endpoint.
 // @Summary      Divide two numbers
-// @Description  Takes two integers and returns their quotient.
+// @Description  Takes two integers and returns their quotient, truncated, or rounded to a scale with a rounding mode. A rounded division is recorded as a "divide" calculation of kind "rational", and result is omitted when the rounded quotient is not a 32-bit integer.
 // @Accept       json
 // @Produce      json
-// @Param        request body rest.calcRequest true "Divide Request"
-// @Success      200  {object} map[string]int
+// @Param        request body rest.divideRequest true "Divide Request"
+// @Success      200  {object} rest.calcResponse
 // @Router       /divide [post]
 func (a *Adapter) DivideHandler(c *gin.Context) {
-	var req calcRequest
+	var req divideRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		a.logger.Error("Failed to bind JSON request", slog.String("error", err.Error()))
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
@@ -73,14 +113,321 @@ func (a *Adapter) DivideHandler(c *gin.Context) {
 	}
 
 	a.logger.Info("Handling REST Divide request", slog.Int("a", int(req.A)), slog.Int("b", int(req.B)))
-	
-	calculation, err := a.usecase.Divide(c.Request.Context(), req.A, req.B)
+
+	if req.Rounding != "" || req.Scale != nil {
+		division := domain.RoundedDivision{Dividend: req.A, Divisor: req.B, Rounding: req.Rounding}
+		if req.Scale != nil {
+			division.Scale = *req.Scale
+		}
+		calculation, err := a.usecase.Divide(c.Request.Context(), division)
+		if err != nil {
+			a.logger.Error("Usecase failed for REST Divide", slog.String("error", err.Error()))
+			c.JSON(calculationError(err, "failed to perform division"))
+			return
+		}
+
+		resp := toCalcResponse(calculation)
+		resp.Value, resp.Result = domain.RoundedQuotient(calculation)
+		a.logger.Info("REST Divide request successful", slog.String("value", resp.Value))
+		markHistorySkipped(c, calculation)
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+
+	calculation, err := a.usecase.Calculate(c.Request.Context(), "divide", req.A, req.B)
 	if err != nil {
 		a.logger.Error("Usecase failed for REST Divide", slog.String("error", err.Error()))
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(calculationError(err, "failed to perform division"))
 		return
 	}
 
 	a.logger.Info("REST Divide request successful", slog.Int("result", calculation.Result))
-	c.JSON(http.StatusOK, gin.H{"result": calculation.Result})
-}
\ No newline at end of file
+	markHistorySkipped(c, calculation)
+	c.JSON(http.StatusOK, toCalcResponse(calculation))
+}
+
+// operationRequest runs any registered operation.
+type operationRequest struct {
+	Operation string  `json:"operation" binding:"required"`
+	Operands  []int32 `json:"operands"`
+}
+
+// operationSignature describes a registered operation.
+type operationSignature struct {
+	Name        string   `json:"name"`
+	Arity       int      `json:"arity"`
+	Description string   `json:"description"`
+	Operands    []string `json:"operands"`
+	Commutative bool     `json:"commutative"`
+}
+
+// CalculateHandler handles HTTP POST requests to the /calculate endpoint.
+// @Summary      Run an operation
+// @Description  Runs any registered operation; GET /operations lists them.
+// @Accept       json
+// @Produce      json
+// @Param        request body rest.operationRequest true "Calculate Request"
+// @Success      200  {object} rest.calcResponse
+// @Router       /calculate [post]
+func (a *Adapter) CalculateHandler(c *gin.Context) {
+	var req operationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		a.logger.Error("Failed to bind JSON request", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	a.logger.Info("Handling REST Calculate request", slog.String("operation", req.Operation), slog.Any("operands", req.Operands))
+
+	calculation, err := a.usecase.Calculate(c.Request.Context(), req.Operation, req.Operands...)
+	if err != nil {
+		a.logger.Error("Usecase failed for REST Calculate", slog.String("error", err.Error()))
+		c.JSON(calculationError(err, "failed to perform calculation"))
+		return
+	}
+
+	a.logger.Info("REST Calculate request successful", slog.Int("result", calculation.Result))
+	markHistorySkipped(c, calculation)
+	c.JSON(http.StatusOK, toCalcResponse(calculation))
+}
+
+// ListOperationsHandler handles HTTP GET requests to the /operations endpoint.
+// @Summary      List operations
+// @Description  Describes the operations the caller may run.
+// @Produce      json
+// @Success      200  {array} rest.operationSignature
+// @Router       /operations [get]
+func (a *Adapter) ListOperationsHandler(c *gin.Context) {
+	signatures, err := a.usecase.ListOperations(c.Request.Context())
+	if err != nil {
+		a.logger.Error("Usecase failed for REST ListOperations", slog.String("error", err.Error()))
+		c.JSON(calculationError(err, "failed to list operations"))
+		return
+	}
+
+	resp := make([]operationSignature, 0, len(signatures))
+	for _, sig := range signatures {
+		resp = append(resp, operationSignature{
+			Name:        sig.Name,
+			Arity:       sig.Arity,
+			Description: sig.Description,
+			Operands:    sig.Operands,
+			Commutative: sig.Commutative,
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// evaluateRequest evaluates a scientific function named in the path.
+type evaluateRequest struct {
+	// Operands are numbers in text form, e.g. "0.5" or "1+2i".
+	Operands []string `json:"operands" binding:"required_without_all=ComplexOperands MatrixOperands"`
+	// ComplexOperands are complex operands as real/imag pairs, instead of
+	// Operands; Kind defaults to "complex" with them.
+	ComplexOperands []complexNumber `json:"complexOperands" binding:"excluded_with=Operands"`
+	// MatrixOperands are matrix operands as arrays of rows, instead of
+	// Operands; Kind defaults to "matrix" with them.
+	MatrixOperands []domain.Matrix `json:"matrixOperands" binding:"excluded_with=Operands ComplexOperands"`
+	// Kind is "float" (the default), "decimal", "bigint", "rational",
+	// "complex" or "matrix".
+	Kind domain.Kind `json:"kind"`
+	// Angle is "radians" (the default) or "degrees".
+	Angle domain.AngleUnit `json:"angle"`
+	// Precision is the number of significant digits of decimal results.
+	Precision int `json:"precision"`
+	// Scale is the number of digits after the decimal point of the decimal
+	// rendering of rational results.
+	Scale *int `json:"scale"`
+	// Division is "truncated" (the default), "floored" or "euclidean".
+	Division domain.DivisionMode `json:"division"`
+	// Rounding is how the decimal rendering of rational results is rounded.
+	Rounding domain.RoundingMode `json:"rounding"`
+}
+
+// complexNumber is a complex number as a real/imag pair.
+type complexNumber struct {
+	Real float64 `json:"real"`
+	Imag float64 `json:"imag"`
+}
+
+// toComplexNumbers splits complex numbers in text form into real/imag
+// pairs.
+func toComplexNumbers(texts []string) []complexNumber {
+	numbers := make([]complexNumber, len(texts))
+	for i, text := range texts {
+		numbers[i].Real, numbers[i].Imag, _ = domain.ComplexParts(text)
+	}
+	return numbers
+}
+
+// evaluationResponse is the JSON representation of a function result.
+type evaluationResponse struct {
+	Value    string      `json:"value"`
+	Kind     domain.Kind `json:"kind"`
+	Operands []string    `json:"operands"`
+	// ComplexValue and ComplexOperands repeat Value and Operands as
+	// real/imag pairs for kind "complex".
+	ComplexValue    *complexNumber  `json:"complexValue,omitempty"`
+	ComplexOperands []complexNumber `json:"complexOperands,omitempty"`
+	// MatrixValue and MatrixOperands repeat Value and Operands as matrices
+	// for kind "matrix".
+	MatrixValue    domain.Matrix            `json:"matrixValue,omitempty"`
+	MatrixOperands []domain.Matrix          `json:"matrixOperands,omitempty"`
+	Details        map[string]string        `json:"details,omitempty"`
+	Options        domain.EvaluationOptions `json:"options"`
+	ID             string                   `json:"id"`
+	CreatedAt      time.Time                `json:"createdAt"`
+	Receipt        *receiptResponse         `json:"receipt,omitempty"`
+}
+
+// functionSignature describes a scientific function of a kind of number.
+type functionSignature struct {
+	Kind        domain.Kind `json:"kind"`
+	Name        string      `json:"name"`
+	Arity       int         `json:"arity"`
+	Description string      `json:"description"`
+	Operands    []string    `json:"operands"`
+	Commutative bool        `json:"commutative"`
+	Options     []string    `json:"options"`
+}
+
+// EvaluateHandler handles HTTP POST requests to the /functions/:name endpoint.
+// @Summary      Evaluate a function
+// @Description  Evaluates a scientific function of float or decimal operands, an exact operation of bigint or rational operands, or an operation of complex or matrix operands; GET /functions lists them.
+// @Accept       json
+// @Produce      json
+// @Param        name     path  string                 true  "Function name"
+// @Param        request  body  rest.evaluateRequest   true  "Evaluate Request"
+// @Success      200  {object} rest.evaluationResponse
+// @Router       /functions/{name} [post]
+func (a *Adapter) EvaluateHandler(c *gin.Context) {
+	var req evaluateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		a.logger.Error("Failed to bind JSON request", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	function := c.Param("name")
+	a.logger.Info("Handling REST Evaluate request", slog.String("function", function), slog.Any("operands", req.Operands))
+
+	kind, operands := req.Kind, req.Operands
+	if len(req.ComplexOperands) > 0 {
+		operands = make([]string, len(req.ComplexOperands))
+		for i, z := range req.ComplexOperands {
+			operands[i] = domain.FormatComplex(complex(z.Real, z.Imag))
+		}
+		if kind == "" {
+			kind = domain.KindComplex
+		}
+	}
+	if len(req.MatrixOperands) > 0 {
+		evaluation := domain.MatrixCalculation(function, req.MatrixOperands...)
+		operands = evaluation.Operands
+		if kind == "" {
+			kind = domain.KindMatrix
+		}
+	}
+	calculation, err := a.usecase.Evaluate(c.Request.Context(), domain.Evaluation{
+		Function: function,
+		Kind:     kind,
+		Operands: operands,
+		Options:  domain.EvaluationOptions{Angle: req.Angle, Precision: req.Precision, Scale: req.Scale, Division: req.Division, Rounding: req.Rounding},
+	})
+	if err != nil {
+		a.logger.Error("Usecase failed for REST Evaluate", slog.String("error", err.Error()))
+		c.JSON(calculationError(err, "failed to evaluate function"))
+		return
+	}
+
+	a.logger.Info("REST Evaluate request successful", slog.String("value", calculation.Value))
+	resp := evaluationResponse{
+		Value:     calculation.Value,
+		Kind:      calculation.Kind,
+		Operands:  calculation.Operands,
+		Details:   calculation.Details,
+		Options:   calculation.Options,
+		ID:        calculation.ID,
+		CreatedAt: calculation.CreatedAt,
+	}
+	if calculation.Kind == domain.KindComplex {
+		value := toComplexNumbers([]string{calculation.Value})
+		resp.ComplexValue, resp.ComplexOperands = &value[0], toComplexNumbers(calculation.Operands)
+	}
+	if operands, value, ok := calculation.Matrices(); ok {
+		resp.MatrixValue, resp.MatrixOperands = value, operands
+	}
+	if r := calculation.Receipt; r != nil {
+		resp.Receipt = &receiptResponse{KeyID: r.KeyID, Algorithm: r.Algorithm, Signature: r.Signature}
+	}
+	markHistorySkipped(c, calculation)
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListFunctionsHandler handles HTTP GET requests to the /functions endpoint.
+// @Summary      List functions
+// @Description  Describes the scientific functions the caller may evaluate, per kind of number.
+// @Produce      json
+// @Success      200  {array} rest.functionSignature
+// @Router       /functions [get]
+func (a *Adapter) ListFunctionsHandler(c *gin.Context) {
+	signatures, err := a.usecase.ListFunctions(c.Request.Context())
+	if err != nil {
+		a.logger.Error("Usecase failed for REST ListFunctions", slog.String("error", err.Error()))
+		c.JSON(calculationError(err, "failed to list functions"))
+		return
+	}
+
+	resp := make([]functionSignature, 0, len(signatures))
+	for _, sig := range signatures {
+		resp = append(resp, functionSignature{
+			Kind:        sig.Kind,
+			Name:        sig.Name,
+			Arity:       sig.Arity,
+			Description: sig.Description,
+			Operands:    sig.Operands,
+			Commutative: sig.Commutative,
+			Options:     sig.Options,
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// calculationError maps calculator usecase errors onto HTTP responses;
+// failure is reported for unexpected errors.
+func calculationError(err error, failure string) (int, gin.H) {
+	switch {
+	case errors.Is(err, domain.ErrDivisionByZero), errors.Is(err, domain.ErrOperandOutOfRange),
+		errors.Is(err, domain.ErrUnsupportedOperation), errors.Is(err, domain.ErrInvalidOperands),
+		errors.Is(err, domain.ErrOutOfDomain), errors.Is(err, domain.ErrSingularMatrix):
+		return http.StatusBadRequest, gin.H{"error": err.Error()}
+	case errors.Is(err, domain.ErrOperationNotAllowed):
+		return http.StatusForbidden, gin.H{"error": err.Error()}
+	case errors.Is(err, domain.ErrUnavailable):
+		return http.StatusServiceUnavailable, gin.H{"error": domain.ErrUnavailable.Error()}
+	case errors.Is(err, domain.ErrOperationFailed):
+		return http.StatusInternalServerError, gin.H{"error": err.Error()}
+	default:
+		return http.StatusInternalServerError, gin.H{"error": failure}
+	}
+}
+
+func toCalcResponse(calc *domain.Calculation) calcResponse {
+	resp := calcResponse{Result: &calc.Result, ID: calc.ID}
+	if !calc.CreatedAt.IsZero() {
+		resp.CreatedAt = &calc.CreatedAt
+	}
+	if r := calc.Receipt; r != nil {
+		resp.Receipt = &receiptResponse{KeyID: r.KeyID, Algorithm: r.Algorithm, Signature: r.Signature}
+	}
+	return resp
+}
+
+// markHistorySkipped sets the degraded header on the response of a
+// calculation that was not recorded, also when the database became
+// unavailable after the request was received.
+func markHistorySkipped(c *gin.Context, calc *domain.Calculation) {
+	if calc.HistorySkipped {
+		c.Header(health.DegradedHeader, "no-history")
+	}
+}
@@ -0,0 +1,127 @@
+package rest
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	domain "go-prisma-calculator/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// statsQuery defines the query parameters accepted by the statistics endpoint.
+type statsQuery struct {
+	From           time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To             time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Bucket         string    `form:"bucket"`
+	Operation      string    `form:"operation"`
+	Top            int       `form:"top"`
+	IncludeDeleted bool      `form:"includeDeleted"`
+}
+
+// statisticsResponse is the JSON representation of the history statistics.
+type statisticsResponse struct {
+	From       time.Time                `json:"from"`
+	To         time.Time                `json:"to"`
+	Bucket     string                   `json:"bucket"`
+	Total      int                      `json:"total"`
+	Operations []operationStatsResponse `json:"operations"`
+	Histogram  []histogramResponse      `json:"histogram"`
+	TopPairs   []operandPairResponse    `json:"topPairs"`
+	Errors     []errorStatsResponse     `json:"errors"`
+}
+
+type operationStatsResponse struct {
+	Operation string  `json:"operation"`
+	Count     int     `json:"count"`
+	MinResult int     `json:"minResult"`
+	MaxResult int     `json:"maxResult"`
+	AvgResult float64 `json:"avgResult"`
+}
+
+type histogramResponse struct {
+	Start time.Time `json:"start"`
+	Count int       `json:"count"`
+}
+
+type operandPairResponse struct {
+	Operation string `json:"operation"`
+	A         int    `json:"a"`
+	B         int    `json:"b"`
+	Count     int    `json:"count"`
+}
+
+type errorStatsResponse struct {
+	Operation string  `json:"operation"`
+	Finished  int     `json:"finished"`
+	Failed    int     `json:"failed"`
+	Rate      float64 `json:"rate"`
+}
+
+// GetStatisticsHandler handles HTTP GET requests to the /stats endpoint.
+// @Summary      Get history statistics
+// @Description  Aggregates the calculation history over a time range: counts and results per operation, a histogram, the most frequent operand pairs and job error rates.
+// @Produce      json
+// @Param        from       query  string  false  "Start of the range (RFC 3339), defaults to seven days before to"
+// @Param        to         query  string  false  "End of the range (RFC 3339), defaults to now"
+// @Param        bucket     query  string  false  "Histogram bucket: hour or day"
+// @Param        operation  query  string  false  "Filter by operation"
+// @Param        top        query  int     false  "Number of most frequent operand pairs (default 10)"
+// @Param        includeDeleted  query  bool  false  "Also count soft-deleted calculations"
+// @Success      200  {object} rest.statisticsResponse
+// @Router       /stats [get]
+func (a *Adapter) GetStatisticsHandler(c *gin.Context) {
+	var query statsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	stats, err := a.history.GetStatistics(c.Request.Context(), domain.StatsQuery{
+		From:           query.From,
+		To:             query.To,
+		Bucket:         domain.StatsBucket(query.Bucket),
+		Operation:      query.Operation,
+		TopPairs:       query.Top,
+		IncludeDeleted: query.IncludeDeleted,
+	})
+	if err != nil {
+		a.logger.Error("Usecase failed for REST GetStatistics", slog.String("error", err.Error()))
+		c.JSON(historyError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, toStatisticsResponse(stats))
+}
+
+func toStatisticsResponse(stats *domain.Statistics) statisticsResponse {
+	resp := statisticsResponse{
+		From:       stats.From,
+		To:         stats.To,
+		Bucket:     string(stats.Bucket),
+		Total:      stats.Total,
+		Operations: make([]operationStatsResponse, 0, len(stats.Operations)),
+		Histogram:  make([]histogramResponse, 0, len(stats.Histogram)),
+		TopPairs:   make([]operandPairResponse, 0, len(stats.TopPairs)),
+		Errors:     make([]errorStatsResponse, 0, len(stats.Errors)),
+	}
+	for _, op := range stats.Operations {
+		resp.Operations = append(resp.Operations, operationStatsResponse(op))
+	}
+	for _, b := range stats.Histogram {
+		resp.Histogram = append(resp.Histogram, histogramResponse(b))
+	}
+	for _, p := range stats.TopPairs {
+		resp.TopPairs = append(resp.TopPairs, operandPairResponse(p))
+	}
+	for _, e := range stats.Errors {
+		resp.Errors = append(resp.Errors, errorStatsResponse{
+			Operation: e.Operation,
+			Finished:  e.Finished,
+			Failed:    e.Failed,
+			Rate:      e.Rate(),
+		})
+	}
+	return resp
+}
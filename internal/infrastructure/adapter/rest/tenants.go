@@ -0,0 +1,213 @@
+package rest
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	domain "go-prisma-calculator/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// tenantRequest defines the structure for creating and updating tenants.
+type tenantRequest struct {
+	ID                string   `json:"id"`
+	Name              string   `json:"name"`
+	AllowedOperations []string `json:"allowedOperations"`
+	MaxOperand        int      `json:"maxOperand"`
+	MaxPendingJobs    int      `json:"maxPendingJobs"`
+}
+
+// tenantResponse is the JSON representation of a tenant.
+type tenantResponse struct {
+	ID                string     `json:"id"`
+	Name              string     `json:"name"`
+	AllowedOperations []string   `json:"allowedOperations"`
+	MaxOperand        int        `json:"maxOperand"`
+	MaxPendingJobs    int        `json:"maxPendingJobs"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	DisabledAt        *time.Time `json:"disabledAt,omitempty"`
+}
+
+// CreateTenantHandler handles HTTP POST requests to the /tenants endpoint.
+// @Summary      Create a tenant
+// @Description  Adds a tenant with its allowed operations and limits. Admin only.
+// @Accept       json
+// @Produce      json
+// @Param        request body rest.tenantRequest true "Tenant"
+// @Success      201  {object} rest.tenantResponse
+// @Router       /tenants [post]
+func (a *Adapter) CreateTenantHandler(c *gin.Context) {
+	var req tenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		a.logger.Error("Failed to bind JSON request", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	a.logger.Info("Handling REST CreateTenant request", slog.String("tenant", req.ID))
+
+	tenant, err := a.tenants.CreateTenant(c.Request.Context(), req.toTenant())
+	if err != nil {
+		a.logger.Error("Usecase failed for REST CreateTenant", slog.String("error", err.Error()))
+		c.JSON(tenantError(err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, toTenantResponse(tenant))
+}
+
+// ListTenantsHandler handles HTTP GET requests to the /tenants endpoint.
+// @Summary      List tenants
+// @Description  Returns every tenant, including disabled ones. Admin only.
+// @Produce      json
+// @Success      200  {array} rest.tenantResponse
+// @Router       /tenants [get]
+func (a *Adapter) ListTenantsHandler(c *gin.Context) {
+	tenants, err := a.tenants.ListTenants(c.Request.Context())
+	if err != nil {
+		a.logger.Error("Usecase failed for REST ListTenants", slog.String("error", err.Error()))
+		c.JSON(tenantError(err))
+		return
+	}
+
+	resp := make([]tenantResponse, 0, len(tenants))
+	for i := range tenants {
+		resp = append(resp, toTenantResponse(&tenants[i]))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetTenantHandler handles HTTP GET requests to the /tenants/:id endpoint.
+// @Summary      Get a tenant
+// @Description  Returns a single tenant. Admin only.
+// @Produce      json
+// @Param        id   path  string  true  "Tenant ID"
+// @Success      200  {object} rest.tenantResponse
+// @Router       /tenants/{id} [get]
+func (a *Adapter) GetTenantHandler(c *gin.Context) {
+	tenant, err := a.tenants.GetTenant(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		a.logger.Error("Usecase failed for REST GetTenant", slog.String("error", err.Error()))
+		c.JSON(tenantError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, toTenantResponse(tenant))
+}
+
+// UpdateTenantHandler handles HTTP PUT requests to the /tenants/:id endpoint.
+// @Summary      Update a tenant
+// @Description  Replaces the name, allowed operations and limits of a tenant. Admin only.
+// @Accept       json
+// @Produce      json
+// @Param        id       path  string              true  "Tenant ID"
+// @Param        request  body  rest.tenantRequest  true  "Tenant"
+// @Success      200  {object} rest.tenantResponse
+// @Router       /tenants/{id} [put]
+func (a *Adapter) UpdateTenantHandler(c *gin.Context) {
+	var req tenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		a.logger.Error("Failed to bind JSON request", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+	req.ID = c.Param("id")
+
+	a.logger.Info("Handling REST UpdateTenant request", slog.String("tenant", req.ID))
+
+	tenant, err := a.tenants.UpdateTenant(c.Request.Context(), req.toTenant())
+	if err != nil {
+		a.logger.Error("Usecase failed for REST UpdateTenant", slog.String("error", err.Error()))
+		c.JSON(tenantError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, toTenantResponse(tenant))
+}
+
+// DisableTenantHandler handles HTTP POST requests to the /tenants/:id/disable endpoint.
+// @Summary      Disable a tenant
+// @Description  Rejects further requests to a tenant while keeping its history. Admin only.
+// @Produce      json
+// @Param        id   path  string  true  "Tenant ID"
+// @Success      200  {object} rest.tenantResponse
+// @Router       /tenants/{id}/disable [post]
+func (a *Adapter) DisableTenantHandler(c *gin.Context) {
+	a.logger.Info("Handling REST DisableTenant request", slog.String("tenant", c.Param("id")))
+
+	tenant, err := a.tenants.DisableTenant(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		a.logger.Error("Usecase failed for REST DisableTenant", slog.String("error", err.Error()))
+		c.JSON(tenantError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, toTenantResponse(tenant))
+}
+
+// EnableTenantHandler handles HTTP POST requests to the /tenants/:id/enable endpoint.
+// @Summary      Enable a tenant
+// @Description  Accepts requests to a disabled tenant again. Admin only.
+// @Produce      json
+// @Param        id   path  string  true  "Tenant ID"
+// @Success      200  {object} rest.tenantResponse
+// @Router       /tenants/{id}/enable [post]
+func (a *Adapter) EnableTenantHandler(c *gin.Context) {
+	a.logger.Info("Handling REST EnableTenant request", slog.String("tenant", c.Param("id")))
+
+	tenant, err := a.tenants.EnableTenant(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		a.logger.Error("Usecase failed for REST EnableTenant", slog.String("error", err.Error()))
+		c.JSON(tenantError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, toTenantResponse(tenant))
+}
+
+// tenantError maps tenant usecase errors onto an HTTP status code and response body.
+func tenantError(err error) (int, gin.H) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return http.StatusNotFound, gin.H{"error": "tenant not found"}
+	case errors.Is(err, domain.ErrInvalidTenant):
+		return http.StatusBadRequest, gin.H{"error": err.Error()}
+	case errors.Is(err, domain.ErrTenantExists):
+		return http.StatusConflict, gin.H{"error": err.Error()}
+	case errors.Is(err, domain.ErrForbidden):
+		return http.StatusForbidden, gin.H{"error": err.Error()}
+	case errors.Is(err, domain.ErrUnavailable):
+		return http.StatusServiceUnavailable, gin.H{"error": domain.ErrUnavailable.Error()}
+	default:
+		return http.StatusInternalServerError, gin.H{"error": "an unexpected error occurred"}
+	}
+}
+
+func (r tenantRequest) toTenant() domain.Tenant {
+	return domain.Tenant{
+		ID:                r.ID,
+		Name:              r.Name,
+		AllowedOperations: r.AllowedOperations,
+		MaxOperand:        r.MaxOperand,
+		MaxPendingJobs:    r.MaxPendingJobs,
+	}
+}
+
+func toTenantResponse(tenant *domain.Tenant) tenantResponse {
+	resp := tenantResponse{
+		ID:                tenant.ID,
+		Name:              tenant.Name,
+		AllowedOperations: tenant.AllowedOperations,
+		MaxOperand:        tenant.MaxOperand,
+		MaxPendingJobs:    tenant.MaxPendingJobs,
+		CreatedAt:         tenant.CreatedAt,
+		DisabledAt:        tenant.DisabledAt,
+	}
+	if resp.AllowedOperations == nil {
+		resp.AllowedOperations = []string{}
+	}
+	return resp
+}
@@ -0,0 +1,244 @@
+// Package auth resolves the principal of REST and gRPC requests from an API
+// key or a JWT bearer token, and the tenant the request runs in.
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/ports/in"
+	"go-prisma-calculator/internal/infrastructure/config"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Header names carrying credentials and the requested tenant. gRPC metadata
+// keys are the lowercase forms.
+const (
+	APIKeyHeader        = "X-API-Key"
+	AuthorizationHeader = "Authorization"
+	TenantHeader        = "X-Tenant-ID"
+)
+
+// healthServicePrefix is the method prefix of the standard gRPC health service.
+const healthServicePrefix = "/grpc.health.v1.Health/"
+
+var (
+	// ErrMissingCredentials is returned when authentication is required but none was sent.
+	ErrMissingCredentials = errors.New("missing credentials")
+	// ErrInvalidCredentials is returned for an unknown API key or an invalid token.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// Authenticator maps request credentials onto a domain.Principal and
+// resolves the tenant of the request.
+type Authenticator struct {
+	keys      map[string]domain.Principal
+	jwtSecret []byte
+	required  bool
+	tenants   in.TenantPort
+}
+
+// NewAuthenticator builds an Authenticator from the API_KEYS, JWT_SECRET and
+// AUTH_REQUIRED settings.
+func NewAuthenticator(c *config.Config, tenants in.TenantPort) (*Authenticator, error) {
+	keys, err := parseAPIKeys(c.APIKeys)
+	if err != nil {
+		return nil, err
+	}
+	return &Authenticator{keys: keys, jwtSecret: []byte(c.JWTSecret), required: c.AuthRequired, tenants: tenants}, nil
+}
+
+// Authenticate resolves the principal from an API key or an Authorization
+// header. Without credentials it returns domain.Anonymous, unless
+// authentication is required.
+func (a *Authenticator) Authenticate(apiKey, authorization string) (domain.Principal, error) {
+	switch {
+	case apiKey != "":
+		for key, principal := range a.keys {
+			if subtle.ConstantTimeCompare([]byte(key), []byte(apiKey)) == 1 {
+				return principal, nil
+			}
+		}
+		return domain.Principal{}, ErrInvalidCredentials
+	case authorization != "":
+		token, ok := strings.CutPrefix(authorization, "Bearer ")
+		if !ok || len(a.jwtSecret) == 0 {
+			return domain.Principal{}, ErrInvalidCredentials
+		}
+		return a.parseToken(token)
+	case a.required:
+		return domain.Principal{}, ErrMissingCredentials
+	default:
+		return domain.Anonymous, nil
+	}
+}
+
+// claims are the JWT claims understood by the service: "sub" names the
+// principal, "role" is "user" (the default) or "admin" and the optional
+// "tenant" binds the token to a tenant.
+type claims struct {
+	Role   domain.Role `json:"role"`
+	Tenant string      `json:"tenant"`
+	jwt.RegisteredClaims
+}
+
+func (a *Authenticator) parseToken(raw string) (domain.Principal, error) {
+	var c claims
+	_, err := jwt.ParseWithClaims(raw, &c, func(*jwt.Token) (any, error) {
+		return a.jwtSecret, nil
+	}, jwt.WithValidMethods([]string{"HS256", "HS384", "HS512"}), jwt.WithExpirationRequired())
+	if err != nil || c.Subject == "" {
+		return domain.Principal{}, ErrInvalidCredentials
+	}
+
+	role := c.Role
+	if role == "" {
+		role = domain.RoleUser
+	}
+	if role != domain.RoleUser && role != domain.RoleAdmin {
+		return domain.Principal{}, ErrInvalidCredentials
+	}
+	return domain.Principal{Name: c.Subject, Role: role, Tenant: c.Tenant}, nil
+}
+
+// Middleware authenticates REST requests and stores the principal and the
+// tenant in the request context. Requests with bad credentials are rejected
+// with 401 and requests to a tenant they may not use with 403.
+func (a *Authenticator) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, err := a.Authenticate(c.GetHeader(APIKeyHeader), c.GetHeader(AuthorizationHeader))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		ctx := domain.WithPrincipal(c.Request.Context(), principal)
+
+		tenant, err := a.tenants.ResolveTenant(ctx, c.GetHeader(TenantHeader))
+		if err != nil {
+			c.AbortWithStatusJSON(tenantErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.Request = c.Request.WithContext(domain.WithTenant(ctx, *tenant))
+		c.Next()
+	}
+}
+
+// tenantErrorStatus maps a tenant resolution error onto an HTTP status code.
+func tenantErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrForbidden), errors.Is(err, domain.ErrUnknownTenant), errors.Is(err, domain.ErrTenantDisabled):
+		return http.StatusForbidden
+	default:
+		return http.StatusServiceUnavailable
+	}
+}
+
+// UnaryServerInterceptor authenticates gRPC calls and stores the principal in
+// the call context. Calls with bad credentials fail with UNAUTHENTICATED.
+// Health checks are always allowed so probes need no credentials.
+func (a *Authenticator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if strings.HasPrefix(info.FullMethod, healthServicePrefix) {
+			return handler(ctx, req)
+		}
+		ctx, err := a.authenticateContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of UnaryServerInterceptor.
+func (a *Authenticator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if strings.HasPrefix(info.FullMethod, healthServicePrefix) {
+			return handler(srv, ss)
+		}
+		ctx, err := a.authenticateContext(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticatedStream replaces the context of a server stream with one
+// carrying the principal.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (a *Authenticator) authenticateContext(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	principal, err := a.Authenticate(first(md, APIKeyHeader), first(md, AuthorizationHeader))
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	ctx = domain.WithPrincipal(ctx, principal)
+
+	tenant, err := a.tenants.ResolveTenant(ctx, first(md, TenantHeader))
+	if err != nil {
+		code := codes.Unavailable
+		if tenantErrorStatus(err) == http.StatusForbidden {
+			code = codes.PermissionDenied
+		}
+		return nil, status.Error(code, err.Error())
+	}
+	return domain.WithTenant(ctx, *tenant), nil
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// parseAPIKeys parses "name:key:role:tenant" entries separated by commas, e.g.
+// "ci:s3cret:user:billing,ops:t0psecret:admin". The role defaults to user and
+// keys without a tenant use the default tenant; admins may select another
+// one with the X-Tenant-ID header.
+func parseAPIKeys(raw string) (map[string]domain.Principal, error) {
+	keys := make(map[string]domain.Principal)
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.Split(entry, ":")
+		if len(parts) < 2 || len(parts) > 4 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid API_KEYS entry for %q: want name:key[:role[:tenant]]", parts[0])
+		}
+		role := domain.RoleUser
+		if len(parts) >= 3 && parts[2] != "" {
+			role = domain.Role(parts[2])
+		}
+		var tenant string
+		if len(parts) == 4 {
+			tenant = parts[3]
+		}
+		if role != domain.RoleUser && role != domain.RoleAdmin {
+			return nil, fmt.Errorf("invalid role %q for API key %q", role, parts[0])
+		}
+		keys[parts[1]] = domain.Principal{Name: parts[0], Role: role, Tenant: tenant}
+	}
+	return keys, nil
+}
@@ -0,0 +1,195 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/ports/in"
+	"go-prisma-calculator/internal/infrastructure/config"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testSecret = "s3cret"
+
+// defaultTenants resolves every request to the default tenant.
+type defaultTenants struct {
+	in.TenantPort
+}
+
+func (defaultTenants) ResolveTenant(context.Context, string) (*domain.Tenant, error) {
+	tenant := domain.DefaultTenant
+	return &tenant, nil
+}
+
+func newTestAuthenticator(t *testing.T, required bool) *Authenticator {
+	t.Helper()
+	a, err := NewAuthenticator(&config.Config{
+		APIKeys:      "ci:k1,ops:k2:admin",
+		JWTSecret:    testSecret,
+		AuthRequired: required,
+	}, defaultTenants{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestParseAPIKeys(t *testing.T) {
+	keys, err := parseAPIKeys(" ci:k1 , ops:k2:admin, billing:k3:user:team-a, batch:k4::team-b,")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]domain.Principal{
+		"k1": {Name: "ci", Role: domain.RoleUser},
+		"k2": {Name: "ops", Role: domain.RoleAdmin},
+		"k3": {Name: "billing", Role: domain.RoleUser, Tenant: "team-a"},
+		"k4": {Name: "batch", Role: domain.RoleUser, Tenant: "team-b"},
+	}
+	if len(keys) != len(want) {
+		t.Errorf("got %d keys, want %d", len(keys), len(want))
+	}
+	for key, principal := range want {
+		if keys[key] != principal {
+			t.Errorf("key %q = %+v, want %+v", key, keys[key], principal)
+		}
+	}
+
+	for _, raw := range []string{
+		"ci",
+		"ci:",
+		":k1",
+		"ci:k1:root",
+		"ci:k1:user:team-a:extra",
+	} {
+		if _, err := parseAPIKeys(raw); err == nil {
+			t.Errorf("parseAPIKeys(%q) accepted an invalid entry", raw)
+		}
+	}
+}
+
+func TestParseToken(t *testing.T) {
+	a := newTestAuthenticator(t, true)
+	expiresAt := jwt.NewNumericDate(time.Now().Add(time.Hour))
+	sign := func(method jwt.SigningMethod, key any, c jwt.MapClaims) string {
+		raw, err := jwt.NewWithClaims(method, c).SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return raw
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    domain.Principal
+		wantErr bool
+	}{
+		{
+			name:  "default role",
+			token: sign(jwt.SigningMethodHS256, []byte(testSecret), jwt.MapClaims{"sub": "alice", "exp": expiresAt}),
+			want:  domain.Principal{Name: "alice", Role: domain.RoleUser},
+		},
+		{
+			name:  "admin bound to a tenant",
+			token: sign(jwt.SigningMethodHS512, []byte(testSecret), jwt.MapClaims{"sub": "bob", "role": "admin", "tenant": "team-a", "exp": expiresAt}),
+			want:  domain.Principal{Name: "bob", Role: domain.RoleAdmin, Tenant: "team-a"},
+		},
+		{
+			name:    "missing exp",
+			token:   sign(jwt.SigningMethodHS256, []byte(testSecret), jwt.MapClaims{"sub": "alice"}),
+			wantErr: true,
+		},
+		{
+			name:    "expired",
+			token:   sign(jwt.SigningMethodHS256, []byte(testSecret), jwt.MapClaims{"sub": "alice", "exp": time.Now().Add(-time.Minute).Unix()}),
+			wantErr: true,
+		},
+		{
+			name:    "alg none",
+			token:   sign(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, jwt.MapClaims{"sub": "alice", "exp": expiresAt}),
+			wantErr: true,
+		},
+		{
+			name:    "other secret",
+			token:   sign(jwt.SigningMethodHS256, []byte("other"), jwt.MapClaims{"sub": "alice", "exp": expiresAt}),
+			wantErr: true,
+		},
+		{
+			name:    "unknown role",
+			token:   sign(jwt.SigningMethodHS256, []byte(testSecret), jwt.MapClaims{"sub": "alice", "role": "root", "exp": expiresAt}),
+			wantErr: true,
+		},
+		{
+			name:    "missing sub",
+			token:   sign(jwt.SigningMethodHS256, []byte(testSecret), jwt.MapClaims{"exp": expiresAt}),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := a.Authenticate("", "Bearer "+tt.token)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidCredentials) {
+					t.Errorf("error = %v, want %v", err, ErrInvalidCredentials)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("Authenticate = %+v, %v, want %+v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+// testStream is a server stream with a context.
+type testStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s testStream) Context() context.Context { return s.ctx }
+
+func TestInterceptorsAllowHealthChecks(t *testing.T) {
+	a := newTestAuthenticator(t, true)
+	withKey := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", "k1"))
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		method   string
+		wantCode codes.Code
+	}{
+		{"health check without credentials", context.Background(), "/grpc.health.v1.Health/Check", codes.OK},
+		{"health watch without credentials", context.Background(), "/grpc.health.v1.Health/Watch", codes.OK},
+		{"calculation without credentials", context.Background(), "/calculator.CalculatorService/Add", codes.Unauthenticated},
+		{"calculation with an API key", withKey, "/calculator.CalculatorService/Add", codes.OK},
+		{"health-like method of another service", context.Background(), "/calculator.CalculatorService/grpc.health.v1.Health/Check", codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unary := a.UnaryServerInterceptor()
+			_, err := unary(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(context.Context, any) (any, error) {
+				return nil, nil
+			})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("unary code = %v, want %v", got, tt.wantCode)
+			}
+
+			stream := a.StreamServerInterceptor()
+			err = stream(nil, testStream{ctx: tt.ctx}, &grpc.StreamServerInfo{FullMethod: tt.method}, func(any, grpc.ServerStream) error {
+				return nil
+			})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("stream code = %v, want %v", got, tt.wantCode)
+			}
+		})
+	}
+}
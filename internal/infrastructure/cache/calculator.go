@@ -0,0 +1,156 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/ports/in"
+	"go-prisma-calculator/internal/domain/ports/out"
+	"go-prisma-calculator/internal/domain/service"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	cacheHits = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "calculator_cache_hits_total",
+		Help: "Number of calculations served from the result cache.",
+	}, []string{"operation"})
+
+	cacheMisses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "calculator_cache_misses_total",
+		Help: "Number of calculations that had to be computed.",
+	}, []string{"operation"})
+)
+
+// Options configures the result cache.
+type Options struct {
+	// Size is the maximum number of results kept in memory.
+	Size int
+	// TTL is how long a cached result stays valid.
+	TTL time.Duration
+	// RecordHits controls whether a cache hit still writes a history row.
+	// Hits that are not recorded are returned without an ID.
+	RecordHits bool
+}
+
+// CalculatorCache is a decorator around in.CalculatorPort that memoizes
+// results of pure calculations in an LRU cache with a TTL.
+type CalculatorCache struct {
+	next       in.CalculatorPort
+	repo       out.CalculationRepositoryPort
+	operations *service.OperationRegistry
+	logger     *slog.Logger
+	lru        *expirable.LRU[string, domain.Calculation]
+	opts       Options
+}
+
+// NewCalculatorCache wraps next with an in-memory result cache.
+// The repository is only used to record history rows on cache hits, and the
+// registry tells which operations are commutative.
+func NewCalculatorCache(next in.CalculatorPort, repo out.CalculationRepositoryPort, operations *service.OperationRegistry, opts Options, logger *slog.Logger) in.CalculatorPort {
+	c := &CalculatorCache{
+		next:       next,
+		repo:       repo,
+		operations: operations,
+		logger:     logger,
+		lru:        expirable.NewLRU[string, domain.Calculation](opts.Size, nil, opts.TTL),
+		opts:       opts,
+	}
+	// A replaced operation may compute different results. Operations change
+	// rarely, so the whole cache is dropped.
+	operations.OnChange(func(string) { c.lru.Purge() })
+	return c
+}
+
+// Calculate returns a cached result when available, otherwise delegates and caches the result.
+func (c *CalculatorCache) Calculate(ctx context.Context, operation string, operands ...int32) (*domain.Calculation, error) {
+	k := key(operation, c.canonical(operation, operands)...)
+	if cached, ok := c.lru.Get(k); ok {
+		cacheHits.WithLabelValues(operation).Inc()
+		return c.hit(ctx, cached, operands)
+	}
+
+	cacheMisses.WithLabelValues(operation).Inc()
+	calc, err := c.next.Calculate(ctx, operation, operands...)
+	if err != nil {
+		// Errors are not cached; invalid input is rejected before any I/O anyway.
+		return nil, err
+	}
+
+	c.lru.Add(k, *calc)
+	return calc, nil
+}
+
+// ListOperations delegates to the wrapped port.
+func (c *CalculatorCache) ListOperations(ctx context.Context) ([]domain.OperationSignature, error) {
+	return c.next.ListOperations(ctx)
+}
+
+// Divide delegates to the wrapped port. Like evaluations, rounded divisions
+// are not cached.
+func (c *CalculatorCache) Divide(ctx context.Context, d domain.RoundedDivision) (*domain.Calculation, error) {
+	return c.next.Divide(ctx, d)
+}
+
+// Evaluate delegates to the wrapped port. Evaluations are cheap compared
+// to recording them and are not cached.
+func (c *CalculatorCache) Evaluate(ctx context.Context, e domain.Evaluation) (*domain.Calculation, error) {
+	return c.next.Evaluate(ctx, e)
+}
+
+// ListFunctions delegates to the wrapped port.
+func (c *CalculatorCache) ListFunctions(ctx context.Context) ([]domain.FunctionSignature, error) {
+	return c.next.ListFunctions(ctx)
+}
+
+// canonical orders the operands of commutative operations, so that 2+3 and
+// 3+2 share a cache entry.
+func (c *CalculatorCache) canonical(operation string, operands []int32) []int32 {
+	op, err := c.operations.Lookup(operation)
+	if err != nil || !op.Describe().Commutative || len(operands) != 2 || operands[0] <= operands[1] {
+		return operands
+	}
+	return []int32{operands[1], operands[0]}
+}
+
+// hit returns a copy of a cached calculation for the requested operands,
+// recording it in the history if configured. A hit that is not recorded has
+// no ID, as there is no history row it could refer to.
+func (c *CalculatorCache) hit(ctx context.Context, cached domain.Calculation, operands []int32) (*domain.Calculation, error) {
+	calc := cached
+	calc.ID, calc.HistorySkipped = "", false
+	calc.CreatedAt = domain.ChainTime(time.Now())
+	calc.A = int(operands[0])
+	if len(operands) > 1 {
+		calc.B = int(operands[1])
+	}
+	// The cached entry may have been computed for somebody else, possibly
+	// in another tenant.
+	calc.Principal = domain.PrincipalFromContext(ctx).Name
+	calc.TenantID = domain.TenantFromContext(ctx).ID
+
+	if c.opts.RecordHits {
+		calc.ID = domain.NewCalculationID()
+		switch err := c.repo.Save(ctx, calc); {
+		case errors.Is(err, domain.ErrHistorySkipped):
+			calc.ID, calc.HistorySkipped = "", true
+		case err != nil:
+			return nil, err
+		}
+	}
+
+	c.logger.Debug("Served calculation from cache", slog.String("operation", calc.Operation))
+	return &calc, nil
+}
+
+// key builds the cache key from the operation name and its canonicalized operands.
+func key(operation string, operands ...int32) string {
+	return fmt.Sprint(operation, operands)
+}
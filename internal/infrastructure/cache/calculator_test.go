@@ -0,0 +1,182 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/operations"
+	"go-prisma-calculator/internal/domain/ports/in"
+	"go-prisma-calculator/internal/domain/ports/out"
+	"go-prisma-calculator/internal/domain/service"
+)
+
+// countingCalculator computes calculations with the registry and counts the
+// calls that reach it.
+type countingCalculator struct {
+	in.CalculatorPort
+	registry *service.OperationRegistry
+	calls    int
+}
+
+func (c *countingCalculator) Calculate(ctx context.Context, operation string, operands ...int32) (*domain.Calculation, error) {
+	c.calls++
+	result, err := c.registry.Evaluate(operation, operands...)
+	if err != nil {
+		return nil, err
+	}
+	calc := &domain.Calculation{
+		ID:        fmt.Sprintf("computed-%d", c.calls),
+		Operation: operation,
+		A:         int(operands[0]),
+		B:         int(operands[1]),
+		Result:    int(result),
+		Principal: domain.PrincipalFromContext(ctx).Name,
+		TenantID:  domain.TenantFromContext(ctx).ID,
+		CreatedAt: time.Now(),
+	}
+	return calc, nil
+}
+
+// savingRepo records saved calculations and fails while err is set.
+type savingRepo struct {
+	out.CalculationRepositoryPort
+	err   error
+	saved []domain.Calculation
+}
+
+func (r *savingRepo) Save(_ context.Context, calc domain.Calculation) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.saved = append(r.saved, calc)
+	return nil
+}
+
+// square is an operation registered at runtime, like a plugin.
+type square struct{}
+
+func (square) Name() string                      { return "square" }
+func (square) Arity() int                        { return 1 }
+func (square) Validate([]int32) error            { return nil }
+func (square) Evaluate(x []int32) (int32, error) { return x[0] * x[0], nil }
+func (square) Describe() domain.OperationDoc     { return domain.OperationDoc{Operands: []string{"x"}} }
+
+func newTestCache(t *testing.T, recordHits bool) (*CalculatorCache, *countingCalculator, *savingRepo) {
+	t.Helper()
+	registry, err := service.NewOperationRegistry([]domain.Operation{operations.NewAdd(), operations.NewDivide()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := &countingCalculator{registry: registry}
+	repo := &savingRepo{}
+	c := NewCalculatorCache(next, repo, registry, Options{Size: 16, TTL: time.Minute, RecordHits: recordHits},
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+	return c.(*CalculatorCache), next, repo
+}
+
+func contextOf(principal, tenant string) context.Context {
+	ctx := domain.WithTenant(context.Background(), domain.Tenant{ID: tenant})
+	return domain.WithPrincipal(ctx, domain.Principal{Name: principal, Role: domain.RoleUser})
+}
+
+func TestCalculateUnrecordedHit(t *testing.T) {
+	c, next, repo := newTestCache(t, false)
+
+	first, err := c.Calculate(contextOf("alice", "a"), "add", 2, 3)
+	if err != nil || first.ID != "computed-1" {
+		t.Fatalf("Calculate = %+v, %v, want the computed calculation", first, err)
+	}
+
+	// Addition is commutative, so 3+2 is served from the entry of 2+3.
+	hit, err := c.Calculate(contextOf("bob", "b"), "add", 3, 2)
+	if err != nil {
+		t.Fatalf("Calculate hit: %v", err)
+	}
+	if next.calls != 1 {
+		t.Errorf("%d calls computed, want 1", next.calls)
+	}
+	if hit.ID != "" {
+		t.Errorf("unrecorded hit has ID %q, want none", hit.ID)
+	}
+	if hit.A != 3 || hit.B != 2 || hit.Result != 5 || hit.Principal != "bob" || hit.TenantID != "b" {
+		t.Errorf("hit = %+v, want 3+2=5 by bob in tenant b", hit)
+	}
+	if len(repo.saved) != 0 {
+		t.Errorf("saved %+v, want nothing", repo.saved)
+	}
+}
+
+func TestCalculateRecordedHit(t *testing.T) {
+	c, next, repo := newTestCache(t, true)
+	ctx := contextOf("alice", "a")
+
+	first, err := c.Calculate(ctx, "add", 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hit, err := c.Calculate(ctx, "add", 2, 3)
+	if err != nil {
+		t.Fatalf("Calculate hit: %v", err)
+	}
+	if next.calls != 1 {
+		t.Errorf("%d calls computed, want 1", next.calls)
+	}
+	if hit.ID == "" || hit.ID == first.ID {
+		t.Errorf("recorded hit has ID %q, want a new one", hit.ID)
+	}
+	if len(repo.saved) != 1 || repo.saved[0].ID != hit.ID || repo.saved[0].Result != 5 {
+		t.Errorf("saved %+v, want the hit", repo.saved)
+	}
+
+	repo.err = domain.ErrHistorySkipped
+	if hit, err := c.Calculate(ctx, "add", 2, 3); err != nil || hit.ID != "" || !hit.HistorySkipped {
+		t.Errorf("Calculate = %+v, %v, want a hit without ID while the history is unavailable", hit, err)
+	}
+
+	repo.err = errors.New("database down")
+	if _, err := c.Calculate(ctx, "add", 2, 3); !errors.Is(err, repo.err) {
+		t.Errorf("Calculate error = %v, want the save error", err)
+	}
+}
+
+func TestCalculateKeys(t *testing.T) {
+	c, next, _ := newTestCache(t, false)
+	ctx := contextOf("alice", "a")
+
+	// Division is not commutative; errors are not cached.
+	for _, operands := range [][]int32{{6, 3}, {3, 6}, {6, 3}, {1, 0}, {1, 0}} {
+		_, _ = c.Calculate(ctx, "divide", operands...)
+	}
+	if next.calls != 4 {
+		t.Errorf("%d calls computed, want 4", next.calls)
+	}
+	if got, err := c.Calculate(ctx, "divide", 3, 6); err != nil || got.Result != 0 {
+		t.Errorf("divide(3, 6) = %+v, %v, want 0", got, err)
+	}
+}
+
+func TestCalculatePurgedOnOperationChange(t *testing.T) {
+	c, next, _ := newTestCache(t, false)
+	ctx := contextOf("alice", "a")
+
+	if _, err := c.Calculate(ctx, "add", 2, 3); err != nil {
+		t.Fatal(err)
+	}
+	c.operations.Unregister("unknown")
+	if _, err := c.Calculate(ctx, "add", 2, 3); err != nil || next.calls != 1 {
+		t.Fatalf("%d calls computed, %v, want a hit", next.calls, err)
+	}
+
+	if err := c.operations.Register(square{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Calculate(ctx, "add", 2, 3); err != nil || next.calls != 2 {
+		t.Errorf("%d calls computed, %v, want a miss after the registry changed", next.calls, err)
+	}
+}
@@ -1,14 +1,124 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
 	DatabaseURL string
+
+	// DBConnectTimeout is how long startup waits for the database before
+	// serving in degraded no-history mode.
+	DBConnectTimeout time.Duration
+	// DBReconnectMinBackoff and DBReconnectMaxBackoff bound the delay between
+	// connection attempts.
+	DBReconnectMinBackoff time.Duration
+	DBReconnectMaxBackoff time.Duration
+
+	// AuthRequired rejects requests without credentials instead of treating
+	// them as anonymous users.
+	AuthRequired bool
+	// APIKeys lists the accepted API keys as comma-separated
+	// "name:key:role:tenant" entries; role and tenant are optional.
+	APIKeys string
+	// JWTSecret is the HMAC secret used to verify bearer tokens; empty disables JWTs.
+	JWTSecret string
+	// TenantCacheTTL is how long resolved tenants are cached in memory.
+	TenantCacheTTL time.Duration
+
+	// ReceiptSigningKey is the base64 Ed25519 private key, or its 32-byte
+	// seed, that signs calculation receipts; empty disables receipts.
+	ReceiptSigningKey string
+	// ReceiptKeyID names the signing key; it defaults to a fingerprint of the public key.
+	ReceiptKeyID string
+	// ReceiptPublicKeys lists retired public keys that are still published,
+	// as comma-separated "keyID:base64" entries.
+	ReceiptPublicKeys string
+
+	// PluginDir is scanned for WebAssembly operation plugins; empty disables plugins.
+	PluginDir string
+	// PluginMemoryLimitMB is the maximum memory of a plugin instance in MiB.
+	PluginMemoryLimitMB int
+	// PluginTimeout bounds every call into a plugin.
+	PluginTimeout time.Duration
+	// PluginReloadInterval is how often PluginDir is checked for changed
+	// plugins; 0 disables hot reloading.
+	PluginReloadInterval time.Duration
+
+	// BigIntMaxBits bounds the size of big integer operands and results, and
+	// of the numerators and denominators of rationals.
+	BigIntMaxBits int
+	// MatrixMaxDimension bounds the number of rows and columns of matrix
+	// operands.
+	MatrixMaxDimension int
+
+	// JobWorkers is the number of goroutines executing asynchronous jobs.
+	JobWorkers int
+	// JobQueueSize bounds how many submitted jobs may wait for a worker.
+	JobQueueSize int
+
+	// CacheEnabled turns on the in-memory result cache for calculations.
+	CacheEnabled bool
+	// CacheSize is the maximum number of cached results.
+	CacheSize int
+	// CacheTTL is how long a cached result stays valid.
+	CacheTTL time.Duration
+	// CacheRecordHits controls whether a cache hit still writes a history row.
+	CacheRecordHits bool
+
+	// RepoCallTimeout bounds each individual repository call attempt.
+	RepoCallTimeout time.Duration
+	// RepoMaxAttempts is the total number of attempts for retryable errors.
+	RepoMaxAttempts int
+	// RepoRetryBaseDelay and RepoRetryMaxDelay bound the jittered backoff between attempts.
+	RepoRetryBaseDelay time.Duration
+	RepoRetryMaxDelay  time.Duration
+	// BreakerFailureThreshold is the number of consecutive failures that opens the circuit.
+	BreakerFailureThreshold int
+	// BreakerOpenDuration is how long the circuit stays open before probing.
+	BreakerOpenDuration time.Duration
+	// BreakerHalfOpenProbes is the number of concurrent probes allowed while half-open.
+	BreakerHalfOpenProbes int
+
+	// PersistenceMode is "sync" (Save blocks on the database) or "async"
+	// (Save queues the calculation for a background batching writer).
+	PersistenceMode string
+	// WriteBehindQueueSize bounds the number of calculations waiting to be written.
+	WriteBehindQueueSize int
+	// WriteBehindBatchSize is the number of calculations written per flush.
+	WriteBehindBatchSize int
+	// WriteBehindFlushInterval forces a flush of a partial batch.
+	WriteBehindFlushInterval time.Duration
+	// WriteBehindOverflow is "block", "drop" or "spill" and applies when the queue is full.
+	WriteBehindOverflow string
+	// WriteBehindJournal is the local file used to spill calculations.
+	WriteBehindJournal string
+
+	// RetentionEnabled runs the retention enforcer in the server.
+	RetentionEnabled bool
+	// RetentionInterval is the time between retention runs.
+	RetentionInterval time.Duration
+	// RetentionMaxAge expires calculations older than this; zero keeps them forever.
+	RetentionMaxAge time.Duration
+	// RetentionMaxRowsPerPrincipal keeps only the newest calculations of each caller; zero is unlimited.
+	RetentionMaxRowsPerPrincipal int
+	// RetentionOperationMaxAge overrides RetentionMaxAge per operation as
+	// comma-separated "operation=duration" entries.
+	RetentionOperationMaxAge string
+	// RetentionMode is "delete" or "archive".
+	RetentionMode string
+	// RetentionArchiveDir receives the compressed NDJSON archives in archive mode.
+	RetentionArchiveDir string
+	// RetentionBatchSize is the number of calculations removed per statement.
+	RetentionBatchSize int
+	// RetentionDryRun only reports what would be expired.
+	RetentionDryRun bool
 }
 
 // NewConfig loads environment variables and returns a Config struct.
@@ -18,7 +128,130 @@ func NewConfig() *Config {
 		log.Println("No .env file found, using environment variables")
 	}
 
+	return fromEnv()
+}
+
+// LoadFile is like NewConfig but reads the variables from the given env file,
+// which must exist. Variables already set in the environment take precedence.
+func LoadFile(path string) (*Config, error) {
+	if err := godotenv.Load(path); err != nil {
+		return nil, fmt.Errorf("load config %s: %w", path, err)
+	}
+
+	return fromEnv(), nil
+}
+
+// fromEnv builds a Config from the process environment.
+func fromEnv() *Config {
 	return &Config{
 		DatabaseURL: os.Getenv("DATABASE_URL"),
+
+		DBConnectTimeout:      getEnvDuration("DB_CONNECT_TIMEOUT", 15*time.Second),
+		DBReconnectMinBackoff: getEnvDuration("DB_RECONNECT_MIN_BACKOFF", 500*time.Millisecond),
+		DBReconnectMaxBackoff: getEnvDuration("DB_RECONNECT_MAX_BACKOFF", 30*time.Second),
+
+		AuthRequired: getEnvBool("AUTH_REQUIRED", false),
+		APIKeys:      os.Getenv("API_KEYS"),
+		JWTSecret:    os.Getenv("JWT_SECRET"),
+
+		TenantCacheTTL: getEnvDuration("TENANT_CACHE_TTL", 30*time.Second),
+
+		ReceiptSigningKey: os.Getenv("RECEIPT_SIGNING_KEY"),
+		ReceiptKeyID:      os.Getenv("RECEIPT_KEY_ID"),
+		ReceiptPublicKeys: os.Getenv("RECEIPT_PUBLIC_KEYS"),
+
+		PluginDir:            os.Getenv("PLUGIN_DIR"),
+		PluginMemoryLimitMB:  getEnvInt("PLUGIN_MEMORY_LIMIT_MB", 16),
+		PluginTimeout:        getEnvDuration("PLUGIN_TIMEOUT", 100*time.Millisecond),
+		PluginReloadInterval: getEnvDuration("PLUGIN_RELOAD_INTERVAL", 2*time.Second),
+
+		BigIntMaxBits:      getEnvInt("BIGINT_MAX_BITS", 4096),
+		MatrixMaxDimension: getEnvInt("MATRIX_MAX_DIMENSION", 32),
+
+		JobWorkers:   getEnvInt("JOB_WORKERS", 4),
+		JobQueueSize: getEnvInt("JOB_QUEUE_SIZE", 100),
+
+		CacheEnabled:    getEnvBool("CACHE_ENABLED", true),
+		CacheSize:       getEnvInt("CACHE_SIZE", 10000),
+		CacheTTL:        getEnvDuration("CACHE_TTL", 10*time.Minute),
+		CacheRecordHits: getEnvBool("CACHE_RECORD_HITS", true),
+
+		RepoCallTimeout:         getEnvDuration("REPO_CALL_TIMEOUT", 2*time.Second),
+		RepoMaxAttempts:         getEnvInt("REPO_MAX_ATTEMPTS", 3),
+		RepoRetryBaseDelay:      getEnvDuration("REPO_RETRY_BASE_DELAY", 50*time.Millisecond),
+		RepoRetryMaxDelay:       getEnvDuration("REPO_RETRY_MAX_DELAY", time.Second),
+		BreakerFailureThreshold: getEnvInt("BREAKER_FAILURE_THRESHOLD", 5),
+		BreakerOpenDuration:     getEnvDuration("BREAKER_OPEN_DURATION", 30*time.Second),
+		BreakerHalfOpenProbes:   getEnvInt("BREAKER_HALF_OPEN_PROBES", 1),
+
+		PersistenceMode:          getEnv("PERSISTENCE_MODE", "sync"),
+		WriteBehindQueueSize:     getEnvInt("WRITE_BEHIND_QUEUE_SIZE", 10000),
+		WriteBehindBatchSize:     getEnvInt("WRITE_BEHIND_BATCH_SIZE", 100),
+		WriteBehindFlushInterval: getEnvDuration("WRITE_BEHIND_FLUSH_INTERVAL", time.Second),
+		WriteBehindOverflow:      getEnv("WRITE_BEHIND_OVERFLOW", "block"),
+		WriteBehindJournal:       getEnv("WRITE_BEHIND_JOURNAL", "write-behind.journal"),
+
+		RetentionEnabled:             getEnvBool("RETENTION_ENABLED", false),
+		RetentionInterval:            getEnvDuration("RETENTION_INTERVAL", time.Hour),
+		RetentionMaxAge:              getEnvDuration("RETENTION_MAX_AGE", 0),
+		RetentionMaxRowsPerPrincipal: getEnvInt("RETENTION_MAX_ROWS_PER_PRINCIPAL", 0),
+		RetentionOperationMaxAge:     os.Getenv("RETENTION_OPERATION_MAX_AGE"),
+		RetentionMode:                getEnv("RETENTION_MODE", "delete"),
+		RetentionArchiveDir:          getEnv("RETENTION_ARCHIVE_DIR", "archive"),
+		RetentionBatchSize:           getEnvInt("RETENTION_BATCH_SIZE", 500),
+		RetentionDryRun:              getEnvBool("RETENTION_DRY_RUN", false),
+	}
+}
+
+// getEnv reads a string environment variable, falling back to def when it is unset.
+func getEnv(key, def string) string {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
+	}
+	return def
+}
+
+// getEnvInt reads an integer environment variable, falling back to def when
+// the variable is unset or not a valid number.
+func getEnvInt(key string, def int) int {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return def
 	}
-}
\ No newline at end of file
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Invalid value %q for %s, using default %d", value, key, def)
+		return def
+	}
+	return n
+}
+
+// getEnvBool reads a boolean environment variable, falling back to def when
+// the variable is unset or not a valid boolean.
+func getEnvBool(key string, def bool) bool {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Invalid value %q for %s, using default %t", value, key, def)
+		return def
+	}
+	return b
+}
+
+// getEnvDuration reads a duration such as "30s" or "5m" from the environment,
+// falling back to def when the variable is unset or invalid.
+func getEnvDuration(key string, def time.Duration) time.Duration {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Invalid value %q for %s, using default %s", value, key, def)
+		return def
+	}
+	return d
+}
@@ -0,0 +1,66 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	domain "go-prisma-calculator/internal/domain/models"
+)
+
+// csvHeader names the columns of CSV exports.
+var csvHeader = []string{"id", "operation", "principal", "a", "b", "result", "created_at", "deleted_at", "kind", "operands", "value", "options", "details"}
+
+type csvWriter struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) Write(calc domain.Calculation) error {
+	if err := c.header(); err != nil {
+		return err
+	}
+
+	deletedAt := ""
+	if calc.DeletedAt != nil {
+		deletedAt = calc.DeletedAt.UTC().Format(time.RFC3339Nano)
+	}
+	kind, operands, value, details, options := textColumns(calc)
+	return c.w.Write([]string{
+		calc.ID,
+		calc.Operation,
+		calc.Principal,
+		strconv.Itoa(calc.A),
+		strconv.Itoa(calc.B),
+		strconv.Itoa(calc.Result),
+		calc.CreatedAt.UTC().Format(time.RFC3339Nano),
+		deletedAt,
+		kind,
+		operands,
+		value,
+		options,
+		details,
+	})
+}
+
+func (c *csvWriter) Close() error {
+	if err := c.header(); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// header writes the header row once, so an empty export is still a valid file.
+func (c *csvWriter) header() error {
+	if c.wroteHeader {
+		return nil
+	}
+	c.wroteHeader = true
+	return c.w.Write(csvHeader)
+}
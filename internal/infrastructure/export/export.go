@@ -0,0 +1,155 @@
+// Package export encodes calculations as CSV, NDJSON or Parquet files and
+// decodes CSV and NDJSON files for imports.
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	domain "go-prisma-calculator/internal/domain/models"
+)
+
+// Format is a file format calculations can be exported to.
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatNDJSON  Format = "ndjson"
+	FormatParquet Format = "parquet"
+)
+
+// ErrUnknownFormat is returned for formats other than csv, ndjson and parquet.
+var ErrUnknownFormat = errors.New("unknown export format: want csv, ndjson or parquet")
+
+// ParseFormat validates a format name; an empty name selects CSV.
+func ParseFormat(name string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(name))); f {
+	case "":
+		return FormatCSV, nil
+	case FormatCSV, FormatNDJSON, FormatParquet:
+		return f, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownFormat, name)
+	}
+}
+
+// ContentType returns the media type of files in this format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	case FormatParquet:
+		return "application/vnd.apache.parquet"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// Extension returns the usual file name extension, without the dot.
+func (f Format) Extension() string {
+	return string(f)
+}
+
+// Writer encodes calculations one at a time. Nothing is written to the
+// underlying io.Writer before the first Write or Close, so callers can still
+// report an error instead of a file until the first row is available.
+type Writer interface {
+	Write(calc domain.Calculation) error
+	// Close writes any trailer the format needs. It does not close the underlying io.Writer.
+	Close() error
+}
+
+// NewWriter returns a Writer encoding calculations to w in format f.
+func NewWriter(f Format, w io.Writer) (Writer, error) {
+	switch f {
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	case FormatNDJSON:
+		return newNDJSONWriter(w), nil
+	case FormatParquet:
+		return newParquetWriter(w), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, f)
+	}
+}
+
+// Record is the exported form of a calculation and the line format of NDJSON files.
+type Record struct {
+	ID        string `json:"id"`
+	Operation string `json:"operation"`
+	Principal string `json:"principal"`
+	A         int    `json:"a"`
+	B         int    `json:"b"`
+	Result    int    `json:"result"`
+	// Kind, Operands, Value, Details and Options are only set for
+	// calculations that are not integer ones.
+	Kind      domain.Kind               `json:"kind,omitempty"`
+	Operands  []string                  `json:"operands,omitempty"`
+	Value     string                    `json:"value,omitempty"`
+	Details   map[string]string         `json:"details,omitempty"`
+	Options   *domain.EvaluationOptions `json:"options,omitempty"`
+	CreatedAt time.Time                 `json:"createdAt"`
+	DeletedAt *time.Time                `json:"deletedAt,omitempty"`
+}
+
+// NewRecord converts a calculation into its exported form.
+func NewRecord(calc domain.Calculation) Record {
+	r := Record{
+		ID:        calc.ID,
+		Operation: calc.Operation,
+		Principal: calc.Principal,
+		A:         calc.A,
+		B:         calc.B,
+		Result:    calc.Result,
+		CreatedAt: calc.CreatedAt.UTC(),
+		DeletedAt: calc.DeletedAt,
+	}
+	if !calc.IsInteger() {
+		r.Kind, r.Operands, r.Value, r.Options = calc.Kind, calc.Operands, calc.Value, &calc.Options
+		r.Details = calc.Details
+	}
+	return r
+}
+
+// Calculation converts an exported record back into a calculation.
+func (r Record) Calculation() domain.Calculation {
+	calc := domain.Calculation{
+		ID:        r.ID,
+		Operation: r.Operation,
+		Principal: r.Principal,
+		A:         r.A,
+		B:         r.B,
+		Result:    r.Result,
+		CreatedAt: r.CreatedAt,
+		DeletedAt: r.DeletedAt,
+		Kind:      r.Kind,
+		Operands:  r.Operands,
+		Value:     r.Value,
+		Details:   r.Details,
+	}
+	if r.Options != nil {
+		calc.Options = *r.Options
+	}
+	return calc
+}
+
+// textColumns returns the kind of a calculation and, for kinds other than
+// integers, its operands, details and options as JSON and its value, as
+// they are stored in the columns of CSV and Parquet files. Details are
+// empty when there are none.
+func textColumns(calc domain.Calculation) (kind, operands, value, details, options string) {
+	if calc.IsInteger() {
+		return string(domain.KindInteger), "", "", "", ""
+	}
+	o, _ := json.Marshal(calc.Operands)
+	opts, _ := json.Marshal(calc.Options)
+	if len(calc.Details) > 0 {
+		d, _ := json.Marshal(calc.Details)
+		details = string(d)
+	}
+	return string(calc.Kind), string(o), calc.Value, details, string(opts)
+}
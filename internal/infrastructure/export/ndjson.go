@@ -0,0 +1,24 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+
+	domain "go-prisma-calculator/internal/domain/models"
+)
+
+type ndjsonWriter struct {
+	enc *json.Encoder
+}
+
+func newNDJSONWriter(w io.Writer) *ndjsonWriter {
+	return &ndjsonWriter{enc: json.NewEncoder(w)}
+}
+
+func (n *ndjsonWriter) Write(calc domain.Calculation) error {
+	return n.enc.Encode(NewRecord(calc))
+}
+
+func (n *ndjsonWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,250 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	domain "go-prisma-calculator/internal/domain/models"
+)
+
+// maxLineSize bounds a single NDJSON line.
+const maxLineSize = 1 << 20
+
+// ErrInvalidFile is returned when an import file cannot be read at all, as
+// opposed to individual rows that cannot be decoded.
+var ErrInvalidFile = errors.New("invalid import file")
+
+// Reader decodes an import file one row at a time. Rows that cannot be
+// decoded are returned with ImportRow.Err set, so the caller can report them
+// and carry on; Read itself only fails when the file cannot be read any
+// further. It returns io.EOF after the last row.
+type Reader interface {
+	Read() (domain.ImportRow, error)
+}
+
+// NewReader returns a Reader decoding calculations from r in format f.
+// Parquet files cannot be imported.
+func NewReader(f Format, r io.Reader) (Reader, error) {
+	switch f {
+	case FormatCSV:
+		return newCSVReader(r), nil
+	case FormatNDJSON:
+		return newNDJSONReader(r), nil
+	default:
+		return nil, fmt.Errorf("%s files cannot be imported, use csv or ndjson", f)
+	}
+}
+
+// FormatFromFileName guesses the format of an uploaded file from its
+// extension, falling back to CSV.
+func FormatFromFileName(name string) Format {
+	switch {
+	case strings.HasSuffix(name, ".ndjson"), strings.HasSuffix(name, ".jsonl"):
+		return FormatNDJSON
+	case strings.HasSuffix(name, ".parquet"):
+		return FormatParquet
+	default:
+		return FormatCSV
+	}
+}
+
+// csvRequired lists the columns an import file must have. The other export
+// columns are optional and id is ignored.
+var csvRequired = []string{"operation", "a", "b", "result"}
+
+type csvReader struct {
+	r       *csv.Reader
+	columns map[string]int
+}
+
+func newCSVReader(r io.Reader) *csvReader {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	cr.ReuseRecord = true
+	return &csvReader{r: cr}
+}
+
+func (c *csvReader) Read() (domain.ImportRow, error) {
+	if c.columns == nil {
+		if err := c.readHeader(); err != nil {
+			return domain.ImportRow{}, err
+		}
+	}
+
+	record, err := c.r.Read()
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return domain.ImportRow{Line: parseErr.Line, Err: parseErr.Err}, nil
+	}
+	if err != nil {
+		return domain.ImportRow{}, err
+	}
+	line, _ := c.r.FieldPos(0)
+
+	field := func(name string) string {
+		if i, ok := c.columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	calc, err := parseFields(field)
+	return domain.ImportRow{Line: line, Calculation: calc, Err: err}, nil
+}
+
+func (c *csvReader) readHeader() error {
+	header, err := c.r.Read()
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return fmt.Errorf("%w: CSV header: %v", ErrInvalidFile, parseErr.Err)
+	}
+	if err != nil {
+		return err
+	}
+
+	c.columns = make(map[string]int, len(header))
+	for i, name := range header {
+		c.columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range csvRequired {
+		if _, ok := c.columns[name]; !ok {
+			return fmt.Errorf("%w: CSV header is missing the %q column", ErrInvalidFile, name)
+		}
+	}
+	return nil
+}
+
+// parseFields builds a calculation from named CSV fields.
+func parseFields(field func(name string) string) (domain.Calculation, error) {
+	calc := domain.Calculation{
+		Operation: field("operation"),
+		Principal: field("principal"),
+	}
+
+	for _, f := range []struct {
+		name string
+		dst  *int
+	}{{"a", &calc.A}, {"b", &calc.B}, {"result", &calc.Result}} {
+		n, err := strconv.Atoi(field(f.name))
+		if err != nil {
+			return calc, fmt.Errorf("%s is not an integer", f.name)
+		}
+		*f.dst = n
+	}
+
+	if v := field("created_at"); v != "" {
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return calc, errors.New("created_at is not an RFC 3339 timestamp")
+		}
+		calc.CreatedAt = t
+	}
+	if v := field("deleted_at"); v != "" {
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return calc, errors.New("deleted_at is not an RFC 3339 timestamp")
+		}
+		calc.DeletedAt = &t
+	}
+	if kind := domain.Kind(field("kind")); kind != "" && kind != domain.KindInteger {
+		calc.Kind, calc.Value = kind, field("value")
+		if err := json.Unmarshal([]byte(field("operands")), &calc.Operands); err != nil {
+			return calc, errors.New("operands is not a JSON array of strings")
+		}
+		if v := field("options"); v != "" {
+			if err := json.Unmarshal([]byte(v), &calc.Options); err != nil {
+				return calc, errors.New("options is not a JSON object")
+			}
+		}
+		if v := field("details"); v != "" {
+			if err := json.Unmarshal([]byte(v), &calc.Details); err != nil {
+				return calc, errors.New("details is not a JSON object of strings")
+			}
+		}
+	}
+	return calc, nil
+}
+
+// importRecord is the NDJSON line format accepted by imports. Unlike Record
+// it tells missing numbers apart from zeros.
+type importRecord struct {
+	Operation string     `json:"operation"`
+	Principal string     `json:"principal"`
+	A         *int       `json:"a"`
+	B         *int       `json:"b"`
+	Result    *int       `json:"result"`
+	CreatedAt *time.Time `json:"createdAt"`
+	DeletedAt *time.Time `json:"deletedAt"`
+	// Kind, Operands, Value, Details and Options replace a, b and result
+	// for calculations that are not integer ones.
+	Kind     domain.Kind              `json:"kind"`
+	Operands []string                 `json:"operands"`
+	Value    *string                  `json:"value"`
+	Details  map[string]string        `json:"details"`
+	Options  domain.EvaluationOptions `json:"options"`
+}
+
+type ndjsonReader struct {
+	s    *bufio.Scanner
+	line int
+}
+
+func newNDJSONReader(r io.Reader) *ndjsonReader {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, 64<<10), maxLineSize)
+	return &ndjsonReader{s: s}
+}
+
+func (n *ndjsonReader) Read() (domain.ImportRow, error) {
+	for n.s.Scan() {
+		n.line++
+		text := strings.TrimSpace(n.s.Text())
+		if text == "" {
+			continue
+		}
+
+		var record importRecord
+		if err := json.Unmarshal([]byte(text), &record); err != nil {
+			return domain.ImportRow{Line: n.line, Err: errors.New("invalid JSON")}, nil
+		}
+		calc, err := record.calculation()
+		return domain.ImportRow{Line: n.line, Calculation: calc, Err: err}, nil
+	}
+	if err := n.s.Err(); errors.Is(err, bufio.ErrTooLong) {
+		return domain.ImportRow{}, fmt.Errorf("%w: line %d is longer than %d bytes", ErrInvalidFile, n.line+1, maxLineSize)
+	} else if err != nil {
+		return domain.ImportRow{}, err
+	}
+	return domain.ImportRow{}, io.EOF
+}
+
+func (r importRecord) calculation() (domain.Calculation, error) {
+	calc := domain.Calculation{
+		Operation: r.Operation,
+		Principal: r.Principal,
+		DeletedAt: r.DeletedAt,
+	}
+	if r.CreatedAt != nil {
+		calc.CreatedAt = *r.CreatedAt
+	}
+	if r.Kind != "" && r.Kind != domain.KindInteger {
+		if r.Operands == nil || r.Value == nil {
+			return calc, errors.New("operands and value are required")
+		}
+		calc.Kind, calc.Operands, calc.Value, calc.Options = r.Kind, r.Operands, *r.Value, r.Options
+		calc.Details = r.Details
+		return calc, nil
+	}
+	if r.A == nil || r.B == nil || r.Result == nil {
+		return calc, errors.New("a, b and result are required")
+	}
+	calc.A, calc.B, calc.Result = *r.A, *r.B, *r.Result
+	return calc, nil
+}
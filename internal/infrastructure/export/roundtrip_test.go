@@ -0,0 +1,237 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"maps"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go-prisma-calculator/internal/application/usecase"
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/operations"
+	"go-prisma-calculator/internal/domain/ports/out"
+	"go-prisma-calculator/internal/domain/rational"
+	"go-prisma-calculator/internal/domain/scientific"
+	"go-prisma-calculator/internal/domain/service"
+
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+// memoryRepo keeps the calculations saved by the calculator and the
+// batches stored by an import.
+type memoryRepo struct {
+	out.CalculationRepositoryPort
+	saved    []domain.Calculation
+	imported []domain.Calculation
+}
+
+func (r *memoryRepo) Save(_ context.Context, calc domain.Calculation) error {
+	r.saved = append(r.saved, calc)
+	return nil
+}
+
+func (r *memoryRepo) Import(_ context.Context, calcs []domain.Calculation) error {
+	r.imported = append(r.imported, calcs...)
+	return nil
+}
+
+func newTestService(t *testing.T, repo out.CalculationRepositoryPort) (*service.CalculatorService, *service.OperationRegistry) {
+	t.Helper()
+	ops, err := service.NewOperationRegistry([]domain.Operation{operations.NewAdd(), operations.NewDivide()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	evaluators, err := service.NewEvaluatorRegistry([]domain.Evaluator{scientific.NewFloatEvaluator(), rational.NewEvaluator(0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return service.NewCalculatorService(repo, ops, evaluators), ops
+}
+
+// history computes an integer calculation, an evaluation and a rounded
+// division, as the calculator records them.
+func history(t *testing.T) []domain.Calculation {
+	t.Helper()
+	repo := &memoryRepo{}
+	svc, _ := newTestService(t, repo)
+	ctx := domain.WithPrincipal(context.Background(), domain.Principal{Name: "alice", Role: domain.RoleUser})
+
+	if _, err := svc.Calculate(ctx, "add", 2, 3); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Evaluate(ctx, domain.Evaluation{Kind: domain.KindFloat, Function: "sqrt", Operands: []string{"2"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Divide(ctx, domain.RoundedDivision{Dividend: 1000, Divisor: 3, Rounding: domain.RoundHalfEven, Scale: 2}); err != nil {
+		t.Fatal(err)
+	}
+	return repo.saved
+}
+
+// export writes calcs in format f.
+func export(t *testing.T, f Format, calcs []domain.Calculation) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriter(f, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, calc := range calcs {
+		if err := w.Write(calc); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// TestExportImportRoundTrip exports a history with a row whose result is
+// wrong and a row of an unknown operation, and imports it back with
+// recomputation: the wrong result is corrected, the unknown operation is
+// rejected and the other rows come back as they were exported.
+func TestExportImportRoundTrip(t *testing.T) {
+	calcs := history(t)
+	wrong := calcs[0]
+	wrong.ID, wrong.Result = "wrong", 6
+	unknown := calcs[0]
+	unknown.ID, unknown.Operation = "unknown", "frobnicate"
+	exported := append(append([]domain.Calculation{}, calcs...), wrong, unknown)
+
+	tests := []struct {
+		format Format
+		// firstLine is the line of the first row.
+		firstLine int
+	}{
+		{FormatCSV, 2},
+		{FormatNDJSON, 1},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			r, err := NewReader(tt.format, bytes.NewReader(export(t, tt.format, exported)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			repo := &memoryRepo{}
+			svc, ops := newTestService(t, repo)
+			uc := usecase.NewImportUseCase(svc, ops, repo)
+			ctx := domain.WithTenant(
+				domain.WithPrincipal(context.Background(), domain.Principal{Name: "root", Role: domain.RoleAdmin}),
+				domain.DefaultTenant,
+			)
+
+			report, err := uc.ImportCalculations(ctx, domain.ImportOptions{Recompute: true, OnMismatch: domain.MismatchCorrect}, r.Read)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantReport := domain.ImportReport{Total: 5, Accepted: 4, Rejected: 1, Mismatched: 1}
+			if got := *report; got.Total != wantReport.Total || got.Accepted != wantReport.Accepted ||
+				got.Rejected != wantReport.Rejected || got.Mismatched != wantReport.Mismatched {
+				t.Errorf("report = %+v, want %+v", got, wantReport)
+			}
+			wantIssues := []struct {
+				line   int
+				kind   domain.ImportIssueKind
+				reason string
+			}{
+				{tt.firstLine + 3, domain.ImportIssueMismatched, "recorded result 6, recomputed 5, corrected"},
+				{tt.firstLine + 4, domain.ImportIssueRejected, "frobnicate"},
+			}
+			if len(report.Issues) != len(wantIssues) {
+				t.Fatalf("issues = %+v, want %d", report.Issues, len(wantIssues))
+			}
+			for i, want := range wantIssues {
+				got := report.Issues[i]
+				if got.Line != want.line || got.Kind != want.kind || !strings.Contains(got.Reason, want.reason) {
+					t.Errorf("issue %d = %+v, want line %d, %s, %q", i, got, want.line, want.kind, want.reason)
+				}
+			}
+
+			want := append(append([]domain.Calculation{}, calcs...), calcs[0])
+			if len(repo.imported) != len(want) {
+				t.Fatalf("imported %d calculations, want %d", len(repo.imported), len(want))
+			}
+			for i := range want {
+				if got, want := comparable(repo.imported[i]), comparable(want[i]); !reflect.DeepEqual(got, want) {
+					t.Errorf("imported calculation %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+// comparable clears the fields an import does not keep: a new ID, the
+// tenant and the principal are assigned when it is stored, and integer
+// calculations read back with no kind.
+func comparable(calc domain.Calculation) domain.Calculation {
+	calc.ID, calc.TenantID, calc.Principal = "", "", ""
+	calc.CreatedAt = calc.CreatedAt.UTC()
+	if calc.IsInteger() {
+		calc.Kind = ""
+	}
+	if len(calc.Details) == 0 {
+		calc.Details = nil
+	}
+	return calc
+}
+
+// TestParquetExport reads a Parquet export back. Parquet files cannot be
+// imported, so the columns are compared with the exported calculations.
+func TestParquetExport(t *testing.T) {
+	calcs := history(t)
+	if _, err := NewReader(FormatParquet, bytes.NewReader(nil)); err == nil {
+		t.Error("NewReader accepted Parquet")
+	}
+
+	pr, err := reader.NewParquetReader(buffer.NewBufferFileFromBytes(export(t, FormatParquet, calcs)), new(parquetRecord), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pr.ReadStop()
+	records := make([]parquetRecord, pr.GetNumRows())
+	if err := pr.Read(&records); err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != len(calcs) {
+		t.Fatalf("read %d rows, want %d", len(records), len(calcs))
+	}
+
+	for i, calc := range calcs {
+		got := records[i]
+		if got.ID != calc.ID || got.Operation != calc.Operation || got.Principal != calc.Principal ||
+			got.A != int64(calc.A) || got.B != int64(calc.B) || got.Result != int64(calc.Result) ||
+			got.CreatedAt != calc.CreatedAt.UnixMilli() || got.DeletedAt != nil {
+			t.Errorf("row %d = %+v, want %+v", i, got, calc)
+		}
+
+		if calc.IsInteger() {
+			if got.Kind != string(domain.KindInteger) || got.Operands != "" || got.Value != "" {
+				t.Errorf("row %d has the text columns %+v, want an integer row", i, got)
+			}
+			continue
+		}
+		var operands []string
+		var options domain.EvaluationOptions
+		var details map[string]string
+		if err := json.Unmarshal([]byte(got.Operands), &operands); err != nil {
+			t.Errorf("row %d operands: %v", i, err)
+		}
+		if err := json.Unmarshal([]byte(got.Options), &options); err != nil {
+			t.Errorf("row %d options: %v", i, err)
+		}
+		if got.Details != "" {
+			if err := json.Unmarshal([]byte(got.Details), &details); err != nil {
+				t.Errorf("row %d details: %v", i, err)
+			}
+		}
+		if got.Kind != string(calc.Kind) || got.Value != calc.Value || !reflect.DeepEqual(operands, calc.Operands) ||
+			!reflect.DeepEqual(options, calc.Options) || len(details) != len(calc.Details) || !maps.Equal(details, calc.Details) {
+			t.Errorf("row %d = %+v, want %+v", i, got, calc)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	grpc_health "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+// DegradedHeader is set on responses served while calculation history is unavailable.
+const DegradedHeader = "X-Calculator-Degraded"
+
+// HistoryService is the gRPC health service name that reflects database availability.
+const HistoryService = "calculator.history"
+
+// Monitor tracks the availability of the database so the application can keep
+// serving calculations in a degraded "no-history" mode while it reconnects.
+type Monitor struct {
+	databaseReady atomic.Bool
+	grpcHealth    *grpc_health.Server
+}
+
+// NewMonitor creates a monitor that starts out degraded until the database connects.
+func NewMonitor() *Monitor {
+	m := &Monitor{grpcHealth: grpc_health.NewServer()}
+	m.SetDatabaseReady(false)
+	return m
+}
+
+// SetDatabaseReady records whether the database connection is usable.
+func (m *Monitor) SetDatabaseReady(ready bool) {
+	m.databaseReady.Store(ready)
+
+	status := healthpb.HealthCheckResponse_SERVING
+	if !ready {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	m.grpcHealth.SetServingStatus(HistoryService, status)
+}
+
+// DatabaseReady reports whether calculation history can be read and written.
+func (m *Monitor) DatabaseReady() bool {
+	return m.databaseReady.Load()
+}
+
+// GRPCHealthServer exposes the monitor through the standard gRPC health protocol.
+// The overall service ("") is always SERVING; HistoryService follows the database.
+func (m *Monitor) GRPCHealthServer() healthpb.HealthServer {
+	return m.grpcHealth
+}
+
+// LiveHandler answers liveness probes; the process is alive if it can respond.
+func (m *Monitor) LiveHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// ReadyHandler answers readiness probes. Calculations are still served while
+// the database is down, so the service stays ready but reports "degraded".
+func (m *Monitor) ReadyHandler(c *gin.Context) {
+	if !m.DatabaseReady() {
+		c.JSON(http.StatusOK, gin.H{"status": "degraded", "database": "unavailable", "history": false})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok", "database": "available", "history": true})
+}
+
+// Middleware marks REST responses served in degraded mode.
+func (m *Monitor) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !m.DatabaseReady() {
+			c.Header(DegradedHeader, "no-history")
+		}
+		c.Next()
+	}
+}
+
+// UnaryServerInterceptor marks gRPC responses served in degraded mode.
+func (m *Monitor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !m.DatabaseReady() {
+			_ = grpc.SetHeader(ctx, metadata.Pairs(DegradedHeader, "no-history"))
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor marks gRPC streams opened in degraded mode.
+func (m *Monitor) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !m.DatabaseReady() {
+			_ = ss.SetHeader(metadata.Pairs(DegradedHeader, "no-history"))
+		}
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,101 @@
+package migrations
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// TestEmbeddedMigrations checks that every embedded migration can be
+// reverted and that versions follow each other without gaps, so that a
+// down migration to any version undoes exactly the later ones.
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("no embedded migrations")
+	}
+	for i, m := range migrations {
+		if want := int64(i + 1); m.Version != want {
+			t.Errorf("migration %d_%s has version %d, want %d", m.Version, m.Name, m.Version, want)
+		}
+		if strings.TrimSpace(m.Up) == "" {
+			t.Errorf("migration %d_%s has an empty up script", m.Version, m.Name)
+		}
+		if strings.TrimSpace(m.Down) == "" {
+			t.Errorf("migration %d_%s has no down script", m.Version, m.Name)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	file := func(body string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(body)} }
+	tests := []struct {
+		name    string
+		fsys    fstest.MapFS
+		wantErr string
+	}{
+		{
+			name: "ordered by version",
+			fsys: fstest.MapFS{
+				"sql/0002_b.up.sql":   file("B"),
+				"sql/0002_b.down.sql": file("-B"),
+				"sql/0001_a.up.sql":   file("A"),
+				"sql/0001_a.down.sql": file("-A"),
+			},
+		},
+		{
+			name:    "unexpected file name",
+			fsys:    fstest.MapFS{"sql/0001_A.up.sql": file("A")},
+			wantErr: "unexpected migration file name",
+		},
+		{
+			name: "conflicting names",
+			fsys: fstest.MapFS{
+				"sql/0001_a.up.sql":   file("A"),
+				"sql/0001_b.down.sql": file("-B"),
+			},
+			wantErr: "conflicting names",
+		},
+		{
+			name:    "no up script",
+			fsys:    fstest.MapFS{"sql/0001_a.down.sql": file("-A")},
+			wantErr: "has no up script",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := load(tt.fsys, "sql")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := []Migration{{1, "a", "A", "-A"}, {2, "b", "B", "-B"}}
+			if len(migrations) != len(want) {
+				t.Fatalf("got %d migrations, want %d", len(migrations), len(want))
+			}
+			for i := range want {
+				if migrations[i] != want[i] {
+					t.Errorf("migration %d = %+v, want %+v", i, migrations[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPrismaParams(t *testing.T) {
+	const databaseURL = "postgresql://u:p@db:5432/calc?schema=app&connection_limit=5&sslmode=disable"
+	if got, want := withoutPrismaParams(databaseURL), "postgresql://u:p@db:5432/calc?sslmode=disable"; got != want {
+		t.Errorf("withoutPrismaParams = %q, want %q", got, want)
+	}
+	if got := prismaSchema(databaseURL); got != "app" {
+		t.Errorf("prismaSchema = %q, want %q", got, "app")
+	}
+}
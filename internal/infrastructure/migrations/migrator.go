@@ -0,0 +1,275 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// lockID is the key of the Postgres advisory lock that serializes migrations
+// across replicas starting at the same time.
+const lockID int64 = 0x63616c636d6967 // "calcmig"
+
+const createTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version    BIGINT PRIMARY KEY,
+	name       TEXT NOT NULL,
+	applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+)`
+
+// Status describes whether a migration has been applied.
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Migrator applies migrations over a single dedicated connection, which is
+// required for the session-level advisory lock to be held for the whole run.
+type Migrator struct {
+	conn       *pgx.Conn
+	migrations []Migration
+	logger     *slog.Logger
+	// DryRun prints the SQL that would run to Out instead of executing it.
+	DryRun bool
+	Out    io.Writer
+}
+
+// Connect opens a connection for migrating the database at databaseURL.
+// Prisma's ?schema= parameter is translated into a search_path.
+func Connect(ctx context.Context, databaseURL string, logger *slog.Logger) (*Migrator, error) {
+	if databaseURL == "" {
+		return nil, errors.New("DATABASE_URL is not set")
+	}
+
+	cfg, err := pgx.ParseConfig(withoutPrismaParams(databaseURL))
+	if err != nil {
+		return nil, fmt.Errorf("invalid DATABASE_URL: %w", err)
+	}
+	if schema := prismaSchema(databaseURL); schema != "" {
+		cfg.RuntimeParams["search_path"] = schema
+	}
+
+	conn, err := pgx.ConnectConfig(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations, err := Load()
+	if err != nil {
+		conn.Close(ctx)
+		return nil, err
+	}
+
+	return &Migrator{conn: conn, migrations: migrations, logger: logger, Out: io.Discard}, nil
+}
+
+// Close closes the underlying connection.
+func (m *Migrator) Close(ctx context.Context) error {
+	return m.conn.Close(ctx)
+}
+
+// Status lists every known migration and whether it has been applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		at, ok := applied[migration.Version]
+		statuses = append(statuses, Status{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			Applied:   ok,
+			AppliedAt: at,
+		})
+	}
+	return statuses, nil
+}
+
+// Up applies up to steps pending migrations in order; steps <= 0 applies all of them.
+func (m *Migrator) Up(ctx context.Context, steps int) error {
+	return m.locked(ctx, func() error {
+		applied, err := m.applied(ctx)
+		if err != nil {
+			return err
+		}
+
+		done := 0
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if steps > 0 && done >= steps {
+				break
+			}
+			if err := m.apply(ctx, migration, migration.Up, true); err != nil {
+				return err
+			}
+			done++
+		}
+
+		if done == 0 {
+			m.logger.Info("Database schema is up to date")
+		}
+		return nil
+	})
+}
+
+// Down reverts the last steps applied migrations, newest first; steps <= 0 reverts one.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	if steps <= 0 {
+		steps = 1
+	}
+
+	return m.locked(ctx, func() error {
+		applied, err := m.applied(ctx)
+		if err != nil {
+			return err
+		}
+
+		done := 0
+		for i := len(m.migrations) - 1; i >= 0 && done < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("migration %d_%s cannot be reverted: no down script", migration.Version, migration.Name)
+			}
+			if err := m.apply(ctx, migration, migration.Down, false); err != nil {
+				return err
+			}
+			done++
+		}
+		return nil
+	})
+}
+
+// apply runs one migration script and records it in schema_migrations, in a
+// single transaction so a failed migration leaves no trace.
+func (m *Migrator) apply(ctx context.Context, migration Migration, script string, up bool) error {
+	direction := "down"
+	if up {
+		direction = "up"
+	}
+
+	if m.DryRun {
+		fmt.Fprintf(m.Out, "-- %04d_%s (%s)\n%s\n", migration.Version, migration.Name, direction, script)
+		return nil
+	}
+
+	tx, err := m.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, script); err != nil {
+		return fmt.Errorf("migration %d_%s (%s): %w", migration.Version, migration.Name, direction, err)
+	}
+
+	if up {
+		_, err = tx.Exec(ctx, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, migration.Version, migration.Name)
+	} else {
+		_, err = tx.Exec(ctx, `DELETE FROM schema_migrations WHERE version = $1`, migration.Version)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	m.logger.Info("Applied migration",
+		slog.Int64("version", migration.Version),
+		slog.String("name", migration.Name),
+		slog.String("direction", direction),
+	)
+	return nil
+}
+
+// applied returns the applied migration versions and when they were applied.
+func (m *Migrator) applied(ctx context.Context) (map[int64]time.Time, error) {
+	if m.DryRun {
+		// A dry run must not change the database, not even to create the table.
+		var exists bool
+		if err := m.conn.QueryRow(ctx, `SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists); err != nil {
+			return nil, err
+		}
+		if !exists {
+			return map[int64]time.Time{}, nil
+		}
+	} else if _, err := m.conn.Exec(ctx, createTable); err != nil {
+		return nil, err
+	}
+
+	rows, err := m.conn.Query(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		applied[version] = at
+	}
+	return applied, rows.Err()
+}
+
+// locked runs fn while holding the migration advisory lock. Other replicas
+// block here until the holder finishes, then find nothing left to apply.
+func (m *Migrator) locked(ctx context.Context, fn func() error) error {
+	if m.DryRun {
+		return fn()
+	}
+
+	m.logger.Info("Waiting for migration lock")
+	if _, err := m.conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, lockID); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := m.conn.Exec(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, lockID); err != nil {
+			m.logger.Error("Failed to release migration lock", slog.String("error", err.Error()))
+		}
+	}()
+
+	return fn()
+}
+
+// withoutPrismaParams strips query parameters understood only by Prisma.
+func withoutPrismaParams(databaseURL string) string {
+	u, err := url.Parse(databaseURL)
+	if err != nil {
+		return databaseURL
+	}
+	q := u.Query()
+	for _, key := range []string{"schema", "connection_limit", "pool_timeout", "pgbouncer"} {
+		q.Del(key)
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// prismaSchema returns the schema selected with Prisma's ?schema= parameter.
+func prismaSchema(databaseURL string) string {
+	u, err := url.Parse(databaseURL)
+	if err != nil {
+		return ""
+	}
+	return u.Query().Get("schema")
+}
@@ -0,0 +1,11 @@
+-- Baseline: databases created with `prisma db push` already have this table.
+CREATE TABLE IF NOT EXISTS "Calculation" (
+    "id" TEXT NOT NULL,
+    "operation" TEXT NOT NULL,
+    "a" INTEGER NOT NULL,
+    "b" INTEGER NOT NULL,
+    "result" INTEGER NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "Calculation_pkey" PRIMARY KEY ("id")
+);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "Calculation_deletedAt_createdAt_idx";
+
+ALTER TABLE "Calculation" DROP COLUMN IF EXISTS "deletedAt";
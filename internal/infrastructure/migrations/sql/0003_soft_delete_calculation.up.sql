@@ -0,0 +1,3 @@
+ALTER TABLE "Calculation" ADD COLUMN IF NOT EXISTS "deletedAt" TIMESTAMP(3);
+
+CREATE INDEX IF NOT EXISTS "Calculation_deletedAt_createdAt_idx" ON "Calculation"("deletedAt", "createdAt");
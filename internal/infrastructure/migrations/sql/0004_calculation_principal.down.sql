@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "Calculation_createdAt_idx";
+DROP INDEX IF EXISTS "Calculation_principal_createdAt_idx";
+
+ALTER TABLE "Calculation" DROP COLUMN IF EXISTS "principal";
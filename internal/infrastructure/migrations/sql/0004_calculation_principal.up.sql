@@ -0,0 +1,4 @@
+ALTER TABLE "Calculation" ADD COLUMN IF NOT EXISTS "principal" TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS "Calculation_principal_createdAt_idx" ON "Calculation"("principal", "createdAt");
+CREATE INDEX IF NOT EXISTS "Calculation_createdAt_idx" ON "Calculation"("createdAt");
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS "Job_tenantId_status_createdAt_idx";
+DROP INDEX IF EXISTS "Calculation_tenantId_operation_createdAt_idx";
+DROP INDEX IF EXISTS "Calculation_tenantId_createdAt_idx";
+
+ALTER TABLE "Job" DROP COLUMN IF EXISTS "tenantId";
+ALTER TABLE "Calculation" DROP COLUMN IF EXISTS "tenantId";
+
+DROP TABLE IF EXISTS "Tenant";
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS "Tenant" (
+    "id" TEXT NOT NULL,
+    "name" TEXT NOT NULL,
+    "allowedOperations" TEXT[],
+    "maxOperand" INTEGER NOT NULL DEFAULT 0,
+    "maxPendingJobs" INTEGER NOT NULL DEFAULT 0,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "disabledAt" TIMESTAMP(3),
+
+    CONSTRAINT "Tenant_pkey" PRIMARY KEY ("id")
+);
+
+INSERT INTO "Tenant" ("id", "name") VALUES ('default', 'Default') ON CONFLICT ("id") DO NOTHING;
+
+ALTER TABLE "Calculation" ADD COLUMN IF NOT EXISTS "tenantId" TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE "Job" ADD COLUMN IF NOT EXISTS "tenantId" TEXT NOT NULL DEFAULT 'default';
+
+CREATE INDEX IF NOT EXISTS "Calculation_tenantId_createdAt_idx" ON "Calculation"("tenantId", "createdAt");
+CREATE INDEX IF NOT EXISTS "Calculation_tenantId_operation_createdAt_idx" ON "Calculation"("tenantId", "operation", "createdAt");
+CREATE INDEX IF NOT EXISTS "Job_tenantId_status_createdAt_idx" ON "Job"("tenantId", "status", "createdAt");
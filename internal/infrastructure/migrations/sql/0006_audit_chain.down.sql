@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS "Calculation_tenantId_prevHash_key";
+DROP INDEX IF EXISTS "Calculation_seq_key";
+
+ALTER TABLE "Calculation" DROP COLUMN IF EXISTS "prevHash";
+ALTER TABLE "Calculation" DROP COLUMN IF EXISTS "hash";
+ALTER TABLE "Calculation" DROP COLUMN IF EXISTS "seq";
@@ -0,0 +1,6 @@
+ALTER TABLE "Calculation" ADD COLUMN IF NOT EXISTS "seq" SERIAL;
+ALTER TABLE "Calculation" ADD COLUMN IF NOT EXISTS "hash" TEXT;
+ALTER TABLE "Calculation" ADD COLUMN IF NOT EXISTS "prevHash" TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "Calculation_seq_key" ON "Calculation"("seq");
+CREATE UNIQUE INDEX IF NOT EXISTS "Calculation_tenantId_prevHash_key" ON "Calculation"("tenantId", "prevHash");
@@ -0,0 +1,4 @@
+ALTER TABLE "Calculation" DROP COLUMN IF EXISTS "options";
+ALTER TABLE "Calculation" DROP COLUMN IF EXISTS "value";
+ALTER TABLE "Calculation" DROP COLUMN IF EXISTS "operands";
+ALTER TABLE "Calculation" DROP COLUMN IF EXISTS "kind";
@@ -0,0 +1,4 @@
+ALTER TABLE "Calculation" ADD COLUMN IF NOT EXISTS "kind" TEXT NOT NULL DEFAULT 'integer';
+ALTER TABLE "Calculation" ADD COLUMN IF NOT EXISTS "operands" TEXT;
+ALTER TABLE "Calculation" ADD COLUMN IF NOT EXISTS "value" TEXT;
+ALTER TABLE "Calculation" ADD COLUMN IF NOT EXISTS "options" TEXT;
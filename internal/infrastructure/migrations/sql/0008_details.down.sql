@@ -0,0 +1 @@
+ALTER TABLE "Calculation" DROP COLUMN IF EXISTS "details";
@@ -0,0 +1 @@
+ALTER TABLE "Calculation" ADD COLUMN IF NOT EXISTS "details" TEXT;
@@ -0,0 +1 @@
+ALTER TABLE "Job" DROP COLUMN IF EXISTS "calculationId";
@@ -0,0 +1 @@
+ALTER TABLE "Job" ADD COLUMN IF NOT EXISTS "calculationId" TEXT;
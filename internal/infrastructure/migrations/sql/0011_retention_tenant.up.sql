@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "Calculation_principal_createdAt_idx";
+
+CREATE INDEX IF NOT EXISTS "Calculation_tenantId_principal_createdAt_idx" ON "Calculation"("tenantId", "principal", "createdAt");
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "ChainTombstone_tenantId_seq_idx";
+
+DROP TABLE IF EXISTS "ChainTombstone";
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS "ChainTombstone" (
+    "id" TEXT NOT NULL,
+    "tenantId" TEXT NOT NULL,
+    "calculationId" TEXT NOT NULL,
+    "seq" INTEGER NOT NULL,
+    "hash" TEXT NOT NULL,
+    "prevHash" TEXT NOT NULL,
+    "reason" TEXT NOT NULL,
+    "removedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "ChainTombstone_pkey" PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "ChainTombstone_tenantId_seq_idx" ON "ChainTombstone"("tenantId", "seq");
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "Calculation_tenantId_operation_rounding_scale_idx";
+
+ALTER TABLE "Calculation" DROP COLUMN IF EXISTS "scale";
+ALTER TABLE "Calculation" DROP COLUMN IF EXISTS "rounding";
@@ -0,0 +1,9 @@
+ALTER TABLE "Calculation" ADD COLUMN IF NOT EXISTS "rounding" TEXT;
+ALTER TABLE "Calculation" ADD COLUMN IF NOT EXISTS "scale" INTEGER;
+
+UPDATE "Calculation"
+SET "rounding" = "options"::jsonb ->> 'rounding',
+    "scale" = ("options"::jsonb ->> 'scale')::INTEGER
+WHERE "options" IS NOT NULL AND "rounding" IS NULL AND "scale" IS NULL;
+
+CREATE INDEX IF NOT EXISTS "Calculation_tenantId_operation_rounding_scale_idx" ON "Calculation"("tenantId", "operation", "rounding", "scale");
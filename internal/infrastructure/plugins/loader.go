@@ -0,0 +1,296 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"go-prisma-calculator/internal/domain/service"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+var pluginLoads = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "calculator_plugin_loads_total",
+	Help: "Number of plugin modules loaded, by result.",
+}, []string{"result"})
+
+// wasmPageSize is the size of a WebAssembly memory page.
+const wasmPageSize = 64 << 10
+
+// Options configures the plugin loader.
+type Options struct {
+	// Dir is scanned for *.wasm files.
+	Dir string
+	// MemoryLimit is the maximum memory of a plugin instance in bytes.
+	MemoryLimit int
+	// Timeout bounds every call into a plugin, including its initialization.
+	Timeout time.Duration
+	// ReloadInterval is how often Dir is checked for changed files; 0
+	// loads the plugins once at startup.
+	ReloadInterval time.Duration
+	// MaxInstances is the number of idle instances kept per plugin.
+	MaxInstances int
+}
+
+// loaded is a plugin file and the state it was loaded in.
+type loaded struct {
+	modTime time.Time
+	size    int64
+	// plugin is nil when the file failed to load.
+	plugin *plugin
+}
+
+// Loader loads the plugins of a directory into the operation registry and
+// reloads them when the files change.
+type Loader struct {
+	registry *service.OperationRegistry
+	opts     Options
+	logger   *slog.Logger
+	runtime  wazero.Runtime
+
+	// mu serializes scans started by the scheduler and by Start.
+	mu    sync.Mutex
+	files map[string]*loaded
+
+	stop context.CancelFunc
+	done chan struct{}
+}
+
+// NewLoader creates a loader for opts.Dir.
+func NewLoader(registry *service.OperationRegistry, opts Options, logger *slog.Logger) *Loader {
+	if opts.MemoryLimit <= 0 {
+		opts.MemoryLimit = 16 << 20
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 100 * time.Millisecond
+	}
+	if opts.MaxInstances <= 0 {
+		opts.MaxInstances = 4
+	}
+	return &Loader{
+		registry: registry,
+		opts:     opts,
+		logger:   logger,
+		files:    make(map[string]*loaded),
+	}
+}
+
+// Start creates the sandboxed runtime, loads the plugins and starts
+// watching the directory.
+func (l *Loader) Start(ctx context.Context) error {
+	config := wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(uint32(l.opts.MemoryLimit / wasmPageSize)).
+		WithCloseOnContextDone(true)
+	l.runtime = wazero.NewRuntimeWithConfig(context.Background(), config)
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, l.runtime); err != nil {
+		return fmt.Errorf("plugins: %w", err)
+	}
+
+	if err := l.scan(ctx); err != nil {
+		return err
+	}
+	l.logger.Info("Plugins loaded",
+		slog.String("dir", l.opts.Dir),
+		slog.Int("plugins", l.count()),
+		slog.Duration("reload_interval", l.opts.ReloadInterval),
+	)
+
+	if l.opts.ReloadInterval <= 0 {
+		return nil
+	}
+	watchCtx, cancel := context.WithCancel(context.Background())
+	l.stop = cancel
+	l.done = make(chan struct{})
+	go l.watch(watchCtx)
+	return nil
+}
+
+// Stop stops watching the directory, unregisters the plugins and closes
+// the runtime.
+func (l *Loader) Stop(ctx context.Context) error {
+	if l.stop != nil {
+		l.stop()
+		select {
+		case <-l.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for path, f := range l.files {
+		l.unload(ctx, f)
+		delete(l.files, path)
+	}
+	if l.runtime == nil {
+		return nil
+	}
+	return l.runtime.Close(ctx)
+}
+
+func (l *Loader) watch(ctx context.Context) {
+	defer close(l.done)
+
+	ticker := time.NewTicker(l.opts.ReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := l.scan(ctx); err != nil && ctx.Err() == nil {
+				l.logger.Error("Plugin scan failed", slog.String("error", err.Error()))
+			}
+		}
+	}
+}
+
+// scan loads new and changed files and unloads removed ones. A changed
+// file replaces its operation without a gap. A file that fails to load is
+// logged and retried once it changes again; the version loaded before, if
+// any, is unloaded.
+func (l *Loader) scan(ctx context.Context) error {
+	paths, err := filepath.Glob(filepath.Join(l.opts.Dir, "*.wasm"))
+	if err != nil {
+		return fmt.Errorf("plugins: %w", err)
+	}
+	sort.Strings(paths)
+
+	infos := make(map[string]os.FileInfo, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("plugins: %w", err)
+		}
+		infos[path] = info
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for path, f := range l.files {
+		if infos[path] == nil {
+			l.unload(ctx, f)
+			delete(l.files, path)
+			l.logger.Info("Plugin removed", slog.String("file", path))
+		}
+	}
+
+	for _, path := range paths {
+		info := infos[path]
+		if info == nil {
+			continue
+		}
+		old := l.files[path]
+		if old != nil && old.modTime.Equal(info.ModTime()) && old.size == info.Size() {
+			continue
+		}
+
+		p := l.load(ctx, path)
+		switch {
+		case old == nil:
+		case p == nil:
+			l.unload(ctx, old)
+		case old.plugin != nil:
+			// Register replaced an operation of the same name; one with a
+			// different name is left behind.
+			if old.plugin.Name() != p.Name() {
+				l.registry.Unregister(old.plugin.Name())
+			}
+			old.plugin.close(ctx)
+		}
+		l.files[path] = &loaded{modTime: info.ModTime(), size: info.Size(), plugin: p}
+	}
+	return nil
+}
+
+// load compiles a plugin file and registers its operation, returning nil
+// when it fails.
+func (l *Loader) load(ctx context.Context, path string) *plugin {
+	p, err := l.compile(ctx, path)
+	if err == nil {
+		if err = l.claim(p); err == nil {
+			err = l.registry.Register(p)
+		}
+		if err != nil {
+			p.close(ctx)
+		}
+	}
+	if err != nil {
+		pluginLoads.WithLabelValues("failed").Inc()
+		l.logger.Error("Failed to load plugin", slog.String("file", path), slog.String("error", err.Error()))
+		return nil
+	}
+
+	pluginLoads.WithLabelValues("loaded").Inc()
+	l.logger.Info("Plugin loaded",
+		slog.String("file", path),
+		slog.String("operation", p.Name()),
+		slog.Int("arity", p.Arity()),
+	)
+	return p
+}
+
+func (l *Loader) compile(ctx context.Context, path string) (*plugin, error) {
+	code, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	compiled, err := l.runtime.CompileModule(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	p, err := newPlugin(ctx, l.runtime, compiled, path, l.opts)
+	if err != nil {
+		_ = compiled.Close(ctx)
+		return nil, err
+	}
+	return p, nil
+}
+
+// claim rejects a plugin whose operation is provided by another file.
+func (l *Loader) claim(p *plugin) error {
+	for path, f := range l.files {
+		if f.plugin != nil && f.plugin.Name() == p.Name() && path != p.file {
+			return fmt.Errorf("operation %q is already provided by %s", p.Name(), path)
+		}
+	}
+	return nil
+}
+
+// unload unregisters the operation of a loaded file.
+func (l *Loader) unload(ctx context.Context, f *loaded) {
+	if f.plugin == nil {
+		return
+	}
+	l.registry.Unregister(f.plugin.Name())
+	f.plugin.close(ctx)
+}
+
+func (l *Loader) count() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	n := 0
+	for _, f := range l.files {
+		if f.plugin != nil {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,158 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/service"
+)
+
+// Evaluation modes of testdata/counter.wasm.
+const (
+	modeCount int32 = iota
+	modeLoop
+	modeTrap
+	modeReject
+)
+
+// install copies the fixture name into dir as file.
+func install(t *testing.T, dir, name, file string) {
+	t.Helper()
+	code, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, file), code, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// startLoader starts a loader of the plugins in dir.
+func startLoader(t *testing.T, dir string) (*Loader, *service.OperationRegistry) {
+	t.Helper()
+	registry, err := service.NewOperationRegistry(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	l := NewLoader(registry, Options{Dir: dir, Timeout: 50 * time.Millisecond, MaxInstances: 1}, logger)
+	if err := l.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Stop(context.Background()) })
+	return l, registry
+}
+
+func TestLoaderRejectsInvalidPlugins(t *testing.T) {
+	tests := []struct {
+		fixture   string
+		operation string
+		wantErr   string
+	}{
+		{"abi_v2.wasm", "counter_v2", "ABI version 2 is not supported"},
+		{"wrong_signature.wasm", "wrong_signature", "calc_evaluate has the wrong signature"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			dir := t.TempDir()
+			install(t, dir, tt.fixture, tt.fixture)
+			l, registry := startLoader(t, dir)
+
+			if _, err := registry.Lookup(tt.operation); err == nil {
+				t.Errorf("operation %q registered", tt.operation)
+			}
+			_, err := l.compile(context.Background(), filepath.Join(dir, tt.fixture))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("compile error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestPluginInstances checks that an instance is reused after a success or
+// a rejection and discarded after a trap or a timeout. counter.wasm counts
+// the calls of its instance, so a new instance starts again at 1.
+func TestPluginInstances(t *testing.T) {
+	dir := t.TempDir()
+	install(t, dir, "counter.wasm", "counter.wasm")
+	_, registry := startLoader(t, dir)
+
+	steps := []struct {
+		name    string
+		mode    int32
+		want    int32
+		wantErr error
+	}{
+		{"first call", modeCount, 1, nil},
+		{"instance reused", modeCount, 2, nil},
+		{"rejected", modeReject, 0, domain.ErrInvalidOperands},
+		{"instance kept after a rejection", modeCount, 3, nil},
+		{"trap", modeTrap, 0, domain.ErrOperationFailed},
+		{"instance discarded after a trap", modeCount, 1, nil},
+		{"timeout", modeLoop, 0, domain.ErrOperationFailed},
+		{"instance discarded after a timeout", modeCount, 1, nil},
+	}
+	for _, step := range steps {
+		start := time.Now()
+		got, err := registry.Evaluate("counter", 0, step.mode)
+		if !errors.Is(err, step.wantErr) || (err == nil) != (step.wantErr == nil) {
+			t.Fatalf("%s: error = %v, want %v", step.name, err, step.wantErr)
+		}
+		if got != step.want {
+			t.Errorf("%s: result %d, want %d", step.name, got, step.want)
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("%s took %v, want the timeout to abort it", step.name, elapsed)
+		}
+	}
+}
+
+func TestLoaderHotReload(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	install(t, dir, "counter.wasm", "plugin.wasm")
+	l, registry := startLoader(t, dir)
+
+	registered := func() bool {
+		_, err := registry.Lookup("counter")
+		return err == nil
+	}
+	if !registered() {
+		t.Fatal("operation not registered at startup")
+	}
+
+	// A replacement that fails to load unregisters the operation it replaces.
+	install(t, dir, "abi_v2.wasm", "plugin.wasm")
+	if err := l.scan(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if registered() {
+		t.Error("operation still registered after its file failed to load")
+	}
+
+	install(t, dir, "counter.wasm", "plugin.wasm")
+	if err := l.scan(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !registered() {
+		t.Error("operation not registered after its file was fixed")
+	}
+
+	if err := os.Remove(filepath.Join(dir, "plugin.wasm")); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.scan(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if registered() {
+		t.Error("operation still registered after its file was removed")
+	}
+}
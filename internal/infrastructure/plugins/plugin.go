@@ -0,0 +1,264 @@
+// Package plugins loads calculator operations from WebAssembly modules at
+// runtime, so custom operations can be shipped without rebuilding the
+// server.
+//
+// # ABI
+//
+// A plugin is a .wasm file that exports its linear memory as "memory" and
+// the following functions:
+//
+//	calc_abi_version() -> i32
+//		Returns 1, the version of the ABI described here.
+//	calc_metadata() -> i64
+//		Returns ptr<<32 | len of a UTF-8 JSON document in memory:
+//		{"name":"pow","arity":2,"description":"Raises base to exponent.",
+//		 "operands":["base","exponent"],"commutative":false}
+//	calc_evaluate(a i32, b i32) -> i64
+//		Returns status<<32 | uint32(result). Status 0 is success, 1 rejects
+//		the operands as invalid and anything else is a failure. Unary
+//		operations receive 0 as b.
+//
+// Modules may import WASI (wasi_snapshot_preview1) but get no arguments,
+// environment, file system or network. Reactor modules are initialized
+// through their "_initialize" export; "_start" is never run. calc_evaluate
+// must be a pure function of its operands, because results are cached.
+//
+// # Sandbox
+//
+// Every plugin runs in its own instances with a memory limit, and every
+// call is aborted when it exceeds the evaluation timeout. Instances are
+// reused between calls and discarded after a failure.
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	domain "go-prisma-calculator/internal/domain/models"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+)
+
+// abiVersion is the version of the plugin ABI this server implements.
+const abiVersion = 1
+
+// Exports every plugin provides.
+const (
+	exportABIVersion = "calc_abi_version"
+	exportMetadata   = "calc_metadata"
+	exportEvaluate   = "calc_evaluate"
+)
+
+// Status codes returned by calc_evaluate.
+const (
+	statusOK       = 0
+	statusRejected = 1
+)
+
+// signatures are the parameter and result types of the exported functions.
+var signatures = map[string]struct{ params, results []api.ValueType }{
+	exportABIVersion: {nil, []api.ValueType{api.ValueTypeI32}},
+	exportMetadata:   {nil, []api.ValueType{api.ValueTypeI64}},
+	exportEvaluate:   {[]api.ValueType{api.ValueTypeI32, api.ValueTypeI32}, []api.ValueType{api.ValueTypeI64}},
+}
+
+// checkExports verifies that a compiled module implements the ABI.
+func checkExports(compiled wazero.CompiledModule) error {
+	if _, ok := compiled.ExportedMemories()["memory"]; !ok {
+		return errors.New("plugin does not export its memory")
+	}
+	functions := compiled.ExportedFunctions()
+	for name, want := range signatures {
+		fn, ok := functions[name]
+		if !ok {
+			return fmt.Errorf("plugin does not export %s", name)
+		}
+		if !slices.Equal(fn.ParamTypes(), want.params) || !slices.Equal(fn.ResultTypes(), want.results) {
+			return fmt.Errorf("plugin export %s has the wrong signature", name)
+		}
+	}
+	return nil
+}
+
+// maxMetadataSize bounds the metadata document a plugin may return.
+const maxMetadataSize = 64 << 10
+
+// metadata is the JSON document returned by calc_metadata.
+type metadata struct {
+	Name        string   `json:"name"`
+	Arity       int      `json:"arity"`
+	Description string   `json:"description"`
+	Operands    []string `json:"operands"`
+	Commutative bool     `json:"commutative"`
+}
+
+// plugin is an operation implemented by a compiled WebAssembly module.
+type plugin struct {
+	file     string
+	meta     metadata
+	runtime  wazero.Runtime
+	compiled wazero.CompiledModule
+	opts     Options
+	// pool keeps idle instances for reuse until the plugin is closed.
+	mu     sync.Mutex
+	pool   []api.Module
+	closed bool
+}
+
+var _ domain.Operation = (*plugin)(nil)
+
+// newPlugin instantiates a compiled module once to check its ABI version
+// and read its metadata.
+func newPlugin(ctx context.Context, runtime wazero.Runtime, compiled wazero.CompiledModule, file string, opts Options) (*plugin, error) {
+	if err := checkExports(compiled); err != nil {
+		return nil, err
+	}
+	p := &plugin{
+		file:     file,
+		runtime:  runtime,
+		compiled: compiled,
+		opts:     opts,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	mod, err := p.instantiate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer mod.Close(context.WithoutCancel(ctx))
+
+	results, err := call(ctx, mod, exportABIVersion)
+	if err != nil {
+		return nil, err
+	}
+	if version := api.DecodeI32(results[0]); version != abiVersion {
+		return nil, fmt.Errorf("plugin ABI version %d is not supported, want %d", version, abiVersion)
+	}
+
+	results, err = call(ctx, mod, exportMetadata)
+	if err != nil {
+		return nil, err
+	}
+	ptr, size := uint32(results[0]>>32), uint32(results[0])
+	if size > maxMetadataSize {
+		return nil, fmt.Errorf("plugin metadata is %d bytes, want at most %d", size, maxMetadataSize)
+	}
+	raw, ok := mod.Memory().Read(ptr, size)
+	if !ok {
+		return nil, errors.New("plugin metadata is out of memory bounds")
+	}
+	if err := json.Unmarshal(raw, &p.meta); err != nil {
+		return nil, fmt.Errorf("invalid plugin metadata: %w", err)
+	}
+	return p, nil
+}
+
+func (p *plugin) Name() string { return p.meta.Name }
+func (p *plugin) Arity() int   { return p.meta.Arity }
+
+// Validate accepts any operands; plugins reject operands when evaluating.
+func (p *plugin) Validate(operands []int32) error { return nil }
+
+func (p *plugin) Describe() domain.OperationDoc {
+	return domain.OperationDoc{
+		Description: p.meta.Description,
+		Operands:    p.meta.Operands,
+		Commutative: p.meta.Commutative,
+	}
+}
+
+// Evaluate calls calc_evaluate within the evaluation timeout.
+func (p *plugin) Evaluate(operands []int32) (int32, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.opts.Timeout)
+	defer cancel()
+
+	mod, err := p.acquire(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s: %v", domain.ErrOperationFailed, p.meta.Name, err)
+	}
+
+	var a, b int32
+	a = operands[0]
+	if len(operands) > 1 {
+		b = operands[1]
+	}
+	results, err := call(ctx, mod, exportEvaluate, api.EncodeI32(a), api.EncodeI32(b))
+	p.release(ctx, mod, err != nil)
+	if err != nil {
+		if ctx.Err() != nil {
+			return 0, fmt.Errorf("%w: %s: exceeded the %s timeout", domain.ErrOperationFailed, p.meta.Name, p.opts.Timeout)
+		}
+		// Traps carry a wasm stack trace after the first line.
+		reason, _, _ := strings.Cut(err.Error(), "\n")
+		return 0, fmt.Errorf("%w: %s: %s", domain.ErrOperationFailed, p.meta.Name, reason)
+	}
+
+	switch status := uint32(results[0] >> 32); status {
+	case statusOK:
+		return int32(uint32(results[0])), nil
+	case statusRejected:
+		return 0, fmt.Errorf("%w: %s rejected the operands", domain.ErrInvalidOperands, p.meta.Name)
+	default:
+		return 0, fmt.Errorf("%w: %s returned status %d", domain.ErrOperationFailed, p.meta.Name, status)
+	}
+}
+
+// acquire returns an idle instance or instantiates a new one.
+func (p *plugin) acquire(ctx context.Context) (api.Module, error) {
+	p.mu.Lock()
+	if n := len(p.pool); n > 0 {
+		mod := p.pool[n-1]
+		p.pool = p.pool[:n-1]
+		p.mu.Unlock()
+		return mod, nil
+	}
+	p.mu.Unlock()
+	return p.instantiate(ctx)
+}
+
+// release returns an instance to the pool, or closes it when it failed,
+// since a trap or an aborted call may leave it in any state.
+func (p *plugin) release(ctx context.Context, mod api.Module, failed bool) {
+	p.mu.Lock()
+	if !failed && !p.closed && len(p.pool) < p.opts.MaxInstances {
+		p.pool = append(p.pool, mod)
+		p.mu.Unlock()
+		return
+	}
+	p.mu.Unlock()
+	_ = mod.Close(context.WithoutCancel(ctx))
+}
+
+func (p *plugin) instantiate(ctx context.Context) (api.Module, error) {
+	config := wazero.NewModuleConfig().
+		WithName("").
+		WithStartFunctions("_initialize")
+	return p.runtime.InstantiateModule(ctx, p.compiled, config)
+}
+
+// close releases the idle instances and the compiled module. Calls in
+// progress finish on their own instances.
+func (p *plugin) close(ctx context.Context) {
+	p.mu.Lock()
+	pool := p.pool
+	p.pool, p.closed = nil, true
+	p.mu.Unlock()
+
+	for _, mod := range pool {
+		_ = mod.Close(ctx)
+	}
+	_ = p.compiled.Close(ctx)
+}
+
+// call invokes an exported function checked by checkExports.
+func call(ctx context.Context, mod api.Module, name string, params ...uint64) ([]uint64, error) {
+	return mod.ExportedFunction(name).Call(ctx, params...)
+}
@@ -0,0 +1,30 @@
+;; counter.wasm: a plugin whose calc_evaluate(a, mode) returns the number of
+;; calls its instance has answered, so tests can tell a reused instance from
+;; a new one. mode 1 loops forever, mode 2 traps and mode 3 rejects the
+;; operands.
+;;
+;; abi_v2.wasm is the same module named "counter_v2" with calc_abi_version
+;; returning 2. wrong_signature.wasm is named "wrong_signature" and declares
+;; calc_evaluate as (param i32) (result i64), returning 0.
+(module
+  (memory (export "memory") 1)
+  (global $calls (mut i32) (i32.const 0))
+
+  (func (export "calc_abi_version") (result i32)
+    i32.const 1)
+
+  ;; 16<<32 | the length of the metadata document at offset 16.
+  (func (export "calc_metadata") (result i64)
+    i64.const 68719476867)
+
+  (func (export "calc_evaluate") (param $a i32) (param $mode i32) (result i64)
+    (if (i32.eq (local.get $mode) (i32.const 1))
+      (then (loop $forever (br $forever))))
+    (if (i32.eq (local.get $mode) (i32.const 2))
+      (then unreachable))
+    (if (i32.eq (local.get $mode) (i32.const 3))
+      (then (return (i64.const 4294967296))))
+    (global.set $calls (i32.add (global.get $calls) (i32.const 1)))
+    (i64.extend_i32_u (global.get $calls)))
+
+  (data (i32.const 16) "{\"name\":\"counter\",\"arity\":2,\"description\":\"Counts the calls answered by its instance.\",\"operands\":[\"a\",\"mode\"],\"commutative\":false}"))
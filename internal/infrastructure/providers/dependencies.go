@@ -5,21 +5,36 @@ import (
 	"log/slog"
 
 	"go-prisma-calculator/internal/application/usecase"
+	"go-prisma-calculator/internal/domain/bigint"
+	"go-prisma-calculator/internal/domain/complexnum"
+	"go-prisma-calculator/internal/domain/matrix"
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/operations"
 	"go-prisma-calculator/internal/domain/ports/in"
 	"go-prisma-calculator/internal/domain/ports/out"
+	"go-prisma-calculator/internal/domain/rational"
+	"go-prisma-calculator/internal/domain/scientific"
 	"go-prisma-calculator/internal/domain/service"
 	grpc_adapter "go-prisma-calculator/internal/infrastructure/adapter/grpc"
 	rest_adapter "go-prisma-calculator/internal/infrastructure/adapter/rest"
+	"go-prisma-calculator/internal/infrastructure/auth"
+	"go-prisma-calculator/internal/infrastructure/cache"
 	"go-prisma-calculator/internal/infrastructure/config"
+	"go-prisma-calculator/internal/infrastructure/health"
 	"go-prisma-calculator/internal/infrastructure/logger"
+	"go-prisma-calculator/internal/infrastructure/plugins"
 	"go-prisma-calculator/internal/infrastructure/repository"
 	db "go-prisma-calculator/internal/infrastructure/repository/prisma"
+	"go-prisma-calculator/internal/infrastructure/retention"
+	"go-prisma-calculator/internal/infrastructure/signing"
 
 	"go.uber.org/fx"
 )
 
-// Module bundles all of our application's components for fx.
-var Module = fx.Options(
+// Core bundles the components needed to run calculations and read the
+// history, without the job workers or the API adapters. The CLI uses it to
+// run one-off commands next to a live server.
+var Core = fx.Options(
 	// 1. Provide the Logger, managing the file lifecycle with fx.
 	fx.Provide(func(lifecycle fx.Lifecycle) *slog.Logger {
 		l, f := logger.NewLogger()
@@ -36,27 +51,74 @@ var Module = fx.Options(
 	// 2. Provide the application configuration.
 	fx.Provide(config.NewConfig),
 
-	// 3. Provide the Prisma database client, which depends on Config.
+	// 3. Provide the Prisma database client, which depends on Config. The
+	// client is connected by a retrying Connection when the app starts; while
+	// the database is unreachable the health Monitor reports degraded mode.
+	fx.Provide(health.NewMonitor),
 	fx.Provide(func(c *config.Config) *db.PrismaClient {
-		// In a real app, you would use c.DatabaseURL
-		client := db.NewClient()
-		if err := client.Connect(); err != nil {
-			panic(err) // In a real app, handle this more gracefully
+		if c.DatabaseURL != "" {
+			return db.NewClient(db.WithDatasourceURL(c.DatabaseURL))
 		}
-		return client
+		return db.NewClient()
+	}),
+	fx.Provide(func(lifecycle fx.Lifecycle, client *db.PrismaClient, m *health.Monitor, c *config.Config, l *slog.Logger) *repository.Connection {
+		conn := repository.NewConnection(client, m, repository.ConnectionOptions{
+			StartupDeadline: c.DBConnectTimeout,
+			MinBackoff:      c.DBReconnectMinBackoff,
+			MaxBackoff:      c.DBReconnectMaxBackoff,
+		}, l)
+		lifecycle.Append(fx.Hook{
+			OnStart: conn.Start,
+			OnStop:  conn.Stop,
+		})
+		return conn
 	}),
 
-	// 4. Provide the Repository, mapping the implementation to the outbound port.
+	// 4. Provide the Repository, mapping the implementation to the outbound
+	// port and layering the configured decorators on top of it.
+	fx.Provide(newCalculationRepository),
+
+	// 5. Provide the operation registry, filled from the "operations" group,
+	// and the Domain Service, which depends on it and the repository port.
+	// Registering another operation constructor with asOperation makes it
+	// available through every API.
 	fx.Provide(
-		fx.Annotate(
-			repository.NewPrismaRepository,
-			fx.As(new(out.CalculationRepositoryPort)),
-		),
+		asOperation(operations.NewAdd),
+		asOperation(operations.NewDivide),
 	),
+	fx.Provide(fx.Annotate(service.NewOperationRegistry, fx.ParamTags(`group:"operations"`))),
 
-	// 5. Provide the Domain Service, which depends on the repository port.
+	// 5b. Provide the evaluator registry for the kinds of numbers other than
+	// integers, filled from the "evaluators" group like the operations.
+	fx.Provide(
+		asEvaluator(scientific.NewFloatEvaluator),
+		asEvaluator(scientific.NewDecimalEvaluator),
+		asEvaluator(func(c *config.Config) domain.Evaluator { return bigint.NewEvaluator(c.BigIntMaxBits) }),
+		asEvaluator(func(c *config.Config) domain.Evaluator { return rational.NewEvaluator(c.BigIntMaxBits) }),
+		asEvaluator(complexnum.NewEvaluator),
+		asEvaluator(func(c *config.Config) domain.Evaluator { return matrix.NewEvaluator(c.MatrixMaxDimension) }),
+	),
+	fx.Provide(fx.Annotate(service.NewEvaluatorRegistry, fx.ParamTags(`group:"evaluators"`))),
 	fx.Provide(service.NewCalculatorService),
 
+	// 5a. Load WebAssembly operation plugins into the registry when a plugin
+	// directory is configured, before anything serves calculations.
+	fx.Invoke(func(lifecycle fx.Lifecycle, registry *service.OperationRegistry, c *config.Config, l *slog.Logger) {
+		if c.PluginDir == "" {
+			return
+		}
+		loader := plugins.NewLoader(registry, plugins.Options{
+			Dir:            c.PluginDir,
+			MemoryLimit:    c.PluginMemoryLimitMB << 20,
+			Timeout:        c.PluginTimeout,
+			ReloadInterval: c.PluginReloadInterval,
+		}, l)
+		lifecycle.Append(fx.Hook{
+			OnStart: loader.Start,
+			OnStop:  loader.Stop,
+		})
+	}),
+
 	// 6. Provide the Application Usecase, mapping the implementation to the inbound port.
 	fx.Provide(
 		fx.Annotate(
@@ -65,7 +127,102 @@ var Module = fx.Options(
 		),
 	),
 
-	// 7. Provide the API adapters, which depend on the usecase port and the logger.
+	// 6a. Optionally wrap the calculator port with the in-memory result cache,
+	// then with the tenant policy, so cached results are checked as well, and
+	// finally with the receipt signer, so cached results are signed afresh.
+	fx.Provide(signing.NewSigner),
+	fx.Decorate(func(port in.CalculatorPort, repo out.CalculationRepositoryPort, registry *service.OperationRegistry, calcService *service.CalculatorService, signer *signing.Signer, c *config.Config, l *slog.Logger) in.CalculatorPort {
+		if c.CacheEnabled {
+			port = cache.NewCalculatorCache(port, repo, registry, cache.Options{
+				Size:       c.CacheSize,
+				TTL:        c.CacheTTL,
+				RecordHits: c.CacheRecordHits,
+			}, l)
+		}
+		port = usecase.NewTenantCalculator(port, calcService)
+		if signer.Enabled() {
+			port = signing.NewSigningCalculator(port, signer)
+		}
+		return port
+	}),
+
+	// 7. Provide the history and import usecases, mapping them to their inbound ports.
+	fx.Provide(usecase.NewHistoryUseCase),
+	fx.Provide(usecase.NewImportUseCase),
+
+	// 7a. Provide the retention enforcer. Only the server schedules it; the
+	// CLI runs it on demand.
+	fx.Provide(newRetentionEnforcer),
+
+	// 7b. Provide the tenant repository and usecase, which resolve the tenant
+	// every repository query is scoped to.
+	fx.Provide(repository.NewPrismaTenantRepository),
+	fx.Provide(func(c *config.Config) usecase.TenantOptions {
+		return usecase.TenantOptions{CacheTTL: c.TenantCacheTTL}
+	}),
+	fx.Provide(usecase.NewTenantUseCase),
+)
+
+// asOperation provides an operation constructor to the "operations" group
+// that the operation registry is built from.
+func asOperation(constructor any) any {
+	return fx.Annotate(constructor, fx.ResultTags(`group:"operations"`))
+}
+
+// asEvaluator annotates an evaluator constructor so that fx adds its result
+// to the "evaluators" group of the evaluator registry.
+func asEvaluator(constructor any) any {
+	return fx.Annotate(constructor, fx.ResultTags(`group:"evaluators"`))
+}
+
+// Module bundles all of our application's components for fx.
+var Module = fx.Options(
+	Core,
+
+	// 8. Provide the job subsystem: its repository, the worker pool sized from
+	// Config, and the lifecycle hooks that start and drain the workers.
+	fx.Provide(
+		fx.Annotate(
+			repository.NewPrismaJobRepository,
+			fx.As(new(out.JobRepositoryPort)),
+		),
+	),
+	fx.Provide(func(c *config.Config) usecase.JobOptions {
+		return usecase.JobOptions{Workers: c.JobWorkers, QueueSize: c.JobQueueSize}
+	}),
+	fx.Provide(
+		fx.Annotate(
+			usecase.NewJobUseCase,
+			fx.As(fx.Self()),
+			fx.As(new(in.JobPort)),
+		),
+	),
+	fx.Invoke(func(lifecycle fx.Lifecycle, jobs *usecase.JobUseCase) {
+		lifecycle.Append(fx.Hook{
+			OnStart: jobs.Start,
+			OnStop:  jobs.Stop,
+		})
+	}),
+
+	// 9. Provide the API adapters, which depend on the usecase ports and the
+	// logger, and the authenticator that resolves the caller of each request.
+	fx.Provide(auth.NewAuthenticator),
 	fx.Provide(grpc_adapter.NewAdapter),
 	fx.Provide(rest_adapter.NewAdapter),
-)
\ No newline at end of file
+
+	// 10. Schedule the retention enforcer when it is enabled and the policy
+	// expires anything.
+	fx.Invoke(func(lifecycle fx.Lifecycle, enforcer *retention.Enforcer, c *config.Config, l *slog.Logger) {
+		if !c.RetentionEnabled {
+			return
+		}
+		if !enforcer.Policy().Enabled() {
+			l.Warn("Retention is enabled but no retention policy is configured")
+			return
+		}
+		lifecycle.Append(fx.Hook{
+			OnStart: enforcer.Start,
+			OnStop:  enforcer.Stop,
+		})
+	}),
+)
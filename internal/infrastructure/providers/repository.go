@@ -0,0 +1,66 @@
+package providers
+
+import (
+	"log/slog"
+
+	"go-prisma-calculator/internal/domain/ports/out"
+	"go-prisma-calculator/internal/infrastructure/config"
+	"go-prisma-calculator/internal/infrastructure/health"
+	"go-prisma-calculator/internal/infrastructure/repository"
+	db "go-prisma-calculator/internal/infrastructure/repository/prisma"
+	"go-prisma-calculator/internal/infrastructure/resilience"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/fx"
+)
+
+// newCalculationRepository builds the calculation repository chain, innermost first:
+//
+//	Prisma -> resilience (timeouts, retries, circuit breaker) -> no-history
+//	fallback -> write-behind queue (async mode only)
+//
+// The Connection dependency makes sure the client is connected (or reconnecting)
+// before anything uses the repository.
+func newCalculationRepository(
+	lifecycle fx.Lifecycle,
+	client *db.PrismaClient,
+	_ *repository.Connection,
+	monitor *health.Monitor,
+	c *config.Config,
+	l *slog.Logger,
+) out.CalculationRepositoryPort {
+	var repo out.CalculationRepositoryPort = repository.NewPrismaRepository(client)
+	repo = repository.NewResilientRepository(repo, repository.ResilienceOptions{
+		Retry: resilience.RetryOptions{
+			Timeout:     c.RepoCallTimeout,
+			MaxAttempts: c.RepoMaxAttempts,
+			BaseDelay:   c.RepoRetryBaseDelay,
+			MaxDelay:    c.RepoRetryMaxDelay,
+		},
+		Breaker: resilience.BreakerOptions{
+			FailureThreshold: c.BreakerFailureThreshold,
+			OpenDuration:     c.BreakerOpenDuration,
+			HalfOpenProbes:   c.BreakerHalfOpenProbes,
+		},
+	}, l)
+	repo = repository.NewDegradableRepository(repo, monitor)
+
+	if c.PersistenceMode == "async" {
+		wb := repository.NewWriteBehindRepository(repo, repository.WriteBehindOptions{
+			QueueSize:     c.WriteBehindQueueSize,
+			BatchSize:     c.WriteBehindBatchSize,
+			FlushInterval: c.WriteBehindFlushInterval,
+			Overflow:      repository.OverflowPolicy(c.WriteBehindOverflow),
+			JournalPath:   c.WriteBehindJournal,
+			MaxEntrySize:  repository.JournalEntrySize(c.BigIntMaxBits, c.MatrixMaxDimension),
+			Registerer:    prometheus.DefaultRegisterer,
+		}, l)
+		lifecycle.Append(fx.Hook{
+			OnStart: wb.Start,
+			OnStop:  wb.Stop,
+		})
+		repo = wb
+	}
+
+	return repo
+}
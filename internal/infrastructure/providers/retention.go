@@ -0,0 +1,39 @@
+package providers
+
+import (
+	"log/slog"
+
+	"go-prisma-calculator/internal/infrastructure/config"
+	"go-prisma-calculator/internal/infrastructure/health"
+	"go-prisma-calculator/internal/infrastructure/repository"
+	db "go-prisma-calculator/internal/infrastructure/repository/prisma"
+	"go-prisma-calculator/internal/infrastructure/retention"
+)
+
+// newRetentionEnforcer builds the retention enforcer from Config. Invalid
+// retention settings fail startup rather than silently keeping everything.
+func newRetentionEnforcer(
+	client *db.PrismaClient,
+	_ *repository.Connection,
+	monitor *health.Monitor,
+	c *config.Config,
+	l *slog.Logger,
+) (*retention.Enforcer, error) {
+	overrides, err := retention.ParseOperationMaxAge(c.RetentionOperationMaxAge)
+	if err != nil {
+		return nil, err
+	}
+
+	return retention.NewEnforcer(repository.NewPrismaRetentionRepository(client), monitor, retention.Options{
+		Policy: retention.Policy{
+			MaxAge:              c.RetentionMaxAge,
+			MaxRowsPerPrincipal: c.RetentionMaxRowsPerPrincipal,
+			OperationMaxAge:     overrides,
+		},
+		Interval:   c.RetentionInterval,
+		BatchSize:  c.RetentionBatchSize,
+		Mode:       retention.Mode(c.RetentionMode),
+		ArchiveDir: c.RetentionArchiveDir,
+		DryRun:     c.RetentionDryRun,
+	}, l)
+}
@@ -2,6 +2,11 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sync"
+	"time"
 
 	domain "go-prisma-calculator/internal/domain/models"
 	"go-prisma-calculator/internal/domain/ports/out"
@@ -13,6 +18,8 @@ import (
 // PrismaRepository is the Prisma implementation of our repository port.
 type PrismaRepository struct {
 	client *db.PrismaClient
+	// mu serializes appends to the audit chains of this instance.
+	mu sync.Mutex
 }
 
 // NewPrismaRepository is the constructor that fx uses to create an instance.
@@ -23,16 +30,433 @@ func NewPrismaRepository(client *db.PrismaClient) out.CalculationRepositoryPort
 	}
 }
 
+// maxChainAttempts bounds how often an append to the audit chain is retried
+// after another writer appended to the same chain first.
+const maxChainAttempts = 5
+
 // Save implements the port's contract. It translates the domain model
-// into a Prisma model and saves it to the database.
+// into a Prisma model and saves it to the database, linked into the audit chain.
+//
+// Every query of the repository is scoped to the tenant in the context;
+// writes use the tenant of the calculation when it is set.
 func (r *PrismaRepository) Save(ctx context.Context, calc domain.Calculation) error {
-	// Use the Prisma client's fluent API to create a new record.
-	_, err := r.client.Calculation.CreateOne(
-		db.Calculation.Operation.Set(calc.Operation),
-		db.Calculation.A.Set(calc.A),
-		db.Calculation.B.Set(calc.B),
-		db.Calculation.Result.Set(calc.Result),
+	return r.appendChain(ctx, []domain.Calculation{calc})
+}
+
+// SaveBatch inserts several calculations in a single transaction.
+func (r *PrismaRepository) SaveBatch(ctx context.Context, calcs []domain.Calculation) error {
+	if len(calcs) == 0 {
+		return nil
+	}
+	return r.appendChain(ctx, calcs)
+}
+
+// Import inserts past calculations in a single transaction. The creation
+// time is kept when set, and so is the soft-delete time. Imported
+// calculations join the audit chain in the order they are imported.
+func (r *PrismaRepository) Import(ctx context.Context, calcs []domain.Calculation) error {
+	if len(calcs) == 0 {
+		return nil
+	}
+	return r.appendChain(ctx, calcs)
+}
+
+// appendChain inserts calculations at the head of their tenants' audit
+// chains in a single transaction. The link to the previous record is unique,
+// so when another instance appends to the same chain first the transaction
+// fails and is retried on top of the new head.
+func (r *PrismaRepository) appendChain(ctx context.Context, calcs []domain.Calculation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for attempt := 1; ; attempt++ {
+		err := r.tryAppendChain(ctx, calcs)
+		if lostChainRace(err) && attempt < maxChainAttempts {
+			continue
+		}
+		return err
+	}
+}
+
+// lostChainRace reports whether err is a violation of the unique link to the
+// previous record, rather than, say, of the primary key.
+func lostChainRace(err error) bool {
+	violation, ok := db.IsErrUniqueConstraint(err)
+	return ok && slices.Contains(violation.Fields, "prevHash")
+}
+
+func (r *PrismaRepository) tryAppendChain(ctx context.Context, calcs []domain.Calculation) error {
+	now := domain.ChainTime(time.Now())
+	heads := make(map[string]string)
+	txs := make([]db.PrismaTransaction, 0, len(calcs))
+	for _, calc := range calcs {
+		calc.TenantID = tenantID(ctx, calc.TenantID)
+		calc.CreatedAt = domain.ChainTime(calc.CreatedAt)
+		if calc.CreatedAt.IsZero() {
+			calc.CreatedAt = now
+		}
+
+		prev, ok := heads[calc.TenantID]
+		if !ok {
+			var err error
+			if prev, err = r.chainHead(ctx, calc.TenantID); err != nil {
+				return err
+			}
+		}
+		calc.PrevHash = prev
+		calc.Hash = calc.ChainHash(prev)
+		heads[calc.TenantID] = calc.Hash
+
+		kind, operands, value, details, matrices, options := textualColumns(calc)
+		txs = append(txs, r.client.Calculation.CreateOne(
+			db.Calculation.Operation.Set(calc.Operation),
+			db.Calculation.A.Set(calc.A),
+			db.Calculation.B.Set(calc.B),
+			db.Calculation.Result.Set(calc.Result),
+			db.Calculation.ID.SetIfPresent(optionalString(calc.ID)),
+			db.Calculation.Principal.Set(calc.Principal),
+			db.Calculation.TenantID.Set(calc.TenantID),
+			db.Calculation.CreatedAt.Set(calc.CreatedAt),
+			db.Calculation.DeletedAt.SetIfPresent(calc.DeletedAt),
+			db.Calculation.Kind.Set(kind),
+			db.Calculation.Operands.SetIfPresent(operands),
+			db.Calculation.Value.SetIfPresent(value),
+			db.Calculation.Details.SetIfPresent(details),
+			db.Calculation.Matrices.SetIfPresent(matrices),
+			db.Calculation.Options.SetIfPresent(options),
+			db.Calculation.Rounding.SetIfPresent(optionalString(string(calc.Options.Rounding))),
+			db.Calculation.Scale.SetIfPresent(calc.Options.Scale),
+			db.Calculation.Hash.Set(calc.Hash),
+			db.Calculation.PrevHash.Set(calc.PrevHash),
+		).Tx())
+	}
+
+	return r.client.Prisma.Transaction(txs...).Exec(ctx)
+}
+
+// chainHead returns the hash of the newest record of a tenant's audit chain,
+// or "" when the chain is empty. A record that was purged or expired still
+// heads the chain through its tombstone, so the next record links across it
+// and the removal stays visible to verification.
+func (r *PrismaRepository) chainHead(ctx context.Context, tenant string) (string, error) {
+	live, err := r.client.Calculation.FindFirst(
+		db.Calculation.TenantID.Equals(tenant),
+	).OrderBy(
+		db.Calculation.Seq.Order(db.SortOrderDesc),
+	).Exec(ctx)
+	if err != nil && !errors.Is(err, db.ErrNotFound) {
+		return "", err
+	}
+	removed, err := r.client.ChainTombstone.FindFirst(
+		db.ChainTombstone.TenantID.Equals(tenant),
+	).OrderBy(
+		db.ChainTombstone.Seq.Order(db.SortOrderDesc),
+	).Exec(ctx)
+	if err != nil && !errors.Is(err, db.ErrNotFound) {
+		return "", err
+	}
+
+	switch {
+	case removed != nil && (live == nil || removed.Seq > live.Seq):
+		return removed.Hash, nil
+	case live != nil:
+		hash, _ := live.Hash()
+		return hash, nil
+	}
+	return "", nil
+}
+
+// Chain returns calculations of the tenant in audit chain order.
+func (r *PrismaRepository) Chain(ctx context.Context, afterSeq, limit int) ([]domain.Calculation, error) {
+	rows, err := r.client.Calculation.FindMany(
+		tenantScope(ctx),
+		db.Calculation.Seq.Gt(afterSeq),
+	).OrderBy(
+		db.Calculation.Seq.Order(db.SortOrderAsc),
+	).Take(limit).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	calcs := make([]domain.Calculation, 0, len(rows))
+	for i := range rows {
+		calcs = append(calcs, *toDomainCalculation(&rows[i]))
+	}
+	return calcs, nil
+}
+
+// Tombstones returns up to limit tombstones of the tenant's audit chain
+// whose sequence numbers lie between afterSeq and beforeSeq, in chain order.
+func (r *PrismaRepository) Tombstones(ctx context.Context, afterSeq, beforeSeq, limit int) ([]domain.ChainTombstone, error) {
+	rows, err := r.client.ChainTombstone.FindMany(
+		db.ChainTombstone.TenantID.Equals(domain.TenantFromContext(ctx).ID),
+		db.ChainTombstone.Seq.Gt(afterSeq),
+		db.ChainTombstone.Seq.Lt(beforeSeq),
+	).OrderBy(
+		db.ChainTombstone.Seq.Order(db.SortOrderAsc),
+	).Take(limit).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tombstones := make([]domain.ChainTombstone, 0, len(rows))
+	for _, row := range rows {
+		tombstones = append(tombstones, domain.ChainTombstone{
+			CalculationID: row.CalculationID,
+			Seq:           row.Seq,
+			Hash:          row.Hash,
+			PrevHash:      row.PrevHash,
+			Reason:        row.Reason,
+			RemovedAt:     row.RemovedAt,
+		})
+	}
+	return tombstones, nil
+}
+
+// FindByID loads a single calculation that has not been soft-deleted.
+func (r *PrismaRepository) FindByID(ctx context.Context, id string) (*domain.Calculation, error) {
+	calc, err := r.client.Calculation.FindFirst(
+		db.Calculation.ID.Equals(id),
+		tenantScope(ctx),
+		db.Calculation.DeletedAt.IsNull(),
 	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainCalculation(calc), nil
+}
+
+// List returns calculations matching the filter, newest first. Soft-deleted
+// calculations are only included when the filter asks for them. A cursor
+// (filter.After) pages through large listings without growing offsets.
+func (r *PrismaRepository) List(ctx context.Context, filter domain.CalculationFilter) ([]domain.Calculation, error) {
+	where := []db.CalculationWhereParam{
+		tenantScope(ctx),
+		db.Calculation.Operation.EqualsIfPresent(optionalString(filter.Operation)),
+	}
+	if !filter.IncludeDeleted {
+		where = append(where, db.Calculation.DeletedAt.IsNull())
+	}
 
-	return err
+	// The ID breaks ties between calculations created in the same
+	// millisecond, which keeps cursor pages stable.
+	query := r.client.Calculation.FindMany(where...).OrderBy(
+		db.Calculation.CreatedAt.Order(db.SortOrderDesc),
+		db.Calculation.ID.Order(db.SortOrderDesc),
+	)
+	skip := filter.Offset
+	if filter.After != "" {
+		// The cursor row itself is included by Prisma, so skip it.
+		query = query.Cursor(db.Calculation.ID.Cursor(filter.After))
+		skip++
+	}
+	if skip > 0 {
+		query = query.Skip(skip)
+	}
+	if filter.Limit > 0 {
+		query = query.Take(filter.Limit)
+	}
+
+	rows, err := query.Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	calcs := make([]domain.Calculation, 0, len(rows))
+	for i := range rows {
+		calcs = append(calcs, *toDomainCalculation(&rows[i]))
+	}
+	return calcs, nil
+}
+
+// Delete soft-deletes a calculation by stamping deletedAt. Deleting a
+// calculation that is already deleted reports domain.ErrNotFound.
+func (r *PrismaRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.client.Calculation.FindMany(
+		db.Calculation.ID.Equals(id),
+		tenantScope(ctx),
+		db.Calculation.DeletedAt.IsNull(),
+	).Update(
+		db.Calculation.DeletedAt.Set(time.Now()),
+	).Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if result.Count == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// Restore clears deletedAt. Restoring a calculation that is not deleted is a no-op.
+// Unique updates cannot be scoped to a tenant, so the update is a filtered one.
+func (r *PrismaRepository) Restore(ctx context.Context, id string) (*domain.Calculation, error) {
+	result, err := r.client.Calculation.FindMany(
+		db.Calculation.ID.Equals(id),
+		tenantScope(ctx),
+	).Update(
+		db.Calculation.DeletedAt.SetOptional(nil),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if result.Count == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+// Purge permanently deletes a calculation, whether or not it was
+// soft-deleted, and leaves a tombstone in its place in the audit chain.
+func (r *PrismaRepository) Purge(ctx context.Context, id string) error {
+	calc, err := r.client.Calculation.FindFirst(
+		db.Calculation.ID.Equals(id),
+		tenantScope(ctx),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return domain.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	n, err := removeFromChain(ctx, r.client, []domain.Calculation{*toDomainCalculation(calc)}, domain.TombstonePurged)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// removeFromChain deletes calculations and records a tombstone for each
+// chained one in the same transaction, so that the audit chain still
+// verifies across the gap. It returns how many calculations were deleted.
+// A calculation removed concurrently may get a second tombstone, which
+// verification skips.
+func removeFromChain(ctx context.Context, client *db.PrismaClient, calcs []domain.Calculation, reason string) (int, error) {
+	ids := make([]string, 0, len(calcs))
+	txs := make([]db.PrismaTransaction, 0, len(calcs)+1)
+	for _, calc := range calcs {
+		ids = append(ids, calc.ID)
+		if calc.Hash == "" {
+			continue
+		}
+		txs = append(txs, client.ChainTombstone.CreateOne(
+			db.ChainTombstone.TenantID.Set(calc.TenantID),
+			db.ChainTombstone.CalculationID.Set(calc.ID),
+			db.ChainTombstone.Seq.Set(calc.Seq),
+			db.ChainTombstone.Hash.Set(calc.Hash),
+			db.ChainTombstone.PrevHash.Set(calc.PrevHash),
+			db.ChainTombstone.Reason.Set(reason),
+		).Tx())
+	}
+
+	deleted := client.Calculation.FindMany(
+		db.Calculation.ID.In(ids),
+	).Delete().Tx()
+	txs = append(txs, deleted)
+
+	if err := client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return 0, err
+	}
+	return deleted.Result().Count, nil
+}
+
+// tenantScope restricts a query to the tenant in the context.
+func tenantScope(ctx context.Context) db.CalculationWhereParam {
+	return db.Calculation.TenantID.Equals(domain.TenantFromContext(ctx).ID)
+}
+
+// tenantID returns the tenant of a record being written, falling back to
+// the tenant in the context.
+func tenantID(ctx context.Context, id string) string {
+	if id != "" {
+		return id
+	}
+	return domain.TenantFromContext(ctx).ID
+}
+
+// toDomainCalculation translates a Prisma model into the domain model.
+func toDomainCalculation(m *db.CalculationModel) *domain.Calculation {
+	calc := &domain.Calculation{
+		ID:        m.ID,
+		Operation: m.Operation,
+		Principal: m.Principal,
+		TenantID:  m.TenantID,
+		A:         m.A,
+		B:         m.B,
+		Result:    m.Result,
+		CreatedAt: m.CreatedAt,
+		Seq:       m.Seq,
+	}
+	calc.Hash, _ = m.Hash()
+	calc.PrevHash, _ = m.PrevHash()
+	if deletedAt, ok := m.DeletedAt(); ok {
+		calc.DeletedAt = &deletedAt
+	}
+	if m.Kind != "" && m.Kind != string(domain.KindInteger) {
+		calc.Kind = domain.Kind(m.Kind)
+		if operands, ok := m.Operands(); ok {
+			_ = json.Unmarshal([]byte(operands), &calc.Operands)
+		}
+		calc.Value, _ = m.Value()
+		if text, ok := m.Matrices(); ok {
+			var matrices matrixColumn
+			_ = json.Unmarshal([]byte(text), &matrices)
+			calc.Operands = make([]string, len(matrices.Operands))
+			for i, operand := range matrices.Operands {
+				calc.Operands[i] = domain.FormatMatrix(operand)
+			}
+			calc.Value = domain.FormatMatrix(matrices.Value)
+		}
+		if details, ok := m.Details(); ok {
+			_ = json.Unmarshal([]byte(details), &calc.Details)
+		}
+		if options, ok := m.Options(); ok {
+			_ = json.Unmarshal([]byte(options), &calc.Options)
+		}
+		if rounding, ok := m.Rounding(); ok {
+			calc.Options.Rounding = domain.RoundingMode(rounding)
+		}
+		if scale, ok := m.Scale(); ok {
+			calc.Options.Scale = &scale
+		}
+	}
+	return calc
+}
+
+// matrixColumn is the content of the matrices column of a matrix
+// calculation.
+type matrixColumn struct {
+	Operands []domain.Matrix `json:"operands"`
+	Value    domain.Matrix   `json:"value"`
+}
+
+// textualColumns returns the kind column of a calculation and, for kinds
+// other than integers, its operands, value, details and options columns.
+// Details are only stored when there are any. Matrix calculations store
+// their operands and value in the matrices column instead, as matrices
+// rather than as JSON text inside JSON.
+func textualColumns(calc domain.Calculation) (kind string, operands, value, details, matrices, options *string) {
+	if calc.IsInteger() {
+		return string(domain.KindInteger), nil, nil, nil, nil, nil
+	}
+	opts, _ := json.Marshal(calc.Options)
+	if len(calc.Details) > 0 {
+		d, _ := json.Marshal(calc.Details)
+		details = optionalString(string(d))
+	}
+	if ops, v, ok := calc.Matrices(); ok {
+		m, _ := json.Marshal(matrixColumn{Operands: ops, Value: v})
+		return string(calc.Kind), nil, nil, details, optionalString(string(m)), optionalString(string(opts))
+	}
+	o, _ := json.Marshal(calc.Operands)
+	return string(calc.Kind), optionalString(string(o)), &calc.Value, details, nil, optionalString(string(opts))
 }
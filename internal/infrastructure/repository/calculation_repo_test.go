@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"go-prisma-calculator/internal/application/usecase"
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/operations"
+	"go-prisma-calculator/internal/domain/rational"
+	"go-prisma-calculator/internal/domain/service"
+	"go-prisma-calculator/internal/infrastructure/migrations"
+
+	db "go-prisma-calculator/internal/infrastructure/repository/prisma"
+)
+
+// testDatabase connects to the database at TEST_DATABASE_URL, migrated to
+// the latest schema, and returns a context of an admin in a tenant of its
+// own. Tests that need it are skipped without a database.
+func testDatabase(t *testing.T) (*db.PrismaClient, context.Context) {
+	t.Helper()
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	ctx := context.Background()
+	migrator, err := migrations.Connect(ctx, url, discardLogger())
+	if err != nil {
+		t.Fatalf("connect migrator: %v", err)
+	}
+	defer migrator.Close(ctx)
+	if err := migrator.Up(ctx, 0); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	client := db.NewClient(db.WithDatasourceURL(url))
+	if err := client.Connect(); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Disconnect() })
+
+	tenant := domain.Tenant{ID: "test-" + domain.NewCalculationID()}
+	ctx = domain.WithTenant(ctx, tenant)
+	ctx = domain.WithPrincipal(ctx, domain.Principal{Name: "tester", Role: domain.RoleAdmin})
+	return client, ctx
+}
+
+// TestAppendAfterPurgedHead purges the newest record of a chain and checks
+// that the next record links to it through its tombstone.
+func TestAppendAfterPurgedHead(t *testing.T) {
+	client, ctx := testDatabase(t)
+	repo := NewPrismaRepository(client)
+	history := usecase.NewHistoryUseCase(repo)
+
+	for _, b := range []int{1, 2} {
+		if err := repo.Save(ctx, domain.Calculation{Operation: "add", A: 1, B: b, Result: 1 + b, Principal: "tester"}); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+	chain, err := repo.Chain(ctx, 0, 10)
+	if err != nil || len(chain) != 2 {
+		t.Fatalf("Chain = %d records, %v, want 2", len(chain), err)
+	}
+	head := chain[1]
+
+	if err := repo.Purge(ctx, head.ID); err != nil {
+		t.Fatalf("Purge: %v", err)
+	}
+	if err := repo.Save(ctx, domain.Calculation{Operation: "add", A: 1, B: 3, Result: 4, Principal: "tester"}); err != nil {
+		t.Fatalf("Save after purge: %v", err)
+	}
+
+	chain, err = repo.Chain(ctx, 0, 10)
+	if err != nil || len(chain) != 2 {
+		t.Fatalf("Chain = %d records, %v, want 2", len(chain), err)
+	}
+	if chain[1].PrevHash != head.Hash {
+		t.Errorf("new record links to %s, want the purged head %s", chain[1].PrevHash, head.Hash)
+	}
+
+	report, err := history.VerifyChain(ctx)
+	if err != nil {
+		t.Fatalf("VerifyChain: %v", err)
+	}
+	if !report.Valid() || report.Checked != 2 || report.Removed != 1 || report.Truncated {
+		t.Errorf("VerifyChain = %+v, want 2 records checked across 1 removed", *report)
+	}
+}
+
+// TestRoundedDivisionRoundTrip records a rounded division and reads it back
+// as a divide calculation whose rounding mode and scale can be queried.
+func TestRoundedDivisionRoundTrip(t *testing.T) {
+	client, ctx := testDatabase(t)
+	repo := NewPrismaRepository(client)
+	ops, err := service.NewOperationRegistry([]domain.Operation{operations.NewDivide()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	evaluators, err := service.NewEvaluatorRegistry([]domain.Evaluator{rational.NewEvaluator(4096)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	calculator := service.NewCalculatorService(repo, ops, evaluators)
+
+	division := domain.RoundedDivision{Dividend: 1000, Divisor: 3, Rounding: domain.RoundHalfEven, Scale: 2}
+	calc, err := calculator.Divide(ctx, division)
+	if err != nil {
+		t.Fatalf("Divide: %v", err)
+	}
+	if _, err := calculator.Calculate(ctx, "divide", 1000, 3); err != nil {
+		t.Fatalf("Calculate: %v", err)
+	}
+
+	got, err := repo.FindByID(ctx, calc.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if got.Operation != "divide" || got.Kind != domain.KindRational || got.Value != calc.Value {
+		t.Errorf("read %s of kind %q = %s, want divide of kind rational = %s", got.Operation, got.Kind, got.Value, calc.Value)
+	}
+	if got.Options.Rounding != domain.RoundHalfEven || got.Options.Scale == nil || *got.Options.Scale != 2 {
+		t.Errorf("read options %+v, want half-even with scale 2", got.Options)
+	}
+	if value, _ := domain.RoundedQuotient(got); value != "333.33" {
+		t.Errorf("read rounded quotient %s, want 333.33", value)
+	}
+
+	listed, err := repo.List(ctx, domain.CalculationFilter{Operation: "divide", Limit: 10})
+	if err != nil || len(listed) != 2 {
+		t.Errorf("List(divide) = %d calculations, %v, want the rounded and the integer division", len(listed), err)
+	}
+
+	rows, err := client.Calculation.FindMany(
+		db.Calculation.TenantID.Equals(domain.TenantFromContext(ctx).ID),
+		db.Calculation.Rounding.Equals(string(domain.RoundHalfEven)),
+		db.Calculation.Scale.Equals(2),
+	).Exec(ctx)
+	if err != nil || len(rows) != 1 || rows[0].ID != calc.ID {
+		t.Errorf("query by rounding and scale = %d rows, %v, want the rounded division", len(rows), err)
+	}
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"go-prisma-calculator/internal/infrastructure/health"
+
+	db "go-prisma-calculator/internal/infrastructure/repository/prisma"
+)
+
+// ConnectionOptions controls how the database connection is established.
+type ConnectionOptions struct {
+	// StartupDeadline is how long startup waits for the database before
+	// continuing in degraded mode.
+	StartupDeadline time.Duration
+	// MinBackoff and MaxBackoff bound the delay between connection attempts.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// connector is the part of the Prisma client that Connection manages.
+type connector interface {
+	Connect() error
+	Disconnect() error
+}
+
+// Connection connects the Prisma client with retries and exponential backoff.
+// If the database is still unreachable when the startup deadline passes, the
+// application starts anyway and Connection keeps reconnecting in the background.
+type Connection struct {
+	client  connector
+	monitor *health.Monitor
+	logger  *slog.Logger
+	opts    ConnectionOptions
+
+	stop context.CancelFunc
+	done chan struct{}
+}
+
+// NewConnection is the constructor that fx uses to create an instance.
+func NewConnection(client *db.PrismaClient, monitor *health.Monitor, opts ConnectionOptions, logger *slog.Logger) *Connection {
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = 200 * time.Millisecond
+	}
+	if opts.MaxBackoff < opts.MinBackoff {
+		opts.MaxBackoff = opts.MinBackoff
+	}
+	return &Connection{
+		client:  client,
+		monitor: monitor,
+		logger:  logger,
+		opts:    opts,
+		done:    make(chan struct{}),
+	}
+}
+
+// Start tries to connect until the startup deadline. It never fails: when the
+// deadline passes, reconnection continues in the background.
+func (c *Connection) Start(ctx context.Context) error {
+	runCtx, stop := context.WithCancel(context.Background())
+	c.stop = stop
+
+	startupCtx, cancel := context.WithTimeout(ctx, c.opts.StartupDeadline)
+	defer cancel()
+
+	backoff := c.opts.MinBackoff
+	if c.connect(startupCtx, &backoff) {
+		close(c.done)
+		return nil
+	}
+
+	c.logger.Warn("Database unavailable at startup, serving in degraded no-history mode",
+		slog.Duration("deadline", c.opts.StartupDeadline),
+	)
+	go func() {
+		defer close(c.done)
+		c.connect(runCtx, &backoff)
+	}()
+	return nil
+}
+
+// Stop ends any background reconnection and disconnects the client.
+func (c *Connection) Stop(ctx context.Context) error {
+	if c.stop != nil {
+		c.stop()
+	}
+	select {
+	case <-c.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if !c.monitor.DatabaseReady() {
+		return nil
+	}
+	c.monitor.SetDatabaseReady(false)
+	return c.client.Disconnect()
+}
+
+// connect attempts to connect until it succeeds or ctx ends, sleeping with
+// jittered exponential backoff between attempts.
+func (c *Connection) connect(ctx context.Context, backoff *time.Duration) bool {
+	for attempt := 1; ; attempt++ {
+		err := c.client.Connect()
+		if err == nil {
+			c.monitor.SetDatabaseReady(true)
+			c.logger.Info("Connected to database", slog.Int("attempt", attempt))
+			return true
+		}
+
+		c.logger.Warn("Database connection attempt failed",
+			slog.Int("attempt", attempt),
+			slog.Duration("retry_in", *backoff),
+			slog.String("error", err.Error()),
+		)
+
+		// Jitter keeps replicas that boot together from retrying in lockstep.
+		timer := time.NewTimer(*backoff/2 + rand.N(*backoff/2+1))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return false
+		case <-timer.C:
+		}
+
+		*backoff = min(*backoff*2, c.opts.MaxBackoff)
+	}
+}
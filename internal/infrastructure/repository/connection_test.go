@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go-prisma-calculator/internal/infrastructure/health"
+)
+
+// flakyClient fails to connect until up is set.
+type flakyClient struct {
+	mu           sync.Mutex
+	up           bool
+	attempts     int
+	disconnected bool
+}
+
+func (c *flakyClient) Connect() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.attempts++
+	if !c.up {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func (c *flakyClient) Disconnect() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.disconnected = true
+	return nil
+}
+
+func (c *flakyClient) setUp() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.up = true
+}
+
+func (c *flakyClient) state() (attempts int, disconnected bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.attempts, c.disconnected
+}
+
+func newTestConnection(client *flakyClient, deadline time.Duration) (*Connection, *health.Monitor) {
+	monitor := health.NewMonitor()
+	c := NewConnection(nil, monitor, ConnectionOptions{
+		StartupDeadline: deadline,
+		MinBackoff:      time.Millisecond,
+		MaxBackoff:      4 * time.Millisecond,
+	}, discardLogger())
+	c.client = client
+	return c, monitor
+}
+
+func TestConnectionStartsConnected(t *testing.T) {
+	client := &flakyClient{up: true}
+	c, monitor := newTestConnection(client, time.Second)
+
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !monitor.DatabaseReady() {
+		t.Fatal("database not ready after a successful start")
+	}
+	if err := c.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, disconnected := client.state(); !disconnected || monitor.DatabaseReady() {
+		t.Errorf("Stop left the client connected")
+	}
+}
+
+func TestConnectionReconnectsAfterStartupDeadline(t *testing.T) {
+	client := &flakyClient{}
+	c, monitor := newTestConnection(client, 20*time.Millisecond)
+
+	start := time.Now()
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("Start error = %v, want a degraded start", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Start took %v, want it to give up at the deadline", elapsed)
+	}
+	if monitor.DatabaseReady() {
+		t.Fatal("database ready while unreachable")
+	}
+	attempts, _ := client.state()
+	if attempts < 2 {
+		t.Errorf("%d attempts before the deadline, want retries", attempts)
+	}
+
+	client.setUp()
+	for deadline := time.Now().Add(5 * time.Second); !monitor.DatabaseReady(); {
+		if time.Now().After(deadline) {
+			t.Fatal("database not ready after it became reachable")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := c.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, disconnected := client.state(); !disconnected {
+		t.Errorf("Stop left the client connected")
+	}
+}
+
+func TestConnectionStopWhileReconnecting(t *testing.T) {
+	client := &flakyClient{}
+	c, monitor := newTestConnection(client, 5*time.Millisecond)
+
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := c.Stop(ctx); err != nil {
+		t.Fatalf("Stop error = %v, want the reconnection to end", err)
+	}
+
+	attempts, disconnected := client.state()
+	if disconnected || monitor.DatabaseReady() {
+		t.Errorf("Stop disconnected a client that never connected")
+	}
+	time.Sleep(20 * time.Millisecond)
+	if later, _ := client.state(); later != attempts {
+		t.Errorf("%d connection attempts after Stop", later-attempts)
+	}
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/ports/out"
+	"go-prisma-calculator/internal/infrastructure/health"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var historySkipped = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "calculator_history_skipped_total",
+	Help: "Calculations not recorded because the database was unavailable.",
+})
+
+// DegradableRepository decorates a CalculationRepositoryPort so that history
+// writes are skipped, instead of failing the calculation, while the database
+// is unavailable. Skipped writes return domain.ErrHistorySkipped, so that the
+// caller can answer without an ID. History reads and deletes fail fast with
+// domain.ErrUnavailable.
+type DegradableRepository struct {
+	out.CalculationRepositoryPort
+	monitor *health.Monitor
+}
+
+// durableWritesKey marks contexts whose writes must not be skipped.
+type durableWritesKey struct{}
+
+// withDurableWrites marks writes whose caller keeps what cannot be written,
+// such as the write-behind journal, so that in degraded mode they fail with
+// domain.ErrUnavailable instead of being skipped.
+func withDurableWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, durableWritesKey{}, true)
+}
+
+// skip reports whether a history write should be skipped in degraded mode.
+func (r *DegradableRepository) skip(ctx context.Context) (bool, error) {
+	if r.monitor.DatabaseReady() {
+		return false, nil
+	}
+	if durable, _ := ctx.Value(durableWritesKey{}).(bool); durable {
+		return false, domain.ErrUnavailable
+	}
+	return true, nil
+}
+
+// NewDegradableRepository wraps next with the no-history fallback.
+func NewDegradableRepository(next out.CalculationRepositoryPort, monitor *health.Monitor) *DegradableRepository {
+	return &DegradableRepository{CalculationRepositoryPort: next, monitor: monitor}
+}
+
+// Save records the calculation, or skips it in degraded mode.
+func (r *DegradableRepository) Save(ctx context.Context, calc domain.Calculation) error {
+	if skip, err := r.skip(ctx); skip || err != nil {
+		if skip {
+			historySkipped.Inc()
+			return domain.ErrHistorySkipped
+		}
+		return err
+	}
+	return r.CalculationRepositoryPort.Save(ctx, calc)
+}
+
+// SaveBatch records the calculations, or skips them in degraded mode.
+// Batches of the write-behind writer fail instead, so that it journals them.
+func (r *DegradableRepository) SaveBatch(ctx context.Context, calcs []domain.Calculation) error {
+	if skip, err := r.skip(ctx); skip || err != nil {
+		if skip {
+			historySkipped.Add(float64(len(calcs)))
+			return domain.ErrHistorySkipped
+		}
+		return err
+	}
+	return r.CalculationRepositoryPort.SaveBatch(ctx, calcs)
+}
+
+// FindByID loads a calculation, or fails fast in degraded mode.
+func (r *DegradableRepository) FindByID(ctx context.Context, id string) (*domain.Calculation, error) {
+	if !r.monitor.DatabaseReady() {
+		return nil, domain.ErrUnavailable
+	}
+	return r.CalculationRepositoryPort.FindByID(ctx, id)
+}
+
+// List pages through calculations, or fails fast in degraded mode.
+func (r *DegradableRepository) List(ctx context.Context, filter domain.CalculationFilter) ([]domain.Calculation, error) {
+	if !r.monitor.DatabaseReady() {
+		return nil, domain.ErrUnavailable
+	}
+	return r.CalculationRepositoryPort.List(ctx, filter)
+}
+
+// Delete soft-deletes a calculation, or fails fast in degraded mode.
+func (r *DegradableRepository) Delete(ctx context.Context, id string) error {
+	if !r.monitor.DatabaseReady() {
+		return domain.ErrUnavailable
+	}
+	return r.CalculationRepositoryPort.Delete(ctx, id)
+}
+
+// Restore undoes a soft delete, or fails fast in degraded mode.
+func (r *DegradableRepository) Restore(ctx context.Context, id string) (*domain.Calculation, error) {
+	if !r.monitor.DatabaseReady() {
+		return nil, domain.ErrUnavailable
+	}
+	return r.CalculationRepositoryPort.Restore(ctx, id)
+}
+
+// Purge permanently deletes a calculation, or fails fast in degraded mode.
+func (r *DegradableRepository) Purge(ctx context.Context, id string) error {
+	if !r.monitor.DatabaseReady() {
+		return domain.ErrUnavailable
+	}
+	return r.CalculationRepositoryPort.Purge(ctx, id)
+}
+
+// Import inserts past calculations, or fails fast in degraded mode. Imported
+// rows are never skipped, since the caller expects them to be stored.
+func (r *DegradableRepository) Import(ctx context.Context, calcs []domain.Calculation) error {
+	if !r.monitor.DatabaseReady() {
+		return domain.ErrUnavailable
+	}
+	return r.CalculationRepositoryPort.Import(ctx, calcs)
+}
+
+// Stats aggregates the calculation history, or fails fast in degraded mode.
+func (r *DegradableRepository) Stats(ctx context.Context, query domain.StatsQuery) (*domain.Statistics, error) {
+	if !r.monitor.DatabaseReady() {
+		return nil, domain.ErrUnavailable
+	}
+	return r.CalculationRepositoryPort.Stats(ctx, query)
+}
+
+// Chain loads a page of the audit chain, or fails fast in degraded mode.
+func (r *DegradableRepository) Chain(ctx context.Context, afterSeq, limit int) ([]domain.Calculation, error) {
+	if !r.monitor.DatabaseReady() {
+		return nil, domain.ErrUnavailable
+	}
+	return r.CalculationRepositoryPort.Chain(ctx, afterSeq, limit)
+}
+
+// Tombstones loads a page of the audit chain's tombstones, or fails fast in degraded mode.
+func (r *DegradableRepository) Tombstones(ctx context.Context, afterSeq, beforeSeq, limit int) ([]domain.ChainTombstone, error) {
+	if !r.monitor.DatabaseReady() {
+		return nil, domain.ErrUnavailable
+	}
+	return r.CalculationRepositoryPort.Tombstones(ctx, afterSeq, beforeSeq, limit)
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/ports/out"
+	"go-prisma-calculator/internal/infrastructure/health"
+)
+
+// callRecorder records the calls that reach it.
+type callRecorder struct {
+	out.CalculationRepositoryPort
+	calls []string
+}
+
+func (r *callRecorder) Save(context.Context, domain.Calculation) error {
+	r.calls = append(r.calls, "Save")
+	return nil
+}
+
+func (r *callRecorder) SaveBatch(context.Context, []domain.Calculation) error {
+	r.calls = append(r.calls, "SaveBatch")
+	return nil
+}
+
+func (r *callRecorder) FindByID(context.Context, string) (*domain.Calculation, error) {
+	r.calls = append(r.calls, "FindByID")
+	return &domain.Calculation{}, nil
+}
+
+func (r *callRecorder) Purge(context.Context, string) error {
+	r.calls = append(r.calls, "Purge")
+	return nil
+}
+
+func TestDegradableRepository(t *testing.T) {
+	ctx := context.Background()
+	durable := withDurableWrites(ctx)
+	batch := []domain.Calculation{{ID: "a"}, {ID: "b"}}
+
+	tests := []struct {
+		name string
+		call func(r *DegradableRepository) error
+		// wantDegraded is the error in degraded mode.
+		wantDegraded error
+	}{
+		{"Save", func(r *DegradableRepository) error { return r.Save(ctx, batch[0]) }, domain.ErrHistorySkipped},
+		{"SaveBatch", func(r *DegradableRepository) error { return r.SaveBatch(ctx, batch) }, domain.ErrHistorySkipped},
+		{"Save", func(r *DegradableRepository) error { return r.Save(durable, batch[0]) }, domain.ErrUnavailable},
+		{"SaveBatch", func(r *DegradableRepository) error { return r.SaveBatch(durable, batch) }, domain.ErrUnavailable},
+		{"FindByID", func(r *DegradableRepository) error { _, err := r.FindByID(ctx, "a"); return err }, domain.ErrUnavailable},
+		{"Purge", func(r *DegradableRepository) error { return r.Purge(ctx, "a") }, domain.ErrUnavailable},
+	}
+	for _, tt := range tests {
+		monitor := health.NewMonitor()
+		next := &callRecorder{}
+		r := NewDegradableRepository(next, monitor)
+
+		if err := tt.call(r); !errors.Is(err, tt.wantDegraded) {
+			t.Errorf("degraded %s error = %v, want %v", tt.name, err, tt.wantDegraded)
+		}
+		if len(next.calls) != 0 {
+			t.Errorf("degraded %s reached the database: %v", tt.name, next.calls)
+		}
+
+		monitor.SetDatabaseReady(true)
+		if err := tt.call(r); err != nil {
+			t.Errorf("%s error = %v", tt.name, err)
+		}
+		if len(next.calls) != 1 || next.calls[0] != tt.name {
+			t.Errorf("%s calls = %v, want it delegated once", tt.name, next.calls)
+		}
+	}
+}
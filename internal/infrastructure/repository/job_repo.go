@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/ports/out"
+
+	db "go-prisma-calculator/internal/infrastructure/repository/prisma"
+)
+
+// PrismaJobRepository is the Prisma implementation of the job repository port.
+type PrismaJobRepository struct {
+	client *db.PrismaClient
+}
+
+// NewPrismaJobRepository is the constructor that fx uses to create an instance.
+func NewPrismaJobRepository(client *db.PrismaClient) out.JobRepositoryPort {
+	return &PrismaJobRepository{
+		client: client,
+	}
+}
+
+// Create inserts a new job and returns it with the database-assigned ID.
+func (r *PrismaJobRepository) Create(ctx context.Context, job domain.Job) (*domain.Job, error) {
+	created, err := r.client.Job.CreateOne(
+		db.Job.Operation.Set(job.Operation),
+		db.Job.A.Set(job.A),
+		db.Job.B.Set(job.B),
+		db.Job.Status.Set(string(job.Status)),
+		db.Job.TenantID.Set(tenantID(ctx, job.TenantID)),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainJob(created), nil
+}
+
+// Update persists the mutable state of a job that is still pending or
+// running. The status condition is part of the update, so a job finished
+// concurrently is never overwritten.
+func (r *PrismaJobRepository) Update(ctx context.Context, job domain.Job) error {
+	result, err := r.client.Job.FindMany(
+		db.Job.ID.Equals(job.ID),
+		jobTenantScope(ctx),
+		db.Job.Status.In([]string{string(domain.JobStatusPending), string(domain.JobStatusRunning)}),
+	).Update(
+		db.Job.Status.Set(string(job.Status)),
+		db.Job.Result.SetOptional(job.Result),
+		db.Job.CalculationID.SetOptional(optionalString(job.CalculationID)),
+		db.Job.Error.Set(job.Error),
+		db.Job.StartedAt.SetOptional(job.StartedAt),
+		db.Job.FinishedAt.SetOptional(job.FinishedAt),
+	).Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if result.Count == 0 {
+		// Tell a finished job from a missing one.
+		if _, err := r.FindByID(ctx, job.ID); err != nil {
+			return err
+		}
+		return domain.ErrJobFinished
+	}
+	return nil
+}
+
+// FindByID loads a single job of the current tenant.
+func (r *PrismaJobRepository) FindByID(ctx context.Context, id string) (*domain.Job, error) {
+	job, err := r.client.Job.FindFirst(
+		db.Job.ID.Equals(id),
+		jobTenantScope(ctx),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainJob(job), nil
+}
+
+// List returns jobs matching the filter, newest first.
+func (r *PrismaJobRepository) List(ctx context.Context, filter domain.JobFilter) ([]domain.Job, error) {
+	where := []db.JobWhereParam{
+		db.Job.Status.EqualsIfPresent(optionalString(string(filter.Status))),
+	}
+	if !filter.AllTenants {
+		where = append(where, jobTenantScope(ctx))
+	}
+
+	query := r.client.Job.FindMany(where...).OrderBy(
+		db.Job.CreatedAt.Order(db.SortOrderDesc),
+	)
+	if filter.Offset > 0 {
+		query = query.Skip(filter.Offset)
+	}
+	if filter.Limit > 0 {
+		query = query.Take(filter.Limit)
+	}
+
+	rows, err := query.Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]domain.Job, 0, len(rows))
+	for i := range rows {
+		jobs = append(jobs, *toDomainJob(&rows[i]))
+	}
+	return jobs, nil
+}
+
+// CountUnfinished counts the pending and running jobs of the current tenant.
+// The fluent API has no aggregates, so it is a raw query.
+func (r *PrismaJobRepository) CountUnfinished(ctx context.Context) (int, error) {
+	var rows []struct {
+		Count int `json:"count"`
+	}
+	err := r.client.Prisma.QueryRaw(
+		`SELECT COUNT(*)::int AS "count" FROM "Job" WHERE "tenantId" = $1 AND "status" IN ($2, $3)`,
+		domain.TenantFromContext(ctx).ID, string(domain.JobStatusPending), string(domain.JobStatusRunning),
+	).Exec(ctx, &rows)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	return rows[0].Count, nil
+}
+
+// jobTenantScope restricts a query to the jobs of the tenant in the context.
+func jobTenantScope(ctx context.Context) db.JobWhereParam {
+	return db.Job.TenantID.Equals(domain.TenantFromContext(ctx).ID)
+}
+
+// toDomainJob translates a Prisma model into the domain model.
+func toDomainJob(m *db.JobModel) *domain.Job {
+	job := &domain.Job{
+		ID:        m.ID,
+		TenantID:  m.TenantID,
+		Operation: m.Operation,
+		A:         m.A,
+		B:         m.B,
+		Status:    domain.JobStatus(m.Status),
+		Error:     m.Error,
+		CreatedAt: m.CreatedAt,
+	}
+	if result, ok := m.Result(); ok {
+		job.Result = &result
+	}
+	job.CalculationID, _ = m.CalculationID()
+	if startedAt, ok := m.StartedAt(); ok {
+		job.StartedAt = &startedAt
+	}
+	if finishedAt, ok := m.FinishedAt(); ok {
+		job.FinishedAt = &finishedAt
+	}
+	return job
+}
+
+// optionalString returns nil for an empty string so it can be used with the
+// *IfPresent filters, which skip the condition entirely when given nil.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,309 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"strings"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/ports/out"
+	"go-prisma-calculator/internal/infrastructure/resilience"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	circuitState = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "calculator_repository_circuit_state",
+		Help: "State of the repository circuit breaker (0 closed, 1 half-open, 2 open).",
+	})
+
+	circuitTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "calculator_repository_circuit_transitions_total",
+		Help: "Repository circuit breaker state transitions.",
+	}, []string{"from", "to"})
+
+	repositoryRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "calculator_repository_retries_total",
+		Help: "Repository calls retried after a retryable error.",
+	}, []string{"method"})
+
+	repositoryRejected = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "calculator_repository_rejected_total",
+		Help: "Repository calls rejected because the circuit was open.",
+	})
+)
+
+// retryablePrismaCodes are Prisma error codes for transient connection and
+// contention problems that are safe to retry.
+var retryablePrismaCodes = []string{
+	"P1001", // can't reach database server
+	"P1002", // database server timed out
+	"P1008", // operations timed out
+	"P1017", // server has closed the connection
+	"P2024", // timed out fetching a connection from the pool
+	"P2034", // transaction failed due to a write conflict or deadlock
+}
+
+// ambiguousPrismaCodes are the retryable codes after which a write may have
+// been committed even though it failed.
+var ambiguousPrismaCodes = []string{"P1002", "P1008", "P1017"}
+
+// ResilienceOptions configures the ResilientRepository.
+type ResilienceOptions struct {
+	Retry   resilience.RetryOptions
+	Breaker resilience.BreakerOptions
+}
+
+// ResilientRepository decorates a CalculationRepositoryPort with per-call
+// timeouts, jittered retries of transient errors and a circuit breaker.
+type ResilientRepository struct {
+	out.CalculationRepositoryPort
+	breaker *resilience.Breaker
+	retry   resilience.RetryOptions
+	logger  *slog.Logger
+}
+
+// NewResilientRepository wraps next with the resilience policy.
+func NewResilientRepository(next out.CalculationRepositoryPort, opts ResilienceOptions, logger *slog.Logger) *ResilientRepository {
+	opts.Breaker.OnStateChange = func(from, to resilience.State) {
+		circuitState.Set(float64(to))
+		circuitTransitions.WithLabelValues(from.String(), to.String()).Inc()
+		logger.Warn("Repository circuit breaker changed state",
+			slog.String("from", from.String()),
+			slog.String("to", to.String()),
+		)
+	}
+	if opts.Retry.Retryable == nil {
+		opts.Retry.Retryable = IsRetryable
+	}
+
+	return &ResilientRepository{
+		CalculationRepositoryPort: next,
+		breaker:                   resilience.NewBreaker(opts.Breaker),
+		retry:                     opts.Retry,
+		logger:                    logger,
+	}
+}
+
+// Save stores a calculation through the resilience policy.
+func (r *ResilientRepository) Save(ctx context.Context, calc domain.Calculation) error {
+	return r.call(ctx, "Save", writeOnce, func(ctx context.Context) error {
+		return r.CalculationRepositoryPort.Save(ctx, calc)
+	})
+}
+
+// SaveBatch stores several calculations through the resilience policy.
+func (r *ResilientRepository) SaveBatch(ctx context.Context, calcs []domain.Calculation) error {
+	return r.call(ctx, "SaveBatch", writeOnce, func(ctx context.Context) error {
+		return r.CalculationRepositoryPort.SaveBatch(ctx, calcs)
+	})
+}
+
+// FindByID loads a calculation through the resilience policy.
+func (r *ResilientRepository) FindByID(ctx context.Context, id string) (*domain.Calculation, error) {
+	var calc *domain.Calculation
+	err := r.call(ctx, "FindByID", idempotent, func(ctx context.Context) error {
+		var err error
+		calc, err = r.CalculationRepositoryPort.FindByID(ctx, id)
+		return err
+	})
+	return calc, err
+}
+
+// List pages through calculations through the resilience policy.
+func (r *ResilientRepository) List(ctx context.Context, filter domain.CalculationFilter) ([]domain.Calculation, error) {
+	var calcs []domain.Calculation
+	err := r.call(ctx, "List", idempotent, func(ctx context.Context) error {
+		var err error
+		calcs, err = r.CalculationRepositoryPort.List(ctx, filter)
+		return err
+	})
+	return calcs, err
+}
+
+// Delete soft-deletes a calculation through the resilience policy.
+func (r *ResilientRepository) Delete(ctx context.Context, id string) error {
+	return r.call(ctx, "Delete", removal, func(ctx context.Context) error {
+		return r.CalculationRepositoryPort.Delete(ctx, id)
+	})
+}
+
+// Restore undoes a soft delete through the resilience policy.
+func (r *ResilientRepository) Restore(ctx context.Context, id string) (*domain.Calculation, error) {
+	var calc *domain.Calculation
+	err := r.call(ctx, "Restore", idempotent, func(ctx context.Context) error {
+		var err error
+		calc, err = r.CalculationRepositoryPort.Restore(ctx, id)
+		return err
+	})
+	return calc, err
+}
+
+// Purge permanently deletes a calculation through the resilience policy.
+func (r *ResilientRepository) Purge(ctx context.Context, id string) error {
+	return r.call(ctx, "Purge", removal, func(ctx context.Context) error {
+		return r.CalculationRepositoryPort.Purge(ctx, id)
+	})
+}
+
+// Import inserts past calculations through the resilience policy.
+func (r *ResilientRepository) Import(ctx context.Context, calcs []domain.Calculation) error {
+	return r.call(ctx, "Import", writeOnce, func(ctx context.Context) error {
+		return r.CalculationRepositoryPort.Import(ctx, calcs)
+	})
+}
+
+// Stats aggregates the calculation history through the resilience policy.
+func (r *ResilientRepository) Stats(ctx context.Context, query domain.StatsQuery) (*domain.Statistics, error) {
+	var stats *domain.Statistics
+	err := r.call(ctx, "Stats", idempotent, func(ctx context.Context) error {
+		var err error
+		stats, err = r.CalculationRepositoryPort.Stats(ctx, query)
+		return err
+	})
+	return stats, err
+}
+
+// Chain loads a page of the audit chain through the resilience policy.
+func (r *ResilientRepository) Chain(ctx context.Context, afterSeq, limit int) ([]domain.Calculation, error) {
+	var calcs []domain.Calculation
+	err := r.call(ctx, "Chain", idempotent, func(ctx context.Context) error {
+		var err error
+		calcs, err = r.CalculationRepositoryPort.Chain(ctx, afterSeq, limit)
+		return err
+	})
+	return calcs, err
+}
+
+// Tombstones loads a page of the audit chain's tombstones through the resilience policy.
+func (r *ResilientRepository) Tombstones(ctx context.Context, afterSeq, beforeSeq, limit int) ([]domain.ChainTombstone, error) {
+	var tombstones []domain.ChainTombstone
+	err := r.call(ctx, "Tombstones", idempotent, func(ctx context.Context) error {
+		var err error
+		tombstones, err = r.CalculationRepositoryPort.Tombstones(ctx, afterSeq, beforeSeq, limit)
+		return err
+	})
+	return tombstones, err
+}
+
+// retryMode tells whether a repository call may be repeated after a
+// failure whose outcome is unknown.
+type retryMode int
+
+const (
+	// idempotent calls are reads, and writes that have the same effect when
+	// repeated, such as restoring a row.
+	idempotent retryMode = iota
+	// writeOnce calls insert rows; after a timeout they may have been
+	// committed, and repeating them would store the rows twice.
+	writeOnce
+	// removal calls delete a row and fail with domain.ErrNotFound when it
+	// is gone. After a timeout the row may have been deleted already, so a
+	// repeated call that does not find it succeeds.
+	removal
+)
+
+// call runs fn behind the circuit breaker, retrying transient failures.
+// Errors caused by an unhealthy database are wrapped in domain.ErrUnavailable.
+func (r *ResilientRepository) call(ctx context.Context, method string, mode retryMode, fn func(ctx context.Context) error) error {
+	if err := r.breaker.Allow(); err != nil {
+		repositoryRejected.Inc()
+		return fmt.Errorf("%w: %w", domain.ErrUnavailable, err)
+	}
+
+	policy := r.retry
+	switch mode {
+	case writeOnce:
+		retryable := policy.Retryable
+		policy.Retryable = func(err error) bool {
+			return retryable(err) && !isAmbiguous(err)
+		}
+	case removal:
+		fn = removedOnce(fn)
+	}
+	policy.OnRetry = func(attempt int, err error) {
+		repositoryRetries.WithLabelValues(method).Inc()
+		r.logger.Debug("Retrying repository call",
+			slog.String("method", method),
+			slog.Int("attempt", attempt),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	err := resilience.Retry(ctx, policy, fn)
+
+	// Only failures of the database itself count against the circuit; a
+	// request cancelled or timed out by its caller, or a constraint
+	// violation, says nothing about its health.
+	if err != nil && ctx.Err() != nil {
+		r.breaker.Release()
+		return err
+	}
+	transient := err != nil && IsRetryable(err)
+	r.breaker.Record(!transient)
+
+	if transient {
+		return fmt.Errorf("%w: %w", domain.ErrUnavailable, err)
+	}
+	return err
+}
+
+// removedOnce wraps a removal so that an attempt that does not find its row
+// succeeds when an earlier attempt may have committed.
+func removedOnce(fn func(ctx context.Context) error) func(ctx context.Context) error {
+	ambiguous := false
+	return func(ctx context.Context) error {
+		err := fn(ctx)
+		if ambiguous && errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		ambiguous = ambiguous || (err != nil && isAmbiguous(err))
+		return err
+	}
+}
+
+// IsRetryable reports whether err is a transient database error.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	return hasPrismaCode(err, retryablePrismaCodes)
+}
+
+// isAmbiguous reports whether a failed write may have been committed: the
+// call timed out, or the connection was lost, after the write was sent.
+func isAmbiguous(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return hasPrismaCode(err, ambiguousPrismaCodes)
+}
+
+// hasPrismaCode reports whether err carries one of the Prisma error codes.
+func hasPrismaCode(err error, codes []string) bool {
+	msg := err.Error()
+	for _, code := range codes {
+		if strings.Contains(msg, code) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/ports/out"
+	"go-prisma-calculator/internal/infrastructure/resilience"
+)
+
+var (
+	// errTimedOut is a failure after which a write may have been committed.
+	errTimedOut = context.DeadlineExceeded
+	// errUnreachable is a transient failure before anything was sent.
+	errUnreachable = errors.New("P1001: can't reach database server")
+)
+
+// scriptedRepo fails its calls with errs, in order, and then succeeds.
+type scriptedRepo struct {
+	out.CalculationRepositoryPort
+	errs  []error
+	calls int
+}
+
+func (r *scriptedRepo) next() error {
+	r.calls++
+	if len(r.errs) == 0 {
+		return nil
+	}
+	err := r.errs[0]
+	r.errs = r.errs[1:]
+	return err
+}
+
+func (r *scriptedRepo) Save(context.Context, domain.Calculation) error { return r.next() }
+func (r *scriptedRepo) Delete(context.Context, string) error           { return r.next() }
+func (r *scriptedRepo) Purge(context.Context, string) error            { return r.next() }
+
+func (r *scriptedRepo) FindByID(context.Context, string) (*domain.Calculation, error) {
+	if err := r.next(); err != nil {
+		return nil, err
+	}
+	return &domain.Calculation{ID: "a"}, nil
+}
+
+func newTestResilientRepository(next out.CalculationRepositoryPort, failureThreshold int) *ResilientRepository {
+	return NewResilientRepository(next, ResilienceOptions{
+		Retry: resilience.RetryOptions{
+			MaxAttempts: 3,
+			BaseDelay:   time.Millisecond,
+			MaxDelay:    time.Millisecond,
+		},
+		Breaker: resilience.BreakerOptions{
+			FailureThreshold: failureThreshold,
+			OpenDuration:     time.Hour,
+		},
+	}, discardLogger())
+}
+
+func TestResilientRepositoryRetries(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name      string
+		call      func(r *ResilientRepository) error
+		errs      []error
+		wantErr   error
+		wantCalls int
+	}{
+		{
+			name:      "save retried before anything was sent",
+			call:      func(r *ResilientRepository) error { return r.Save(ctx, domain.Calculation{}) },
+			errs:      []error{errUnreachable},
+			wantCalls: 2,
+		},
+		{
+			name:      "save not retried after a timeout",
+			call:      func(r *ResilientRepository) error { return r.Save(ctx, domain.Calculation{}) },
+			errs:      []error{errTimedOut},
+			wantErr:   domain.ErrUnavailable,
+			wantCalls: 1,
+		},
+		{
+			name:      "read retried after a timeout",
+			call:      func(r *ResilientRepository) error { _, err := r.FindByID(ctx, "a"); return err },
+			errs:      []error{errTimedOut, errTimedOut},
+			wantCalls: 3,
+		},
+		{
+			name:      "read attempts exhausted",
+			call:      func(r *ResilientRepository) error { _, err := r.FindByID(ctx, "a"); return err },
+			errs:      []error{errUnreachable, errUnreachable, errUnreachable},
+			wantErr:   domain.ErrUnavailable,
+			wantCalls: 3,
+		},
+		{
+			name:      "purge committed before a timeout",
+			call:      func(r *ResilientRepository) error { return r.Purge(ctx, "a") },
+			errs:      []error{errTimedOut, domain.ErrNotFound},
+			wantCalls: 2,
+		},
+		{
+			name:      "purge committed before a timeout, then unreachable",
+			call:      func(r *ResilientRepository) error { return r.Purge(ctx, "a") },
+			errs:      []error{errTimedOut, errUnreachable, domain.ErrNotFound},
+			wantCalls: 3,
+		},
+		{
+			name:      "delete committed before a timeout",
+			call:      func(r *ResilientRepository) error { return r.Delete(ctx, "a") },
+			errs:      []error{errTimedOut, domain.ErrNotFound},
+			wantCalls: 2,
+		},
+		{
+			name:      "purge of a missing record",
+			call:      func(r *ResilientRepository) error { return r.Purge(ctx, "a") },
+			errs:      []error{domain.ErrNotFound},
+			wantErr:   domain.ErrNotFound,
+			wantCalls: 1,
+		},
+		{
+			name:      "purge of a missing record retried before anything was sent",
+			call:      func(r *ResilientRepository) error { return r.Purge(ctx, "a") },
+			errs:      []error{errUnreachable, domain.ErrNotFound},
+			wantErr:   domain.ErrNotFound,
+			wantCalls: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &scriptedRepo{errs: tt.errs}
+			err := tt.call(newTestResilientRepository(next, 10))
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if next.calls != tt.wantCalls {
+				t.Errorf("%d attempts, want %d", next.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestResilientRepositoryBreaker(t *testing.T) {
+	ctx := context.Background()
+	next := &scriptedRepo{}
+	r := newTestResilientRepository(next, 2)
+
+	// Errors that are not transient, and calls cancelled by their caller,
+	// do not count against the circuit.
+	next.errs = []error{domain.ErrNotFound}
+	if _, err := r.FindByID(ctx, "a"); !errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrUnavailable) {
+		t.Errorf("FindByID error = %v, want %v only", err, domain.ErrNotFound)
+	}
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	next.errs = []error{context.Canceled}
+	_ = r.Save(cancelled, domain.Calculation{})
+	if got := r.breaker.State(); got != resilience.StateClosed {
+		t.Fatalf("breaker %v, want closed", got)
+	}
+
+	next.errs = []error{errTimedOut, errTimedOut}
+	for range 2 {
+		if err := r.Save(ctx, domain.Calculation{}); !errors.Is(err, domain.ErrUnavailable) {
+			t.Errorf("Save error = %v, want %v", err, domain.ErrUnavailable)
+		}
+	}
+	if got := r.breaker.State(); got != resilience.StateOpen {
+		t.Fatalf("breaker %v after 2 failures, want open", got)
+	}
+
+	calls := next.calls
+	err := r.Save(ctx, domain.Calculation{})
+	if !errors.Is(err, domain.ErrUnavailable) || !errors.Is(err, resilience.ErrCircuitOpen) {
+		t.Errorf("Save error = %v, want an open circuit", err)
+	}
+	if next.calls != calls {
+		t.Errorf("the open circuit let a call through")
+	}
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/ports/out"
+
+	db "go-prisma-calculator/internal/infrastructure/repository/prisma"
+)
+
+// PrismaRetentionRepository is the Prisma implementation of the retention repository port.
+type PrismaRetentionRepository struct {
+	client *db.PrismaClient
+}
+
+// NewPrismaRetentionRepository is the constructor that fx uses to create an instance.
+func NewPrismaRetentionRepository(client *db.PrismaClient) out.RetentionRepositoryPort {
+	return &PrismaRetentionRepository{
+		client: client,
+	}
+}
+
+// FindExpired returns the calculations selected by the query, oldest first.
+// With KeepNewest the rows are ordered newest first so that the kept rows can
+// be skipped.
+func (r *PrismaRetentionRepository) FindExpired(ctx context.Context, query domain.RetentionQuery) ([]domain.Calculation, error) {
+	var where []db.CalculationWhereParam
+	if !query.CreatedBefore.IsZero() {
+		where = append(where, db.Calculation.CreatedAt.Lt(query.CreatedBefore))
+	}
+	if len(query.Operations) > 0 {
+		where = append(where, db.Calculation.Operation.In(query.Operations))
+	}
+	if len(query.ExcludeOperations) > 0 {
+		where = append(where, db.Calculation.Operation.NotIn(query.ExcludeOperations))
+	}
+	if query.TenantID != "" {
+		where = append(where, db.Calculation.TenantID.Equals(query.TenantID))
+	}
+	if query.Principal != nil {
+		where = append(where, db.Calculation.Principal.Equals(*query.Principal))
+	}
+
+	order := db.SortOrderAsc
+	if query.KeepNewest > 0 {
+		order = db.SortOrderDesc
+	}
+
+	q := r.client.Calculation.FindMany(where...).OrderBy(
+		db.Calculation.CreatedAt.Order(order),
+	)
+	if skip := query.KeepNewest + query.Offset; skip > 0 {
+		q = q.Skip(skip)
+	}
+	if query.Limit > 0 {
+		q = q.Take(query.Limit)
+	}
+
+	rows, err := q.Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	calcs := make([]domain.Calculation, 0, len(rows))
+	for i := range rows {
+		calcs = append(calcs, *toDomainCalculation(&rows[i]))
+	}
+	return calcs, nil
+}
+
+// PrincipalsOver returns the principals of every tenant owning more than
+// maxRows calculations in that tenant.
+func (r *PrismaRetentionRepository) PrincipalsOver(ctx context.Context, maxRows int) ([]domain.RetentionOwner, error) {
+	var rows []struct {
+		TenantID  string `json:"tenantId"`
+		Principal string `json:"principal"`
+	}
+	err := r.client.Prisma.QueryRaw(
+		`SELECT "tenantId", "principal" FROM "Calculation" GROUP BY "tenantId", "principal" HAVING COUNT(*) > $1 ORDER BY "tenantId", "principal"`,
+		maxRows,
+	).Exec(ctx, &rows)
+	if err != nil {
+		return nil, err
+	}
+
+	owners := make([]domain.RetentionOwner, 0, len(rows))
+	for _, row := range rows {
+		owners = append(owners, domain.RetentionOwner{TenantID: row.TenantID, Principal: row.Principal})
+	}
+	return owners, nil
+}
+
+// PurgeBatch permanently deletes the given calculations and leaves
+// tombstones in their places in the audit chains.
+func (r *PrismaRetentionRepository) PurgeBatch(ctx context.Context, calcs []domain.Calculation) (int, error) {
+	if len(calcs) == 0 {
+		return 0, nil
+	}
+	return removeFromChain(ctx, r.client, calcs, domain.TombstoneExpired)
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	domain "go-prisma-calculator/internal/domain/models"
+)
+
+// statsTimeLayout formats time bounds for the timestamp columns, which Prisma
+// stores in UTC without a time zone.
+const statsTimeLayout = "2006-01-02 15:04:05.000"
+
+// Stats aggregates the calculations selected by the query. The fluent API
+// cannot group or truncate dates, so each aggregate is a raw SQL query.
+func (r *PrismaRepository) Stats(ctx context.Context, query domain.StatsQuery) (*domain.Statistics, error) {
+	where, args := statsWhere(ctx, query, true)
+	stats := &domain.Statistics{}
+
+	var operations []struct {
+		Operation string  `json:"operation"`
+		Count     int     `json:"count"`
+		MinResult int     `json:"min"`
+		MaxResult int     `json:"max"`
+		AvgResult float64 `json:"avg"`
+	}
+	// Only integer calculations have a numeric result column; the others
+	// are counted but left out of the aggregates.
+	err := r.client.Prisma.QueryRaw(
+		`SELECT "operation", COUNT(*)::int AS "count",
+			COALESCE(MIN("result") FILTER (WHERE "kind" = 'integer'), 0) AS "min",
+			COALESCE(MAX("result") FILTER (WHERE "kind" = 'integer'), 0) AS "max",
+			COALESCE(AVG("result") FILTER (WHERE "kind" = 'integer'), 0)::float8 AS "avg"
+		FROM "Calculation" WHERE `+where+` GROUP BY "operation" ORDER BY "count" DESC, "operation"`,
+		args...,
+	).Exec(ctx, &operations)
+	if err != nil {
+		return nil, fmt.Errorf("aggregating operations: %w", err)
+	}
+	for _, op := range operations {
+		stats.Total += op.Count
+		stats.Operations = append(stats.Operations, domain.OperationStats(op))
+	}
+
+	// The bucket is one of two fixed units, so it is safe to interpolate
+	// and keeps the positional arguments shared with the other queries.
+	bucket := string(domain.StatsBucketDay)
+	if query.Bucket == domain.StatsBucketHour {
+		bucket = string(domain.StatsBucketHour)
+	}
+	var histogram []struct {
+		Start time.Time `json:"start"`
+		Count int       `json:"count"`
+	}
+	err = r.client.Prisma.QueryRaw(
+		`SELECT date_trunc('`+bucket+`', "createdAt") AS "start", COUNT(*)::int AS "count"
+		FROM "Calculation" WHERE `+where+` GROUP BY 1 ORDER BY 1`,
+		args...,
+	).Exec(ctx, &histogram)
+	if err != nil {
+		return nil, fmt.Errorf("aggregating histogram: %w", err)
+	}
+	for _, b := range histogram {
+		stats.Histogram = append(stats.Histogram, domain.HistogramBucket{Start: b.Start, Count: b.Count})
+	}
+
+	if query.TopPairs > 0 {
+		var pairs []struct {
+			Operation string `json:"operation"`
+			A         int    `json:"a"`
+			B         int    `json:"b"`
+			Count     int    `json:"count"`
+		}
+		err = r.client.Prisma.QueryRaw(
+			fmt.Sprintf(`SELECT "operation", "a", "b", COUNT(*)::int AS "count"
+			FROM "Calculation" WHERE %s AND "kind" = 'integer' GROUP BY "operation", "a", "b"
+			ORDER BY "count" DESC, "operation", "a", "b" LIMIT $%d`, where, len(args)+1),
+			append(args, query.TopPairs)...,
+		).Exec(ctx, &pairs)
+		if err != nil {
+			return nil, fmt.Errorf("aggregating operand pairs: %w", err)
+		}
+		for _, p := range pairs {
+			stats.TopPairs = append(stats.TopPairs, domain.OperandPairStats(p))
+		}
+	}
+
+	jobWhere, jobArgs := statsWhere(ctx, query, false)
+	var errs []struct {
+		Operation string `json:"operation"`
+		Finished  int    `json:"finished"`
+		Failed    int    `json:"failed"`
+	}
+	err = r.client.Prisma.QueryRaw(
+		`SELECT "operation",
+			COUNT(*) FILTER (WHERE "status" IN ('succeeded', 'failed'))::int AS "finished",
+			COUNT(*) FILTER (WHERE "status" = 'failed')::int AS "failed"
+		FROM "Job" WHERE `+jobWhere+` GROUP BY "operation" ORDER BY "operation"`,
+		jobArgs...,
+	).Exec(ctx, &errs)
+	if err != nil {
+		return nil, fmt.Errorf("aggregating job errors: %w", err)
+	}
+	for _, e := range errs {
+		stats.Errors = append(stats.Errors, domain.ErrorStats(e))
+	}
+
+	return stats, nil
+}
+
+// statsWhere builds the WHERE clause shared by the statistics queries and
+// its positional arguments, scoped to the tenant in the context. Jobs are
+// never soft-deleted, so calculations selects whether the deletedAt
+// condition applies.
+func statsWhere(ctx context.Context, query domain.StatsQuery, calculations bool) (string, []any) {
+	conditions := []string{`"tenantId" = $1`, `"createdAt" >= $2::timestamp`, `"createdAt" < $3::timestamp`}
+	args := []any{
+		domain.TenantFromContext(ctx).ID,
+		query.From.UTC().Format(statsTimeLayout),
+		query.To.UTC().Format(statsTimeLayout),
+	}
+	if query.Operation != "" {
+		args = append(args, query.Operation)
+		conditions = append(conditions, fmt.Sprintf(`"operation" = $%d`, len(args)))
+	}
+	if calculations && !query.IncludeDeleted {
+		conditions = append(conditions, `"deletedAt" IS NULL`)
+	}
+	return strings.Join(conditions, " AND "), args
+}
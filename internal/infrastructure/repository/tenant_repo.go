@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/ports/out"
+
+	db "go-prisma-calculator/internal/infrastructure/repository/prisma"
+)
+
+// PrismaTenantRepository is the Prisma implementation of the tenant repository port.
+type PrismaTenantRepository struct {
+	client *db.PrismaClient
+}
+
+// NewPrismaTenantRepository is the constructor that fx uses to create an instance.
+func NewPrismaTenantRepository(client *db.PrismaClient) out.TenantRepositoryPort {
+	return &PrismaTenantRepository{
+		client: client,
+	}
+}
+
+// Create inserts a new tenant.
+func (r *PrismaTenantRepository) Create(ctx context.Context, tenant domain.Tenant) (*domain.Tenant, error) {
+	created, err := r.client.Tenant.CreateOne(
+		db.Tenant.ID.Set(tenant.ID),
+		db.Tenant.Name.Set(tenant.Name),
+		db.Tenant.AllowedOperations.Set(tenant.AllowedOperations),
+		db.Tenant.MaxOperand.Set(tenant.MaxOperand),
+		db.Tenant.MaxPendingJobs.Set(tenant.MaxPendingJobs),
+	).Exec(ctx)
+	if _, ok := db.IsErrUniqueConstraint(err); ok {
+		return nil, domain.ErrTenantExists
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainTenant(created), nil
+}
+
+// FindByID loads a single tenant.
+func (r *PrismaTenantRepository) FindByID(ctx context.Context, id string) (*domain.Tenant, error) {
+	tenant, err := r.client.Tenant.FindUnique(
+		db.Tenant.ID.Equals(id),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainTenant(tenant), nil
+}
+
+// List returns every tenant, ordered by ID.
+func (r *PrismaTenantRepository) List(ctx context.Context) ([]domain.Tenant, error) {
+	rows, err := r.client.Tenant.FindMany().OrderBy(
+		db.Tenant.ID.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tenants := make([]domain.Tenant, 0, len(rows))
+	for i := range rows {
+		tenants = append(tenants, *toDomainTenant(&rows[i]))
+	}
+	return tenants, nil
+}
+
+// Update stores the name, configuration and disabled state of a tenant.
+func (r *PrismaTenantRepository) Update(ctx context.Context, tenant domain.Tenant) (*domain.Tenant, error) {
+	updated, err := r.client.Tenant.FindUnique(
+		db.Tenant.ID.Equals(tenant.ID),
+	).Update(
+		db.Tenant.Name.Set(tenant.Name),
+		db.Tenant.AllowedOperations.Set(tenant.AllowedOperations),
+		db.Tenant.MaxOperand.Set(tenant.MaxOperand),
+		db.Tenant.MaxPendingJobs.Set(tenant.MaxPendingJobs),
+		db.Tenant.DisabledAt.SetOptional(tenant.DisabledAt),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainTenant(updated), nil
+}
+
+// toDomainTenant translates a Prisma model into the domain model.
+func toDomainTenant(m *db.TenantModel) *domain.Tenant {
+	tenant := &domain.Tenant{
+		ID:                m.ID,
+		Name:              m.Name,
+		AllowedOperations: m.AllowedOperations,
+		MaxOperand:        m.MaxOperand,
+		MaxPendingJobs:    m.MaxPendingJobs,
+		CreatedAt:         m.CreatedAt,
+	}
+	if disabledAt, ok := m.DisabledAt(); ok {
+		tenant.DisabledAt = &disabledAt
+	}
+	return tenant
+}
@@ -0,0 +1,437 @@
+package repository
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/ports/out"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// OverflowPolicy decides what happens when the write-behind queue is full.
+type OverflowPolicy string
+
+const (
+	// OverflowBlock makes Save wait until the queue has room (or the request is cancelled).
+	OverflowBlock OverflowPolicy = "block"
+	// OverflowDrop discards the calculation and counts it in a metric.
+	OverflowDrop OverflowPolicy = "drop"
+	// OverflowSpill appends the calculation to a local journal that is
+	// replayed once the database accepts writes again.
+	OverflowSpill OverflowPolicy = "spill"
+)
+
+// journalReplayInterval is how often the journal is replayed while it has
+// entries.
+const journalReplayInterval = 10 * time.Second
+
+// DefaultMaxJournalEntry bounds a journal line when no bound is configured.
+const DefaultMaxJournalEntry = 1 << 20
+
+// JournalEntrySize returns the size of the largest journal line a
+// calculation can take, given the bit limit of big integers and rationals
+// and the dimension limit of matrices. Every text of a calculation, i.e. an
+// operand, the value or a detail, is at most as long as the largest matrix
+// or rational, and a calculation has no more than three operands and a few
+// details; the fixed fields fit in the rest.
+func JournalEntrySize(maxBits, maxDimension int) int {
+	// A decimal digit carries more than 3 bits, and a matrix entry is a
+	// float64 of at most 24 characters followed by a comma.
+	rational := 2*(maxBits/3+4) + 1
+	matrix := maxDimension*maxDimension*25 + 2*maxDimension + 2
+	text := max(rational, matrix, 1000)
+	return 8*text + 64<<10
+}
+
+var (
+	writeBehindDropped = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "calculator_write_behind_dropped_total",
+		Help: "Calculations discarded because the write-behind queue was full.",
+	})
+
+	writeBehindSpilled = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "calculator_write_behind_spilled_total",
+		Help: "Calculations written to the local journal instead of the database.",
+	})
+
+	writeBehindFlushErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "calculator_write_behind_flush_errors_total",
+		Help: "Batches that could not be written to the database.",
+	})
+
+	writeBehindBatchSize = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "calculator_write_behind_batch_size",
+		Help:    "Number of calculations written per flush.",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 10),
+	})
+)
+
+// WriteBehindOptions configures the asynchronous persistence queue.
+type WriteBehindOptions struct {
+	QueueSize     int
+	BatchSize     int
+	FlushInterval time.Duration
+	Overflow      OverflowPolicy
+	// JournalPath is the local file used by OverflowSpill and for batches that fail to flush.
+	JournalPath string
+	// MaxEntrySize bounds the size of a journal line; 0 selects
+	// DefaultMaxJournalEntry. Calculations that do not fit are not
+	// journaled, so every line of the journal can be read back.
+	MaxEntrySize int
+	// Registerer registers the queue depth gauge; nil leaves it unregistered.
+	Registerer prometheus.Registerer
+}
+
+// WriteBehindRepository decorates a CalculationRepositoryPort so that Save
+// returns as soon as the calculation is queued. A background writer flushes
+// the queue in batches, by size or by interval, and drains it on shutdown.
+type WriteBehindRepository struct {
+	out.CalculationRepositoryPort
+	opts   WriteBehindOptions
+	logger *slog.Logger
+
+	queue chan domain.Calculation
+	done  chan struct{}
+	// stopReplay ends the replay loop, which closes replayed when it exits.
+	stopReplay chan struct{}
+	replayed   chan struct{}
+
+	// mu guards closed; Save holds it for reading while enqueueing so the
+	// queue is never closed underneath a pending send.
+	mu     sync.RWMutex
+	closed bool
+
+	// journalMu guards the journal file. Replays rename the journal away
+	// under it and write to the database without it, so that spilling is
+	// never blocked by a slow database.
+	journalMu sync.Mutex
+}
+
+// NewWriteBehindRepository wraps next with an in-memory write-behind queue.
+func NewWriteBehindRepository(next out.CalculationRepositoryPort, opts WriteBehindOptions, logger *slog.Logger) *WriteBehindRepository {
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 1
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 1
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = time.Second
+	}
+	if opts.MaxEntrySize <= 0 {
+		opts.MaxEntrySize = DefaultMaxJournalEntry
+	}
+
+	r := &WriteBehindRepository{
+		CalculationRepositoryPort: next,
+		opts:                      opts,
+		logger:                    logger,
+		queue:                     make(chan domain.Calculation, opts.QueueSize),
+		done:                      make(chan struct{}),
+		stopReplay:                make(chan struct{}),
+		replayed:                  make(chan struct{}),
+	}
+
+	if opts.Registerer != nil {
+		depth := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "calculator_write_behind_queue_depth",
+			Help: "Calculations waiting to be written to the database.",
+		}, func() float64 { return float64(len(r.queue)) })
+		if err := opts.Registerer.Register(depth); err != nil {
+			logger.Warn("Failed to register the write-behind queue depth gauge", slog.String("error", err.Error()))
+		}
+	}
+
+	return r
+}
+
+// Save queues the calculation for the background writer. The tenant is
+// taken from the request now, since the writer runs outside of it.
+func (r *WriteBehindRepository) Save(ctx context.Context, calc domain.Calculation) error {
+	if calc.TenantID == "" {
+		calc.TenantID = domain.TenantFromContext(ctx).ID
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.closed {
+		// The writer has been drained; fall back to a synchronous write.
+		return r.CalculationRepositoryPort.Save(ctx, calc)
+	}
+
+	select {
+	case r.queue <- calc:
+		return nil
+	default:
+	}
+
+	switch r.opts.Overflow {
+	case OverflowDrop:
+		writeBehindDropped.Inc()
+		r.logger.Warn("Write-behind queue full, dropping calculation", slog.String("operation", calc.Operation))
+		return nil
+	case OverflowSpill:
+		if err := r.spill([]domain.Calculation{calc}); err != nil {
+			return err
+		}
+		writeBehindSpilled.Inc()
+		return nil
+	default:
+		select {
+		case r.queue <- calc:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// SaveBatch queues each calculation individually.
+func (r *WriteBehindRepository) SaveBatch(ctx context.Context, calcs []domain.Calculation) error {
+	for _, calc := range calcs {
+		if err := r.Save(ctx, calc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Start launches the background writer and the journal replay loop, which
+// first replays the calculations spilled by a previous run.
+func (r *WriteBehindRepository) Start(ctx context.Context) error {
+	go r.run()
+	go r.replayLoop()
+	return nil
+}
+
+// Stop stops accepting new work and waits until the queue has been flushed.
+func (r *WriteBehindRepository) Stop(ctx context.Context) error {
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.queue)
+		close(r.stopReplay)
+	}
+	r.mu.Unlock()
+
+	for _, done := range []chan struct{}{r.done, r.replayed} {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return fmt.Errorf("write-behind queue not drained: %d calculations pending: %w", len(r.queue), ctx.Err())
+		}
+	}
+	return nil
+}
+
+func (r *WriteBehindRepository) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.opts.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]domain.Calculation, 0, r.opts.BatchSize)
+	for {
+		select {
+		case calc, ok := <-r.queue:
+			if !ok {
+				r.flush(batch)
+				return
+			}
+			batch = append(batch, calc)
+			if len(batch) >= r.opts.BatchSize {
+				r.flush(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			r.flush(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+// flush writes one batch. Failed batches are journaled so they are not lost.
+func (r *WriteBehindRepository) flush(batch []domain.Calculation) {
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(withDurableWrites(context.Background()), 30*time.Second)
+	defer cancel()
+
+	writeBehindBatchSize.Observe(float64(len(batch)))
+	err := r.CalculationRepositoryPort.SaveBatch(ctx, batch)
+	if err == nil {
+		return
+	}
+
+	writeBehindFlushErrors.Inc()
+	r.logger.Error("Write-behind flush failed", slog.Int("batch_size", len(batch)), slog.String("error", err.Error()))
+
+	if spillErr := r.spill(batch); spillErr != nil {
+		r.logger.Error("Failed to journal calculations, they are lost",
+			slog.Int("batch_size", len(batch)),
+			slog.String("error", spillErr.Error()),
+		)
+		return
+	}
+	writeBehindSpilled.Add(float64(len(batch)))
+}
+
+// spill appends calculations to the journal as newline-delimited JSON.
+func (r *WriteBehindRepository) spill(calcs []domain.Calculation) error {
+	if r.opts.JournalPath == "" {
+		return errors.New("write-behind journal is not configured")
+	}
+
+	r.journalMu.Lock()
+	defer r.journalMu.Unlock()
+
+	f, err := os.OpenFile(r.opts.JournalPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	for _, calc := range calcs {
+		line, err := json.Marshal(calc)
+		if err != nil {
+			return err
+		}
+		if len(line) >= r.opts.MaxEntrySize {
+			return fmt.Errorf("calculation of %d bytes exceeds the journal entry limit of %d bytes", len(line), r.opts.MaxEntrySize)
+		}
+		if _, err := f.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+	return f.Sync()
+}
+
+// replayLoop replays the journal on start and then periodically, so that
+// calculations spilled during an outage reach the database once it recovers
+// rather than on the next restart.
+func (r *WriteBehindRepository) replayLoop() {
+	defer close(r.replayed)
+	if r.opts.JournalPath == "" {
+		return
+	}
+
+	ticker := time.NewTicker(journalReplayInterval)
+	defer ticker.Stop()
+	for {
+		r.replayJournal()
+		select {
+		case <-r.stopReplay:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// replayPath is where the journal is moved while it is replayed.
+func (r *WriteBehindRepository) replayPath() string {
+	return r.opts.JournalPath + ".replay"
+}
+
+// replayJournal writes journaled calculations to the database. The journal
+// is renamed to replayPath under journalMu, so new spills start a fresh
+// journal while the database writes run without the lock. A replay file
+// left by a failed or interrupted replay is finished first.
+func (r *WriteBehindRepository) replayJournal() {
+	path := r.replayPath()
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		r.journalMu.Lock()
+		err := os.Rename(r.opts.JournalPath, path)
+		r.journalMu.Unlock()
+		if errors.Is(err, os.ErrNotExist) {
+			return
+		}
+		if err != nil {
+			r.logger.Error("Failed to move write-behind journal for replay", slog.String("error", err.Error()))
+			return
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		r.logger.Error("Failed to open write-behind journal", slog.String("error", err.Error()))
+		return
+	}
+
+	var calcs []domain.Calculation
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64<<10), r.opts.MaxEntrySize)
+	for scanner.Scan() {
+		var calc domain.Calculation
+		if err := json.Unmarshal(scanner.Bytes(), &calc); err != nil {
+			r.logger.Warn("Skipping corrupt journal entry", slog.String("error", err.Error()))
+			continue
+		}
+		calcs = append(calcs, calc)
+	}
+	f.Close()
+	if err := scanner.Err(); err != nil {
+		r.logger.Error("Failed to read write-behind journal", slog.String("error", err.Error()))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(withDurableWrites(context.Background()), time.Minute)
+	defer cancel()
+
+	for start := 0; start < len(calcs); start += r.opts.BatchSize {
+		end := min(start+r.opts.BatchSize, len(calcs))
+		if err := r.CalculationRepositoryPort.SaveBatch(ctx, calcs[start:end]); err != nil {
+			// Keep the unsaved tail so nothing is replayed twice on the next attempt.
+			r.logger.Warn("Failed to replay write-behind journal, will retry", slog.String("error", err.Error()))
+			r.rewriteJournal(path, calcs[start:])
+			return
+		}
+	}
+
+	if err := os.Remove(path); err != nil {
+		r.logger.Error("Failed to remove replayed journal", slog.String("error", err.Error()))
+		return
+	}
+	if len(calcs) > 0 {
+		r.logger.Info("Replayed write-behind journal", slog.Int("calculations", len(calcs)))
+	}
+}
+
+// rewriteJournal replaces the journal file at path with the given
+// calculations.
+func (r *WriteBehindRepository) rewriteJournal(path string, calcs []domain.Calculation) {
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		r.logger.Error("Failed to rewrite write-behind journal", slog.String("error", err.Error()))
+		return
+	}
+
+	enc := json.NewEncoder(f)
+	for _, calc := range calcs {
+		if err := enc.Encode(calc); err != nil {
+			f.Close()
+			r.logger.Error("Failed to rewrite write-behind journal", slog.String("error", err.Error()))
+			return
+		}
+	}
+	if err := f.Close(); err != nil {
+		r.logger.Error("Failed to rewrite write-behind journal", slog.String("error", err.Error()))
+		return
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		r.logger.Error("Failed to rewrite write-behind journal", slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/ports/out"
+)
+
+// batchRecorder records the batches written to it and fails while err is set.
+type batchRecorder struct {
+	out.CalculationRepositoryPort
+	mu      sync.Mutex
+	err     error
+	batches [][]domain.Calculation
+}
+
+func (r *batchRecorder) SaveBatch(_ context.Context, calcs []domain.Calculation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	r.batches = append(r.batches, append([]domain.Calculation(nil), calcs...))
+	return nil
+}
+
+func (r *batchRecorder) saved() []domain.Calculation {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var calcs []domain.Calculation
+	for _, batch := range r.batches {
+		calcs = append(calcs, batch...)
+	}
+	return calcs
+}
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// largestMatrix returns a matrix calculation of the largest size allowed by
+// a dimension limit, with entries of the longest text form.
+func largestMatrix(dimension int) domain.Calculation {
+	m := make(domain.Matrix, dimension)
+	for i := range m {
+		m[i] = make([]float64, dimension)
+		for j := range m[i] {
+			m[i][j] = -1.2345678901234567e-300
+		}
+	}
+	text := domain.FormatMatrix(m)
+	return domain.Calculation{
+		ID:        "matrix",
+		Operation: "mul",
+		Kind:      domain.KindMatrix,
+		Operands:  []string{text, text},
+		Value:     text,
+	}
+}
+
+func TestReplayJournalLargeEntry(t *testing.T) {
+	journal := filepath.Join(t.TempDir(), "journal.ndjson")
+	next := &batchRecorder{}
+	r := NewWriteBehindRepository(next, WriteBehindOptions{
+		BatchSize:    10,
+		JournalPath:  journal,
+		MaxEntrySize: JournalEntrySize(4096, 32),
+	}, discardLogger())
+
+	large := largestMatrix(32)
+	if err := r.spill([]domain.Calculation{{ID: "small", Operation: "add", A: 1, B: 2, Result: 3}, large}); err != nil {
+		t.Fatalf("spill: %v", err)
+	}
+	info, err := os.Stat(journal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() <= 64<<10 {
+		t.Fatalf("journal has %d bytes, want an entry beyond the default scanner limit", info.Size())
+	}
+
+	r.replayJournal()
+
+	saved := next.saved()
+	if len(saved) != 2 || saved[0].ID != "small" || saved[1].ID != "matrix" || saved[1].Value != large.Value {
+		t.Fatalf("replayed %d calculations, want the small and the large one", len(saved))
+	}
+	for _, path := range []string{journal, r.replayPath()} {
+		if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("%s still exists after the replay: %v", filepath.Base(path), err)
+		}
+	}
+}
+
+func TestReplayJournalSkipsCorruptEntries(t *testing.T) {
+	journal := filepath.Join(t.TempDir(), "journal.ndjson")
+	next := &batchRecorder{}
+	r := NewWriteBehindRepository(next, WriteBehindOptions{JournalPath: journal}, discardLogger())
+
+	content := `{"ID":"a","Operation":"add"}` + "\n" + `{"ID":"b",` + "\n" + `{"ID":"c","Operation":"add"}` + "\n"
+	if err := os.WriteFile(journal, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	r.replayJournal()
+
+	saved := next.saved()
+	if len(saved) != 2 || saved[0].ID != "a" || saved[1].ID != "c" {
+		t.Errorf("replayed %+v, want a and c", saved)
+	}
+}
+
+func TestReplayJournalKeepsUnsavedTail(t *testing.T) {
+	journal := filepath.Join(t.TempDir(), "journal.ndjson")
+	next := &batchRecorder{err: errors.New("database down")}
+	r := NewWriteBehindRepository(next, WriteBehindOptions{BatchSize: 1, JournalPath: journal}, discardLogger())
+
+	if err := r.spill([]domain.Calculation{{ID: "a"}, {ID: "b"}}); err != nil {
+		t.Fatal(err)
+	}
+	r.replayJournal()
+	if len(next.saved()) != 0 {
+		t.Fatalf("saved %+v while the database was down", next.saved())
+	}
+
+	// New spills go to a fresh journal while the replay file is pending.
+	if err := r.spill([]domain.Calculation{{ID: "c"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	next.mu.Lock()
+	next.err = nil
+	next.mu.Unlock()
+	r.replayJournal()
+	r.replayJournal()
+
+	var ids []string
+	for _, calc := range next.saved() {
+		ids = append(ids, calc.ID)
+	}
+	if got := strings.Join(ids, ","); got != "a,b,c" {
+		t.Errorf("replayed %s, want a,b,c", got)
+	}
+}
+
+func TestSpillRejectsOversizedEntry(t *testing.T) {
+	journal := filepath.Join(t.TempDir(), "journal.ndjson")
+	r := NewWriteBehindRepository(&batchRecorder{}, WriteBehindOptions{
+		JournalPath:  journal,
+		MaxEntrySize: 1024,
+	}, discardLogger())
+
+	err := r.spill([]domain.Calculation{{ID: "small"}, {ID: "large", Value: strings.Repeat("9", 1024)}})
+	if err == nil {
+		t.Fatal("spill of an oversized entry succeeded")
+	}
+	data, err := os.ReadFile(journal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), `"large"`) {
+		t.Errorf("journal contains the oversized entry")
+	}
+}
+
+func TestJournalEntrySize(t *testing.T) {
+	for _, dimension := range []int{1, 8, 32, 64} {
+		calc := largestMatrix(dimension)
+		r := NewWriteBehindRepository(&batchRecorder{}, WriteBehindOptions{
+			JournalPath:  filepath.Join(t.TempDir(), "journal.ndjson"),
+			MaxEntrySize: JournalEntrySize(0, dimension),
+		}, discardLogger())
+		if err := r.spill([]domain.Calculation{calc}); err != nil {
+			t.Errorf("dimension %d: %v", dimension, err)
+		}
+	}
+	if got := JournalEntrySize(4096, 32); got < DefaultMaxJournalEntry/8 {
+		t.Errorf("JournalEntrySize(4096, 32) = %d, implausibly small", got)
+	}
+}
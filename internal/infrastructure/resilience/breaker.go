@@ -0,0 +1,157 @@
+package resilience
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned when the breaker rejects a call without trying it.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// State is the state of a circuit breaker.
+type State int
+
+const (
+	// StateClosed lets every call through and counts consecutive failures.
+	StateClosed State = iota
+	// StateHalfOpen lets a limited number of probe calls through after the open period.
+	StateHalfOpen
+	// StateOpen rejects calls until the open period has elapsed.
+	StateOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half-open"
+	case StateOpen:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+// BreakerOptions configures a circuit breaker.
+type BreakerOptions struct {
+	// FailureThreshold is the number of consecutive failures that opens the circuit.
+	FailureThreshold int
+	// OpenDuration is how long the circuit stays open before allowing probes.
+	OpenDuration time.Duration
+	// HalfOpenProbes is how many concurrent probe calls are allowed while half-open.
+	HalfOpenProbes int
+	// OnStateChange is called, outside the breaker's lock, after every transition.
+	OnStateChange func(from, to State)
+}
+
+// Breaker is a closed/open/half-open circuit breaker.
+type Breaker struct {
+	opts BreakerOptions
+	now  func() time.Time
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	probes   int
+}
+
+// NewBreaker creates a breaker in the closed state.
+func NewBreaker(opts BreakerOptions) *Breaker {
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = 1
+	}
+	if opts.HalfOpenProbes <= 0 {
+		opts.HalfOpenProbes = 1
+	}
+	return &Breaker{opts: opts, now: time.Now}
+}
+
+// State returns the current state of the breaker.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Allow reports whether a call may proceed. Every successful Allow must be
+// followed by exactly one call to Record or Release.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	from := b.state
+
+	switch b.state {
+	case StateOpen:
+		if b.now().Sub(b.openedAt) < b.opts.OpenDuration {
+			b.mu.Unlock()
+			return ErrCircuitOpen
+		}
+		b.state = StateHalfOpen
+		b.probes = 0
+		fallthrough
+	case StateHalfOpen:
+		if b.probes >= b.opts.HalfOpenProbes {
+			b.mu.Unlock()
+			b.notify(from, StateHalfOpen)
+			return ErrCircuitOpen
+		}
+		b.probes++
+	}
+
+	to := b.state
+	b.mu.Unlock()
+	b.notify(from, to)
+	return nil
+}
+
+// Record reports the outcome of a call that was allowed through.
+func (b *Breaker) Record(success bool) {
+	b.mu.Lock()
+	from := b.state
+
+	switch {
+	case success:
+		b.failures = 0
+		b.state = StateClosed
+	case b.state == StateHalfOpen:
+		b.trip()
+	default:
+		b.failures++
+		if b.failures >= b.opts.FailureThreshold {
+			b.trip()
+		}
+	}
+	if from == StateHalfOpen && b.probes > 0 {
+		b.probes--
+	}
+
+	to := b.state
+	b.mu.Unlock()
+	b.notify(from, to)
+}
+
+// Release ends a call that was allowed through without reporting an
+// outcome, e.g. one abandoned by its caller, which says nothing about the
+// health of the protected resource.
+func (b *Breaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == StateHalfOpen && b.probes > 0 {
+		b.probes--
+	}
+}
+
+// trip opens the circuit. The caller must hold mu.
+func (b *Breaker) trip() {
+	b.state = StateOpen
+	b.openedAt = b.now()
+	b.failures = 0
+}
+
+func (b *Breaker) notify(from, to State) {
+	if from != to && b.opts.OnStateChange != nil {
+		b.opts.OnStateChange(from, to)
+	}
+}
@@ -0,0 +1,169 @@
+package resilience
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// step is one action on a breaker and the state expected after it.
+type step struct {
+	// action is "allow", "reject", "success", "failure", "release" or "wait".
+	action string
+	want   State
+}
+
+func TestBreakerTransitions(t *testing.T) {
+	tests := []struct {
+		name        string
+		threshold   int
+		probes      int
+		steps       []step
+		transitions []string
+	}{
+		{
+			name:      "failures below the threshold keep it closed",
+			threshold: 3,
+			steps: []step{
+				{"allow", StateClosed}, {"failure", StateClosed},
+				{"allow", StateClosed}, {"failure", StateClosed},
+				{"allow", StateClosed}, {"success", StateClosed},
+				{"allow", StateClosed}, {"failure", StateClosed},
+				{"allow", StateClosed}, {"failure", StateClosed},
+			},
+		},
+		{
+			name:      "consecutive failures open it",
+			threshold: 2,
+			steps: []step{
+				{"allow", StateClosed}, {"failure", StateClosed},
+				{"allow", StateClosed}, {"failure", StateOpen},
+				{"reject", StateOpen},
+			},
+			transitions: []string{"closed->open"},
+		},
+		{
+			name:      "a successful probe closes it",
+			threshold: 1,
+			steps: []step{
+				{"allow", StateClosed}, {"failure", StateOpen},
+				{"wait", StateOpen},
+				{"allow", StateHalfOpen}, {"success", StateClosed},
+				{"allow", StateClosed},
+			},
+			transitions: []string{"closed->open", "open->half-open", "half-open->closed"},
+		},
+		{
+			name:      "a failed probe opens it again",
+			threshold: 1,
+			steps: []step{
+				{"allow", StateClosed}, {"failure", StateOpen},
+				{"wait", StateOpen},
+				{"allow", StateHalfOpen}, {"failure", StateOpen},
+				{"reject", StateOpen},
+			},
+			transitions: []string{"closed->open", "open->half-open", "half-open->open"},
+		},
+		{
+			name:      "probes are limited while half-open",
+			threshold: 1,
+			probes:    2,
+			steps: []step{
+				{"allow", StateClosed}, {"failure", StateOpen},
+				{"wait", StateOpen},
+				{"allow", StateHalfOpen}, {"allow", StateHalfOpen},
+				{"reject", StateHalfOpen},
+				{"success", StateClosed},
+			},
+			transitions: []string{"closed->open", "open->half-open", "half-open->closed"},
+		},
+		{
+			name:      "a released probe frees its slot without closing it",
+			threshold: 1,
+			steps: []step{
+				{"allow", StateClosed}, {"failure", StateOpen},
+				{"wait", StateOpen},
+				{"allow", StateHalfOpen}, {"reject", StateHalfOpen},
+				{"release", StateHalfOpen},
+				{"allow", StateHalfOpen}, {"success", StateClosed},
+			},
+			transitions: []string{"closed->open", "open->half-open", "half-open->closed"},
+		},
+		{
+			name:      "a release while closed does not count as a failure",
+			threshold: 1,
+			steps: []step{
+				{"allow", StateClosed}, {"release", StateClosed},
+				{"allow", StateClosed}, {"release", StateClosed},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var transitions []string
+			b := NewBreaker(BreakerOptions{
+				FailureThreshold: tt.threshold,
+				OpenDuration:     time.Minute,
+				HalfOpenProbes:   tt.probes,
+				OnStateChange: func(from, to State) {
+					transitions = append(transitions, fmt.Sprintf("%s->%s", from, to))
+				},
+			})
+			now := time.Unix(0, 0)
+			b.now = func() time.Time { return now }
+
+			for i, s := range tt.steps {
+				switch s.action {
+				case "allow":
+					if err := b.Allow(); err != nil {
+						t.Fatalf("step %d: Allow() = %v, want nil", i, err)
+					}
+				case "reject":
+					if err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+						t.Fatalf("step %d: Allow() = %v, want %v", i, err, ErrCircuitOpen)
+					}
+				case "success":
+					b.Record(true)
+				case "failure":
+					b.Record(false)
+				case "release":
+					b.Release()
+				case "wait":
+					now = now.Add(time.Minute)
+				}
+				if got := b.State(); got != s.want {
+					t.Fatalf("step %d (%s): state = %s, want %s", i, s.action, got, s.want)
+				}
+			}
+
+			if fmt.Sprint(transitions) != fmt.Sprint(tt.transitions) {
+				t.Errorf("transitions = %v, want %v", transitions, tt.transitions)
+			}
+		})
+	}
+}
+
+func TestBreakerStaysOpenForOpenDuration(t *testing.T) {
+	b := NewBreaker(BreakerOptions{FailureThreshold: 1, OpenDuration: time.Minute})
+	now := time.Unix(0, 0)
+	b.now = func() time.Time { return now }
+
+	if err := b.Allow(); err != nil {
+		t.Fatalf("Allow() = %v", err)
+	}
+	b.Record(false)
+
+	now = now.Add(time.Minute - time.Nanosecond)
+	if err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Allow() before the open period ended = %v, want %v", err, ErrCircuitOpen)
+	}
+	now = now.Add(time.Nanosecond)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("Allow() after the open period = %v, want nil", err)
+	}
+	if got := b.State(); got != StateHalfOpen {
+		t.Errorf("state = %s, want %s", got, StateHalfOpen)
+	}
+}
@@ -0,0 +1,60 @@
+package resilience
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryOptions configures Retry.
+type RetryOptions struct {
+	// Timeout bounds each individual attempt. Zero means no per-attempt timeout.
+	Timeout time.Duration
+	// MaxAttempts is the total number of attempts, including the first.
+	MaxAttempts int
+	// BaseDelay and MaxDelay bound the jittered exponential backoff between attempts.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// Retryable decides whether an error is worth another attempt.
+	Retryable func(error) bool
+	// OnRetry is called before sleeping ahead of another attempt.
+	OnRetry func(attempt int, err error)
+}
+
+// Retry calls fn until it succeeds, returns a non-retryable error, the
+// attempts are exhausted or ctx ends. It returns the last error seen.
+func Retry(ctx context.Context, opts RetryOptions, fn func(ctx context.Context) error) error {
+	attempts := max(opts.MaxAttempts, 1)
+	delay := opts.BaseDelay
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = attemptOnce(ctx, opts.Timeout, fn)
+		if err == nil || attempt >= attempts || opts.Retryable == nil || !opts.Retryable(err) {
+			return err
+		}
+
+		if opts.OnRetry != nil {
+			opts.OnRetry(attempt, err)
+		}
+
+		// Full jitter: sleep a random duration in [0, delay).
+		timer := time.NewTimer(rand.N(delay + 1))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		delay = min(delay*2, opts.MaxDelay)
+	}
+}
+
+func attemptOnce(ctx context.Context, timeout time.Duration, fn func(ctx context.Context) error) error {
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return fn(ctx)
+}
@@ -0,0 +1,79 @@
+package retention
+
+import (
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/infrastructure/export"
+)
+
+// archiver writes batches of calculations to gzip-compressed NDJSON files.
+// Each batch gets its own file, which is complete on disk before the rows
+// are deleted from the database.
+type archiver struct {
+	dir string
+
+	mu  sync.Mutex
+	seq int
+}
+
+func newArchiver(dir string) (*archiver, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("retention archive directory is not configured")
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("create retention archive directory: %w", err)
+	}
+	return &archiver{dir: dir}, nil
+}
+
+// write stores calcs in a new archive file and returns its path.
+func (a *archiver) write(rule string, calcs []domain.Calculation, now time.Time) (string, error) {
+	a.mu.Lock()
+	a.seq++
+	name := fmt.Sprintf("calculations-%s-%s-%04d.ndjson.gz", now.UTC().Format("20060102T150405Z"), rule, a.seq)
+	a.mu.Unlock()
+
+	path := filepath.Join(a.dir, name)
+	tmp := path + ".tmp"
+	if err := writeArchive(tmp, calcs); err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+	return path, nil
+}
+
+func writeArchive(path string, calcs []domain.Calculation) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o640)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zw := gzip.NewWriter(f)
+	w, err := export.NewWriter(export.FormatNDJSON, zw)
+	if err != nil {
+		return err
+	}
+	for _, calc := range calcs {
+		if err := w.Write(calc); err != nil {
+			return err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return f.Sync()
+}
@@ -0,0 +1,334 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/ports/out"
+	"go-prisma-calculator/internal/infrastructure/health"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Rule names, used in reports, archive file names and metric labels.
+const (
+	RuleMaxAge          = "max_age"
+	RuleOperationMaxAge = "operation_max_age"
+	RuleMaxRows         = "max_rows"
+)
+
+var (
+	retentionRows = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "calculator_retention_rows_total",
+		Help: "Calculations expired by the retention policy, by rule and action (deleted, archived or dry_run).",
+	}, []string{"rule", "action"})
+
+	retentionRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "calculator_retention_runs_total",
+		Help: "Retention runs by result (success, error or skipped).",
+	}, []string{"result"})
+
+	retentionDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "calculator_retention_run_duration_seconds",
+		Help:    "Duration of retention runs.",
+		Buckets: prometheus.ExponentialBuckets(0.01, 4, 10),
+	})
+
+	retentionLastSuccess = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "calculator_retention_last_success_timestamp_seconds",
+		Help: "Unix time of the last successful retention run.",
+	})
+)
+
+// Options configures the retention enforcer.
+type Options struct {
+	Policy Policy
+	// Interval is the time between runs.
+	Interval time.Duration
+	// BatchSize is the number of calculations deleted per statement.
+	BatchSize int
+	Mode      Mode
+	// ArchiveDir receives the archive files in ModeArchive.
+	ArchiveDir string
+	// DryRun only counts and logs what would be expired.
+	DryRun bool
+}
+
+// Report summarizes one retention run.
+type Report struct {
+	DryRun bool
+	// Expired counts the calculations expired (or that would be) per rule.
+	Expired map[string]int
+	// Files lists the archive files written.
+	Files []string
+}
+
+// Total returns the number of calculations expired by all rules.
+func (r Report) Total() int {
+	total := 0
+	for _, n := range r.Expired {
+		total += n
+	}
+	return total
+}
+
+// Enforcer applies the retention policy on a schedule.
+type Enforcer struct {
+	repo     out.RetentionRepositoryPort
+	monitor  *health.Monitor
+	archiver *archiver
+	opts     Options
+	logger   *slog.Logger
+	now      func() time.Time
+
+	// mu serializes runs started by the scheduler and by RunOnce.
+	mu sync.Mutex
+
+	stop context.CancelFunc
+	done chan struct{}
+}
+
+// NewEnforcer validates the options and creates an enforcer.
+func NewEnforcer(repo out.RetentionRepositoryPort, monitor *health.Monitor, opts Options, logger *slog.Logger) (*Enforcer, error) {
+	if opts.Interval <= 0 {
+		opts.Interval = time.Hour
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 500
+	}
+	if opts.Mode == "" {
+		opts.Mode = ModeDelete
+	}
+
+	e := &Enforcer{
+		repo:    repo,
+		monitor: monitor,
+		opts:    opts,
+		logger:  logger,
+		now:     time.Now,
+	}
+
+	switch opts.Mode {
+	case ModeDelete:
+	case ModeArchive:
+		a, err := newArchiver(opts.ArchiveDir)
+		if err != nil {
+			return nil, err
+		}
+		e.archiver = a
+	default:
+		return nil, fmt.Errorf("invalid retention mode %q: want %q or %q", opts.Mode, ModeDelete, ModeArchive)
+	}
+	return e, nil
+}
+
+// Policy returns the policy being enforced.
+func (e *Enforcer) Policy() Policy {
+	return e.opts.Policy
+}
+
+// Start launches the scheduler. The first run happens right away.
+func (e *Enforcer) Start(context.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	e.stop = cancel
+	e.done = make(chan struct{})
+
+	e.logger.Info("Retention enforcer started",
+		slog.String("mode", string(e.opts.Mode)),
+		slog.Duration("interval", e.opts.Interval),
+		slog.Bool("dry_run", e.opts.DryRun),
+	)
+	go e.run(ctx)
+	return nil
+}
+
+// Stop cancels a run in progress, between batches, and waits for the scheduler to exit.
+func (e *Enforcer) Stop(ctx context.Context) error {
+	if e.stop == nil {
+		return nil
+	}
+	e.stop()
+
+	select {
+	case <-e.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (e *Enforcer) run(ctx context.Context) {
+	defer close(e.done)
+
+	ticker := time.NewTicker(e.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		e.scheduled(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// scheduled performs one run, skipping it while the database is down.
+func (e *Enforcer) scheduled(ctx context.Context) {
+	if e.monitor != nil && !e.monitor.DatabaseReady() {
+		retentionRuns.WithLabelValues("skipped").Inc()
+		e.logger.Debug("Skipping retention run, database unavailable")
+		return
+	}
+
+	report, err := e.RunOnce(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			e.logger.Error("Retention run failed", slog.String("error", err.Error()))
+		}
+		return
+	}
+	if total := report.Total(); total > 0 {
+		e.logger.Info("Retention run finished",
+			slog.Int("expired", total),
+			slog.Bool("dry_run", report.DryRun),
+			slog.Any("rules", report.Expired),
+		)
+	}
+}
+
+// RunOnce applies every rule of the policy once and reports what was expired.
+func (e *Enforcer) RunOnce(ctx context.Context) (Report, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	start := e.now()
+	report := Report{DryRun: e.opts.DryRun, Expired: make(map[string]int)}
+
+	err := e.apply(ctx, start, &report)
+	retentionDuration.Observe(time.Since(start).Seconds())
+	if err != nil {
+		retentionRuns.WithLabelValues("error").Inc()
+		return report, err
+	}
+
+	retentionRuns.WithLabelValues("success").Inc()
+	retentionLastSuccess.SetToCurrentTime()
+	return report, nil
+}
+
+func (e *Enforcer) apply(ctx context.Context, now time.Time, report *Report) error {
+	policy := e.opts.Policy
+	overridden := policy.overriddenOperations()
+
+	for _, op := range overridden {
+		age := policy.OperationMaxAge[op]
+		if age <= 0 {
+			continue
+		}
+		if err := e.expire(ctx, RuleOperationMaxAge, domain.RetentionQuery{
+			CreatedBefore: now.Add(-age),
+			Operations:    []string{op},
+		}, report); err != nil {
+			return err
+		}
+	}
+
+	if policy.MaxAge > 0 {
+		if err := e.expire(ctx, RuleMaxAge, domain.RetentionQuery{
+			CreatedBefore:     now.Add(-policy.MaxAge),
+			ExcludeOperations: overridden,
+		}, report); err != nil {
+			return err
+		}
+	}
+
+	if policy.MaxRowsPerPrincipal > 0 {
+		owners, err := e.repo.PrincipalsOver(ctx, policy.MaxRowsPerPrincipal)
+		if err != nil {
+			return fmt.Errorf("find principals over the row limit: %w", err)
+		}
+		for _, owner := range owners {
+			if err := e.expire(ctx, RuleMaxRows, domain.RetentionQuery{
+				TenantID:   owner.TenantID,
+				Principal:  &owner.Principal,
+				KeepNewest: policy.MaxRowsPerPrincipal,
+			}, report); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// expire removes the calculations selected by query in batches. A dry run
+// pages through them instead, since nothing is deleted between batches.
+func (e *Enforcer) expire(ctx context.Context, rule string, query domain.RetentionQuery, report *Report) error {
+	query.Limit = e.opts.BatchSize
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		calcs, err := e.repo.FindExpired(ctx, query)
+		if err != nil {
+			return fmt.Errorf("%s: find expired calculations: %w", rule, err)
+		}
+		if len(calcs) == 0 {
+			return nil
+		}
+
+		if e.opts.DryRun {
+			report.Expired[rule] += len(calcs)
+			retentionRows.WithLabelValues(rule, "dry_run").Add(float64(len(calcs)))
+			query.Offset += len(calcs)
+		} else {
+			n, err := e.remove(rule, calcs, report)
+			if err != nil {
+				return fmt.Errorf("%s: %w", rule, err)
+			}
+			if n == 0 {
+				// Somebody else removed the rows; do not spin on the same batch.
+				return nil
+			}
+		}
+
+		if len(calcs) < e.opts.BatchSize {
+			return nil
+		}
+	}
+}
+
+// remove archives a batch when configured and deletes it. It runs to
+// completion even when the enforcer is stopping so an archived batch is
+// never left behind in the database.
+func (e *Enforcer) remove(rule string, calcs []domain.Calculation, report *Report) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	action := "deleted"
+	if e.archiver != nil {
+		path, err := e.archiver.write(rule, calcs, e.now())
+		if err != nil {
+			return 0, fmt.Errorf("archive calculations: %w", err)
+		}
+		report.Files = append(report.Files, path)
+		action = "archived"
+	}
+
+	n, err := e.repo.PurgeBatch(ctx, calcs)
+	if err != nil {
+		return 0, fmt.Errorf("delete calculations: %w", err)
+	}
+
+	report.Expired[rule] += n
+	retentionRows.WithLabelValues(rule, action).Add(float64(n))
+	return n, nil
+}
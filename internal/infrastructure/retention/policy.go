@@ -0,0 +1,82 @@
+// Package retention periodically removes old calculations from the history,
+// optionally archiving them to compressed NDJSON files first.
+package retention
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Mode decides what happens to expired calculations.
+type Mode string
+
+const (
+	// ModeDelete permanently deletes expired calculations.
+	ModeDelete Mode = "delete"
+	// ModeArchive writes expired calculations to gzip-compressed NDJSON files before deleting them.
+	ModeArchive Mode = "archive"
+)
+
+// Policy describes how long calculations are kept. Zero values keep
+// calculations forever.
+type Policy struct {
+	// MaxAge expires calculations older than this.
+	MaxAge time.Duration
+	// MaxRowsPerPrincipal keeps only the newest calculations of each principal.
+	MaxRowsPerPrincipal int
+	// OperationMaxAge overrides MaxAge for individual operations; a zero
+	// duration keeps that operation forever.
+	OperationMaxAge map[string]time.Duration
+}
+
+// Enabled reports whether the policy expires anything at all.
+func (p Policy) Enabled() bool {
+	if p.MaxAge > 0 || p.MaxRowsPerPrincipal > 0 {
+		return true
+	}
+	for _, age := range p.OperationMaxAge {
+		if age > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// overriddenOperations returns the operations with their own max age, sorted.
+func (p Policy) overriddenOperations() []string {
+	ops := make([]string, 0, len(p.OperationMaxAge))
+	for op := range p.OperationMaxAge {
+		ops = append(ops, op)
+	}
+	sort.Strings(ops)
+	return ops
+}
+
+// ParseOperationMaxAge parses per-operation overrides written as
+// comma-separated "operation=duration" entries, e.g. "add=720h,divide=0".
+func ParseOperationMaxAge(s string) (map[string]time.Duration, error) {
+	overrides := make(map[string]time.Duration)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		op, value, ok := strings.Cut(entry, "=")
+		op = strings.TrimSpace(op)
+		if !ok || op == "" {
+			return nil, fmt.Errorf("invalid retention override %q: want operation=duration", entry)
+		}
+		age, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || age < 0 {
+			return nil, fmt.Errorf("invalid retention override %q: bad duration", entry)
+		}
+		if _, dup := overrides[op]; dup {
+			return nil, fmt.Errorf("duplicate retention override for %q", op)
+		}
+		overrides[op] = age
+	}
+	return overrides, nil
+}
@@ -0,0 +1,64 @@
+package retention
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseOperationMaxAge(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    map[string]time.Duration
+		wantErr bool
+	}{
+		{in: "", want: map[string]time.Duration{}},
+		{in: " , ,", want: map[string]time.Duration{}},
+		{in: "add=720h", want: map[string]time.Duration{"add": 720 * time.Hour}},
+		{
+			in:   " add = 720h , divide=0,",
+			want: map[string]time.Duration{"add": 720 * time.Hour, "divide": 0},
+		},
+		{in: "add", wantErr: true},
+		{in: "=1h", wantErr: true},
+		{in: "add=", wantErr: true},
+		{in: "add=30d", wantErr: true},
+		{in: "add=-1h", wantErr: true},
+		{in: "add=1h,add=2h", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseOperationMaxAge(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseOperationMaxAge(%q) = %v, want an error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseOperationMaxAge(%q) error = %v", tt.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseOperationMaxAge(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPolicyEnabled(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy Policy
+		want   bool
+	}{
+		{"zero", Policy{}, false},
+		{"max age", Policy{MaxAge: time.Hour}, true},
+		{"max rows", Policy{MaxRowsPerPrincipal: 10}, true},
+		{"kept override", Policy{OperationMaxAge: map[string]time.Duration{"add": 0}}, false},
+		{"override", Policy{OperationMaxAge: map[string]time.Duration{"add": 0, "divide": time.Hour}}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.policy.Enabled(); got != tt.want {
+			t.Errorf("%s: Enabled() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
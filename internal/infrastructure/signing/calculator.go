@@ -0,0 +1,72 @@
+package signing
+
+import (
+	"context"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/ports/in"
+)
+
+// SigningCalculator is a decorator around in.CalculatorPort that attaches a
+// signed receipt to every recorded calculation it returns.
+type SigningCalculator struct {
+	next   in.CalculatorPort
+	signer *Signer
+}
+
+// NewSigningCalculator wraps next so that its results carry receipts.
+func NewSigningCalculator(next in.CalculatorPort, signer *Signer) in.CalculatorPort {
+	return &SigningCalculator{next: next, signer: signer}
+}
+
+// Calculate delegates and signs the result.
+func (c *SigningCalculator) Calculate(ctx context.Context, operation string, operands ...int32) (*domain.Calculation, error) {
+	calc, err := c.next.Calculate(ctx, operation, operands...)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.sign(calc), nil
+}
+
+// ListOperations delegates to the wrapped port.
+func (c *SigningCalculator) ListOperations(ctx context.Context) ([]domain.OperationSignature, error) {
+	return c.next.ListOperations(ctx)
+}
+
+// Divide delegates and signs the result.
+func (c *SigningCalculator) Divide(ctx context.Context, d domain.RoundedDivision) (*domain.Calculation, error) {
+	calc, err := c.next.Divide(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.sign(calc), nil
+}
+
+// Evaluate delegates and signs the result.
+func (c *SigningCalculator) Evaluate(ctx context.Context, e domain.Evaluation) (*domain.Calculation, error) {
+	calc, err := c.next.Evaluate(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.sign(calc), nil
+}
+
+// ListFunctions delegates to the wrapped port.
+func (c *SigningCalculator) ListFunctions(ctx context.Context) ([]domain.FunctionSignature, error) {
+	return c.next.ListFunctions(ctx)
+}
+
+// sign returns a copy of calc with its receipt. Calculations without an ID,
+// such as cache hits that are not recorded, are returned unsigned: a receipt
+// must not vouch for a record that does not exist.
+func (c *SigningCalculator) sign(calc *domain.Calculation) *domain.Calculation {
+	if calc.ID == "" {
+		return calc
+	}
+	signed := *calc
+	signed.Receipt = c.signer.Sign(signed)
+	return &signed
+}
@@ -0,0 +1,49 @@
+package signing
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"testing"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/domain/ports/in"
+	"go-prisma-calculator/internal/infrastructure/config"
+)
+
+// fixedCalculator returns calc from every calculation.
+type fixedCalculator struct {
+	in.CalculatorPort
+	calc domain.Calculation
+}
+
+func (c fixedCalculator) Calculate(context.Context, string, ...int32) (*domain.Calculation, error) {
+	calc := c.calc
+	return &calc, nil
+}
+
+func TestSigningCalculatorSkipsUnrecorded(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	signer, err := NewSigner(&config.Config{ReceiptSigningKey: base64.StdEncoding.EncodeToString(seed)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		id          string
+		wantReceipt bool
+	}{
+		{id: "c1", wantReceipt: true},
+		{id: "", wantReceipt: false},
+	}
+	for _, tt := range tests {
+		next := fixedCalculator{calc: domain.Calculation{ID: tt.id, Operation: "add", A: 2, B: 3, Result: 5}}
+		calc, err := NewSigningCalculator(next, signer).Calculate(context.Background(), "add", 2, 3)
+		if err != nil {
+			t.Fatalf("Calculate: %v", err)
+		}
+		if got := calc.Receipt != nil; got != tt.wantReceipt {
+			t.Errorf("calculation %q has a receipt: %v, want %v", tt.id, got, tt.wantReceipt)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// Package signing attaches signed receipts to calculations and publishes
+// the public keys that verify them.
+package signing
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/infrastructure/config"
+	"go-prisma-calculator/pkg/receipt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Signer signs calculation receipts with the configured Ed25519 key.
+type Signer struct {
+	keyID string
+	key   ed25519.PrivateKey
+	keys  receipt.KeySet
+}
+
+// NewSigner loads the signing key and the retired public keys from Config.
+// Without a signing key receipts are disabled, but retired keys are still
+// published so that receipts issued earlier can be verified.
+func NewSigner(c *config.Config) (*Signer, error) {
+	s := &Signer{keys: receipt.KeySet{Keys: []receipt.Key{}}}
+
+	if c.ReceiptSigningKey != "" {
+		key, err := parsePrivateKey(c.ReceiptSigningKey)
+		if err != nil {
+			return nil, err
+		}
+		pub := key.Public().(ed25519.PublicKey)
+		s.key = key
+		s.keyID = c.ReceiptKeyID
+		if s.keyID == "" {
+			s.keyID = receipt.KeyID(pub)
+		}
+		s.keys.Keys = append(s.keys.Keys, receipt.Key{ID: s.keyID, Algorithm: receipt.Algorithm, PublicKey: pub})
+	}
+
+	retired, err := parsePublicKeys(c.ReceiptPublicKeys)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range retired {
+		if _, ok := s.keys.Find(k.ID); ok {
+			return nil, fmt.Errorf("duplicate receipt key ID %q", k.ID)
+		}
+		s.keys.Keys = append(s.keys.Keys, k)
+	}
+	return s, nil
+}
+
+// Enabled reports whether a signing key is configured.
+func (s *Signer) Enabled() bool {
+	return s.key != nil
+}
+
+// Sign returns the receipt of a calculation.
+func (s *Signer) Sign(calc domain.Calculation) *domain.Receipt {
+	r := receipt.Receipt{
+		ID:        calc.ID,
+		Operation: calc.Operation,
+		A:         int64(calc.A),
+		B:         int64(calc.B),
+		Result:    int64(calc.Result),
+		Timestamp: calc.CreatedAt,
+	}
+	if !calc.IsInteger() {
+		r.Kind = string(calc.Kind)
+		r.Operands = calc.Operands
+		r.Value = calc.Value
+		r.Details = calc.Details
+		r.Options = receiptOptions(calc.Options)
+	}
+	signed := receipt.Sign(r, s.keyID, s.key)
+
+	return &domain.Receipt{
+		KeyID:     signed.KeyID,
+		Algorithm: receipt.Algorithm,
+		Signature: signed.Signature,
+	}
+}
+
+// receiptOptions lists the options that are set.
+func receiptOptions(opts domain.EvaluationOptions) map[string]string {
+	options := make(map[string]string)
+	if opts.Angle != "" {
+		options["angle"] = string(opts.Angle)
+	}
+	if opts.Precision != 0 {
+		options["precision"] = strconv.Itoa(opts.Precision)
+	}
+	if opts.Scale != nil {
+		options["scale"] = strconv.Itoa(*opts.Scale)
+	}
+	if opts.Rounding != "" {
+		options["rounding"] = string(opts.Rounding)
+	}
+	if opts.Division != "" {
+		options["division"] = string(opts.Division)
+	}
+	return options
+}
+
+// KeysHandler publishes the public keys at receipt.WellKnownPath. It needs
+// no credentials.
+func (s *Signer) KeysHandler(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, s.keys)
+}
+
+// parsePrivateKey decodes a base64 Ed25519 private key or seed.
+func parsePrivateKey(raw string) (ed25519.PrivateKey, error) {
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(raw))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RECEIPT_SIGNING_KEY: %w", err)
+	}
+	switch len(b) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(b), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(b), nil
+	default:
+		return nil, fmt.Errorf("invalid RECEIPT_SIGNING_KEY: want a %d-byte seed or a %d-byte private key, got %d bytes",
+			ed25519.SeedSize, ed25519.PrivateKeySize, len(b))
+	}
+}
+
+// parsePublicKeys decodes comma-separated "keyID:base64" entries.
+func parsePublicKeys(raw string) ([]receipt.Key, error) {
+	var keys []receipt.Key
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		id, encoded, ok := strings.Cut(entry, ":")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("invalid RECEIPT_PUBLIC_KEYS entry %q: want keyID:base64", entry)
+		}
+		pub, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(pub) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid public key for receipt key %q", id)
+		}
+		keys = append(keys, receipt.Key{ID: id, Algorithm: receipt.Algorithm, PublicKey: pub})
+	}
+	return keys, nil
+}
@@ -0,0 +1,210 @@
+package signing
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	domain "go-prisma-calculator/internal/domain/models"
+	"go-prisma-calculator/internal/infrastructure/config"
+	"go-prisma-calculator/pkg/receipt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// testKey returns a signing key derived from a seed filled with b.
+func testKey(b byte) ed25519.PrivateKey {
+	return ed25519.NewKeyFromSeed([]byte(strings.Repeat(string(b), ed25519.SeedSize)))
+}
+
+func encodeKey(key ed25519.PrivateKey) string {
+	return base64.StdEncoding.EncodeToString(key.Seed())
+}
+
+func encodePublicKey(id string, key ed25519.PrivateKey) string {
+	return id + ":" + base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey))
+}
+
+// clientReceipt rebuilds the receipt a client verifies from a calculation
+// and the signature the server sent with it.
+func clientReceipt(calc domain.Calculation, signed *domain.Receipt) receipt.Receipt {
+	r := receipt.Receipt{
+		ID:        calc.ID,
+		Operation: calc.Operation,
+		A:         int64(calc.A),
+		B:         int64(calc.B),
+		Result:    int64(calc.Result),
+		Timestamp: calc.CreatedAt,
+		KeyID:     signed.KeyID,
+		Signature: signed.Signature,
+	}
+	if !calc.IsInteger() {
+		r.Kind = string(calc.Kind)
+		r.Operands = calc.Operands
+		r.Value = calc.Value
+		r.Details = calc.Details
+		r.Options = receiptOptions(calc.Options)
+	}
+	return r
+}
+
+// fetchKeys publishes the key set of s and fetches it like a client.
+func fetchKeys(t *testing.T, s *Signer) receipt.KeySet {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET(receipt.WellKnownPath, s.KeysHandler)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	keys, err := receipt.FetchKeys(context.Background(), server.Client(), server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+func TestReceiptRoundTrip(t *testing.T) {
+	signer, err := NewSigner(&config.Config{ReceiptSigningKey: encodeKey(testKey(1)), ReceiptKeyID: "k1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := fetchKeys(t, signer)
+
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 6_000_000, time.UTC)
+	scale := 2
+	tests := []struct {
+		name   string
+		calc   domain.Calculation
+		tamper func(r *receipt.Receipt)
+	}{
+		{
+			name:   "integer",
+			calc:   domain.Calculation{ID: "c1", Operation: "add", A: 2, B: 3, Result: 5, CreatedAt: createdAt},
+			tamper: func(r *receipt.Receipt) { r.Result = 6 },
+		},
+		{
+			name: "textual",
+			calc: domain.Calculation{
+				ID: "c2", Operation: "divide", Kind: domain.KindRational, Operands: []string{"1000", "3"},
+				Value: "1000/3", Details: map[string]string{"decimal": "333.33"},
+				Options:   domain.EvaluationOptions{Scale: &scale, Rounding: domain.RoundHalfEven},
+				CreatedAt: createdAt,
+			},
+			tamper: func(r *receipt.Receipt) { r.Options["scale"] = "3" },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signed := signer.Sign(tt.calc)
+			if signed.KeyID != "k1" || signed.Algorithm != receipt.Algorithm {
+				t.Errorf("receipt key %q/%q, want k1/%s", signed.KeyID, signed.Algorithm, receipt.Algorithm)
+			}
+			r := clientReceipt(tt.calc, signed)
+			if err := receipt.Verify(r, keys); err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			tt.tamper(&r)
+			if err := receipt.Verify(r, keys); !errors.Is(err, receipt.ErrInvalidSignature) {
+				t.Errorf("Verify of a tampered receipt error = %v, want %v", err, receipt.ErrInvalidSignature)
+			}
+		})
+	}
+}
+
+// TestReceiptKeyRotation checks that receipts signed before a rotation
+// still verify once the old key is published through RECEIPT_PUBLIC_KEYS.
+func TestReceiptKeyRotation(t *testing.T) {
+	oldKey := testKey(1)
+	calc := domain.Calculation{ID: "c1", Operation: "add", A: 2, B: 3, Result: 5, CreatedAt: time.Now()}
+
+	before, err := NewSigner(&config.Config{ReceiptSigningKey: encodeKey(oldKey), ReceiptKeyID: "2025"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := clientReceipt(calc, before.Sign(calc))
+
+	after, err := NewSigner(&config.Config{
+		ReceiptSigningKey: encodeKey(testKey(2)),
+		ReceiptKeyID:      "2026",
+		ReceiptPublicKeys: encodePublicKey("2025", oldKey),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	current := clientReceipt(calc, after.Sign(calc))
+	keys := fetchKeys(t, after)
+	if len(keys.Keys) != 2 {
+		t.Fatalf("published %d keys, want the current and the retired one", len(keys.Keys))
+	}
+	for _, r := range []receipt.Receipt{old, current} {
+		if err := receipt.Verify(r, keys); err != nil {
+			t.Errorf("Verify of a receipt of key %q: %v", r.KeyID, err)
+		}
+	}
+
+	// Once the old key is no longer published, its receipts no longer verify.
+	withdrawn, err := NewSigner(&config.Config{ReceiptSigningKey: encodeKey(testKey(2)), ReceiptKeyID: "2026"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := receipt.Verify(old, fetchKeys(t, withdrawn)); !errors.Is(err, receipt.ErrUnknownKey) {
+		t.Errorf("Verify with the key withdrawn error = %v, want %v", err, receipt.ErrUnknownKey)
+	}
+
+	// Without a signing key the retired keys are still published.
+	retired, err := NewSigner(&config.Config{ReceiptPublicKeys: encodePublicKey("2025", oldKey)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retired.Enabled() {
+		t.Error("signer enabled without a signing key")
+	}
+	if err := receipt.Verify(old, fetchKeys(t, retired)); err != nil {
+		t.Errorf("Verify with retired keys only: %v", err)
+	}
+}
+
+func TestNewSignerRejectsInvalidKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		c    config.Config
+	}{
+		{
+			name: "retired key with the ID of the signing key",
+			c: config.Config{
+				ReceiptSigningKey: encodeKey(testKey(1)),
+				ReceiptKeyID:      "k1",
+				ReceiptPublicKeys: encodePublicKey("k1", testKey(2)),
+			},
+		},
+		{
+			name: "two retired keys with the same ID",
+			c:    config.Config{ReceiptPublicKeys: encodePublicKey("k1", testKey(1)) + "," + encodePublicKey("k1", testKey(2))},
+		},
+		{
+			name: "entry without an ID",
+			c:    config.Config{ReceiptPublicKeys: base64.StdEncoding.EncodeToString(testKey(1).Public().(ed25519.PublicKey))},
+		},
+		{
+			name: "short public key",
+			c:    config.Config{ReceiptPublicKeys: "k1:" + base64.StdEncoding.EncodeToString([]byte("short"))},
+		},
+		{
+			name: "short signing key",
+			c:    config.Config{ReceiptSigningKey: base64.StdEncoding.EncodeToString([]byte("short"))},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSigner(&tt.c); err == nil {
+				t.Error("NewSigner accepted the configuration")
+			}
+		})
+	}
+}
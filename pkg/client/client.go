@@ -0,0 +1,161 @@
+// Package client is the Go SDK for the calculator service. It wraps the
+// generated gRPC client with a typed API, default deadlines, retries,
+// API-key/JWT credentials and an optional REST fallback.
+//
+//	c, err := client.New("localhost:50051", client.WithAPIKey(key))
+//	if err != nil { ... }
+//	defer c.Close()
+//	res, err := c.Add(ctx, 2, 3)
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-prisma-calculator/pkg/receipt"
+)
+
+// Errors returned by every transport. Use errors.Is to test for them.
+var (
+	ErrInvalidArgument  = errors.New("invalid argument")
+	ErrNotFound         = errors.New("not found")
+	ErrUnavailable      = errors.New("service unavailable")
+	ErrUnauthenticated  = errors.New("unauthenticated")
+	ErrPermissionDenied = errors.New("permission denied")
+	// ErrNoReceipt is returned by VerifyReceipt for results without a receipt.
+	ErrNoReceipt = errors.New("result has no receipt")
+	// ErrInsecureTransport is returned when an API key or token would be
+	// sent over a plaintext connection without WithInsecureCredentials.
+	ErrInsecureTransport = errors.New("credentials require a TLS connection; use WithTLS, an https URL or WithInsecureCredentials")
+)
+
+// Transport names reported in Result.Transport.
+const (
+	TransportGRPC = "grpc"
+	TransportREST = "rest"
+)
+
+// Calculator is the API offered by every transport.
+type Calculator interface {
+	Add(ctx context.Context, a, b int32) (*Result, error)
+	Divide(ctx context.Context, dividend, divisor int32) (*Result, error)
+	// Calculate runs any operation the server offers; ListOperations
+	// describes them.
+	Calculate(ctx context.Context, operation string, operands ...int32) (*Result, error)
+	ListOperations(ctx context.Context) ([]Operation, error)
+	GetCalculation(ctx context.Context, id string) (*Calculation, error)
+	ListCalculations(ctx context.Context, opts ListOptions) ([]Calculation, error)
+	Close() error
+}
+
+// Result is the outcome of a calculation. A and B are the first and second
+// operands; B is 0 for unary operations.
+type Result struct {
+	Operation string
+	A         int32
+	B         int32
+	Value     int32
+	// ID and CreatedAt identify the calculation in the server's history.
+	ID        string
+	CreatedAt time.Time
+	// Receipt is the server's signed statement of the result, when the
+	// server signs its results. Check it with VerifyReceipt.
+	Receipt *receipt.Receipt
+	// Degraded reports that the server answered without recording the
+	// calculation in its history because its database was unavailable.
+	Degraded bool
+	// Transport is TransportGRPC or TransportREST.
+	Transport string
+	// Latency is the time the call took, including retries.
+	Latency time.Duration
+}
+
+// Operation describes an operation the server offers.
+type Operation struct {
+	Name        string
+	Arity       int
+	Description string
+	// Operands names each operand in order, e.g. "dividend" and "divisor".
+	Operands    []string
+	Commutative bool
+}
+
+// operandPair returns the first two operands, which results record.
+func operandPair(operands []int32) (a, b int32) {
+	if len(operands) > 0 {
+		a = operands[0]
+	}
+	if len(operands) > 1 {
+		b = operands[1]
+	}
+	return a, b
+}
+
+// Calculation is a calculation recorded in the server's history.
+type Calculation struct {
+	ID        string
+	Operation string
+	A         int32
+	B         int32
+	Result    int32
+	CreatedAt time.Time
+}
+
+// VerifyReceipt checks offline that res carries a valid receipt signed with
+// one of keys, which can be fetched once with receipt.FetchKeys.
+func VerifyReceipt(res *Result, keys receipt.KeySet) error {
+	if res.Receipt == nil {
+		return ErrNoReceipt
+	}
+	return receipt.Verify(*res.Receipt, keys)
+}
+
+// newReceipt builds the receipt of a result from the signature the server sent.
+func newReceipt(res *Result, keyID string, signature []byte) *receipt.Receipt {
+	if len(signature) == 0 {
+		return nil
+	}
+	return &receipt.Receipt{
+		ID:        res.ID,
+		Operation: res.Operation,
+		A:         int64(res.A),
+		B:         int64(res.B),
+		Result:    int64(res.Value),
+		Timestamp: res.CreatedAt,
+		KeyID:     keyID,
+		Signature: signature,
+	}
+}
+
+// ListOptions filters and pages through the history.
+type ListOptions struct {
+	// Operation limits the listing to one operation, e.g. "add".
+	Operation string
+	Limit     int
+	Offset    int
+}
+
+// New connects to the gRPC endpoint at target. When WithRESTFallback is
+// given, calls that find the gRPC endpoint unavailable are retried over REST.
+func New(target string, opts ...Option) (Calculator, error) {
+	o := newOptions(opts)
+
+	primary, err := newGRPC(target, o)
+	if err != nil {
+		return nil, err
+	}
+	if o.restURL == "" {
+		return primary, nil
+	}
+
+	return &fallback{primary: primary, secondary: newREST(o.restURL, o)}, nil
+}
+
+// withDefaultTimeout applies timeout unless ctx already carries a deadline.
+func withDefaultTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
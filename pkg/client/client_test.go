@@ -0,0 +1,286 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pb "go-prisma-calculator/generated/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeServer answers Add with add, counting the calls.
+type fakeServer struct {
+	pb.UnimplementedCalculatorServiceServer
+	calls atomic.Int32
+	add   func(ctx context.Context, req *pb.AddRequest) (*pb.CalculationResponse, error)
+}
+
+func (s *fakeServer) Add(ctx context.Context, req *pb.AddRequest) (*pb.CalculationResponse, error) {
+	s.calls.Add(1)
+	return s.add(ctx, req)
+}
+
+// unavailableTimes fails the first n calls with UNAVAILABLE and then returns
+// the sum.
+func unavailableTimes(n int32, s *fakeServer) func(context.Context, *pb.AddRequest) (*pb.CalculationResponse, error) {
+	return func(_ context.Context, req *pb.AddRequest) (*pb.CalculationResponse, error) {
+		if s.calls.Load() <= n {
+			return nil, status.Error(codes.Unavailable, "starting")
+		}
+		sum := req.GetA() + req.GetB()
+		return &pb.CalculationResponse{Result: &sum, Id: "a"}, nil
+	}
+}
+
+// newTestGRPC serves s in memory and returns a client connected to it.
+func newTestGRPC(t *testing.T, s *fakeServer, opts ...Option) *GRPCClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	pb.RegisterCalculatorServiceServer(server, s)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	dialer := func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }
+	opts = append(opts, WithDialOptions(grpc.WithContextDialer(dialer)))
+	c, err := NewGRPC("passthrough:///bufnet", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+// unreachable is a dial option for a gRPC endpoint that refuses connections.
+func unreachable() Option {
+	return WithDialOptions(grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return nil, errors.New("connection refused")
+	}))
+}
+
+// restAdd serves the REST add endpoint, failing the first n calls with 503.
+func restAdd(n int32, calls *atomic.Int32) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= n {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error":"starting"}`))
+			return
+		}
+		if r.URL.Path != "/add" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"result":5,"id":"a","createdAt":"2026-01-02T03:04:05Z"}`))
+	})
+}
+
+func TestGRPCRetries(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxAttempts int
+		failures    int32
+		wantErr     error
+		wantCalls   int32
+	}{
+		{"recovers", 3, 2, nil, 3},
+		{"attempts exhausted", 3, 3, ErrUnavailable, 3},
+		{"retries disabled", 1, 1, ErrUnavailable, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &fakeServer{}
+			s.add = unavailableTimes(tt.failures, s)
+			c := newTestGRPC(t, s, WithRetry(tt.maxAttempts, time.Millisecond, time.Millisecond))
+
+			res, err := c.Add(context.Background(), 2, 3)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("Add error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (res.Value != 5 || res.Transport != TransportGRPC) {
+				t.Errorf("Add = %+v, want 5 over gRPC", res)
+			}
+			if got := s.calls.Load(); got != tt.wantCalls {
+				t.Errorf("%d calls, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRESTRetries(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxAttempts int
+		failures    int32
+		wantErr     error
+		wantCalls   int32
+	}{
+		{"recovers", 3, 2, nil, 3},
+		{"attempts exhausted", 3, 3, ErrUnavailable, 3},
+		{"retries disabled", 1, 1, ErrUnavailable, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(restAdd(tt.failures, &calls))
+			defer server.Close()
+			c := NewREST(server.URL, WithRetry(tt.maxAttempts, time.Millisecond, time.Millisecond))
+
+			res, err := c.Add(context.Background(), 2, 3)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("Add error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (res.Value != 5 || res.ID != "a" || res.Transport != TransportREST) {
+				t.Errorf("Add = %+v, want 5 over REST", res)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("%d calls, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestDeadlines(t *testing.T) {
+	block := func(ctx context.Context, _ *pb.AddRequest) (*pb.CalculationResponse, error) {
+		<-ctx.Done()
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+
+	t.Run("default deadline", func(t *testing.T) {
+		c := newTestGRPC(t, &fakeServer{add: block}, WithTimeout(20*time.Millisecond))
+		start := time.Now()
+		if _, err := c.Add(context.Background(), 2, 3); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Add error = %v, want %v", err, context.DeadlineExceeded)
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("Add took %v, want the default deadline to end it", elapsed)
+		}
+	})
+
+	t.Run("caller deadline kept", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+		defer cancel()
+		want, _ := ctx.Deadline()
+		ctx, cancelDefault := withDefaultTimeout(ctx, time.Millisecond)
+		defer cancelDefault()
+		if got, _ := ctx.Deadline(); !got.Equal(want) {
+			t.Errorf("deadline = %v, want the caller's %v", got, want)
+		}
+	})
+
+	t.Run("no default deadline", func(t *testing.T) {
+		ctx, cancel := withDefaultTimeout(context.Background(), 0)
+		defer cancel()
+		if _, ok := ctx.Deadline(); ok {
+			t.Error("WithTimeout(0) set a deadline")
+		}
+	})
+
+	t.Run("REST default deadline", func(t *testing.T) {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			<-release
+		}))
+		defer server.Close()
+		c := NewREST(server.URL, WithTimeout(20*time.Millisecond))
+		_, err := c.Add(context.Background(), 2, 3)
+		close(release)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Add error = %v, want %v", err, context.DeadlineExceeded)
+		}
+	})
+}
+
+func TestCredentialsRequireTLS(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr error
+	}{
+		{"API key", []Option{WithAPIKey("k")}, ErrInsecureTransport},
+		{"bearer token", []Option{WithBearerToken("t")}, ErrInsecureTransport},
+		{"allowed", []Option{WithAPIKey("k"), WithInsecureCredentials()}, nil},
+		{"tenant only", []Option{WithTenant("team-a")}, nil},
+		{"no credentials", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewGRPC("localhost:50051", tt.opts...)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Errorf("NewGRPC error = %v, want %v", err, tt.wantErr)
+			}
+			if c != nil {
+				c.Close()
+			}
+		})
+	}
+
+	t.Run("REST over http", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(restAdd(0, &calls))
+		defer server.Close()
+		if _, err := NewREST(server.URL, WithAPIKey("k")).Add(context.Background(), 2, 3); !errors.Is(err, ErrInsecureTransport) {
+			t.Errorf("Add error = %v, want %v", err, ErrInsecureTransport)
+		}
+		if calls.Load() != 0 {
+			t.Error("the API key was sent over plaintext")
+		}
+	})
+
+	t.Run("REST over https", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewTLSServer(restAdd(0, &calls))
+		defer server.Close()
+		c := NewREST(server.URL, WithAPIKey("k"), WithHTTPClient(server.Client()))
+		if _, err := c.Add(context.Background(), 2, 3); err != nil {
+			t.Errorf("Add error = %v", err)
+		}
+	})
+}
+
+func TestRESTFallback(t *testing.T) {
+	t.Run("gRPC unavailable", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(restAdd(0, &calls))
+		defer server.Close()
+		c, err := New("localhost:50051", unreachable(), WithRetry(1, 0, 0), WithRESTFallback(server.URL))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer c.Close()
+
+		res, err := c.Add(context.Background(), 2, 3)
+		if err != nil {
+			t.Fatalf("Add error = %v, want the REST result", err)
+		}
+		if res.Value != 5 || res.Transport != TransportREST {
+			t.Errorf("Add = %+v, want 5 over REST", res)
+		}
+	})
+
+	t.Run("other errors", func(t *testing.T) {
+		s := &fakeServer{add: func(context.Context, *pb.AddRequest) (*pb.CalculationResponse, error) {
+			return nil, status.Error(codes.InvalidArgument, "overflow")
+		}}
+		var calls atomic.Int32
+		server := httptest.NewServer(restAdd(0, &calls))
+		defer server.Close()
+		c := &fallback{primary: newTestGRPC(t, s), secondary: NewREST(server.URL)}
+
+		if _, err := c.Add(context.Background(), 2, 3); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("Add error = %v, want %v", err, ErrInvalidArgument)
+		}
+		if calls.Load() != 0 {
+			t.Error("a failure other than unavailability fell back to REST")
+		}
+	})
+}
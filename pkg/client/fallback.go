@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"errors"
+)
+
+// fallback sends calls to the primary transport and repeats them on the
+// secondary one when the primary is unavailable.
+type fallback struct {
+	primary   Calculator
+	secondary Calculator
+}
+
+func (f *fallback) Add(ctx context.Context, a, b int32) (*Result, error) {
+	return try(f, func(c Calculator) (*Result, error) { return c.Add(ctx, a, b) })
+}
+
+func (f *fallback) Divide(ctx context.Context, dividend, divisor int32) (*Result, error) {
+	return try(f, func(c Calculator) (*Result, error) { return c.Divide(ctx, dividend, divisor) })
+}
+
+func (f *fallback) Calculate(ctx context.Context, operation string, operands ...int32) (*Result, error) {
+	return try(f, func(c Calculator) (*Result, error) { return c.Calculate(ctx, operation, operands...) })
+}
+
+func (f *fallback) ListOperations(ctx context.Context) ([]Operation, error) {
+	return try(f, func(c Calculator) ([]Operation, error) { return c.ListOperations(ctx) })
+}
+
+func (f *fallback) GetCalculation(ctx context.Context, id string) (*Calculation, error) {
+	return try(f, func(c Calculator) (*Calculation, error) { return c.GetCalculation(ctx, id) })
+}
+
+func (f *fallback) ListCalculations(ctx context.Context, opts ListOptions) ([]Calculation, error) {
+	return try(f, func(c Calculator) ([]Calculation, error) { return c.ListCalculations(ctx, opts) })
+}
+
+func (f *fallback) Close() error {
+	return errors.Join(f.primary.Close(), f.secondary.Close())
+}
+
+func try[T any](f *fallback, call func(c Calculator) (T, error)) (T, error) {
+	result, err := call(f.primary)
+	if errors.Is(err, ErrUnavailable) {
+		return call(f.secondary)
+	}
+	return result, err
+}
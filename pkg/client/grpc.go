@@ -0,0 +1,279 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "go-prisma-calculator/generated/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// degradedHeader is the metadata key the server sets while it serves without history.
+const degradedHeader = "x-calculator-degraded"
+
+// GRPCClient is the gRPC transport.
+type GRPCClient struct {
+	conn    *grpc.ClientConn
+	client  pb.CalculatorServiceClient
+	timeout time.Duration
+}
+
+// NewGRPC connects to the gRPC endpoint at target without a REST fallback.
+func NewGRPC(target string, opts ...Option) (*GRPCClient, error) {
+	return newGRPC(target, newOptions(opts))
+}
+
+func newGRPC(target string, o *options) (*GRPCClient, error) {
+	if err := o.checkCredentials(o.tlsConfig != nil); err != nil {
+		return nil, err
+	}
+	creds := insecure.NewCredentials()
+	if o.tlsConfig != nil {
+		creds = credentials.NewTLS(o.tlsConfig)
+	}
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultServiceConfig(retryServiceConfig(o)),
+	}
+	if o.apiKey != "" || o.tokenSource != nil || o.tenant != "" {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(perRPCCredentials{o}))
+	}
+	dialOpts = append(dialOpts, o.dialOptions...)
+
+	conn, err := grpc.NewClient(target, dialOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return &GRPCClient{conn: conn, client: pb.NewCalculatorServiceClient(conn), timeout: o.timeout}, nil
+}
+
+// Add returns the sum of a and b.
+func (c *GRPCClient) Add(ctx context.Context, a, b int32) (*Result, error) {
+	return c.calculate(ctx, "add", a, b, func(ctx context.Context, opts ...grpc.CallOption) (*pb.CalculationResponse, error) {
+		return c.client.Add(ctx, &pb.AddRequest{A: a, B: b}, opts...)
+	})
+}
+
+// Divide returns the integer quotient of dividend and divisor.
+func (c *GRPCClient) Divide(ctx context.Context, dividend, divisor int32) (*Result, error) {
+	return c.calculate(ctx, "divide", dividend, divisor, func(ctx context.Context, opts ...grpc.CallOption) (*pb.CalculationResponse, error) {
+		return c.client.Divide(ctx, &pb.DivideRequest{Dividend: dividend, Divisor: divisor}, opts...)
+	})
+}
+
+// Calculate runs any operation the server offers.
+func (c *GRPCClient) Calculate(ctx context.Context, operation string, operands ...int32) (*Result, error) {
+	a, b := operandPair(operands)
+	return c.calculate(ctx, operation, a, b, func(ctx context.Context, opts ...grpc.CallOption) (*pb.CalculationResponse, error) {
+		return c.client.Calculate(ctx, &pb.CalculateRequest{Operation: operation, Operands: operands}, opts...)
+	})
+}
+
+// ListOperations describes the operations the caller may run.
+func (c *GRPCClient) ListOperations(ctx context.Context) ([]Operation, error) {
+	ctx, cancel := withDefaultTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.client.ListOperations(ctx, &pb.ListOperationsRequest{})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	operations := make([]Operation, 0, len(resp.GetOperations()))
+	for _, op := range resp.GetOperations() {
+		operations = append(operations, Operation{
+			Name:        op.GetName(),
+			Arity:       int(op.GetArity()),
+			Description: op.GetDescription(),
+			Operands:    op.GetOperands(),
+			Commutative: op.GetCommutative(),
+		})
+	}
+	return operations, nil
+}
+
+func (c *GRPCClient) calculate(
+	ctx context.Context,
+	operation string,
+	a, b int32,
+	call func(ctx context.Context, opts ...grpc.CallOption) (*pb.CalculationResponse, error),
+) (*Result, error) {
+	ctx, cancel := withDefaultTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	var header, trailer metadata.MD
+	resp, err := call(ctx, grpc.Header(&header), grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	res := &Result{
+		Operation: operation,
+		A:         a,
+		B:         b,
+		Value:     resp.GetResult(),
+		ID:        resp.GetId(),
+		Degraded:  len(header.Get(degradedHeader)) > 0 || len(trailer.Get(degradedHeader)) > 0,
+		Transport: TransportGRPC,
+		Latency:   time.Since(start),
+	}
+	if resp.GetCreatedAt() != nil {
+		res.CreatedAt = resp.GetCreatedAt().AsTime()
+	}
+	res.Receipt = newReceipt(res, resp.GetReceipt().GetKeyId(), resp.GetReceipt().GetSignature())
+	return res, nil
+}
+
+// GetCalculation fetches a single calculation from the history.
+func (c *GRPCClient) GetCalculation(ctx context.Context, id string) (*Calculation, error) {
+	ctx, cancel := withDefaultTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.client.GetCalculation(ctx, &pb.GetCalculationRequest{Id: id})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	calc := fromProto(resp)
+	return &calc, nil
+}
+
+// ListCalculations fetches a page of the history, newest first.
+func (c *GRPCClient) ListCalculations(ctx context.Context, opts ListOptions) ([]Calculation, error) {
+	ctx, cancel := withDefaultTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.client.ListCalculations(ctx, &pb.ListCalculationsRequest{
+		Operation: opts.Operation,
+		Limit:     int32(opts.Limit),
+		Offset:    int32(opts.Offset),
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	calcs := make([]Calculation, 0, len(resp.GetCalculations()))
+	for _, calc := range resp.GetCalculations() {
+		calcs = append(calcs, fromProto(calc))
+	}
+	return calcs, nil
+}
+
+// Close closes the connection.
+func (c *GRPCClient) Close() error {
+	return c.conn.Close()
+}
+
+// Raw returns the generated client for RPCs the SDK does not wrap.
+func (c *GRPCClient) Raw() pb.CalculatorServiceClient {
+	return c.client
+}
+
+func fromProto(calc *pb.Calculation) Calculation {
+	return Calculation{
+		ID:        calc.GetId(),
+		Operation: calc.GetOperation(),
+		A:         calc.GetA(),
+		B:         calc.GetB(),
+		Result:    calc.GetResult(),
+		CreatedAt: calc.GetCreatedAt().AsTime(),
+	}
+}
+
+// grpcError maps a gRPC status onto the SDK errors, keeping the server's message.
+func grpcError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	var kind error
+	switch st.Code() {
+	case codes.InvalidArgument:
+		kind = ErrInvalidArgument
+	case codes.NotFound:
+		kind = ErrNotFound
+	case codes.Unavailable:
+		kind = ErrUnavailable
+	case codes.Unauthenticated:
+		kind = ErrUnauthenticated
+	case codes.PermissionDenied:
+		kind = ErrPermissionDenied
+	case codes.DeadlineExceeded:
+		kind = context.DeadlineExceeded
+	case codes.Canceled:
+		kind = context.Canceled
+	default:
+		return err
+	}
+	return fmt.Errorf("%w: %s", kind, st.Message())
+}
+
+// retryServiceConfig builds the gRPC service config that retries calls
+// failing with UNAVAILABLE.
+func retryServiceConfig(o *options) string {
+	type retryPolicy struct {
+		MaxAttempts          int      `json:"maxAttempts"`
+		InitialBackoff       string   `json:"initialBackoff"`
+		MaxBackoff           string   `json:"maxBackoff"`
+		BackoffMultiplier    float64  `json:"backoffMultiplier"`
+		RetryableStatusCodes []string `json:"retryableStatusCodes"`
+	}
+	type name struct {
+		Service string `json:"service"`
+	}
+	type methodConfig struct {
+		Name        []name       `json:"name"`
+		RetryPolicy *retryPolicy `json:"retryPolicy,omitempty"`
+	}
+
+	mc := methodConfig{Name: []name{{Service: pb.CalculatorService_ServiceDesc.ServiceName}}}
+	// A retry policy needs at least two attempts to be valid.
+	if o.maxAttempts > 1 {
+		mc.RetryPolicy = &retryPolicy{
+			MaxAttempts:          o.maxAttempts,
+			InitialBackoff:       seconds(o.initialBackoff),
+			MaxBackoff:           seconds(o.maxBackoff),
+			BackoffMultiplier:    2,
+			RetryableStatusCodes: []string{"UNAVAILABLE"},
+		}
+	}
+
+	config, _ := json.Marshal(map[string]any{"methodConfig": []methodConfig{mc}})
+	return string(config)
+}
+
+// seconds formats d in the "1.5s" form used by service configs.
+func seconds(d time.Duration) string {
+	s := fmt.Sprintf("%.3f", d.Seconds())
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "" || s == "0" {
+		s = "0.001"
+	}
+	return s + "s"
+}
+
+// perRPCCredentials attaches the API key and bearer token to every call.
+type perRPCCredentials struct {
+	o *options
+}
+
+func (c perRPCCredentials) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+	return c.o.authHeaders(ctx)
+}
+
+// RequireTransportSecurity is true unless WithInsecureCredentials allows
+// credentials on a plaintext connection. The tenant header alone is no
+// secret, so it never requires TLS.
+func (c perRPCCredentials) RequireTransportSecurity() bool {
+	return c.o.checkCredentials(false) != nil
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// TokenSource returns the bearer token (e.g. a JWT) to send with a call. It
+// is called for every request, so it may refresh tokens as they expire.
+type TokenSource func(ctx context.Context) (string, error)
+
+// Option configures a client.
+type Option func(*options)
+
+type options struct {
+	timeout        time.Duration
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+
+	tlsConfig           *tls.Config
+	insecureCredentials bool
+	apiKey              string
+	tokenSource         TokenSource
+	tenant              string
+
+	restURL     string
+	httpClient  *http.Client
+	dialOptions []grpc.DialOption
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		timeout:        10 * time.Second,
+		maxAttempts:    3,
+		initialBackoff: 100 * time.Millisecond,
+		maxBackoff:     2 * time.Second,
+		httpClient:     http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithTimeout sets the deadline applied to calls whose context has none.
+// Zero disables the default deadline. The default is 10 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) { o.timeout = timeout }
+}
+
+// WithRetry configures how often calls failing with an unavailable server
+// are attempted, and the bounds of the exponential backoff between attempts.
+// gRPC caps maxAttempts at 5. The default is 3 attempts, 100ms to 2s.
+func WithRetry(maxAttempts int, initialBackoff, maxBackoff time.Duration) Option {
+	return func(o *options) {
+		o.maxAttempts = maxAttempts
+		o.initialBackoff = initialBackoff
+		o.maxBackoff = maxBackoff
+	}
+}
+
+// WithTLS connects over TLS. Without it the gRPC connection is plaintext.
+func WithTLS(cfg *tls.Config) Option {
+	return func(o *options) { o.tlsConfig = cfg }
+}
+
+// WithInsecureCredentials allows API keys and tokens to be sent over
+// plaintext connections, e.g. to a server on localhost. Without it a client
+// with credentials requires WithTLS, or an https URL for the REST transport.
+func WithInsecureCredentials() Option {
+	return func(o *options) { o.insecureCredentials = true }
+}
+
+// WithAPIKey sends key in the x-api-key header of every call.
+func WithAPIKey(key string) Option {
+	return func(o *options) { o.apiKey = key }
+}
+
+// WithBearerToken sends a fixed bearer token, such as a JWT, in the
+// Authorization header of every call.
+func WithBearerToken(token string) Option {
+	return WithTokenSource(func(context.Context) (string, error) { return token, nil })
+}
+
+// WithTokenSource sends the token returned by source in the Authorization
+// header of every call.
+func WithTokenSource(source TokenSource) Option {
+	return func(o *options) { o.tokenSource = source }
+}
+
+// WithTenant sends id in the x-tenant-id header of every call. Admins may
+// select any tenant with it; other callers only the one they are bound to.
+func WithTenant(id string) Option {
+	return func(o *options) { o.tenant = id }
+}
+
+// WithRESTFallback retries calls over the REST API at baseURL (e.g.
+// "http://localhost:8080") when the gRPC endpoint is unavailable.
+func WithRESTFallback(baseURL string) Option {
+	return func(o *options) { o.restURL = baseURL }
+}
+
+// WithHTTPClient sets the HTTP client used by the REST transport.
+func WithHTTPClient(c *http.Client) Option {
+	return func(o *options) { o.httpClient = c }
+}
+
+// WithDialOptions appends raw gRPC dial options, e.g. interceptors.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) { o.dialOptions = append(o.dialOptions, opts...) }
+}
+
+// checkCredentials rejects sending an API key or token over a plaintext
+// connection unless WithInsecureCredentials allows it.
+func (o *options) checkCredentials(secure bool) error {
+	if secure || o.insecureCredentials || (o.apiKey == "" && o.tokenSource == nil) {
+		return nil
+	}
+	return ErrInsecureTransport
+}
+
+// authHeaders returns the credential and tenant headers to send with a call.
+func (o *options) authHeaders(ctx context.Context) (map[string]string, error) {
+	headers := make(map[string]string, 3)
+	if o.apiKey != "" {
+		headers["x-api-key"] = o.apiKey
+	}
+	if o.tenant != "" {
+		headers["x-tenant-id"] = o.tenant
+	}
+	if o.tokenSource != nil {
+		token, err := o.tokenSource(ctx)
+		if err != nil {
+			return nil, err
+		}
+		headers["authorization"] = "Bearer " + token
+	}
+	return headers, nil
+}
@@ -0,0 +1,260 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RESTClient is the REST transport. It offers the same API as GRPCClient.
+type RESTClient struct {
+	baseURL string
+	o       *options
+}
+
+// NewREST talks to the REST API at baseURL, e.g. "http://localhost:8080".
+func NewREST(baseURL string, opts ...Option) *RESTClient {
+	return newREST(baseURL, newOptions(opts))
+}
+
+func newREST(baseURL string, o *options) *RESTClient {
+	return &RESTClient{baseURL: strings.TrimRight(baseURL, "/"), o: o}
+}
+
+// Add returns the sum of a and b.
+func (c *RESTClient) Add(ctx context.Context, a, b int32) (*Result, error) {
+	return c.calculate(ctx, "add", "/add", map[string]int32{"a": a, "b": b}, a, b)
+}
+
+// Divide returns the integer quotient of dividend and divisor.
+func (c *RESTClient) Divide(ctx context.Context, dividend, divisor int32) (*Result, error) {
+	return c.calculate(ctx, "divide", "/divide", map[string]int32{"a": dividend, "b": divisor}, dividend, divisor)
+}
+
+// Calculate runs any operation the server offers.
+func (c *RESTClient) Calculate(ctx context.Context, operation string, operands ...int32) (*Result, error) {
+	request := struct {
+		Operation string  `json:"operation"`
+		Operands  []int32 `json:"operands"`
+	}{operation, operands}
+	a, b := operandPair(operands)
+	return c.calculate(ctx, operation, "/calculate", request, a, b)
+}
+
+// ListOperations describes the operations the caller may run.
+func (c *RESTClient) ListOperations(ctx context.Context) ([]Operation, error) {
+	var resp []struct {
+		Name        string   `json:"name"`
+		Arity       int      `json:"arity"`
+		Description string   `json:"description"`
+		Operands    []string `json:"operands"`
+		Commutative bool     `json:"commutative"`
+	}
+	if _, err := c.do(ctx, http.MethodGet, "/operations", nil, &resp); err != nil {
+		return nil, err
+	}
+	operations := make([]Operation, 0, len(resp))
+	for _, op := range resp {
+		operations = append(operations, Operation(op))
+	}
+	return operations, nil
+}
+
+// calculate posts request to path and records a and b as the operands of
+// the result.
+func (c *RESTClient) calculate(ctx context.Context, operation, path string, request any, a, b int32) (*Result, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	var resp struct {
+		Result    int32     `json:"result"`
+		ID        string    `json:"id"`
+		CreatedAt time.Time `json:"createdAt"`
+		Receipt   *struct {
+			KeyID     string `json:"keyId"`
+			Signature []byte `json:"signature"`
+		} `json:"receipt"`
+	}
+	header, err := c.do(ctx, http.MethodPost, path, body, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Result{
+		Operation: operation,
+		A:         a,
+		B:         b,
+		Value:     resp.Result,
+		ID:        resp.ID,
+		CreatedAt: resp.CreatedAt,
+		Degraded:  header.Get(degradedHeader) != "",
+		Transport: TransportREST,
+		Latency:   time.Since(start),
+	}
+	if resp.Receipt != nil {
+		res.Receipt = newReceipt(res, resp.Receipt.KeyID, resp.Receipt.Signature)
+	}
+	return res, nil
+}
+
+// restCalculation is the JSON form of a calculation.
+type restCalculation struct {
+	ID        string    `json:"id"`
+	Operation string    `json:"operation"`
+	A         int32     `json:"a"`
+	B         int32     `json:"b"`
+	Result    int32     `json:"result"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (r restCalculation) toCalculation() Calculation {
+	return Calculation(r)
+}
+
+// GetCalculation fetches a single calculation from the history.
+func (c *RESTClient) GetCalculation(ctx context.Context, id string) (*Calculation, error) {
+	var resp restCalculation
+	if _, err := c.do(ctx, http.MethodGet, "/calculations/"+url.PathEscape(id), nil, &resp); err != nil {
+		return nil, err
+	}
+	calc := resp.toCalculation()
+	return &calc, nil
+}
+
+// ListCalculations fetches a page of the history, newest first.
+func (c *RESTClient) ListCalculations(ctx context.Context, opts ListOptions) ([]Calculation, error) {
+	query := url.Values{}
+	if opts.Operation != "" {
+		query.Set("operation", opts.Operation)
+	}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.Offset > 0 {
+		query.Set("offset", strconv.Itoa(opts.Offset))
+	}
+
+	var resp []restCalculation
+	if _, err := c.do(ctx, http.MethodGet, "/calculations?"+query.Encode(), nil, &resp); err != nil {
+		return nil, err
+	}
+
+	calcs := make([]Calculation, 0, len(resp))
+	for _, calc := range resp {
+		calcs = append(calcs, calc.toCalculation())
+	}
+	return calcs, nil
+}
+
+// Close releases idle connections.
+func (c *RESTClient) Close() error {
+	c.o.httpClient.CloseIdleConnections()
+	return nil
+}
+
+// do sends a request, retrying unavailable responses with the same policy
+// as the gRPC transport, and decodes the JSON response into out.
+func (c *RESTClient) do(ctx context.Context, method, path string, body []byte, out any) (http.Header, error) {
+	ctx, cancel := withDefaultTimeout(ctx, c.o.timeout)
+	defer cancel()
+
+	attempts := max(c.o.maxAttempts, 1)
+	backoff := c.o.initialBackoff
+	for attempt := 1; ; attempt++ {
+		header, err := c.send(ctx, method, path, body, out)
+		if err == nil || !errors.Is(err, ErrUnavailable) || attempt >= attempts {
+			return header, err
+		}
+
+		// Full jitter, like gRPC's own retry policy.
+		delay := time.Duration(rand.Int64N(int64(backoff) + 1))
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		backoff = min(backoff*2, c.o.maxBackoff)
+	}
+}
+
+func (c *RESTClient) send(ctx context.Context, method, path string, body []byte, out any) (http.Header, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if err := c.o.checkCredentials(req.URL.Scheme == "https"); err != nil {
+		return nil, err
+	}
+	headers, err := c.o.authHeaders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := c.o.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, restError(resp)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return resp.Header, nil
+}
+
+// restError maps an HTTP error response onto the SDK errors, keeping the server's message.
+func restError(resp *http.Response) error {
+	var body struct {
+		Error string `json:"error"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	message := body.Error
+	if message == "" {
+		message = resp.Status
+	}
+
+	var kind error
+	switch resp.StatusCode {
+	case http.StatusBadRequest:
+		kind = ErrInvalidArgument
+	case http.StatusNotFound:
+		kind = ErrNotFound
+	case http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusGatewayTimeout:
+		kind = ErrUnavailable
+	case http.StatusUnauthorized:
+		kind = ErrUnauthenticated
+	case http.StatusForbidden:
+		kind = ErrPermissionDenied
+	default:
+		return fmt.Errorf("unexpected response %s: %s", resp.Status, message)
+	}
+	return fmt.Errorf("%w: %s", kind, message)
+}
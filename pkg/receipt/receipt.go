@@ -0,0 +1,194 @@
+// Package receipt signs and verifies calculation receipts. A receipt is a
+// detached Ed25519 signature over the canonical form of a calculation, so
+// anyone holding the server's public keys can check a result offline.
+//
+//	keys, err := receipt.FetchKeys(ctx, http.DefaultClient, "http://localhost:8080")
+//	if err != nil { ... }
+//	err = receipt.Verify(r, keys)
+package receipt
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Algorithm is the only signature algorithm in use.
+const Algorithm = "Ed25519"
+
+// WellKnownPath is where the server publishes its public keys.
+const WellKnownPath = "/.well-known/calculator-keys"
+
+// timestampFormat fixes the precision of the signed timestamp to milliseconds.
+const timestampFormat = "2006-01-02T15:04:05.000Z"
+
+var (
+	// ErrUnknownKey means the receipt was signed with a key that is not in the key set.
+	ErrUnknownKey = errors.New("receipt: unknown signing key")
+	// ErrInvalidSignature means the receipt does not match its signature.
+	ErrInvalidSignature = errors.New("receipt: invalid signature")
+)
+
+// Receipt states that the server computed Result from the operands at
+// Timestamp. Calculations that are not integer ones set Kind and state
+// their Operands, Value, Details and Options as text instead of A, B and
+// Result.
+type Receipt struct {
+	ID        string            `json:"id"`
+	Operation string            `json:"operation"`
+	A         int64             `json:"a"`
+	B         int64             `json:"b"`
+	Result    int64             `json:"result"`
+	Kind      string            `json:"kind,omitempty"`
+	Operands  []string          `json:"operands,omitempty"`
+	Value     string            `json:"value,omitempty"`
+	Details   map[string]string `json:"details,omitempty"`
+	Options   map[string]string `json:"options,omitempty"`
+	Timestamp time.Time         `json:"timestamp"`
+	KeyID     string            `json:"keyId"`
+	Signature []byte            `json:"signature"`
+}
+
+// Payload returns the canonical bytes that are signed: a version line
+// followed by one line per field, in this order:
+//
+//	calculator-receipt/v1
+//	id=<id>
+//	operation=<operation>
+//	a=<a>
+//	b=<b>
+//	result=<result>
+//	timestamp=<UTC RFC 3339 with milliseconds>
+//
+// Receipts with a Kind use version 2, which replaces the integer fields:
+//
+//	calculator-receipt/v2
+//	id=<id>
+//	operation=<operation>
+//	kind=<kind>
+//	operand=<operand>            one line per operand
+//	value=<value>
+//	detail.<name>=<value>        one line per detail, ordered by name
+//	option.<name>=<value>        one line per option, ordered by name
+//	timestamp=<UTC RFC 3339 with milliseconds>
+func (r Receipt) Payload() []byte {
+	var b strings.Builder
+	if r.Kind != "" {
+		b.WriteString("calculator-receipt/v2\n")
+		b.WriteString("id=" + r.ID + "\n")
+		b.WriteString("operation=" + r.Operation + "\n")
+		b.WriteString("kind=" + r.Kind + "\n")
+		for _, operand := range r.Operands {
+			b.WriteString("operand=" + operand + "\n")
+		}
+		b.WriteString("value=" + r.Value + "\n")
+		writeSorted(&b, "detail.", r.Details)
+		writeSorted(&b, "option.", r.Options)
+		b.WriteString("timestamp=" + r.Timestamp.UTC().Format(timestampFormat) + "\n")
+		return []byte(b.String())
+	}
+	b.WriteString("calculator-receipt/v1\n")
+	b.WriteString("id=" + r.ID + "\n")
+	b.WriteString("operation=" + r.Operation + "\n")
+	b.WriteString("a=" + strconv.FormatInt(r.A, 10) + "\n")
+	b.WriteString("b=" + strconv.FormatInt(r.B, 10) + "\n")
+	b.WriteString("result=" + strconv.FormatInt(r.Result, 10) + "\n")
+	b.WriteString("timestamp=" + r.Timestamp.UTC().Format(timestampFormat) + "\n")
+	return []byte(b.String())
+}
+
+// writeSorted writes one prefixed line per entry of m, ordered by name.
+func writeSorted(b *strings.Builder, prefix string, m map[string]string) {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		b.WriteString(prefix + name + "=" + m[name] + "\n")
+	}
+}
+
+// Sign returns a copy of r signed with key, recording keyID.
+func Sign(r Receipt, keyID string, key ed25519.PrivateKey) Receipt {
+	r.KeyID = keyID
+	r.Signature = ed25519.Sign(key, r.Payload())
+	return r
+}
+
+// Verify checks the signature of r against the key it names.
+func Verify(r Receipt, keys KeySet) error {
+	key, ok := keys.Find(r.KeyID)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownKey, r.KeyID)
+	}
+	if key.Algorithm != Algorithm || len(key.PublicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("%w: unsupported key %q", ErrUnknownKey, key.ID)
+	}
+	if !ed25519.Verify(key.PublicKey, r.Payload(), r.Signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Key is a public key that receipts may be signed with.
+type Key struct {
+	ID        string            `json:"kid"`
+	Algorithm string            `json:"alg"`
+	PublicKey ed25519.PublicKey `json:"publicKey"`
+}
+
+// KeySet is the document published at WellKnownPath. It lists the current
+// signing key and the retired keys whose receipts are still valid.
+type KeySet struct {
+	Keys []Key `json:"keys"`
+}
+
+// Find returns the key with the given ID.
+func (s KeySet) Find(id string) (Key, bool) {
+	for _, k := range s.Keys {
+		if k.ID == id {
+			return k, true
+		}
+	}
+	return Key{}, false
+}
+
+// KeyID derives the default ID of a public key from its SHA-256 fingerprint.
+func KeyID(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256(pub)
+	return hex.EncodeToString(sum[:8])
+}
+
+// FetchKeys downloads the key set of the server at baseURL, e.g.
+// "http://localhost:8080". Verification itself needs no network access, so
+// the key set may be fetched once and kept.
+func FetchKeys(ctx context.Context, client *http.Client, baseURL string) (KeySet, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(baseURL, "/")+WellKnownPath, nil)
+	if err != nil {
+		return KeySet{}, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return KeySet{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return KeySet{}, fmt.Errorf("receipt: fetch keys: %s", resp.Status)
+	}
+	var keys KeySet
+	if err := json.NewDecoder(resp.Body).Decode(&keys); err != nil {
+		return KeySet{}, fmt.Errorf("receipt: decode keys: %w", err)
+	}
+	return keys, nil
+}